@@ -55,11 +55,29 @@ func main() {
 		log.Fatalf("category mgr: %v", err)
 	}
 
+	authMgr, err := ctn.SafeGetLogicAuth()
+	if err != nil {
+		log.Fatalf("auth mgr: %v", err)
+	}
+
+	rbacMgr, err := ctn.SafeGetLogicRbac()
+	if err != nil {
+		log.Fatalf("rbac mgr: %v", err)
+	}
+
+	apiKeyMgr, err := ctn.SafeGetLogicApiKey()
+	if err != nil {
+		log.Fatalf("api key mgr: %v", err)
+	}
+
 	apiCfg := &api.Config{
 		BaseUrl:         cfg.API.BaseUrl,
 		Logger:          _logger,
 		Port:            cfg.API.Port,
 		CategoryService: categoryMgr,
+		AuthService:     authMgr,
+		RBACService:     rbacMgr,
+		APIKeyService:   apiKeyMgr,
 	}
 
 	if err := migrate(cfg); err != nil {
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/pasteback"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	applyInput  string
+	applyYes    bool
+	applyDryRun bool
+)
+
+var applyClipboardCommand = &cobra.Command{
+	Use:   applyClipboard.string() + " [root]",
+	Short: "Writes the files of a pasted LLM response to the working tree.",
+	Long: `
+		The reverse of "clip-file-contents": reads a response from the clipboard, or from
+		--input ("-" for stdin, or a file), and finds the whole files in it:
+		  - "--- path ---" sections, as clipped in the plain format.
+		  - Fenced markdown blocks, named by the line above them ("### path", "**path**",
+		    "` + "`path`" + `" or "File: path"), or by their info string ("` + "```go main.go" + `").
+
+		Paths are relative to the root (the current directory by default), and cannot
+		point outside of it, go through a symlinked directory, or into a .git directory.
+
+		The diff of every file against the working tree is shown, and each file is written
+		once accepted ([y]es, [n]o, [a]ll, [q]uit), or right away with --yes. --dry-run only
+		shows the diffs. Files are replaced atomically, and the ones overwritten are backed
+		up under "apply-backups" of the app dir first.
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := pasteback.Options{Root: ".", Input: applyInput}
+		if len(args) == 1 {
+			opts.Root = args[0]
+		}
+
+		plan, err := srv.PlanApplyClipboard(&opts)
+		if err != nil {
+			return err
+		}
+
+		var answers *bufio.Reader
+		if !applyYes && !applyDryRun && plan.Count(pasteback.OpUnchanged) < len(plan.Changes) {
+			r, err := openAnswers(applyInput)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+			answers = bufio.NewReader(r)
+		}
+
+		out := cmd.OutOrStdout()
+		accepted := make([]pasteback.Change, 0, len(plan.Changes))
+		acceptAll := applyYes
+	changes:
+		for _, change := range plan.Changes {
+			if change.Op == pasteback.OpUnchanged {
+				log.Infof("unchanged '%s'", change.Path)
+				continue
+			}
+
+			printDiff(out, change.Diff)
+
+			if applyDryRun {
+				continue
+			}
+
+			if !acceptAll {
+				answer, err := ask(out, answers, fmt.Sprintf("%s '%s'? [y]es/[n]o/[a]ll/[q]uit: ", change.Op, change.Path))
+				if err != nil {
+					return err
+				}
+				switch answer {
+				case "y", "yes":
+				case "a", "all":
+					acceptAll = true
+				case "q", "quit":
+					break changes
+				default:
+					continue
+				}
+			}
+
+			accepted = append(accepted, change)
+		}
+
+		if applyDryRun || len(accepted) == 0 {
+			log.Infof("nothing written, %d file(s) in the response", len(plan.Changes))
+			return nil
+		}
+
+		result, err := srv.ApplyClipboard(&pasteback.Plan{Root: plan.Root, Changes: accepted})
+		if result != nil {
+			for _, path := range result.Written {
+				log.Infof("wrote '%s'", path)
+			}
+			if result.BackupDir != "" {
+				log.Infof("backed up the overwritten files to '%s'", result.BackupDir)
+			}
+		}
+		return err
+	},
+}
+
+func init() {
+	flags := applyClipboardCommand.Flags()
+	flags.StringVarP(&applyInput, "input", "i", "", "read the response from a file, or '-' for stdin, instead of the clipboard")
+	flags.BoolVarP(&applyYes, "yes", "y", false, "write every changed file without asking")
+	flags.BoolVar(&applyDryRun, "dry-run", false, "only show the diffs")
+}
+
+// openAnswers opens the terminal to read the answers from, or stdin when the
+// response does not come from it.
+func openAnswers(input string) (io.ReadCloser, error) {
+	if tty, err := os.Open("/dev/tty"); err == nil {
+		return tty, nil
+	}
+
+	if strings.TrimSpace(input) == cliputil.InputStdin {
+		return nil, errors.New("no terminal to confirm the changes on, use --yes or --dry-run")
+	}
+
+	return io.NopCloser(os.Stdin), nil
+}
+
+// ask prints the question, and returns the lower case answer.
+func ask(out io.Writer, answers *bufio.Reader, question string) (string, error) {
+	_, _ = fmt.Fprint(out, question)
+
+	answer, err := answers.ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && answer != "") {
+		return "", fmt.Errorf("read answer: %v", err)
+	}
+
+	return strings.ToLower(strings.TrimSpace(answer)), nil
+}
+
+// printDiff prints a unified diff, with added lines in green and removed ones in red.
+func printDiff(out io.Writer, diff string) {
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			line = "\033[1m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		case strings.HasPrefix(line, "@@"):
+			line = "\033[36m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		case strings.HasPrefix(line, "+"):
+			line = "\033[32m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		case strings.HasPrefix(line, "-"):
+			line = "\033[31m" + strings.TrimSuffix(line, "\n") + "\033[0m\n"
+		}
+		_, _ = fmt.Fprint(out, line)
+	}
+}
@@ -1,20 +1,112 @@
 package main
 
 import (
+	"github.com/dembygenesis/local.tools/internal/lib/gitlib"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
 	"github.com/spf13/cobra"
 )
 
+var (
+	clipInclude       []string
+	clipExclude       []string
+	clipMaxFileBytes  int64
+	clipMaxTotalBytes int64
+	clipFormat        string
+	clipOutput        string
+	clipTree          bool
+	clipTreeOnly      bool
+	clipGitDiff       string
+	clipStaged        bool
+	clipDiffOnly      bool
+	clipUnified       int
+	clipRedact        redact.Config
+)
+
 var copyToClipboardCmd = &cobra.Command{
 	Use:   clipFileContents.string(),
 	Short: "Copy to clipboard copies from the directory provided.",
-	Long:  `Copies files contents from the root path provided.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `
+		Copies files contents from the root path provided.
+
+		Files ignored by git are skipped: every ".gitignore" from the repository root down,
+		and ".git/info/exclude" are honored with full gitignore semantics (negation, "**",
+		anchored and directory-only patterns).
+
+		Use --include to only keep files matching a glob, and --exclude to skip more paths,
+		e.g. --include '*.go' --exclude 'docs/**'.
+
+		Binary files, and files that do not fit the per-file or total byte budgets are
+		left out, and listed in a summary at the end of the clipped output.
+
+		Use --format to render files as "plain" (--- path --- headers), "markdown" (fenced
+		code blocks), "xml" (<file path="..."> elements) or "json" (an object with the
+		tree, the path/content files and the skipped files notes), and --output to write
+		to a file, or "-" for stdout, when no clipboard is available (e.g. over SSH or in
+		CI containers).
+
+		Use --tree to prepend a tree of the clipped files (with sizes and line counts), or
+		--tree-only to clip just the tree.
+
+		Use --git-diff <rev> to only clip files changed against a revision (including untracked
+		files), or --staged to only clip staged changes (against HEAD, or the --git-diff revision).
+		Add --diff to clip unified diffs with --unified lines of context instead of whole files.
+		This runs the local "git" binary, so it works offline.
+
+		Secrets are masked before anything is clipped: AWS keys, JWTs, private keys, env
+		assignments like DB_PASS=..., and high-entropy strings. Add patterns with
+		--redact-pattern, or one per line in "redact-patterns" of the app dir, and turn
+		masking off with --no-redact. Masked secrets are listed, without their values.
+	`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		files, err := srv.CopyToClipboard(args[0], nil)
+		opts := cliputil.ClipOptions{
+			Root:          args[0],
+			Include:       clipInclude,
+			Exclude:       clipExclude,
+			MaxFileBytes:  clipMaxFileBytes,
+			MaxTotalBytes: clipMaxTotalBytes,
+			Format:        clipFormat,
+			Output:        clipOutput,
+			Tree:          clipTree,
+			TreeOnly:      clipTreeOnly,
+			Diff: gitlib.DiffOptions{
+				Rev:          clipGitDiff,
+				Staged:       clipStaged,
+				ContextLines: clipUnified,
+			},
+			DiffOnly: clipDiffOnly,
+			Redact:   clipRedact,
+		}
+
+		prepareOutput(clipOutput)
+
+		report, err := srv.CopyToClipboard(&opts)
 		if err != nil {
 			log.Errorf("copy to clipboard: %v", err)
 			return
 		}
-		log.Infof("copied \033[1;34m%v\033[0m files to clipboard!", len(files))
+
+		for _, skipped := range report.Skipped {
+			log.Warnf("skipped '%s': %s %s", skipped.Path, skipped.Reason, skipped.Detail)
+		}
+		logRedacted(report.Redacted)
+		log.Infof("copied \033[1;34m%v\033[0m files (%s) to %s!", len(report.Files), strutil.FormatBytes(report.TotalBytes), describeOutput(clipOutput))
 	},
 }
+
+func init() {
+	copyToClipboardCmd.Flags().StringSliceVar(&clipInclude, "include", nil, "only clip files matching these globs (gitignore syntax)")
+	copyToClipboardCmd.Flags().StringSliceVar(&clipExclude, "exclude", nil, "skip paths matching these globs (gitignore syntax)")
+	copyToClipboardCmd.Flags().Int64Var(&clipMaxFileBytes, "max-file-bytes", 0, "skip files larger than this (0 uses the config, negative disables)")
+	copyToClipboardCmd.Flags().Int64Var(&clipMaxTotalBytes, "max-total-bytes", 0, "stop adding files past this total (0 uses the config, negative disables)")
+	copyToClipboardCmd.Flags().BoolVar(&clipTree, "tree", false, "prepend a tree of the clipped files")
+	copyToClipboardCmd.Flags().BoolVar(&clipTreeOnly, "tree-only", false, "clip only the tree of the selected files")
+	copyToClipboardCmd.Flags().StringVar(&clipGitDiff, "git-diff", "", "only clip files changed against this git revision")
+	copyToClipboardCmd.Flags().BoolVar(&clipStaged, "staged", false, "only clip files with staged changes")
+	copyToClipboardCmd.Flags().BoolVar(&clipDiffOnly, "diff", false, "clip unified diffs instead of whole files (needs --git-diff or --staged)")
+	copyToClipboardCmd.Flags().IntVar(&clipUnified, "unified", gitlib.DefaultContextLines, "lines of context in clipped diffs")
+	addRedactFlags(copyToClipboardCmd, &clipRedact)
+	addOutputFlags(copyToClipboardCmd, &clipFormat, &clipOutput)
+}
@@ -10,7 +10,16 @@ type command string
 const (
 	clipGptPreface   command = "clip-gpt-preface"
 	clipFileContents command = "clip-file-contents"
+	clipContext      command = "clip-context"
 	copyFolderAToB   command = "copy-folder-a-to-b"
+	copyCmd          command = "copy"
+	prefaceCmd       command = "preface"
+	promptCmd        command = "prompt"
+	clipCmd          command = "clip"
+	applyClipboard   command = "apply-clipboard"
+	snippetCmd       command = "snippet"
+	completionCmd    command = "completion"
+	doctorCmd        command = "doctor"
 )
 
 func (c command) string() string {
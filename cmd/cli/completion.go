@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"strings"
+)
+
+var completionCommand = &cobra.Command{
+	Use:   completionCmd.string() + " <bash|zsh|fish>",
+	Short: "Prints the shell completion script.",
+	Long: fmt.Sprintf(`
+		Prints the completion script of the shell. Commands, flags, and the names of
+		prefaces, snippets and copy profiles are completed.
+
+		bash (needs the bash-completion package):
+			source <(%[1]s completion bash)
+		zsh:
+			%[1]s completion zsh > "${fpath[1]}/_%[1]s"
+		fish:
+			%[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+	`, rootCmd.Name()),
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Annotations: map[string]string{
+		skipContainer: "",
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(out, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(out)
+		case "fish":
+			return rootCmd.GenFishCompletion(out, true)
+		default:
+			return fmt.Errorf("unsupported shell '%s', expected one of: %s", args[0], strings.Join(cmd.ValidArgs, ", "))
+		}
+	},
+}
+
+// completeNames completes the first argument with the listed names, or every
+// argument when all is set. The container is loaded on demand, and nothing is
+// completed when it fails to.
+func completeNames(list func() ([]string, error), all bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 && !all {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		if err := loadContainer(cmd); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		names, err := list()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func prefaceNames() ([]string, error) {
+	templates, err := srv.ListPrefaces()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+	return names, nil
+}
+
+func snippetNames() ([]string, error) {
+	snippets, err := srv.ListSnippets()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(snippets))
+	for _, s := range snippets {
+		names = append(names, s.Name)
+	}
+	return names, nil
+}
+
+func copyProfileNames() ([]string, error) {
+	profiles, err := srv.ListCopyProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(profiles))
+	for _, p := range profiles {
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
+
+// completeSnippetValues completes the snippet name, then "placeholder=" for
+// the placeholders not given yet.
+func completeSnippetValues(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return completeNames(snippetNames, false)(cmd, args, toComplete)
+	}
+
+	if err := loadContainer(cmd); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	snip, err := srv.GetSnippet(args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	given := make(map[string]bool, len(args))
+	for _, arg := range args[1:] {
+		key, _, _ := strings.Cut(arg, "=")
+		given[key] = true
+	}
+
+	keys := make([]string, 0)
+	for _, p := range snip.Placeholders() {
+		if !given[p.Name] {
+			keys = append(keys, p.Name+"=")
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
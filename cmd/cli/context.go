@@ -0,0 +1,96 @@
+package main
+
+import (
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/dembygenesis/local.tools/internal/lib/tokenizer"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextInclude       []string
+	contextExclude       []string
+	contextFocus         []string
+	contextNear          string
+	contextMaxTokens     int
+	contextTokenizer     string
+	contextBPEFile       string
+	contextCharsPerToken float64
+	contextFormat        string
+	contextOutput        string
+	contextRedact        redact.Config
+)
+
+var copyContextToClipboardCmd = &cobra.Command{
+	Use:   clipContext.string(),
+	Short: "Copies the most relevant files that fit a token budget.",
+	Long: `
+		Copies file contents from the root path provided, packed to fit an LLM context window.
+
+		Files are selected the same way as "clip-file-contents", then ranked by relevance:
+		  1. Files matching a --focus glob.
+		  2. Files closest to the --near file (by directory distance).
+		  3. Most recently modified files.
+
+		Files are then packed greedily until --max-tokens is reached, and the ones left out
+		are listed at the end of the clipped output.
+
+		Tokens are estimated with --tokenizer "chars" (a chars-per-token heuristic), or "bpe"
+		with a local tiktoken style rank file passed in --bpe-file.
+
+		--format, --output and secret redaction work the same as in "clip-file-contents".
+	`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		opts := cliputil.ContextOptions{
+			ClipOptions: cliputil.ClipOptions{
+				Root:    args[0],
+				Include: contextInclude,
+				Exclude: contextExclude,
+				Format:  contextFormat,
+				Output:  contextOutput,
+				Redact:  contextRedact,
+			},
+			MaxTokens: contextMaxTokens,
+			Focus:     contextFocus,
+			Near:      contextNear,
+			Tokenizer: tokenizer.Config{
+				Kind:          contextTokenizer,
+				BPEFile:       contextBPEFile,
+				CharsPerToken: contextCharsPerToken,
+			},
+		}
+
+		prepareOutput(contextOutput)
+
+		report, err := srv.CopyContextToClipboard(&opts)
+		if err != nil {
+			log.Errorf("copy context to clipboard: %v", err)
+			return
+		}
+
+		for _, skipped := range report.Skipped {
+			log.Warnf("skipped '%s': %s %s", skipped.Path, skipped.Reason, skipped.Detail)
+		}
+		for _, leftOut := range report.LeftOut {
+			log.Warnf("left out '%s': ~%d tokens", leftOut.Path, leftOut.Tokens)
+		}
+		logRedacted(report.Redacted)
+		log.Infof("copied \033[1;34m%v\033[0m files (~%d/%d tokens, %s) to %s!",
+			len(report.Included), report.TotalTokens, report.MaxTokens, report.Tokenizer, describeOutput(contextOutput))
+	},
+}
+
+func init() {
+	flags := copyContextToClipboardCmd.Flags()
+	flags.StringSliceVar(&contextInclude, "include", nil, "only consider files matching these globs (gitignore syntax)")
+	flags.StringSliceVar(&contextExclude, "exclude", nil, "skip paths matching these globs (gitignore syntax)")
+	flags.StringSliceVar(&contextFocus, "focus", nil, "rank files matching these globs first (gitignore syntax)")
+	flags.StringVar(&contextNear, "near", "", "rank files closest to this file first")
+	flags.IntVar(&contextMaxTokens, "max-tokens", 0, "token budget (0 uses the config)")
+	flags.StringVar(&contextTokenizer, "tokenizer", "", "token estimator: chars or bpe (empty uses the config)")
+	flags.StringVar(&contextBPEFile, "bpe-file", "", "tiktoken style rank file for the bpe tokenizer (empty uses the config)")
+	flags.Float64Var(&contextCharsPerToken, "chars-per-token", tokenizer.DefaultCharsPerToken, "characters per token for the chars tokenizer")
+	addRedactFlags(copyContextToClipboardCmd, &contextRedact)
+	addOutputFlags(copyContextToClipboardCmd, &contextFormat, &contextOutput)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/copyprofile"
+	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/spf13/cobra"
+	"runtime"
+	"strings"
+)
+
+var (
+	copyRunAll         bool
+	copyRunDryRun      bool
+	copyAddExclude     []string
+	copyAddExcludeFrom []string
+	copyAddMirror      bool
+	copyAddSync        bool
+	copyAddChecksum    bool
+	copyAddConcurrency int
+	copyAddHook        string
+	copyAddForce       bool
+)
+
+var copyCommand = &cobra.Command{
+	Use:   copyCmd.string(),
+	Short: "Runs named copy profiles.",
+	Long: `
+		Manages named "copy-folder-a-to-b" runs, stored under "profiles" in the
+		"copy-profiles.yaml" file (or .json) of the app dir (THEOVERWATCHTOOLS_APP_DIR):
+
+		  profiles:
+		    api:
+		      source: ~/code/api
+		      destination: /mnt/backup/api
+		      source_exclusions: [node_modules/, .env.*]
+		      sync: true
+		      hook: make test
+
+		Profiles take the options of "copy-folder-a-to-b" (wipe_destination mirrors,
+		sync only copies changes), and an optional hook, a shell command run in the
+		destination after a successful copy.
+	`,
+}
+
+var copyRunCommand = &cobra.Command{
+	Use:   "run [profile...]",
+	Short: "Copies the named profiles, or all of them with --all.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := args
+		if copyRunAll {
+			if len(args) > 0 {
+				return errors.New("--all takes no profile names")
+			}
+
+			profiles, err := srv.ListCopyProfiles()
+			if err != nil {
+				return err
+			}
+			for _, profile := range profiles {
+				names = append(names, profile.Name)
+			}
+		}
+
+		if len(names) == 0 {
+			return errors.New("no profile given, name one or use --all")
+		}
+
+		failed := 0
+		for _, name := range names {
+			plan, err := srv.RunCopyProfile(cmd.Context(), name, &copyprofile.RunOptions{
+				DryRun: copyRunDryRun,
+				Stdout: cmd.OutOrStdout(),
+				Stderr: cmd.ErrOrStderr(),
+			})
+			if plan != nil {
+				logCopyPlan(cmd, name, plan)
+			}
+			if err != nil {
+				log.Errorf("%v", err)
+				failed++
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d profile(s) failed", failed, len(names))
+		}
+		return nil
+	},
+}
+
+var copyListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the copy profiles.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profiles, err := srv.ListCopyProfiles()
+		if err != nil {
+			return err
+		}
+
+		if len(profiles) == 0 {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "No profiles yet, add one with \"copy add\" (stored in %s)\n", srv.CopyProfilesPath())
+			return nil
+		}
+
+		for _, profile := range profiles {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s -> %s\t(%s)\n", profile.Name, profile.Source, profile.Destination, describeProfileMode(&profile))
+		}
+		return nil
+	},
+}
+
+var copyAddCommand = &cobra.Command{
+	Use:   "add <name> <source> <destination>",
+	Short: "Adds a copy profile, or replaces it with --force.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		profile := &copyprofile.Profile{
+			Name: args[0],
+			CopyOptions: fslib.CopyOptions{
+				Source:           args[1],
+				Destination:      args[2],
+				SourceExclusions: copyAddExclude,
+				ExcludeFrom:      copyAddExcludeFrom,
+				WipeDestination:  copyAddMirror,
+				Sync:             copyAddSync,
+				Checksum:         copyAddChecksum,
+				Concurrency:      copyAddConcurrency,
+			},
+			Hook: copyAddHook,
+		}
+
+		if err := srv.AddCopyProfile(profile, copyAddForce); err != nil {
+			return err
+		}
+
+		log.Infof("Added profile '%s' to %s", profile.Name, srv.CopyProfilesPath())
+		return nil
+	},
+}
+
+func init() {
+	copyRunCommand.Flags().BoolVar(&copyRunAll, "all", false, "run every profile")
+	copyRunCommand.ValidArgsFunction = completeNames(copyProfileNames, true)
+	copyRunCommand.Flags().BoolVar(&copyRunDryRun, "dry-run", false, "print the plans without copying or running hooks")
+
+	flags := copyAddCommand.Flags()
+	flags.StringSliceVar(&copyAddExclude, "exclude", nil, "skip paths matching these globs (gitignore syntax)")
+	flags.StringSliceVar(&copyAddExcludeFrom, "exclude-from", nil, "read exclusions from these ignore files")
+	flags.BoolVar(&copyAddMirror, "mirror", false, "wipe the destination before copying")
+	flags.BoolVar(&copyAddSync, "sync", false, "only copy changed files, and delete the files missing from the source")
+	flags.BoolVar(&copyAddChecksum, "checksum", false, "compare file contents when syncing")
+	flags.IntVar(&copyAddConcurrency, "concurrency", 0, fmt.Sprintf("number of files copied at once, 0 uses the number of CPUs (%d)", runtime.NumCPU()))
+	flags.StringVar(&copyAddHook, "hook", "", "shell command run in the destination after copying")
+	flags.BoolVarP(&copyAddForce, "force", "f", false, "replace an existing profile")
+
+	copyCommand.AddCommand(copyRunCommand, copyListCommand, copyAddCommand)
+}
+
+// logCopyPlan prints the plan of a dry run, or the outcome of a copy.
+func logCopyPlan(cmd *cobra.Command, name string, plan *fslib.CopyPlan) {
+	if plan.DryRun {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "[%s]\n%s", name, plan.String())
+		return
+	}
+
+	for _, failure := range plan.Failures {
+		log.Errorf("[%s] failed to %s '%s': %s", name, failure.Op, failure.Path, failure.Err)
+	}
+	log.Infof("[%s] %s", name, plan.Summary())
+}
+
+func describeProfileMode(profile *copyprofile.Profile) string {
+	modes := []string{"copy"}
+	switch {
+	case profile.Sync:
+		modes = []string{"sync"}
+	case profile.WipeDestination:
+		modes = []string{"mirror"}
+	}
+	if profile.Hook != "" {
+		modes = append(modes, "hook")
+	}
+	return strings.Join(modes, ", ")
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/doctor"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlutil"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+var doctorTimeout time.Duration
+
+var doctorCommand = &cobra.Command{
+	Use:   doctorCmd.string(),
+	Short: "Checks the config, app dir, clipboard, database and docker, and tells how to fix them.",
+	Long: fmt.Sprintf(`
+		Runs every check and prints a report, with a hint for each problem:
+
+		- env:       the %[1]s* env vars, warning about unknown ones
+		- config:    the config loads, which every other command needs
+		- app dir:   %[1]sAPP_DIR exists and is writable
+		- clipboard: which backend copies go to (see --clipboard)
+		- database:  the database answers, only the API needs it
+		- docker:    the daemon answers, only the dockerized env needs it
+
+		Exits with an error when a check fails, warnings aside. It runs without
+		loading the config, so it works when every other command fails.
+	`, config.EnvPrefix),
+	Args: cobra.NoArgs,
+	Annotations: map[string]string{
+		skipContainer: "",
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, cfgErr := config.New()
+
+		checks := []doctor.Check{
+			doctor.Env(os.Environ(), config.EnvPrefix, config.EnvKeys()),
+			doctor.Config(func() error { return cfgErr }),
+		}
+		if cfgErr == nil {
+			checks = append(checks, configChecks(cmd, cfg)...)
+		}
+		checks = append(checks, doctor.Docker())
+
+		results := doctor.Run(cmd.Context(), doctorTimeout, checks...)
+		if err := doctor.Write(cmd.OutOrStdout(), results); err != nil {
+			return err
+		}
+
+		if doctor.Failed(results) {
+			cmd.SilenceUsage = true
+			return errors.New("some checks failed")
+		}
+		return nil
+	},
+}
+
+func init() {
+	doctorCommand.Flags().DurationVar(&doctorTimeout, "timeout", 5*time.Second, "how long each check may take")
+}
+
+// configChecks are the checks that need a loaded config.
+func configChecks(cmd *cobra.Command, cfg *config.App) []doctor.Check {
+	clipOpts := &clipboard.Options{
+		Backend: cfg.CopyToClipboard.Backend,
+		File:    cfg.CopyToClipboard.File,
+	}
+	if cmd.Flags().Changed("clipboard") {
+		clipOpts.Backend = clipboardBackend
+	}
+
+	db := mysqlutil.ConnectionSettings{
+		Host:     cfg.MysqlDatabaseCredentials.Host,
+		User:     cfg.MysqlDatabaseCredentials.User,
+		Pass:     cfg.MysqlDatabaseCredentials.Pass,
+		Database: cfg.MysqlDatabaseCredentials.Database,
+		Port:     cfg.MysqlDatabaseCredentials.Port,
+	}
+	target := fmt.Sprintf("mysql %s:%d/%s", db.Host, db.Port, db.Database)
+
+	return []doctor.Check{
+		doctor.Dir("app dir", cfg.Settings.AppDir),
+		doctor.Clipboard(clipOpts),
+		doctor.Database(target, func(ctx context.Context) error {
+			return mysqlhelper.Ping(ctx, db.GetConnectionString(false))
+		}),
+	}
+}
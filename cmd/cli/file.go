@@ -1,8 +1,20 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
 	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
 )
 
 var copyFolderAToBCommand = &cobra.Command{
@@ -13,9 +25,24 @@ var copyFolderAToBCommand = &cobra.Command{
 
         1. **Selective Copying with GenericExclusions from Folder A:**
            - Facilitates selective copying from the source directory, allowing for specific exclusions. Users can define files or subdirectories in Folder 'A' that should be omitted from the copying process, ensuring that only pertinent files are included in the operation.
+           - Exclusions use gitignore syntax ("**" globs, "!" negation, trailing "/" for directories), and are read from a .copyignore file in Folder 'A', --exclude-from files, then the configured and --exclude patterns, in that order. The .copyignore itself is not copied, unless negated with "!/.copyignore". Excluded paths are also kept in Folder 'B' when it is wiped.
 
-        2. **Pre-Copy Cleanup with WipeFolderB:**
-           - Offers an option to perform a cleanup of the destination directory (Folder 'B') prior to copying. If enabled, the command will clear all contents of Folder 'B', paving the way for a clean slate that will exclusively contain the files transferred from Folder 'A'.
+        2. **Pre-Copy Cleanup with --mirror:**
+           - Offers an opt-in cleanup of the destination directory (Folder 'B') prior to copying. With --mirror, the command will clear all contents of Folder 'B', paving the way for a clean slate that will exclusively contain the files transferred from Folder 'A'. Without it, files only present in Folder 'B' are kept.
+
+        3. **Previewing with --dry-run and --interactive:**
+           - --dry-run prints the plan of files created, overwritten and deleted (with byte counts) without changing anything.
+           - --interactive asks for confirmation when --mirror or --sync would delete more than --delete-threshold files.
+
+        4. **Incremental copies with --sync:**
+           - Only copies files whose size or modification time differ from Folder 'B' (or content, with --checksum), and only deletes the files of Folder 'B' that no longer exist in Folder 'A'. Reports the added, updated, deleted and unchanged counts.
+
+        5. **Parallel, faithful copies:**
+           - Files are copied by --concurrency workers. Symlinks are recreated as symlinks, and permissions, modification times and, where possible, ownership and extended attributes are kept.
+           - Each file is written to a temporary file, then renamed into place, so an interrupted copy never leaves a truncated file. Files that fail are reported once the copy is done, instead of aborting it.
+
+        6. **Watching with --watch:**
+           - After the copy, keeps watching Folder 'A' and syncs every change to Folder 'B', batching the changes made within --debounce. Files removed from Folder 'A' are removed from Folder 'B', and the same exclusions apply. Stops cleanly on Ctrl+C.
 
         The command initiates with a preface operation, ensuring all conditions are met for a smooth and error-free file transfer. Post this preliminary step, the command meticulously logs each phase of the operation, ensuring transparency and traceability of the process flow.
     `,
@@ -25,15 +52,173 @@ var copyFolderAToBCommand = &cobra.Command{
 		dst := args[1]
 
 		opts := fslib.CopyOptions{
-			Source:      args[0],
-			Destination: args[1],
+			Source:          args[0],
+			Destination:     args[1],
+			WipeDestination: copyMirror,
+			DryRun:          copyDryRun,
+			Sync:            copySync,
+			Checksum:        copyChecksum,
+			Concurrency:     copyConcurrency,
+
+			SourceExclusions:          copyExclude,
+			WipeDestinationExclusions: copyExclude,
+			ExcludeFrom:               copyExcludeFrom,
+		}
+
+		if copyDryRun && copyWatch {
+			log.Error("--dry-run and --watch are exclusive")
+			return
+		}
+
+		if copyDryRun {
+			plan, err := srv.CopyDirToAnother(&opts)
+			if err != nil {
+				log.Errorf("plan copy folder: %v", err)
+				return
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), plan.String())
+			return
+		}
+
+		if copyInteractive && (copyMirror || copySync) {
+			ok, err := confirmDeletes(cmd, opts)
+			if err != nil {
+				log.Errorf("confirm deletes: %v", err)
+				return
+			}
+			if !ok {
+				log.Info("Aborted, nothing was copied")
+				return
+			}
+		}
+
+		if copyWatch {
+			if err := watchCopy(cmd, opts); err != nil {
+				log.Errorf("watch folder: %v", err)
+			}
+			return
 		}
 
-		if err := srv.CopyDirToAnother(&opts); err != nil {
-			log.Errorf("clip gpt preface: %v", err)
+		plan, err := srv.CopyDirToAnother(&opts)
+		if err != nil {
+			log.Errorf("copy folder: %v", err)
 			return
 		}
 
-		log.Infof("Copied '\033[1m%s\033[0m' to '\033[1m%s\033[0m'", src, dst)
+		for _, failure := range plan.Failures {
+			log.Errorf("failed to %s '%s': %s", failure.Op, failure.Path, failure.Err)
+		}
+
+		log.Infof("Copied '\033[1m%s\033[0m' to '\033[1m%s\033[0m': %s", src, dst, plan.Summary())
 	},
 }
+
+var (
+	copyMirror          bool
+	copyDryRun          bool
+	copyInteractive     bool
+	copyDeleteThreshold int
+	copySync            bool
+	copyChecksum        bool
+	copyConcurrency     int
+	copyExclude         []string
+	copyExcludeFrom     []string
+	copyWatch           bool
+	copyDebounce        time.Duration
+)
+
+func init() {
+	flags := copyFolderAToBCommand.Flags()
+	flags.BoolVar(&copyMirror, "mirror", false, "wipe folder B before copying, so it mirrors folder A")
+	flags.BoolVar(&copyDryRun, "dry-run", false, "print the plan of creates, overwrites and deletes without copying")
+	flags.BoolVarP(&copyInteractive, "interactive", "i", false, "ask before deleting more than --delete-threshold files")
+	flags.IntVar(&copyDeleteThreshold, "delete-threshold", 10, "number of deletions --interactive allows without asking")
+	flags.BoolVar(&copySync, "sync", false, "only copy changed files, and delete the files of folder B missing from folder A")
+	flags.BoolVar(&copyChecksum, "checksum", false, "compare file contents instead of sizes and modification times when syncing")
+	flags.IntVar(&copyConcurrency, "concurrency", runtime.NumCPU(), "number of files copied at once")
+	flags.StringSliceVar(&copyExclude, "exclude", nil, "skip paths matching these globs (gitignore syntax), on top of the configured exclusions")
+	flags.StringSliceVar(&copyExcludeFrom, "exclude-from", nil, "read exclusions from these ignore files")
+	flags.BoolVar(&copyWatch, "watch", false, "keep syncing the changes made to folder A, until interrupted")
+	flags.DurationVar(&copyDebounce, "debounce", fslib.DefaultDebounce, "how long --watch waits for changes to settle")
+}
+
+// watchCopy copies, then syncs changes until SIGINT or SIGTERM.
+func watchCopy(cmd *cobra.Command, opts fslib.CopyOptions) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	// Channel to listen for termination signals
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	go func() {
+		select {
+		case <-quit:
+			log.Info("Stopping watch...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	log.Infof("Watching '\033[1m%s\033[0m' for changes, press Ctrl+C to stop", opts.Source)
+
+	err := srv.WatchDirToAnother(ctx, &opts, &fslib.WatchOptions{
+		Debounce: copyDebounce,
+		OnSync: func(plan *fslib.CopyPlan, err error) {
+			if err != nil {
+				log.Errorf("%v", err)
+				return
+			}
+
+			for _, failure := range plan.Failures {
+				log.Errorf("failed to %s '%s': %s", failure.Op, failure.Path, failure.Err)
+			}
+			if unchanged, _ := plan.Count(fslib.OpUnchanged); unchanged < len(plan.Actions) {
+				log.Infof("Synced: %s", plan.Summary())
+			}
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info("Watch stopped")
+	return nil
+}
+
+// confirmDeletes previews the copy, and asks for confirmation when it deletes
+// more files than the threshold.
+func confirmDeletes(cmd *cobra.Command, opts fslib.CopyOptions) (bool, error) {
+	opts.DryRun = true
+
+	plan, err := srv.CopyDirToAnother(&opts)
+	if err != nil {
+		return false, err
+	}
+
+	deletes, bytes := plan.Count(fslib.OpDelete)
+	if deletes <= copyDeleteThreshold {
+		return true, nil
+	}
+
+	out := cmd.ErrOrStderr()
+	for _, action := range plan.Actions {
+		if action.Op == fslib.OpDelete {
+			_, _ = fmt.Fprintf(out, "- %s\n", action.Path)
+		}
+	}
+	_, _ = fmt.Fprintf(out, "Delete %d file(s) (%s) from '%s'? [y/N] ", deletes, strutil.FormatBytes(bytes), opts.Destination)
+
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("read answer: %v", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
@@ -2,11 +2,17 @@ package main
 
 import (
 	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
 	"github.com/spf13/cobra"
 )
 
+var (
+	prefaceLanguage string
+	prefaceProject  string
+)
+
 var copyGptCodePrefaceToClipboardCommand = &cobra.Command{
-	Use:   clipGptPreface.string(),
+	Use:   clipGptPreface.string() + " [name]",
 	Short: "Copies a code preface for chat GPT that ensures code quality.",
 	Long: `
 		Copies a code preface for chat GPT that ensures code quality.
@@ -17,13 +23,40 @@ var copyGptCodePrefaceToClipboardCommand = &cobra.Command{
 		Defensive programming, testability, readability, modularity - and this
 		preface attempts to remediate that. It obviously will not be perfect,
 		but it gives tangible improvements (at least based on anecdotal experience).
+
+		Pass the name of a preface from the library to clip it instead of the coding
+		standards (see "preface list"). Templates can use {{.Language}} and {{.ProjectName}},
+		set with --language and --project (the project defaults to the repository name).
 	`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := srv.ClipCodingStandardsPreface(); err != nil {
+		opts := preface.Options{
+			Name: preface.DefaultName,
+			Vars: preface.Vars{
+				Language:    prefaceLanguage,
+				ProjectName: prefaceProject,
+			},
+		}
+		if len(args) == 1 {
+			opts.Name = args[0]
+		}
+
+		if err := srv.ClipPreface(&opts); err != nil {
 			return fmt.Errorf("clip gpt preface: %v", err)
 		}
-		log.Info("Copied gpt preface")
+		log.Infof("Copied gpt preface '%s'", opts.Name)
 
 		return nil
 	},
 }
+
+func init() {
+	addPrefaceVarFlags(copyGptCodePrefaceToClipboardCommand)
+	copyGptCodePrefaceToClipboardCommand.ValidArgsFunction = completeNames(prefaceNames, false)
+}
+
+// addPrefaceVarFlags registers the flags filling the preface template variables.
+func addPrefaceVarFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&prefaceLanguage, "language", "", "value of {{.Language}} in the preface")
+	cmd.Flags().StringVar(&prefaceProject, "project", "", "value of {{.ProjectName}} in the preface (defaults to the repository name)")
+}
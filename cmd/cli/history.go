@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
+	"github.com/spf13/cobra"
+	"strconv"
+	"text/tabwriter"
+)
+
+var clipHistoryLimit int
+
+var clipCommand = &cobra.Command{
+	Use:   clipCmd.string(),
+	Short: "Browses the history of payloads copied to the clipboard.",
+	Long: `
+		Every payload the CLI copies to the clipboard is recorded, with the time, the
+		command and the source path, in "clip-history.jsonl" of the app dir
+		(THEOVERWATCHTOOLS_APP_DIR). Only the last THEOVERWATCHTOOLS_CLIP_HISTORY_LIMIT
+		payloads are kept, 0 disables the history.
+
+		Entries are numbered from 1, the most recent.
+	`,
+}
+
+var clipHistoryCommand = &cobra.Command{
+	Use:   "history",
+	Short: "Lists the recorded clips, most recent first.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := srv.ClipHistory()
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "The clipboard history is empty")
+			return nil
+		}
+
+		if clipHistoryLimit > 0 && len(entries) > clipHistoryLimit {
+			entries = entries[:clipHistoryLimit]
+		}
+
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "#\tTIME\tCOMMAND\tSOURCE\tSIZE\tPREVIEW")
+		for i, entry := range entries {
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+				i+1,
+				entry.Time.Local().Format("2006-01-02 15:04:05"),
+				entry.Command,
+				entry.Source,
+				strutil.FormatBytes(int64(entry.Bytes)),
+				entry.Preview(40),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+var clipShowCommand = &cobra.Command{
+	Use:   "show <n>",
+	Short: "Prints the n-th most recent clip.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := parseClipNumber(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry, err := srv.GetClip(n)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprint(cmd.OutOrStdout(), entry.Payload)
+		return err
+	},
+}
+
+var clipRestoreCommand = &cobra.Command{
+	Use:   "restore <n>",
+	Short: "Copies the n-th most recent clip back to the clipboard.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		n, err := parseClipNumber(args[0])
+		if err != nil {
+			return err
+		}
+
+		entry, err := srv.RestoreClip(n)
+		if err != nil {
+			return err
+		}
+
+		log.Infof("restored the %s clip of '%s' (%s) to the clipboard!", entry.Command, entry.Source, strutil.FormatBytes(int64(entry.Bytes)))
+		return nil
+	},
+}
+
+func init() {
+	clipHistoryCommand.Flags().IntVarP(&clipHistoryLimit, "limit", "n", 0, "only list the most recent entries, 0 lists all")
+	clipCommand.AddCommand(clipHistoryCommand, clipShowCommand, clipRestoreCommand)
+}
+
+func parseClipNumber(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid entry '%s', expected a number from 1", arg)
+	}
+	return n, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+// addOutputFlags registers the shared --format and --output flags.
+func addOutputFlags(cmd *cobra.Command, format, output *string) {
+	cmd.Flags().StringVar(format, "format", cliputil.FormatPlain, fmt.Sprintf("output format: %s", strings.Join(cliputil.Formats, ", ")))
+	cmd.Flags().StringVarP(output, "output", "o", "", "write to a file, or '-' for stdout, instead of the clipboard")
+}
+
+// prepareOutput moves logs to stderr when the payload goes to stdout,
+// so they do not end up mixed into it.
+func prepareOutput(output string) {
+	if strings.TrimSpace(output) == cliputil.OutputStdout {
+		log.Logger.SetOutput(os.Stderr)
+	}
+}
+
+// describeOutput names where the payload went, for log messages.
+func describeOutput(output string) string {
+	switch output = strings.TrimSpace(output); output {
+	case "":
+		return "clipboard"
+	case cliputil.OutputStdout:
+		return "stdout"
+	default:
+		return fmt.Sprintf("'\033[1m%s\033[0m'", output)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+var (
+	prefaceFile   string
+	prefaceRender bool
+)
+
+var prefaceCommand = &cobra.Command{
+	Use:   prefaceCmd.string(),
+	Short: "Manages the library of gpt prefaces.",
+	Long: `
+		Manages the named gpt prefaces clipped by "clip-gpt-preface".
+
+		Prefaces are Go text/templates stored as "<name>.tmpl" files in the "prefaces"
+		folder of the app dir (THEOVERWATCHTOOLS_APP_DIR). Built-in prefaces ship with
+		the binary, and are overridden by a library preface of the same name.
+
+		Templates can use {{.Language}} and {{.ProjectName}}.
+	`,
+}
+
+var prefaceListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the available prefaces.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := srv.ListPrefaces()
+		if err != nil {
+			return err
+		}
+
+		for _, tmpl := range templates {
+			source := tmpl.Path
+			if tmpl.BuiltIn {
+				source = "built-in"
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\t(%s)\n", tmpl.Name, source)
+		}
+		return nil
+	},
+}
+
+var prefaceShowCommand = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Prints a preface template, or renders it with --render.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		text := ""
+		if prefaceRender {
+			rendered, err := srv.RenderPreface(&preface.Options{
+				Name: args[0],
+				Vars: preface.Vars{Language: prefaceLanguage, ProjectName: prefaceProject},
+			})
+			if err != nil {
+				return err
+			}
+			text = rendered
+		} else {
+			tmpl, err := srv.GetPreface(args[0])
+			if err != nil {
+				return err
+			}
+			text = tmpl.Text
+		}
+
+		_, err := fmt.Fprint(cmd.OutOrStdout(), text)
+		return err
+	},
+}
+
+var prefaceAddCommand = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Adds a preface, read from --file or stdin.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		text, err := readPrefaceText(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := srv.AddPreface(args[0], text); err != nil {
+			return err
+		}
+		log.Infof("Added preface '%s'", args[0])
+		return nil
+	},
+}
+
+var prefaceEditCommand = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edits a preface in $EDITOR, or replaces it with --file.",
+	Long: `
+		Opens the preface in $EDITOR (vi when unset). Editing a built-in preface saves
+		a copy in the library that overrides it. Use --file to replace the text instead.
+
+		The edited text must be a valid template, otherwise the previous text is restored.
+	`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		if prefaceFile != "" {
+			text, err := readPrefaceText(cmd)
+			if err != nil {
+				return err
+			}
+			if err := srv.SavePreface(name, text); err != nil {
+				return err
+			}
+			log.Infof("Saved preface '%s'", name)
+			return nil
+		}
+
+		tmpl, err := srv.GetPreface(name)
+		if err != nil {
+			return err
+		}
+
+		path, err := srv.PrefacePath(name)
+		if err != nil {
+			return err
+		}
+
+		if err := openEditor(path); err != nil {
+			return err
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read edited preface: %v", err)
+		}
+
+		if err := srv.SavePreface(name, string(b)); err != nil {
+			if restoreErr := srv.SavePreface(name, tmpl.Text); restoreErr != nil {
+				return fmt.Errorf("%v (restore: %v)", err, restoreErr)
+			}
+			return fmt.Errorf("%v, the previous text was restored", err)
+		}
+		log.Infof("Saved preface '%s'", name)
+		return nil
+	},
+}
+
+var prefaceRemoveCommand = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Removes a preface from the library.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := srv.RemovePreface(args[0]); err != nil {
+			return err
+		}
+		log.Infof("Removed preface '%s'", args[0])
+		return nil
+	},
+}
+
+func init() {
+	prefaceShowCommand.Flags().BoolVar(&prefaceRender, "render", false, "render the template instead of printing it")
+	addPrefaceVarFlags(prefaceShowCommand)
+
+	prefaceAddCommand.Flags().StringVarP(&prefaceFile, "file", "f", "", "read the preface from this file instead of stdin")
+	prefaceEditCommand.Flags().StringVarP(&prefaceFile, "file", "f", "", "replace the preface with this file instead of opening $EDITOR")
+
+	for _, cmd := range []*cobra.Command{prefaceShowCommand, prefaceEditCommand, prefaceRemoveCommand} {
+		cmd.ValidArgsFunction = completeNames(prefaceNames, false)
+	}
+
+	prefaceCommand.AddCommand(
+		prefaceListCommand,
+		prefaceShowCommand,
+		prefaceAddCommand,
+		prefaceEditCommand,
+		prefaceRemoveCommand,
+	)
+}
+
+// readPrefaceText reads the preface from --file, or stdin.
+func readPrefaceText(cmd *cobra.Command) (string, error) {
+	if prefaceFile != "" {
+		b, err := os.ReadFile(prefaceFile)
+		if err != nil {
+			return "", fmt.Errorf("read preface file: %v", err)
+		}
+		return string(b), nil
+	}
+
+	b, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return "", fmt.Errorf("read stdin: %v", err)
+	}
+	if strings.TrimSpace(string(b)) == "" {
+		return "", errors.New("empty preface, pass it through stdin or --file")
+	}
+	return string(b), nil
+}
+
+func openEditor(path string) error {
+	editor := strings.TrimSpace(os.Getenv("EDITOR"))
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// $EDITOR may hold arguments, e.g. "code --wait".
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("run editor '%s': %v", editor, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/lib/prompt"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strings"
+)
+
+var (
+	promptPreface   string
+	promptNoPreface bool
+	promptQuestion  string
+	promptOrder     []string
+	promptInclude   []string
+	promptExclude   []string
+	promptTree      bool
+	promptFormat    string
+	promptOutput    string
+	promptRedact    redact.Config
+)
+
+var promptCommand = &cobra.Command{
+	Use:   promptCmd.string() + " [root]",
+	Short: "Copies a preface, files and a question as a single prompt.",
+	Long: `
+		Composes one prompt out of:
+		  1. A preface from the library (see "preface list"), --preface picks which one.
+		  2. The files under the root, selected the same way as "clip-file-contents".
+		  3. The question, from --question, or stdin when it is not set (or set to "-").
+
+		Sections are separated with headings fitting the --format, and composed in the
+		--order given, e.g. --order question,files. The json format is an object with
+		"preface", "files" and "question" keys, so --order only picks its sections.
+		Files are left out without a root, and the preface with --no-preface.
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		question, err := readQuestion(cmd)
+		if err != nil {
+			return err
+		}
+
+		opts := prompt.Options{
+			Preface: preface.Options{
+				Name: promptPreface,
+				Vars: preface.Vars{
+					Language:    prefaceLanguage,
+					ProjectName: prefaceProject,
+				},
+			},
+			NoPreface: promptNoPreface,
+			Clip: cliputil.ClipOptions{
+				Include: promptInclude,
+				Exclude: promptExclude,
+				Tree:    promptTree,
+				Format:  promptFormat,
+				Output:  promptOutput,
+				Redact:  promptRedact,
+			},
+			Question: question,
+			Order:    promptOrder,
+		}
+		if len(args) == 1 {
+			opts.Clip.Root = args[0]
+		}
+
+		prepareOutput(promptOutput)
+
+		report, err := srv.CopyPromptToClipboard(&opts)
+		if err != nil {
+			return fmt.Errorf("copy prompt: %v", err)
+		}
+
+		for _, skipped := range report.Skipped {
+			log.Warnf("skipped '%s': %s %s", skipped.Path, skipped.Reason, skipped.Detail)
+		}
+		logRedacted(report.Redacted)
+		log.Infof("copied prompt with \033[1;34m%v\033[0m files (%s) to %s!", len(report.Files), strutil.FormatBytes(report.TotalBytes), describeOutput(promptOutput))
+
+		return nil
+	},
+}
+
+func init() {
+	flags := promptCommand.Flags()
+	flags.StringVar(&promptPreface, "preface", preface.DefaultName, "name of the preface to start with")
+	flags.BoolVar(&promptNoPreface, "no-preface", false, "leave the preface out")
+	flags.StringVarP(&promptQuestion, "question", "q", "", "the question, read from stdin when empty or '-'")
+	flags.StringSliceVar(&promptOrder, "order", prompt.DefaultOrder, "order of the sections")
+	flags.StringSliceVar(&promptInclude, "include", nil, "only include files matching these globs (gitignore syntax)")
+	flags.StringSliceVar(&promptExclude, "exclude", nil, "skip paths matching these globs (gitignore syntax)")
+	flags.BoolVar(&promptTree, "tree", false, "prepend a tree of the included files")
+	addPrefaceVarFlags(promptCommand)
+	addRedactFlags(promptCommand, &promptRedact)
+	addOutputFlags(promptCommand, &promptFormat, &promptOutput)
+}
+
+// readQuestion takes the question from --question, or from stdin.
+func readQuestion(cmd *cobra.Command) (string, error) {
+	if q := strings.TrimSpace(promptQuestion); q != "" && q != "-" {
+		return promptQuestion, nil
+	}
+
+	if f, ok := cmd.InOrStdin().(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Type the question, then press Ctrl+D:")
+		}
+	}
+
+	b, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return "", fmt.Errorf("read question: %v", err)
+	}
+	return string(b), nil
+}
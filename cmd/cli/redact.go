@@ -0,0 +1,19 @@
+package main
+
+import (
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/spf13/cobra"
+)
+
+// addRedactFlags registers the shared --no-redact and --redact-pattern flags.
+func addRedactFlags(cmd *cobra.Command, cfg *redact.Config) {
+	cmd.Flags().BoolVar(&cfg.Disabled, "no-redact", false, "clip secrets as they are, instead of masking them")
+	cmd.Flags().StringArrayVar(&cfg.Patterns, "redact-pattern", nil, "also mask matches of this regular expression (only its 'secret' group, when it has one)")
+}
+
+// logRedacted lists the masked secrets, without their values.
+func logRedacted(findings []redact.Finding) {
+	for _, finding := range findings {
+		log.Warnf("redacted %s in '%s' line %d", finding.Detector, finding.Path, finding.Line)
+	}
+}
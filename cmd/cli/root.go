@@ -5,43 +5,119 @@ import (
 	"fmt"
 	"github.com/dembygenesis/local.tools/di/ctn/dic"
 	"github.com/dembygenesis/local.tools/internal/cli"
+	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
 	"github.com/dembygenesis/local.tools/internal/lib/logger"
 	"github.com/spf13/cobra"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 var (
 	ctn *dic.Container
-	err error
 	srv *cli.Service
 	log = logger.New(context.TODO())
 )
 
-func init() {
-	ctn, err = dic.NewContainer()
+// skipContainer annotates the commands that run without the container, so they
+// keep working when the config is broken.
+const skipContainer = "skip-container"
+
+var rootCmd = &cobra.Command{
+	Use:   filepath.Base(os.Args[0]),
+	Short: "Clips files and prompts for LLMs, and copies folders around.",
+	Long: fmt.Sprintf(`
+		Clips files, context and prompts for LLMs to the clipboard, applies their
+		responses, and copies folders around.
+
+		It is configured with %[1]s* env vars, e.g. the clipboard
+		history, prefaces and snippets are kept in %[1]sAPP_DIR.
+
+		Run "doctor" to check the config, and "completion --help" to set up shell
+		completion.
+	`, config.EnvPrefix),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("clipboard") && clipboardBackend != clipboard.Auto {
+			if _, err := clipboard.New(&clipboard.Options{Backend: clipboardBackend}); err != nil {
+				return err
+			}
+		}
+
+		if !needsContainer(cmd) {
+			return nil
+		}
+
+		return loadContainer(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+// needsContainer reports whether the command, or one of its parents, was not
+// annotated with skipContainer. Shell completion requests load it on demand.
+func needsContainer(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[skipContainer]; ok {
+			return false
+		}
+		if c.Name() == cobra.ShellCompRequestCmd || c.Name() == cobra.ShellCompNoDescRequestCmd {
+			return false
+		}
+	}
+	return true
+}
+
+// loadContainer builds the container and the cli service once, and applies the
+// --clipboard override to the config.
+func loadContainer(cmd *cobra.Command) error {
+	if srv != nil {
+		return nil
+	}
+
+	c, err := dic.NewContainer()
 	if err != nil {
-		log.Fatalf("new container: %v", err)
+		return fmt.Errorf("new container: %v", err)
 	}
 
-	srv, err = ctn.SafeGetServiceCli()
+	cfg, err := c.SafeGetConfigLayer()
 	if err != nil {
-		log.Fatalf("safe services cli: %v", err)
+		return fmt.Errorf("get config: %v, run \"doctor\" for details", err)
+	}
+	if cmd.Flags().Changed("clipboard") {
+		cfg.CopyToClipboard.Backend = clipboardBackend
 	}
-}
 
-var rootCmd = &cobra.Command{
-	Use:   "Root",
-	Short: "This is the root command.",
-	Long:  `This is the root command.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("Please select a command")
-	},
+	s, err := c.SafeGetServiceCli()
+	if err != nil {
+		return fmt.Errorf("safe services cli: %v", err)
+	}
+
+	ctn, srv = c, s
+	return nil
 }
 
+// clipboardBackend overrides the configured clipboard backend of every command.
+var clipboardBackend string
+
 func init() {
+	rootCmd.PersistentFlags().StringVar(&clipboardBackend, "clipboard", clipboard.Auto, fmt.Sprintf("clipboard backend: %s", strings.Join(clipboard.Names(), ", ")))
+
 	rootCmd.AddCommand(copyToClipboardCmd)
+	rootCmd.AddCommand(copyContextToClipboardCmd)
 	rootCmd.AddCommand(copyGptCodePrefaceToClipboardCommand)
+	rootCmd.AddCommand(prefaceCommand)
+	rootCmd.AddCommand(snippetCommand)
+	rootCmd.AddCommand(promptCommand)
+	rootCmd.AddCommand(clipCommand)
 	rootCmd.AddCommand(copyFolderAToBCommand)
+	rootCmd.AddCommand(copyCommand)
+	rootCmd.AddCommand(applyClipboardCommand)
+	rootCmd.AddCommand(completionCommand)
+	rootCmd.AddCommand(doctorCommand)
+
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
 }
 
 func main() {
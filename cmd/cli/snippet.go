@@ -0,0 +1,263 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/snippet"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+var (
+	snippetOutput      string
+	snippetFile        string
+	snippetDescription string
+	snippetOverwrite   bool
+)
+
+var snippetCommand = &cobra.Command{
+	Use:   snippetCmd.string(),
+	Short: "Manages named text snippets, and copies them with their placeholders filled in.",
+	Long: `
+		Snippets are named texts stored in "snippets.json" of the app dir
+		(THEOVERWATCHTOOLS_APP_DIR). Their {{placeholders}} are filled in when clipped,
+		from key=value arguments:
+
+			snippet add review -d "code review ask" <<< "Review {{file}} for {{focus|bugs}}."
+			snippet clip review file=main.go
+
+		A placeholder written {{name|default}} falls back to the default when no value
+		is given. Names are lower case letters, digits, '.', '-' and '_'.
+	`,
+}
+
+var snippetClipCommand = &cobra.Command{
+	Use:   "clip <name> [key=value...]",
+	Short: "Copies a snippet, with its placeholders filled in from the arguments.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prepareOutput(snippetOutput)
+
+		values, err := snippet.ParseValues(args[1:])
+		if err != nil {
+			return err
+		}
+
+		if err := srv.ClipSnippet(args[0], values, snippetOutput); err != nil {
+			return err
+		}
+		log.Infof("Copied snippet '%s' to %s", args[0], describeOutput(snippetOutput))
+		return nil
+	},
+}
+
+var snippetShowCommand = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Prints a snippet, without filling in its placeholders.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snip, err := srv.GetSnippet(args[0])
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprint(cmd.OutOrStdout(), snip.Text)
+		return err
+	},
+}
+
+var snippetAddCommand = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Adds a snippet, read from --file or stdin.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		text, err := readSnippetText(cmd)
+		if err != nil {
+			return err
+		}
+
+		snip := snippet.Snippet{Name: args[0], Description: snippetDescription, Text: text}
+		if err := srv.AddSnippet(snip, snippetOverwrite); err != nil {
+			return err
+		}
+		log.Infof("Added snippet '%s'", args[0])
+		return nil
+	},
+}
+
+var snippetRemoveCommand = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Removes a snippet.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := srv.RemoveSnippet(args[0]); err != nil {
+			return err
+		}
+		log.Infof("Removed snippet '%s'", args[0])
+		return nil
+	},
+}
+
+var snippetListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the snippets, with their placeholders.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snippets, err := srv.ListSnippets()
+		if err != nil {
+			return err
+		}
+
+		if len(snippets) == 0 {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "There are no snippets, add one with \"snippet add\"")
+			return nil
+		}
+
+		return printSnippets(cmd.OutOrStdout(), snippets)
+	},
+}
+
+var snippetSearchCommand = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Finds snippets by fuzzy name, description or text, best matches first.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		matches, err := srv.SearchSnippets(strings.Join(args, " "))
+		if err != nil {
+			return err
+		}
+
+		if len(matches) == 0 {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "No snippets match")
+			return nil
+		}
+
+		snippets := make([]snippet.Snippet, 0, len(matches))
+		for _, m := range matches {
+			snippets = append(snippets, m.Snippet)
+		}
+		return printSnippets(cmd.OutOrStdout(), snippets)
+	},
+}
+
+var snippetExportCommand = &cobra.Command{
+	Use:   "export [name...]",
+	Short: "Writes the snippets as JSON to stdout, or --output. All of them when no names are given.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snippets, err := srv.ExportSnippets(args...)
+		if err != nil {
+			return err
+		}
+
+		if snippetOutput == "" || snippetOutput == "-" {
+			return snippet.Encode(cmd.OutOrStdout(), snippets)
+		}
+
+		f, err := os.Create(snippetOutput)
+		if err != nil {
+			return fmt.Errorf("create export: %v", err)
+		}
+		if err := snippet.Encode(f, snippets); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("write export: %v", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("close export: %v", err)
+		}
+
+		log.Infof("Exported %d snippet(s) to '%s'", len(snippets), snippetOutput)
+		return nil
+	},
+}
+
+var snippetImportCommand = &cobra.Command{
+	Use:   "import <file|->",
+	Short: "Adds the snippets of a JSON export, all of them or none.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		r := cmd.InOrStdin()
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("open import: %v", err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		snippets, err := snippet.Decode(r)
+		if err != nil {
+			return err
+		}
+
+		if err := srv.ImportSnippets(snippets, snippetOverwrite); err != nil {
+			return err
+		}
+		log.Infof("Imported %d snippet(s)", len(snippets))
+		return nil
+	},
+}
+
+func init() {
+	snippetClipCommand.Flags().StringVarP(&snippetOutput, "output", "o", "", "write to a file, or '-' for stdout, instead of the clipboard")
+
+	snippetAddCommand.Flags().StringVarP(&snippetFile, "file", "f", "", "read the snippet from this file instead of stdin")
+	snippetAddCommand.Flags().StringVarP(&snippetDescription, "description", "d", "", "what the snippet is for, shown in list and search")
+	snippetAddCommand.Flags().BoolVar(&snippetOverwrite, "overwrite", false, "replace a snippet with the same name")
+
+	snippetExportCommand.Flags().StringVarP(&snippetOutput, "output", "o", "", "write the export to this file instead of stdout")
+	snippetImportCommand.Flags().BoolVar(&snippetOverwrite, "overwrite", false, "replace snippets with the same names")
+
+	snippetClipCommand.ValidArgsFunction = completeSnippetValues
+	snippetShowCommand.ValidArgsFunction = completeNames(snippetNames, false)
+	snippetRemoveCommand.ValidArgsFunction = completeNames(snippetNames, false)
+	snippetExportCommand.ValidArgsFunction = completeNames(snippetNames, true)
+
+	snippetCommand.AddCommand(
+		snippetClipCommand,
+		snippetShowCommand,
+		snippetAddCommand,
+		snippetRemoveCommand,
+		snippetListCommand,
+		snippetSearchCommand,
+		snippetExportCommand,
+		snippetImportCommand,
+	)
+}
+
+// printSnippets lists the snippets with their description and placeholders.
+func printSnippets(out io.Writer, snippets []snippet.Snippet) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tDESCRIPTION\tPLACEHOLDERS")
+	for _, s := range snippets {
+		placeholders := make([]string, 0)
+		for _, p := range s.Placeholders() {
+			placeholders = append(placeholders, p.String())
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, s.Description, strings.Join(placeholders, ", "))
+	}
+	return w.Flush()
+}
+
+// readSnippetText reads the snippet from --file, or stdin.
+func readSnippetText(cmd *cobra.Command) (string, error) {
+	if snippetFile != "" {
+		b, err := os.ReadFile(snippetFile)
+		if err != nil {
+			return "", fmt.Errorf("read snippet file: %v", err)
+		}
+		return string(b), nil
+	}
+
+	b, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return "", fmt.Errorf("read stdin: %v", err)
+	}
+	if strings.TrimSpace(string(b)) == "" {
+		return "", errors.New("empty snippet, pass it through stdin or --file")
+	}
+	return string(b), nil
+}
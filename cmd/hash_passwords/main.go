@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"github.com/dembygenesis/local.tools/di/ctn/dic"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"log"
+)
+
+// Hashes the passwords stored in plaintext before hashing was added, with
+// the configured PASSWORD_* parameters. Hashed passwords are left as they are,
+// so it is safe to run again.
+func main() {
+	builder, err := dic.NewBuilder()
+	if err != nil {
+		log.Fatalf("builder: %v", err)
+	}
+
+	ctn := builder.Build()
+	users, err := ctn.SafeGetLogicUser()
+	if err != nil {
+		log.Fatalf("user mgr: %v", err)
+	}
+
+	_log := logger.New(context.Background())
+
+	_log.Info("Hashing plaintext passwords...")
+	hashed, err := users.HashPlaintextPasswords(context.Background())
+	if err != nil {
+		_log.Fatalf("hash passwords: %v", err)
+	}
+
+	_log.Infof("Hashed %d passwords", hashed)
+}
@@ -3,9 +3,14 @@ package dependencies
 import (
 	"fmt"
 	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/authtoken"
+	"github.com/dembygenesis/local.tools/internal/lib/mailer"
+	"github.com/dembygenesis/local.tools/internal/lib/passhash"
+	"github.com/dembygenesis/local.tools/internal/logic_handlers/apikeylogic"
 	"github.com/dembygenesis/local.tools/internal/logic_handlers/authlogic"
 	"github.com/dembygenesis/local.tools/internal/logic_handlers/categorylogic"
 	"github.com/dembygenesis/local.tools/internal/logic_handlers/marketinglogic"
+	"github.com/dembygenesis/local.tools/internal/logic_handlers/rbaclogic"
 	"github.com/dembygenesis/local.tools/internal/logic_handlers/userlogic"
 	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlconn"
 	"github.com/dembygenesis/local.tools/internal/persistence/persistors/mysqlstore"
@@ -17,6 +22,8 @@ const (
 	logicCategory  = "logic_category"
 	logicUser      = "logic_user"
 	logicAuth      = "logic_auth"
+	logicRBAC      = "logic_rbac"
+	logicAPIKey    = "logic_api_key"
 	logicMarketing = "logic_marketing"
 )
 
@@ -29,11 +36,13 @@ func GetLogicHandlers() []dingo.Def {
 				logger *logrus.Entry,
 				txProvider *mysqlconn.Provider,
 				store *mysqlstore.Repository,
+				rbac *rbaclogic.Impl,
 			) (*categorylogic.Service, error) {
 				logic, err := categorylogic.New(&categorylogic.Config{
 					TxProvider: txProvider,
 					Logger:     logger,
 					Persistor:  store,
+					Guard:      rbac,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("logicategory: %v", err)
@@ -47,10 +56,24 @@ func GetLogicHandlers() []dingo.Def {
 				cfg *config.App,
 				logger *logrus.Entry,
 				txProvider *mysqlconn.Provider,
+				store *mysqlstore.Repository,
 			) (*userlogic.Impl, error) {
+				hasher, err := passhash.New(&passhash.Config{
+					Algorithm:         cfg.Password.Algorithm,
+					BcryptCost:        cfg.Password.BcryptCost,
+					Argon2Memory:      cfg.Password.Argon2MemoryKiB,
+					Argon2Iterations:  cfg.Password.Argon2Iterations,
+					Argon2Parallelism: cfg.Password.Argon2Parallelism,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("logicuser hasher: %v", err)
+				}
+
 				logic, err := userlogic.New(&userlogic.Config{
 					TxProvider: txProvider,
 					Logger:     logger,
+					Persistor:  store,
+					Hasher:     hasher,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("logicuser: %v", err)
@@ -64,13 +87,68 @@ func GetLogicHandlers() []dingo.Def {
 				cfg *config.App,
 				logger *logrus.Entry,
 				txProvider *mysqlconn.Provider,
+				store *mysqlstore.Repository,
+				users *userlogic.Impl,
 			) (*authlogic.Impl, error) {
+				signer, err := newAuthSigner(&cfg.Auth)
+				if err != nil {
+					return nil, fmt.Errorf("logicauth signer: %v", err)
+				}
+
+				mail, err := newMailer(&cfg.Mail, cfg.Settings.IsProduction, logger)
+				if err != nil {
+					return nil, fmt.Errorf("logicauth mailer: %v", err)
+				}
+
 				logic, err := authlogic.New(&authlogic.Config{
+					TxProvider:       txProvider,
+					Logger:           logger,
+					Persistor:        store,
+					Passwords:        users,
+					Signer:           signer,
+					RefreshTokenTTL:  cfg.Auth.RefreshTokenTTL,
+					Mailer:           mail,
+					PasswordResetURL: cfg.Auth.PasswordResetURL,
+					PasswordResetTTL: cfg.Auth.PasswordResetTTL,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("logicauth: %v", err)
+				}
+				return logic, nil
+			},
+		},
+		{
+			Name: logicRBAC,
+			Build: func(
+				logger *logrus.Entry,
+				txProvider *mysqlconn.Provider,
+				store *mysqlstore.Repository,
+			) (*rbaclogic.Impl, error) {
+				logic, err := rbaclogic.New(&rbaclogic.Config{
+					TxProvider: txProvider,
+					Logger:     logger,
+					Persistor:  store,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("logicrbac: %v", err)
+				}
+				return logic, nil
+			},
+		},
+		{
+			Name: logicAPIKey,
+			Build: func(
+				logger *logrus.Entry,
+				txProvider *mysqlconn.Provider,
+				store *mysqlstore.Repository,
+			) (*apikeylogic.Impl, error) {
+				logic, err := apikeylogic.New(&apikeylogic.Config{
 					TxProvider: txProvider,
 					Logger:     logger,
+					Persistor:  store,
 				})
 				if err != nil {
-					return nil, fmt.Errorf("logicauth: %v", err)
+					return nil, fmt.Errorf("logicapikey: %v", err)
 				}
 				return logic, nil
 			},
@@ -94,3 +172,51 @@ func GetLogicHandlers() []dingo.Def {
 		},
 	}
 }
+
+// newAuthSigner creates the access token signer, from the HMAC secret or the
+// RSA key file of the config.
+func newAuthSigner(cfg *config.Auth) (*authtoken.Signer, error) {
+	signerCfg := &authtoken.Config{
+		Method:     cfg.SigningMethod,
+		HMACSecret: []byte(cfg.HMACSecret),
+		Issuer:     cfg.Issuer,
+		TTL:        cfg.AccessTokenTTL,
+	}
+
+	if cfg.SigningMethod == authtoken.MethodRS256 {
+		key, err := authtoken.LoadRSAPrivateKey(cfg.RSAPrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("rsa key '%s': %v", cfg.RSAPrivateKeyFile, err)
+		}
+		signerCfg.RSAPrivateKey = key
+	}
+
+	return authtoken.New(signerCfg)
+}
+
+// newMailer creates the mailer of the configured driver. The log driver is
+// refused in production, as the emails would never leave the server.
+func newMailer(cfg *config.Mail, production bool, logger *logrus.Entry) (mailer.Mailer, error) {
+	if production && cfg.Driver == mailer.DriverLog {
+		return nil, fmt.Errorf("the '%s' mail driver only logs the emails, set MAIL_DRIVER to '%s' or '%s' in production", mailer.DriverLog, mailer.DriverSMTP, mailer.DriverFile)
+	}
+
+	switch cfg.Driver {
+	case mailer.DriverSMTP:
+		return mailer.NewSMTP(&mailer.SMTPConfig{
+			Host:        cfg.SMTPHost,
+			Port:        cfg.SMTPPort,
+			Username:    cfg.SMTPUsername,
+			Password:    cfg.SMTPPassword,
+			From:        cfg.From,
+			ImplicitTLS: cfg.SMTPImplicitTLS,
+			Insecure:    cfg.SMTPInsecure,
+		})
+	case mailer.DriverFile:
+		return mailer.NewFile(cfg.File, cfg.From)
+	case mailer.DriverLog:
+		return mailer.NewLog(logger)
+	default:
+		return nil, fmt.Errorf("unsupported mail driver '%s'", cfg.Driver)
+	}
+}
@@ -1,10 +1,13 @@
 package dependencies
 
 import (
+	"fmt"
 	"github.com/dembygenesis/local.tools/di/cfg/dependencies/wrappers"
 	"github.com/dembygenesis/local.tools/internal/cli"
 	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/services/filesrv"
 	"github.com/dembygenesis/local.tools/internal/services/gptsrv"
+	"github.com/dembygenesis/local.tools/internal/services/strsrv"
 	"github.com/sarulabs/dingo/v4"
 )
 
@@ -19,9 +22,20 @@ func GetServicesLayer() []dingo.Def {
 			Build: func(
 				cfg *config.App,
 			) (*cli.Service, error) {
-				fileUtil := wrappers.NewFileUtilsWrapper()
-				strUtil := wrappers.NewStringUtilsWrapper()
-				gptUtil := gptsrv.New()
+				gptUtil, err := gptsrv.New(cfg)
+				if err != nil {
+					return nil, fmt.Errorf("gpt utils: %v", err)
+				}
+
+				strUtil, err := strsrv.New(cfg, wrappers.NewStringUtilsWrapper())
+				if err != nil {
+					return nil, fmt.Errorf("string utils: %v", err)
+				}
+
+				fileUtil, err := filesrv.New(cfg, wrappers.NewFileUtilsWrapper())
+				if err != nil {
+					return nil, fmt.Errorf("file utils: %v", err)
+				}
 
 				return cli.NewService(strUtil, gptUtil, fileUtil), nil
 			},
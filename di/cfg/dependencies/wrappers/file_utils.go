@@ -1,6 +1,7 @@
 package wrappers
 
 import (
+	"context"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
 )
 
@@ -11,6 +12,10 @@ func NewFileUtilsWrapper() *FileWrapper {
 type FileWrapper struct {
 }
 
-func (f *FileWrapper) CopyDirToAnother(opts *fslib.CopyOptions) error {
+func (f *FileWrapper) CopyDirToAnother(opts *fslib.CopyOptions) (*fslib.CopyPlan, error) {
 	return fslib.CopyDirToAnother(opts)
 }
+
+func (f *FileWrapper) Watch(ctx context.Context, opts *fslib.CopyOptions, watchOpts *fslib.WatchOptions) error {
+	return fslib.Watch(ctx, opts, watchOpts)
+}
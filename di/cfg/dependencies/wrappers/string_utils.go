@@ -1,7 +1,11 @@
 package wrappers
 
 import (
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
 	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"strings"
 )
 
 func NewStringUtilsWrapper() *StringWrapper {
@@ -11,6 +15,35 @@ func NewStringUtilsWrapper() *StringWrapper {
 type StringWrapper struct {
 }
 
-func (f *StringWrapper) CopyRootPathToClipboard(root string, exclude []string) ([]string, error) {
-	return cliputil.CopyRootPathToClipboard(root, exclude)
+func (f *StringWrapper) RenderRootPath(opts *cliputil.ClipOptions) (string, *cliputil.ClipReport, error) {
+	return cliputil.RenderRootPath(opts)
+}
+
+func (f *StringWrapper) RenderContext(opts *cliputil.ContextOptions) (string, *cliputil.ContextReport, error) {
+	return cliputil.RenderContext(opts)
+}
+
+func (f *StringWrapper) RenderPreface(libraryDir string, opts *preface.Options) (string, error) {
+	return preface.NewLibrary(libraryDir).Render(opts)
+}
+
+// WriteOutput writes to stdout or a file, the string service writes the
+// clipboard itself with WriteClipboard.
+func (f *StringWrapper) WriteOutput(output, payload string) error {
+	if strings.TrimSpace(output) == "" {
+		return errors.New("no output, use WriteClipboard for the clipboard")
+	}
+	return cliputil.WriteOutput(nil, output, payload)
+}
+
+func (f *StringWrapper) WriteClipboard(opts *clipboard.Options, payload string) (string, error) {
+	return clipboard.Write(opts, payload)
+}
+
+func (f *StringWrapper) ReadInput(input string) (string, error) {
+	return cliputil.ReadInput(input)
+}
+
+func (f *StringWrapper) ReadClipboard(opts *clipboard.Options) (string, string, error) {
+	return clipboard.Read(opts)
 }
@@ -12,9 +12,11 @@ import (
 
 	cli "github.com/dembygenesis/local.tools/internal/cli"
 	config "github.com/dembygenesis/local.tools/internal/config"
+	apikeylogic "github.com/dembygenesis/local.tools/internal/logic_handlers/apikeylogic"
 	authlogic "github.com/dembygenesis/local.tools/internal/logic_handlers/authlogic"
 	categorylogic "github.com/dembygenesis/local.tools/internal/logic_handlers/categorylogic"
 	marketinglogic "github.com/dembygenesis/local.tools/internal/logic_handlers/marketinglogic"
+	rbaclogic "github.com/dembygenesis/local.tools/internal/logic_handlers/rbaclogic"
 	userlogic "github.com/dembygenesis/local.tools/internal/logic_handlers/userlogic"
 	mysqlconn "github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlconn"
 	mysqltx "github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
@@ -610,6 +612,146 @@ func LoggerLogrus(i interface{}) *logrus.Entry {
 	return C(i).GetLoggerLogrus()
 }
 
+// SafeGetLogicApiKey retrieves the "logic_api_key" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_api_key"
+//	type: *apikeylogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// If the object can not be retrieved, it returns an error.
+func (c *Container) SafeGetLogicApiKey() (*apikeylogic.Impl, error) {
+	i, err := c.ctn.SafeGet("logic_api_key")
+	if err != nil {
+		var eo *apikeylogic.Impl
+		return eo, err
+	}
+	o, ok := i.(*apikeylogic.Impl)
+	if !ok {
+		return o, errors.New("could get 'logic_api_key' because the object could not be cast to *apikeylogic.Impl")
+	}
+	return o, nil
+}
+
+// GetLogicApiKey retrieves the "logic_api_key" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_api_key"
+//	type: *apikeylogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// If the object can not be retrieved, it panics.
+func (c *Container) GetLogicApiKey() *apikeylogic.Impl {
+	o, err := c.SafeGetLogicApiKey()
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// UnscopedSafeGetLogicApiKey retrieves the "logic_api_key" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_api_key"
+//	type: *apikeylogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// This method can be called even if main is a sub-scope of the container.
+// If the object can not be retrieved, it returns an error.
+func (c *Container) UnscopedSafeGetLogicApiKey() (*apikeylogic.Impl, error) {
+	i, err := c.ctn.UnscopedSafeGet("logic_api_key")
+	if err != nil {
+		var eo *apikeylogic.Impl
+		return eo, err
+	}
+	o, ok := i.(*apikeylogic.Impl)
+	if !ok {
+		return o, errors.New("could get 'logic_api_key' because the object could not be cast to *apikeylogic.Impl")
+	}
+	return o, nil
+}
+
+// UnscopedGetLogicApiKey retrieves the "logic_api_key" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_api_key"
+//	type: *apikeylogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// This method can be called even if main is a sub-scope of the container.
+// If the object can not be retrieved, it panics.
+func (c *Container) UnscopedGetLogicApiKey() *apikeylogic.Impl {
+	o, err := c.UnscopedSafeGetLogicApiKey()
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// LogicApiKey retrieves the "logic_api_key" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_api_key"
+//	type: *apikeylogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// It tries to find the container with the C method and the given interface.
+// If the container can be retrieved, it calls the GetLogicApiKey method.
+// If the container can not be retrieved, it panics.
+func LogicApiKey(i interface{}) *apikeylogic.Impl {
+	return C(i).GetLogicApiKey()
+}
+
 // SafeGetLogicAuth retrieves the "logic_auth" object from the main scope.
 //
 // ---------------------------------------------
@@ -622,6 +764,8 @@ func LoggerLogrus(i interface{}) *logrus.Entry {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*userlogic.Impl) ["logic_user"]
 //	unshared: false
 //	close: false
 //
@@ -653,6 +797,8 @@ func (c *Container) SafeGetLogicAuth() (*authlogic.Impl, error) {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*userlogic.Impl) ["logic_user"]
 //	unshared: false
 //	close: false
 //
@@ -679,6 +825,8 @@ func (c *Container) GetLogicAuth() *authlogic.Impl {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*userlogic.Impl) ["logic_user"]
 //	unshared: false
 //	close: false
 //
@@ -711,6 +859,8 @@ func (c *Container) UnscopedSafeGetLogicAuth() (*authlogic.Impl, error) {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*userlogic.Impl) ["logic_user"]
 //	unshared: false
 //	close: false
 //
@@ -738,6 +888,8 @@ func (c *Container) UnscopedGetLogicAuth() *authlogic.Impl {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*userlogic.Impl) ["logic_user"]
 //	unshared: false
 //	close: false
 //
@@ -763,6 +915,7 @@ func LogicAuth(i interface{}) *authlogic.Impl {
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
 //		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*rbaclogic.Impl) ["logic_rbac"]
 //	unshared: false
 //	close: false
 //
@@ -795,6 +948,7 @@ func (c *Container) SafeGetLogicCategory() (*categorylogic.Service, error) {
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
 //		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*rbaclogic.Impl) ["logic_rbac"]
 //	unshared: false
 //	close: false
 //
@@ -822,6 +976,7 @@ func (c *Container) GetLogicCategory() *categorylogic.Service {
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
 //		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*rbaclogic.Impl) ["logic_rbac"]
 //	unshared: false
 //	close: false
 //
@@ -855,6 +1010,7 @@ func (c *Container) UnscopedSafeGetLogicCategory() (*categorylogic.Service, erro
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
 //		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*rbaclogic.Impl) ["logic_rbac"]
 //	unshared: false
 //	close: false
 //
@@ -883,6 +1039,7 @@ func (c *Container) UnscopedGetLogicCategory() *categorylogic.Service {
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
 //		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//		- "4": Service(*rbaclogic.Impl) ["logic_rbac"]
 //	unshared: false
 //	close: false
 //
@@ -1035,6 +1192,146 @@ func LogicMarketing(i interface{}) *marketinglogic.Impl {
 	return C(i).GetLogicMarketing()
 }
 
+// SafeGetLogicRbac retrieves the "logic_rbac" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_rbac"
+//	type: *rbaclogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// If the object can not be retrieved, it returns an error.
+func (c *Container) SafeGetLogicRbac() (*rbaclogic.Impl, error) {
+	i, err := c.ctn.SafeGet("logic_rbac")
+	if err != nil {
+		var eo *rbaclogic.Impl
+		return eo, err
+	}
+	o, ok := i.(*rbaclogic.Impl)
+	if !ok {
+		return o, errors.New("could get 'logic_rbac' because the object could not be cast to *rbaclogic.Impl")
+	}
+	return o, nil
+}
+
+// GetLogicRbac retrieves the "logic_rbac" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_rbac"
+//	type: *rbaclogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// If the object can not be retrieved, it panics.
+func (c *Container) GetLogicRbac() *rbaclogic.Impl {
+	o, err := c.SafeGetLogicRbac()
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// UnscopedSafeGetLogicRbac retrieves the "logic_rbac" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_rbac"
+//	type: *rbaclogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// This method can be called even if main is a sub-scope of the container.
+// If the object can not be retrieved, it returns an error.
+func (c *Container) UnscopedSafeGetLogicRbac() (*rbaclogic.Impl, error) {
+	i, err := c.ctn.UnscopedSafeGet("logic_rbac")
+	if err != nil {
+		var eo *rbaclogic.Impl
+		return eo, err
+	}
+	o, ok := i.(*rbaclogic.Impl)
+	if !ok {
+		return o, errors.New("could get 'logic_rbac' because the object could not be cast to *rbaclogic.Impl")
+	}
+	return o, nil
+}
+
+// UnscopedGetLogicRbac retrieves the "logic_rbac" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_rbac"
+//	type: *rbaclogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// This method can be called even if main is a sub-scope of the container.
+// If the object can not be retrieved, it panics.
+func (c *Container) UnscopedGetLogicRbac() *rbaclogic.Impl {
+	o, err := c.UnscopedSafeGetLogicRbac()
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// LogicRbac retrieves the "logic_rbac" object from the main scope.
+//
+// ---------------------------------------------
+//
+//	name: "logic_rbac"
+//	type: *rbaclogic.Impl
+//	scope: "main"
+//	build: func
+//	params:
+//		- "0": Service(*logrus.Entry) ["logger_logrus"]
+//		- "1": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "2": Service(*mysqlstore.Repository) ["persistence_mysql"]
+//	unshared: false
+//	close: false
+//
+// ---------------------------------------------
+//
+// It tries to find the container with the C method and the given interface.
+// If the container can be retrieved, it calls the GetLogicRbac method.
+// If the container can not be retrieved, it panics.
+func LogicRbac(i interface{}) *rbaclogic.Impl {
+	return C(i).GetLogicRbac()
+}
+
 // SafeGetLogicUser retrieves the "logic_user" object from the main scope.
 //
 // ---------------------------------------------
@@ -1047,6 +1344,7 @@ func LogicMarketing(i interface{}) *marketinglogic.Impl {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
 //	unshared: false
 //	close: false
 //
@@ -1078,6 +1376,7 @@ func (c *Container) SafeGetLogicUser() (*userlogic.Impl, error) {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
 //	unshared: false
 //	close: false
 //
@@ -1104,6 +1403,7 @@ func (c *Container) GetLogicUser() *userlogic.Impl {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
 //	unshared: false
 //	close: false
 //
@@ -1136,6 +1436,7 @@ func (c *Container) UnscopedSafeGetLogicUser() (*userlogic.Impl, error) {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
 //	unshared: false
 //	close: false
 //
@@ -1163,6 +1464,7 @@ func (c *Container) UnscopedGetLogicUser() *userlogic.Impl {
 //		- "0": Service(*config.App) ["config_layer"]
 //		- "1": Service(*logrus.Entry) ["logger_logrus"]
 //		- "2": Service(*mysqlconn.Provider) ["tx_provider"]
+//		- "3": Service(*mysqlstore.Repository) ["persistence_mysql"]
 //	unshared: false
 //	close: false
 //
@@ -8,9 +8,11 @@ import (
 
 	cli "github.com/dembygenesis/local.tools/internal/cli"
 	config "github.com/dembygenesis/local.tools/internal/config"
+	apikeylogic "github.com/dembygenesis/local.tools/internal/logic_handlers/apikeylogic"
 	authlogic "github.com/dembygenesis/local.tools/internal/logic_handlers/authlogic"
 	categorylogic "github.com/dembygenesis/local.tools/internal/logic_handlers/categorylogic"
 	marketinglogic "github.com/dembygenesis/local.tools/internal/logic_handlers/marketinglogic"
+	rbaclogic "github.com/dembygenesis/local.tools/internal/logic_handlers/rbaclogic"
 	userlogic "github.com/dembygenesis/local.tools/internal/logic_handlers/userlogic"
 	mysqlconn "github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlconn"
 	mysqltx "github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
@@ -95,6 +97,54 @@ func getDiDefs(provider dingo.Provider) []di.Def {
 			},
 			Unshared: false,
 		},
+		{
+			Name:  "logic_api_key",
+			Scope: "",
+			Build: func(ctn di.Container) (interface{}, error) {
+				d, err := provider.Get("logic_api_key")
+				if err != nil {
+					var eo *apikeylogic.Impl
+					return eo, err
+				}
+				pi0, err := ctn.SafeGet("logger_logrus")
+				if err != nil {
+					var eo *apikeylogic.Impl
+					return eo, err
+				}
+				p0, ok := pi0.(*logrus.Entry)
+				if !ok {
+					var eo *apikeylogic.Impl
+					return eo, errors.New("could not cast parameter 0 to *logrus.Entry")
+				}
+				pi1, err := ctn.SafeGet("tx_provider")
+				if err != nil {
+					var eo *apikeylogic.Impl
+					return eo, err
+				}
+				p1, ok := pi1.(*mysqlconn.Provider)
+				if !ok {
+					var eo *apikeylogic.Impl
+					return eo, errors.New("could not cast parameter 1 to *mysqlconn.Provider")
+				}
+				pi2, err := ctn.SafeGet("persistence_mysql")
+				if err != nil {
+					var eo *apikeylogic.Impl
+					return eo, err
+				}
+				p2, ok := pi2.(*mysqlstore.Repository)
+				if !ok {
+					var eo *apikeylogic.Impl
+					return eo, errors.New("could not cast parameter 2 to *mysqlstore.Repository")
+				}
+				b, ok := d.Build.(func(*logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*apikeylogic.Impl, error))
+				if !ok {
+					var eo *apikeylogic.Impl
+					return eo, errors.New("could not cast build function to func(*logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*apikeylogic.Impl, error)")
+				}
+				return b(p0, p1, p2)
+			},
+			Unshared: false,
+		},
 		{
 			Name:  "logic_auth",
 			Scope: "",
@@ -134,12 +184,32 @@ func getDiDefs(provider dingo.Provider) []di.Def {
 					var eo *authlogic.Impl
 					return eo, errors.New("could not cast parameter 2 to *mysqlconn.Provider")
 				}
-				b, ok := d.Build.(func(*config.App, *logrus.Entry, *mysqlconn.Provider) (*authlogic.Impl, error))
+				pi3, err := ctn.SafeGet("persistence_mysql")
+				if err != nil {
+					var eo *authlogic.Impl
+					return eo, err
+				}
+				p3, ok := pi3.(*mysqlstore.Repository)
+				if !ok {
+					var eo *authlogic.Impl
+					return eo, errors.New("could not cast parameter 3 to *mysqlstore.Repository")
+				}
+				pi4, err := ctn.SafeGet("logic_user")
+				if err != nil {
+					var eo *authlogic.Impl
+					return eo, err
+				}
+				p4, ok := pi4.(*userlogic.Impl)
 				if !ok {
 					var eo *authlogic.Impl
-					return eo, errors.New("could not cast build function to func(*config.App, *logrus.Entry, *mysqlconn.Provider) (*authlogic.Impl, error)")
+					return eo, errors.New("could not cast parameter 4 to *userlogic.Impl")
 				}
-				return b(p0, p1, p2)
+				b, ok := d.Build.(func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository, *userlogic.Impl) (*authlogic.Impl, error))
+				if !ok {
+					var eo *authlogic.Impl
+					return eo, errors.New("could not cast build function to func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository, *userlogic.Impl) (*authlogic.Impl, error)")
+				}
+				return b(p0, p1, p2, p3, p4)
 			},
 			Unshared: false,
 		},
@@ -192,12 +262,22 @@ func getDiDefs(provider dingo.Provider) []di.Def {
 					var eo *categorylogic.Service
 					return eo, errors.New("could not cast parameter 3 to *mysqlstore.Repository")
 				}
-				b, ok := d.Build.(func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*categorylogic.Service, error))
+				pi4, err := ctn.SafeGet("logic_rbac")
+				if err != nil {
+					var eo *categorylogic.Service
+					return eo, err
+				}
+				p4, ok := pi4.(*rbaclogic.Impl)
 				if !ok {
 					var eo *categorylogic.Service
-					return eo, errors.New("could not cast build function to func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*categorylogic.Service, error)")
+					return eo, errors.New("could not cast parameter 4 to *rbaclogic.Impl")
 				}
-				return b(p0, p1, p2, p3)
+				b, ok := d.Build.(func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository, *rbaclogic.Impl) (*categorylogic.Service, error))
+				if !ok {
+					var eo *categorylogic.Service
+					return eo, errors.New("could not cast build function to func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository, *rbaclogic.Impl) (*categorylogic.Service, error)")
+				}
+				return b(p0, p1, p2, p3, p4)
 			},
 			Unshared: false,
 		},
@@ -249,6 +329,54 @@ func getDiDefs(provider dingo.Provider) []di.Def {
 			},
 			Unshared: false,
 		},
+		{
+			Name:  "logic_rbac",
+			Scope: "",
+			Build: func(ctn di.Container) (interface{}, error) {
+				d, err := provider.Get("logic_rbac")
+				if err != nil {
+					var eo *rbaclogic.Impl
+					return eo, err
+				}
+				pi0, err := ctn.SafeGet("logger_logrus")
+				if err != nil {
+					var eo *rbaclogic.Impl
+					return eo, err
+				}
+				p0, ok := pi0.(*logrus.Entry)
+				if !ok {
+					var eo *rbaclogic.Impl
+					return eo, errors.New("could not cast parameter 0 to *logrus.Entry")
+				}
+				pi1, err := ctn.SafeGet("tx_provider")
+				if err != nil {
+					var eo *rbaclogic.Impl
+					return eo, err
+				}
+				p1, ok := pi1.(*mysqlconn.Provider)
+				if !ok {
+					var eo *rbaclogic.Impl
+					return eo, errors.New("could not cast parameter 1 to *mysqlconn.Provider")
+				}
+				pi2, err := ctn.SafeGet("persistence_mysql")
+				if err != nil {
+					var eo *rbaclogic.Impl
+					return eo, err
+				}
+				p2, ok := pi2.(*mysqlstore.Repository)
+				if !ok {
+					var eo *rbaclogic.Impl
+					return eo, errors.New("could not cast parameter 2 to *mysqlstore.Repository")
+				}
+				b, ok := d.Build.(func(*logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*rbaclogic.Impl, error))
+				if !ok {
+					var eo *rbaclogic.Impl
+					return eo, errors.New("could not cast build function to func(*logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*rbaclogic.Impl, error)")
+				}
+				return b(p0, p1, p2)
+			},
+			Unshared: false,
+		},
 		{
 			Name:  "logic_user",
 			Scope: "",
@@ -288,12 +416,22 @@ func getDiDefs(provider dingo.Provider) []di.Def {
 					var eo *userlogic.Impl
 					return eo, errors.New("could not cast parameter 2 to *mysqlconn.Provider")
 				}
-				b, ok := d.Build.(func(*config.App, *logrus.Entry, *mysqlconn.Provider) (*userlogic.Impl, error))
+				pi3, err := ctn.SafeGet("persistence_mysql")
+				if err != nil {
+					var eo *userlogic.Impl
+					return eo, err
+				}
+				p3, ok := pi3.(*mysqlstore.Repository)
 				if !ok {
 					var eo *userlogic.Impl
-					return eo, errors.New("could not cast build function to func(*config.App, *logrus.Entry, *mysqlconn.Provider) (*userlogic.Impl, error)")
+					return eo, errors.New("could not cast parameter 3 to *mysqlstore.Repository")
 				}
-				return b(p0, p1, p2)
+				b, ok := d.Build.(func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*userlogic.Impl, error))
+				if !ok {
+					var eo *userlogic.Impl
+					return eo, errors.New("could not cast build function to func(*config.App, *logrus.Entry, *mysqlconn.Provider, *mysqlstore.Repository) (*userlogic.Impl, error)")
+				}
+				return b(p0, p1, p2, p3)
 			},
 			Unshared: false,
 		},
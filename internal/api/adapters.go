@@ -15,3 +15,30 @@ type categoryService interface {
 	DeleteCategory(ctx context.Context, params *model.DeleteCategory) error
 	RestoreCategory(ctx context.Context, params *model.RestoreCategory) error
 }
+
+//counterfeiter:generate . authService
+type authService interface {
+	Login(ctx context.Context, params *model.Login) (*model.AuthTokens, error)
+	Refresh(ctx context.Context, params *model.RefreshSession) (*model.AuthTokens, error)
+	Logout(ctx context.Context, params *model.Logout) error
+	ForgotPassword(ctx context.Context, params *model.ForgotPassword) error
+	ResetPassword(ctx context.Context, params *model.ResetPassword) error
+	Authenticate(ctx context.Context, accessToken string) (*model.AuthUser, error)
+}
+
+//counterfeiter:generate . rbacService
+type rbacService interface {
+	Authorize(ctx context.Context, permission string) error
+	ListPermissions(ctx context.Context) ([]model.Permission, error)
+	ListRoles(ctx context.Context) ([]model.Role, error)
+	GrantPermission(ctx context.Context, params *model.RolePermission) (*model.Role, error)
+	RevokePermission(ctx context.Context, params *model.RolePermission) (*model.Role, error)
+}
+
+//counterfeiter:generate . apiKeyService
+type apiKeyService interface {
+	CreateAPIKey(ctx context.Context, params *model.CreateAPIKey) (*model.CreatedAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	Authenticate(ctx context.Context, key string) (*model.AuthUser, error)
+}
@@ -38,6 +38,15 @@ type Config struct {
 
 	// CategoryService is the biz function for category
 	CategoryService categoryService `json:"category_manager" validate:"required"`
+
+	// AuthService logs users in, and authenticates their requests
+	AuthService authService `json:"auth_service" validate:"required"`
+
+	// RBACService checks the permissions of the authenticated users, and manages their roles
+	RBACService rbacService `json:"rbac_service" validate:"required"`
+
+	// APIKeyService manages the API keys of machine clients, and authenticates them
+	APIKeyService apiKeyService `json:"api_key_service" validate:"required"`
 }
 
 func (a *Config) Validate() error {
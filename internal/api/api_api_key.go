@@ -0,0 +1,79 @@
+package api
+
+import (
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strconv"
+)
+
+// ListAPIKeys fetches the API keys
+//
+// @Id ListAPIKeys
+// @Summary List API Keys
+// @Description Returns your API keys, and the keys of the organizations you created, newest first. The keys themselves are never returned again
+// @Tags APIKeyService
+// @Produce application/json
+// @Security BearerAuth
+// @Success 200 {object} []model.APIKey
+// @Failure 401 {object} []string
+// @Failure 403 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/api-keys [get]
+func (a *Api) ListAPIKeys(ctx *fiber.Ctx) error {
+	keys, err := a.cfg.APIKeyService.ListAPIKeys(ctx.Context())
+	return a.WriteResponse(ctx, http.StatusOK, keys, err)
+}
+
+// CreateAPIKey creates an API key
+//
+// @Id CreateAPIKey
+// @Summary Create API Key
+// @Description Creates an API key limited to the scopes, which must be permissions of your role. The key is only returned now, store it safely. Send it as "Authorization: Bearer <key>" or "X-API-Key: <key>"
+// @Tags APIKeyService
+// @Accept application/json
+// @Produce application/json
+// @Security BearerAuth
+// @Param body body model.CreateAPIKey true "API key"
+// @Success 201 {object} model.CreatedAPIKey
+// @Failure 400 {object} []string
+// @Failure 401 {object} []string
+// @Failure 403 {object} []string
+// @Failure 404 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/api-keys [post]
+func (a *Api) CreateAPIKey(ctx *fiber.Ctx) error {
+	var body model.CreateAPIKey
+	if err := ctx.BodyParser(&body); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	key, err := a.cfg.APIKeyService.CreateAPIKey(ctx.Context(), &body)
+	return a.WriteResponse(ctx, http.StatusCreated, key, err)
+}
+
+// RevokeAPIKey revokes an API key
+//
+// @Id RevokeAPIKey
+// @Summary Revoke API Key
+// @Description Revokes one of your API keys, or a key of an organization you created
+// @Tags APIKeyService
+// @Produce application/json
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} []string
+// @Failure 401 {object} []string
+// @Failure 403 {object} []string
+// @Failure 404 {object} []string
+// @Failure 409 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/api-keys/{id} [delete]
+func (a *Api) RevokeAPIKey(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	err = a.cfg.APIKeyService.RevokeAPIKey(ctx.Context(), id)
+	return a.WriteResponse(ctx, http.StatusNoContent, nil, err)
+}
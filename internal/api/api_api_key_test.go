@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/api/apifakes"
+	"github.com/dembygenesis/local.tools/internal/api/testassets"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+const mockAPIKey = model.APIKeyPrefix + "mock-api-key"
+
+var mockAPIKeyUser = &model.AuthUser{Id: 2, Email: "demby@yahoo.com", APIKeyId: 7, Scopes: []string{model.PermissionCategoryRead}}
+
+func newTestAPIKeyApi(t *testing.T, authService authService, apiKeyService apiKeyService) *Api {
+	api, err := New(&Config{
+		BaseUrl:         testassets.MockBaseUrl,
+		Port:            3000,
+		CategoryService: &apifakes.FakeCategoryService{},
+		AuthService:     authService,
+		RBACService:     mockRBACService(),
+		APIKeyService:   apiKeyService,
+		Logger:          logger.New(context.TODO()),
+	})
+	require.NoError(t, err, "unexpected error instantiating api")
+	return api
+}
+
+func Test_Authenticated_APIKey(t *testing.T) {
+	authService := mockAuthService()
+	apiKeyService := &apifakes.FakeApiKeyService{}
+	apiKeyService.AuthenticateCalls(func(ctx context.Context, key string) (*model.AuthUser, error) {
+		if key != mockAPIKey {
+			return nil, errs.New(&errs.Cfg{StatusCode: http.StatusUnauthorized, Err: errors.New("invalid, expired or revoked api key")})
+		}
+		return mockAPIKeyUser, nil
+	})
+	api := newTestAPIKeyApi(t, authService, apiKeyService)
+
+	for _, tt := range []struct {
+		name       string
+		headers    map[string]string
+		statusCode int
+		userId     int
+	}{
+		{name: "success-header", headers: map[string]string{"X-API-Key": mockAPIKey}, statusCode: http.StatusOK, userId: mockAPIKeyUser.Id},
+		{name: "success-bearer", headers: map[string]string{"Authorization": "Bearer " + mockAPIKey}, statusCode: http.StatusOK, userId: mockAPIKeyUser.Id},
+		{name: "success-header-over-bearer", headers: map[string]string{"X-API-Key": mockAPIKey, "Authorization": mockBearer}, statusCode: http.StatusOK, userId: mockAPIKeyUser.Id},
+		{name: "success-access-token", headers: map[string]string{"Authorization": mockBearer}, statusCode: http.StatusOK, userId: mockAuthUser.Id},
+		{name: "fail-invalid-key", headers: map[string]string{"X-API-Key": mockAPIKey + "x"}, statusCode: http.StatusUnauthorized},
+		{name: "fail-invalid-bearer-key", headers: map[string]string{"Authorization": "Bearer " + mockAPIKey + "x"}, statusCode: http.StatusUnauthorized},
+		{name: "fail-missing", statusCode: http.StatusUnauthorized},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			code, resp := doRequest(t, api, http.MethodGet, "/api/v1/auth/me", nil, tt.headers)
+			require.Equal(t, tt.statusCode, code, string(resp))
+			if tt.userId == 0 {
+				return
+			}
+
+			var got model.AuthUser
+			require.NoError(t, json.Unmarshal(resp, &got))
+			assert.Equal(t, tt.userId, got.Id)
+		})
+	}
+
+	assert.Equal(t, 1, authService.AuthenticateCallCount(), "api keys never reach the access token check")
+	assert.Equal(t, 5, apiKeyService.AuthenticateCallCount())
+}
+
+func Test_APIKeys(t *testing.T) {
+	apiKeyService := &apifakes.FakeApiKeyService{}
+	apiKeyService.ListAPIKeysReturns([]model.APIKey{{Id: 7, Name: "ci", Prefix: "ltk_abcdefgh", KeyHash: "secret"}}, nil)
+	apiKeyService.CreateAPIKeyReturns(&model.CreatedAPIKey{APIKey: model.APIKey{Id: 8, Name: "ci"}, Key: mockAPIKey}, nil)
+	api := newTestAPIKeyApi(t, mockAuthService(), apiKeyService)
+	auth := map[string]string{"Authorization": mockBearer}
+
+	code, resp := doRequest(t, api, http.MethodGet, "/api/v1/api-keys", nil, auth)
+	require.Equal(t, http.StatusOK, code, string(resp))
+	assert.NotContains(t, string(resp), "secret", "the hash is never returned")
+
+	var keys []model.APIKey
+	require.NoError(t, json.Unmarshal(resp, &keys))
+	require.Len(t, keys, 1)
+	assert.Equal(t, "ltk_abcdefgh", keys[0].Prefix)
+
+	body := &model.CreateAPIKey{Name: "ci", Scopes: []string{model.PermissionCategoryRead}}
+	code, resp = doRequest(t, api, http.MethodPost, "/api/v1/api-keys", body, auth)
+	require.Equal(t, http.StatusCreated, code, string(resp))
+
+	var created model.CreatedAPIKey
+	require.NoError(t, json.Unmarshal(resp, &created))
+	assert.Equal(t, mockAPIKey, created.Key)
+	_, params := apiKeyService.CreateAPIKeyArgsForCall(0)
+	assert.Equal(t, body, params)
+
+	code, resp = doRequest(t, api, http.MethodDelete, "/api/v1/api-keys/8", nil, auth)
+	require.Equal(t, http.StatusNoContent, code, string(resp))
+	_, id := apiKeyService.RevokeAPIKeyArgsForCall(0)
+	assert.Equal(t, 8, id)
+
+	code, _ = doRequest(t, api, http.MethodDelete, "/api/v1/api-keys/abc", nil, auth)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = doRequest(t, api, http.MethodGet, "/api/v1/api-keys", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Equal(t, 1, apiKeyService.ListAPIKeysCallCount())
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+)
+
+// Login logs a user in
+//
+// @Id Login
+// @Summary Login
+// @Description Checks the credentials, and returns an access token with a refresh token
+// @Tags AuthService
+// @Accept application/json
+// @Produce application/json
+// @Param body body model.Login true "Credentials"
+// @Success 200 {object} model.AuthTokens
+// @Failure 400 {object} []string
+// @Failure 401 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/auth/login [post]
+func (a *Api) Login(ctx *fiber.Ctx) error {
+	var body model.Login
+	if err := ctx.BodyParser(&body); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	tokens, err := a.cfg.AuthService.Login(ctx.Context(), &body)
+	return a.WriteResponse(ctx, http.StatusOK, tokens, err)
+}
+
+// Refresh rotates a refresh token
+//
+// @Id Refresh
+// @Summary Refresh
+// @Description Trades a refresh token for new tokens, the refresh token can only be used once
+// @Tags AuthService
+// @Accept application/json
+// @Produce application/json
+// @Param body body model.RefreshSession true "Refresh token"
+// @Success 200 {object} model.AuthTokens
+// @Failure 400 {object} []string
+// @Failure 401 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/auth/refresh [post]
+func (a *Api) Refresh(ctx *fiber.Ctx) error {
+	var body model.RefreshSession
+	if err := ctx.BodyParser(&body); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	tokens, err := a.cfg.AuthService.Refresh(ctx.Context(), &body)
+	return a.WriteResponse(ctx, http.StatusOK, tokens, err)
+}
+
+// Logout revokes a session
+//
+// @Id Logout
+// @Summary Logout
+// @Description Revokes the session of the refresh token, or every session of its user with "all"
+// @Tags AuthService
+// @Accept application/json
+// @Produce application/json
+// @Param body body model.Logout true "Refresh token"
+// @Success 204 "No Content"
+// @Failure 400 {object} []string
+// @Failure 401 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/auth/logout [post]
+func (a *Api) Logout(ctx *fiber.Ctx) error {
+	var body model.Logout
+	if err := ctx.BodyParser(&body); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	err := a.cfg.AuthService.Logout(ctx.Context(), &body)
+	return a.WriteResponse(ctx, http.StatusNoContent, nil, err)
+}
+
+// ForgotPassword mails a password reset link
+//
+// @Id ForgotPassword
+// @Summary Forgot Password
+// @Description Mails a single-use password reset link to the email. Succeeds whether the email is registered or not
+// @Tags AuthService
+// @Accept application/json
+// @Produce application/json
+// @Param body body model.ForgotPassword true "Email"
+// @Success 204 "No Content"
+// @Failure 400 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/auth/forgot-password [post]
+func (a *Api) ForgotPassword(ctx *fiber.Ctx) error {
+	var body model.ForgotPassword
+	if err := ctx.BodyParser(&body); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	err := a.cfg.AuthService.ForgotPassword(ctx.Context(), &body)
+	return a.WriteResponse(ctx, http.StatusNoContent, nil, err)
+}
+
+// ResetPassword sets a new password
+//
+// @Id ResetPassword
+// @Summary Reset Password
+// @Description Sets a new password with the token of a reset link, and revokes every session of the user
+// @Tags AuthService
+// @Accept application/json
+// @Produce application/json
+// @Param body body model.ResetPassword true "Reset token and new password"
+// @Success 204 "No Content"
+// @Failure 400 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/auth/reset-password [post]
+func (a *Api) ResetPassword(ctx *fiber.Ctx) error {
+	var body model.ResetPassword
+	if err := ctx.BodyParser(&body); err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	err := a.cfg.AuthService.ResetPassword(ctx.Context(), &body)
+	return a.WriteResponse(ctx, http.StatusNoContent, nil, err)
+}
+
+// Me returns the authenticated user
+//
+// @Id Me
+// @Summary Current user
+// @Description Returns the user of the access token
+// @Tags AuthService
+// @Produce application/json
+// @Security BearerAuth
+// @Success 200 {object} model.AuthUser
+// @Failure 401 {object} []string
+// @Router /v1/auth/me [get]
+func (a *Api) Me(ctx *fiber.Ctx) error {
+	user, ok := model.AuthUserFromContext(ctx.Context())
+	if !ok {
+		return ctx.Status(http.StatusUnauthorized).JSON([]string{errMissingBearer.Error()})
+	}
+	return a.WriteResponse(ctx, http.StatusOK, user, nil)
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/api/apifakes"
+	"github.com/dembygenesis/local.tools/internal/api/testassets"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const mockBearer = "Bearer mock-access-token"
+
+var mockAuthUser = &model.AuthUser{Id: 1, Email: "demby@gmail.com", CategoryTypeRefId: 1}
+
+// mockAuthService authenticates every request as mockAuthUser.
+func mockAuthService() *apifakes.FakeAuthService {
+	authService := &apifakes.FakeAuthService{}
+	authService.AuthenticateReturns(mockAuthUser, nil)
+	return authService
+}
+
+func newTestAuthApi(t *testing.T, authService authService) *Api {
+	api, err := New(&Config{
+		BaseUrl:         testassets.MockBaseUrl,
+		Port:            3000,
+		CategoryService: &apifakes.FakeCategoryService{},
+		AuthService:     authService,
+		RBACService:     mockRBACService(),
+		APIKeyService:   &apifakes.FakeApiKeyService{},
+		Logger:          logger.New(context.TODO()),
+	})
+	require.NoError(t, err, "unexpected error instantiating api")
+	return api
+}
+
+func doRequest(t *testing.T, api *Api, method, url string, body interface{}, headers map[string]string) (int, []byte) {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		require.NoError(t, err, "unexpected error marshalling the body")
+		reqBody = bytes.NewBuffer(b)
+	}
+
+	req := httptest.NewRequest(method, url, reqBody)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := api.app.Test(req, 100)
+	require.NoError(t, err, "unexpected error executing test")
+
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "unexpected error reading the response")
+	return resp.StatusCode, respBytes
+}
+
+func Test_Authenticated(t *testing.T) {
+	authService := mockAuthService()
+	authService.AuthenticateCalls(func(ctx context.Context, token string) (*model.AuthUser, error) {
+		if token != "good" {
+			return nil, errs.New(&errs.Cfg{StatusCode: http.StatusUnauthorized, Err: errors.New("invalid access token")})
+		}
+		return mockAuthUser, nil
+	})
+	api := newTestAuthApi(t, authService)
+
+	code, resp := doRequest(t, api, http.MethodGet, "/api/v1/auth/me", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Contains(t, string(resp), "missing bearer token")
+
+	code, _ = doRequest(t, api, http.MethodGet, "/api/v1/auth/me", nil, map[string]string{"Authorization": "Basic good"})
+	assert.Equal(t, http.StatusUnauthorized, code, "only bearer tokens are accepted")
+
+	code, resp = doRequest(t, api, http.MethodGet, "/api/v1/auth/me", nil, map[string]string{"Authorization": "Bearer bad"})
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Contains(t, string(resp), "invalid access token")
+
+	code, resp = doRequest(t, api, http.MethodGet, "/api/v1/category", nil, map[string]string{"Authorization": "Bearer bad"})
+	assert.Equal(t, http.StatusUnauthorized, code, "categories need a login")
+	assert.Contains(t, string(resp), "invalid access token")
+
+	code, resp = doRequest(t, api, http.MethodGet, "/api/v1/auth/me", nil, map[string]string{"Authorization": "bearer good"})
+	require.Equal(t, http.StatusOK, code, string(resp))
+
+	var user model.AuthUser
+	require.NoError(t, json.Unmarshal(resp, &user))
+	assert.Equal(t, *mockAuthUser, user, "the user is put on the request context")
+}
+
+func Test_Login(t *testing.T) {
+	authService := mockAuthService()
+	authService.LoginReturns(&model.AuthTokens{TokenType: "Bearer", AccessToken: "access", RefreshToken: "refresh"}, nil)
+	api := newTestAuthApi(t, authService)
+
+	code, resp := doRequest(t, api, http.MethodPost, "/api/v1/auth/login", map[string]string{
+		"email":    "demby@gmail.com",
+		"password": "password123",
+	}, nil)
+	require.Equal(t, http.StatusOK, code, string(resp))
+
+	var tokens model.AuthTokens
+	require.NoError(t, json.Unmarshal(resp, &tokens))
+	assert.Equal(t, "access", tokens.AccessToken)
+	assert.Equal(t, "refresh", tokens.RefreshToken)
+
+	_, login := authService.LoginArgsForCall(0)
+	assert.Equal(t, &model.Login{Email: "demby@gmail.com", Password: "password123"}, login)
+
+	authService.LoginReturns(nil, errs.New(&errs.Cfg{StatusCode: http.StatusUnauthorized, Err: errors.New("invalid email or password")}))
+	code, resp = doRequest(t, api, http.MethodPost, "/api/v1/auth/login", map[string]string{"email": "x", "password": "y"}, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Contains(t, string(resp), "invalid email or password")
+}
+
+func Test_RefreshLogout(t *testing.T) {
+	authService := mockAuthService()
+	authService.RefreshReturns(&model.AuthTokens{AccessToken: "access2", RefreshToken: "refresh2"}, nil)
+	api := newTestAuthApi(t, authService)
+
+	code, resp := doRequest(t, api, http.MethodPost, "/api/v1/auth/refresh", map[string]string{"refresh_token": "refresh"}, nil)
+	require.Equal(t, http.StatusOK, code, string(resp))
+	assert.Contains(t, string(resp), "refresh2")
+
+	_, refresh := authService.RefreshArgsForCall(0)
+	assert.Equal(t, "refresh", refresh.RefreshToken)
+
+	code, _ = doRequest(t, api, http.MethodPost, "/api/v1/auth/logout", map[string]interface{}{"refresh_token": "refresh2", "all": true}, nil)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	_, logout := authService.LogoutArgsForCall(0)
+	assert.Equal(t, &model.Logout{RefreshToken: "refresh2", All: true}, logout)
+}
+
+func Test_ForgotResetPassword(t *testing.T) {
+	authService := mockAuthService()
+	authService.ResetPasswordReturns(errs.New(&errs.Cfg{StatusCode: http.StatusBadRequest, Err: errors.New("invalid or expired password reset token")}))
+	api := newTestAuthApi(t, authService)
+
+	code, resp := doRequest(t, api, http.MethodPost, "/api/v1/auth/forgot-password", map[string]string{"email": "demby@gmail.com"}, nil)
+	require.Equal(t, http.StatusNoContent, code, string(resp))
+
+	_, forgot := authService.ForgotPasswordArgsForCall(0)
+	assert.Equal(t, &model.ForgotPassword{Email: "demby@gmail.com"}, forgot)
+
+	code, resp = doRequest(t, api, http.MethodPost, "/api/v1/auth/reset-password", map[string]string{"token": "expired", "password": "a-new-password"}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(resp), "invalid or expired password reset token")
+
+	_, reset := authService.ResetPasswordArgsForCall(0)
+	assert.Equal(t, &model.ResetPassword{Token: "expired", Password: "a-new-password"}, reset)
+	assert.Equal(t, 0, authService.AuthenticateCallCount(), "no login is needed")
+}
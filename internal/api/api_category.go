@@ -108,11 +108,7 @@ func (a *Api) DeleteCategory(ctx *fiber.Ctx) error {
 	deleteParams := &model.DeleteCategory{ID: categoryId}
 
 	err = a.cfg.CategoryService.DeleteCategory(ctx.Context(), deleteParams)
-	if err != nil {
-		return ctx.Status(http.StatusInternalServerError).JSON(errs.ToArr(err))
-	}
-
-	return ctx.SendStatus(http.StatusNoContent)
+	return a.WriteResponse(ctx, http.StatusNoContent, nil, err)
 }
 
 // RestoreCategory restores a category by ID
@@ -138,9 +134,5 @@ func (a *Api) RestoreCategory(ctx *fiber.Ctx) error {
 	restoreParams := &model.RestoreCategory{ID: categoryID}
 
 	err = a.cfg.CategoryService.RestoreCategory(ctx.Context(), restoreParams)
-	if err != nil {
-		return ctx.Status(http.StatusInternalServerError).JSON(errs.ToArr(err))
-	}
-
-	return ctx.SendStatus(http.StatusNoContent)
+	return a.WriteResponse(ctx, http.StatusNoContent, nil, err)
 }
@@ -119,6 +119,9 @@ func Test_CreateCategory(t *testing.T) {
 				BaseUrl:         testassets.MockBaseUrl,
 				Port:            3000,
 				CategoryService: handlers.catService,
+				AuthService:     mockAuthService(),
+				RBACService:     mockRBACService(),
+				APIKeyService:   &apifakes.FakeApiKeyService{},
 				Logger:          logger.New(context.TODO()),
 			}
 
@@ -132,6 +135,7 @@ func Test_CreateCategory(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/api/v1/category", bytes.NewBuffer(reqB))
 			req.Header = map[string][]string{
 				"Content-Type":    {"application/json"},
+				"Authorization":   {mockBearer},
 				"Accept-Encoding": {"gzip", "deflate", "br"},
 			}
 
@@ -315,6 +319,9 @@ func Test_ListCategories(t *testing.T) {
 				BaseUrl:         testassets.MockBaseUrl,
 				Port:            3000,
 				CategoryService: handlers.CategoryService,
+				AuthService:     mockAuthService(),
+				RBACService:     mockRBACService(),
+				APIKeyService:   &apifakes.FakeApiKeyService{},
 				Logger:          logger.New(context.TODO()),
 			}
 
@@ -326,6 +333,7 @@ func Test_ListCategories(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, url, nil)
 			req.Header = map[string][]string{
 				"Content-Type":    {"application/json"},
+				"Authorization":   {mockBearer},
 				"Accept-Encoding": {"gzip", "deflate", "br"},
 			}
 
@@ -387,6 +395,9 @@ func Test_UpdateCategory(t *testing.T) {
 				BaseUrl:         testassets.MockBaseUrl,
 				Port:            3000,
 				CategoryService: handlers.catService,
+				AuthService:     mockAuthService(),
+				RBACService:     mockRBACService(),
+				APIKeyService:   &apifakes.FakeApiKeyService{},
 				Logger:          logger.New(context.TODO()),
 			}
 
@@ -400,6 +411,7 @@ func Test_UpdateCategory(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPatch, "/api/v1/category", bytes.NewBuffer(reqB))
 			req.Header = map[string][]string{
 				"Content-Type":    {"application/json"},
+				"Authorization":   {mockBearer},
 				"Accept-Encoding": {"gzip", "deflate", "br"},
 			}
 
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strconv"
+)
+
+// ListPermissions fetches the permissions
+//
+// @Id ListPermissions
+// @Summary List Permissions
+// @Description Returns every permission that can be granted to a role
+// @Tags RBACService
+// @Produce application/json
+// @Security BearerAuth
+// @Success 200 {object} []model.Permission
+// @Failure 401 {object} []string
+// @Failure 403 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/rbac/permissions [get]
+func (a *Api) ListPermissions(ctx *fiber.Ctx) error {
+	permissions, err := a.cfg.RBACService.ListPermissions(ctx.Context())
+	return a.WriteResponse(ctx, http.StatusOK, permissions, err)
+}
+
+// ListRoles fetches the roles
+//
+// @Id ListRoles
+// @Summary List Roles
+// @Description Returns the roles, the "User Types" categories, with their permissions
+// @Tags RBACService
+// @Produce application/json
+// @Security BearerAuth
+// @Success 200 {object} []model.Role
+// @Failure 401 {object} []string
+// @Failure 403 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/rbac/roles [get]
+func (a *Api) ListRoles(ctx *fiber.Ctx) error {
+	roles, err := a.cfg.RBACService.ListRoles(ctx.Context())
+	return a.WriteResponse(ctx, http.StatusOK, roles, err)
+}
+
+// GrantPermission grants a permission to a role
+//
+// @Id GrantPermission
+// @Summary Grant Permission
+// @Description Grants a permission to a role, and returns the role
+// @Tags RBACService
+// @Produce application/json
+// @Security BearerAuth
+// @Param id path int true "Role ID"
+// @Param permission path string true "Permission name"
+// @Success 200 {object} model.Role
+// @Failure 400 {object} []string
+// @Failure 401 {object} []string
+// @Failure 403 {object} []string
+// @Failure 404 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/rbac/roles/{id}/permissions/{permission} [put]
+func (a *Api) GrantPermission(ctx *fiber.Ctx) error {
+	params, err := rolePermissionParams(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	role, err := a.cfg.RBACService.GrantPermission(ctx.Context(), params)
+	return a.WriteResponse(ctx, http.StatusOK, role, err)
+}
+
+// RevokePermission revokes a permission from a role
+//
+// @Id RevokePermission
+// @Summary Revoke Permission
+// @Description Revokes a permission from a role, and returns the role. role:write can't be revoked from your own role
+// @Tags RBACService
+// @Produce application/json
+// @Security BearerAuth
+// @Param id path int true "Role ID"
+// @Param permission path string true "Permission name"
+// @Success 200 {object} model.Role
+// @Failure 400 {object} []string
+// @Failure 401 {object} []string
+// @Failure 403 {object} []string
+// @Failure 404 {object} []string
+// @Failure 409 {object} []string
+// @Failure 500 {object} []string
+// @Router /v1/rbac/roles/{id}/permissions/{permission} [delete]
+func (a *Api) RevokePermission(ctx *fiber.Ctx) error {
+	params, err := rolePermissionParams(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusBadRequest).JSON(errs.ToArr(err))
+	}
+	role, err := a.cfg.RBACService.RevokePermission(ctx.Context(), params)
+	return a.WriteResponse(ctx, http.StatusOK, role, err)
+}
+
+// rolePermissionParams reads the role id and permission name of the path.
+func rolePermissionParams(ctx *fiber.Ctx) (*model.RolePermission, error) {
+	roleId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return nil, err
+	}
+	return &model.RolePermission{RoleId: roleId, Permission: ctx.Params("permission")}, nil
+}
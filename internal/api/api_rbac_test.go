@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/api/apifakes"
+	"github.com/dembygenesis/local.tools/internal/api/testassets"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+// mockRBACService grants every permission.
+func mockRBACService() *apifakes.FakeRbacService {
+	rbacService := &apifakes.FakeRbacService{}
+	rbacService.AuthorizeReturns(nil)
+	return rbacService
+}
+
+func newTestRBACApi(t *testing.T, rbacService rbacService) *Api {
+	api, err := New(&Config{
+		BaseUrl:         testassets.MockBaseUrl,
+		Port:            3000,
+		CategoryService: &apifakes.FakeCategoryService{},
+		AuthService:     mockAuthService(),
+		RBACService:     rbacService,
+		APIKeyService:   &apifakes.FakeApiKeyService{},
+		Logger:          logger.New(context.TODO()),
+	})
+	require.NoError(t, err, "unexpected error instantiating api")
+	return api
+}
+
+func Test_Permitted(t *testing.T) {
+	rbacService := mockRBACService()
+	rbacService.AuthorizeCalls(func(ctx context.Context, permission string) error {
+		user, ok := model.AuthUserFromContext(ctx)
+		require.True(t, ok, "the authenticated user is on the context")
+		require.Equal(t, mockAuthUser.Id, user.Id)
+		if permission != model.PermissionCategoryRead {
+			return errs.New(&errs.Cfg{StatusCode: http.StatusForbidden, Err: fmt.Errorf("missing permission '%s'", permission)})
+		}
+		return nil
+	})
+	api := newTestRBACApi(t, rbacService)
+	auth := map[string]string{"Authorization": mockBearer}
+
+	code, resp := doRequest(t, api, http.MethodGet, "/api/v1/category", nil, auth)
+	assert.Equal(t, http.StatusOK, code, string(resp))
+
+	code, resp = doRequest(t, api, http.MethodDelete, "/api/v1/category/1", nil, auth)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, string(resp), "missing permission 'category:delete'")
+
+	code, resp = doRequest(t, api, http.MethodGet, "/api/v1/rbac/roles", nil, auth)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, string(resp), "missing permission 'role:read'")
+
+	code, _ = doRequest(t, api, http.MethodGet, "/api/v1/rbac/roles", nil, nil)
+	assert.Equal(t, http.StatusUnauthorized, code, "authentication is checked first")
+}
+
+func Test_ListRoles(t *testing.T) {
+	roles := []model.Role{
+		{Id: 1, Name: "Super Admin", Permissions: []string{model.PermissionRoleRead, model.PermissionRoleWrite}},
+		{Id: 3, Name: "Regular User", Permissions: []string{model.PermissionCategoryRead}},
+	}
+	rbacService := mockRBACService()
+	rbacService.ListRolesReturns(roles, nil)
+	api := newTestRBACApi(t, rbacService)
+
+	code, resp := doRequest(t, api, http.MethodGet, "/api/v1/rbac/roles", nil, map[string]string{"Authorization": mockBearer})
+	require.Equal(t, http.StatusOK, code, string(resp))
+
+	var got []model.Role
+	require.NoError(t, json.Unmarshal(resp, &got))
+	assert.Equal(t, roles, got)
+}
+
+func Test_ListPermissions(t *testing.T) {
+	permissions := []model.Permission{{Id: 1, Name: model.PermissionCategoryRead, Description: "List categories"}}
+	rbacService := mockRBACService()
+	rbacService.ListPermissionsReturns(permissions, nil)
+	api := newTestRBACApi(t, rbacService)
+
+	code, resp := doRequest(t, api, http.MethodGet, "/api/v1/rbac/permissions", nil, map[string]string{"Authorization": mockBearer})
+	require.Equal(t, http.StatusOK, code, string(resp))
+
+	var got []model.Permission
+	require.NoError(t, json.Unmarshal(resp, &got))
+	assert.Equal(t, permissions, got)
+}
+
+func Test_GrantPermission(t *testing.T) {
+	role := &model.Role{Id: 3, Name: "Regular User", Permissions: []string{model.PermissionCategoryRead, model.PermissionCategoryWrite}}
+	rbacService := mockRBACService()
+	rbacService.GrantPermissionReturns(role, nil)
+	api := newTestRBACApi(t, rbacService)
+	auth := map[string]string{"Authorization": mockBearer}
+
+	code, resp := doRequest(t, api, http.MethodPut, "/api/v1/rbac/roles/3/permissions/category:write", nil, auth)
+	require.Equal(t, http.StatusOK, code, string(resp))
+
+	var got model.Role
+	require.NoError(t, json.Unmarshal(resp, &got))
+	assert.Equal(t, *role, got)
+
+	require.Equal(t, 1, rbacService.GrantPermissionCallCount())
+	_, params := rbacService.GrantPermissionArgsForCall(0)
+	assert.Equal(t, &model.RolePermission{RoleId: 3, Permission: model.PermissionCategoryWrite}, params)
+
+	code, _ = doRequest(t, api, http.MethodPut, "/api/v1/rbac/roles/abc/permissions/category:write", nil, auth)
+	assert.Equal(t, http.StatusBadRequest, code, "the role id must be a number")
+	assert.Equal(t, 1, rbacService.GrantPermissionCallCount())
+}
+
+func Test_RevokePermission(t *testing.T) {
+	rbacService := mockRBACService()
+	rbacService.RevokePermissionReturns(nil, errs.New(&errs.Cfg{
+		StatusCode: http.StatusConflict,
+		Err:        fmt.Errorf("can't revoke '%s' from your own role", model.PermissionRoleWrite),
+	}))
+	api := newTestRBACApi(t, rbacService)
+
+	code, resp := doRequest(t, api, http.MethodDelete, "/api/v1/rbac/roles/1/permissions/role:write", nil, map[string]string{"Authorization": mockBearer})
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Contains(t, string(resp), "from your own role")
+
+	require.Equal(t, 1, rbacService.RevokePermissionCallCount())
+	_, params := rbacService.RevokePermissionArgsForCall(0)
+	assert.Equal(t, &model.RolePermission{RoleId: 1, Permission: model.PermissionRoleWrite}, params)
+}
@@ -0,0 +1,353 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package apifakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+)
+
+type FakeApiKeyService struct {
+	AuthenticateStub        func(context.Context, string) (*model.AuthUser, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	authenticateReturns struct {
+		result1 *model.AuthUser
+		result2 error
+	}
+	authenticateReturnsOnCall map[int]struct {
+		result1 *model.AuthUser
+		result2 error
+	}
+	CreateAPIKeyStub        func(context.Context, *model.CreateAPIKey) (*model.CreatedAPIKey, error)
+	createAPIKeyMutex       sync.RWMutex
+	createAPIKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.CreateAPIKey
+	}
+	createAPIKeyReturns struct {
+		result1 *model.CreatedAPIKey
+		result2 error
+	}
+	createAPIKeyReturnsOnCall map[int]struct {
+		result1 *model.CreatedAPIKey
+		result2 error
+	}
+	ListAPIKeysStub        func(context.Context) ([]model.APIKey, error)
+	listAPIKeysMutex       sync.RWMutex
+	listAPIKeysArgsForCall []struct {
+		arg1 context.Context
+	}
+	listAPIKeysReturns struct {
+		result1 []model.APIKey
+		result2 error
+	}
+	listAPIKeysReturnsOnCall map[int]struct {
+		result1 []model.APIKey
+		result2 error
+	}
+	RevokeAPIKeyStub        func(context.Context, int) error
+	revokeAPIKeyMutex       sync.RWMutex
+	revokeAPIKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	revokeAPIKeyReturns struct {
+		result1 error
+	}
+	revokeAPIKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeApiKeyService) Authenticate(arg1 context.Context, arg2 string) (*model.AuthUser, error) {
+	fake.authenticateMutex.Lock()
+	ret, specificReturn := fake.authenticateReturnsOnCall[len(fake.authenticateArgsForCall)]
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AuthenticateStub
+	fakeReturns := fake.authenticateReturns
+	fake.recordInvocation("Authenticate", []interface{}{arg1, arg2})
+	fake.authenticateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeApiKeyService) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeApiKeyService) AuthenticateCalls(stub func(context.Context, string) (*model.AuthUser, error)) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = stub
+}
+
+func (fake *FakeApiKeyService) AuthenticateArgsForCall(i int) (context.Context, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	argsForCall := fake.authenticateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApiKeyService) AuthenticateReturns(result1 *model.AuthUser, result2 error) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 *model.AuthUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApiKeyService) AuthenticateReturnsOnCall(i int, result1 *model.AuthUser, result2 error) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = nil
+	if fake.authenticateReturnsOnCall == nil {
+		fake.authenticateReturnsOnCall = make(map[int]struct {
+			result1 *model.AuthUser
+			result2 error
+		})
+	}
+	fake.authenticateReturnsOnCall[i] = struct {
+		result1 *model.AuthUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApiKeyService) CreateAPIKey(arg1 context.Context, arg2 *model.CreateAPIKey) (*model.CreatedAPIKey, error) {
+	fake.createAPIKeyMutex.Lock()
+	ret, specificReturn := fake.createAPIKeyReturnsOnCall[len(fake.createAPIKeyArgsForCall)]
+	fake.createAPIKeyArgsForCall = append(fake.createAPIKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.CreateAPIKey
+	}{arg1, arg2})
+	stub := fake.CreateAPIKeyStub
+	fakeReturns := fake.createAPIKeyReturns
+	fake.recordInvocation("CreateAPIKey", []interface{}{arg1, arg2})
+	fake.createAPIKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeApiKeyService) CreateAPIKeyCallCount() int {
+	fake.createAPIKeyMutex.RLock()
+	defer fake.createAPIKeyMutex.RUnlock()
+	return len(fake.createAPIKeyArgsForCall)
+}
+
+func (fake *FakeApiKeyService) CreateAPIKeyCalls(stub func(context.Context, *model.CreateAPIKey) (*model.CreatedAPIKey, error)) {
+	fake.createAPIKeyMutex.Lock()
+	defer fake.createAPIKeyMutex.Unlock()
+	fake.CreateAPIKeyStub = stub
+}
+
+func (fake *FakeApiKeyService) CreateAPIKeyArgsForCall(i int) (context.Context, *model.CreateAPIKey) {
+	fake.createAPIKeyMutex.RLock()
+	defer fake.createAPIKeyMutex.RUnlock()
+	argsForCall := fake.createAPIKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApiKeyService) CreateAPIKeyReturns(result1 *model.CreatedAPIKey, result2 error) {
+	fake.createAPIKeyMutex.Lock()
+	defer fake.createAPIKeyMutex.Unlock()
+	fake.CreateAPIKeyStub = nil
+	fake.createAPIKeyReturns = struct {
+		result1 *model.CreatedAPIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApiKeyService) CreateAPIKeyReturnsOnCall(i int, result1 *model.CreatedAPIKey, result2 error) {
+	fake.createAPIKeyMutex.Lock()
+	defer fake.createAPIKeyMutex.Unlock()
+	fake.CreateAPIKeyStub = nil
+	if fake.createAPIKeyReturnsOnCall == nil {
+		fake.createAPIKeyReturnsOnCall = make(map[int]struct {
+			result1 *model.CreatedAPIKey
+			result2 error
+		})
+	}
+	fake.createAPIKeyReturnsOnCall[i] = struct {
+		result1 *model.CreatedAPIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApiKeyService) ListAPIKeys(arg1 context.Context) ([]model.APIKey, error) {
+	fake.listAPIKeysMutex.Lock()
+	ret, specificReturn := fake.listAPIKeysReturnsOnCall[len(fake.listAPIKeysArgsForCall)]
+	fake.listAPIKeysArgsForCall = append(fake.listAPIKeysArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListAPIKeysStub
+	fakeReturns := fake.listAPIKeysReturns
+	fake.recordInvocation("ListAPIKeys", []interface{}{arg1})
+	fake.listAPIKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeApiKeyService) ListAPIKeysCallCount() int {
+	fake.listAPIKeysMutex.RLock()
+	defer fake.listAPIKeysMutex.RUnlock()
+	return len(fake.listAPIKeysArgsForCall)
+}
+
+func (fake *FakeApiKeyService) ListAPIKeysCalls(stub func(context.Context) ([]model.APIKey, error)) {
+	fake.listAPIKeysMutex.Lock()
+	defer fake.listAPIKeysMutex.Unlock()
+	fake.ListAPIKeysStub = stub
+}
+
+func (fake *FakeApiKeyService) ListAPIKeysArgsForCall(i int) context.Context {
+	fake.listAPIKeysMutex.RLock()
+	defer fake.listAPIKeysMutex.RUnlock()
+	argsForCall := fake.listAPIKeysArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApiKeyService) ListAPIKeysReturns(result1 []model.APIKey, result2 error) {
+	fake.listAPIKeysMutex.Lock()
+	defer fake.listAPIKeysMutex.Unlock()
+	fake.ListAPIKeysStub = nil
+	fake.listAPIKeysReturns = struct {
+		result1 []model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApiKeyService) ListAPIKeysReturnsOnCall(i int, result1 []model.APIKey, result2 error) {
+	fake.listAPIKeysMutex.Lock()
+	defer fake.listAPIKeysMutex.Unlock()
+	fake.ListAPIKeysStub = nil
+	if fake.listAPIKeysReturnsOnCall == nil {
+		fake.listAPIKeysReturnsOnCall = make(map[int]struct {
+			result1 []model.APIKey
+			result2 error
+		})
+	}
+	fake.listAPIKeysReturnsOnCall[i] = struct {
+		result1 []model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApiKeyService) RevokeAPIKey(arg1 context.Context, arg2 int) error {
+	fake.revokeAPIKeyMutex.Lock()
+	ret, specificReturn := fake.revokeAPIKeyReturnsOnCall[len(fake.revokeAPIKeyArgsForCall)]
+	fake.revokeAPIKeyArgsForCall = append(fake.revokeAPIKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.RevokeAPIKeyStub
+	fakeReturns := fake.revokeAPIKeyReturns
+	fake.recordInvocation("RevokeAPIKey", []interface{}{arg1, arg2})
+	fake.revokeAPIKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeApiKeyService) RevokeAPIKeyCallCount() int {
+	fake.revokeAPIKeyMutex.RLock()
+	defer fake.revokeAPIKeyMutex.RUnlock()
+	return len(fake.revokeAPIKeyArgsForCall)
+}
+
+func (fake *FakeApiKeyService) RevokeAPIKeyCalls(stub func(context.Context, int) error) {
+	fake.revokeAPIKeyMutex.Lock()
+	defer fake.revokeAPIKeyMutex.Unlock()
+	fake.RevokeAPIKeyStub = stub
+}
+
+func (fake *FakeApiKeyService) RevokeAPIKeyArgsForCall(i int) (context.Context, int) {
+	fake.revokeAPIKeyMutex.RLock()
+	defer fake.revokeAPIKeyMutex.RUnlock()
+	argsForCall := fake.revokeAPIKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeApiKeyService) RevokeAPIKeyReturns(result1 error) {
+	fake.revokeAPIKeyMutex.Lock()
+	defer fake.revokeAPIKeyMutex.Unlock()
+	fake.RevokeAPIKeyStub = nil
+	fake.revokeAPIKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApiKeyService) RevokeAPIKeyReturnsOnCall(i int, result1 error) {
+	fake.revokeAPIKeyMutex.Lock()
+	defer fake.revokeAPIKeyMutex.Unlock()
+	fake.RevokeAPIKeyStub = nil
+	if fake.revokeAPIKeyReturnsOnCall == nil {
+		fake.revokeAPIKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeAPIKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApiKeyService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	fake.createAPIKeyMutex.RLock()
+	defer fake.createAPIKeyMutex.RUnlock()
+	fake.listAPIKeysMutex.RLock()
+	defer fake.listAPIKeysMutex.RUnlock()
+	fake.revokeAPIKeyMutex.RLock()
+	defer fake.revokeAPIKeyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeApiKeyService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
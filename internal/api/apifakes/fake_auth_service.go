@@ -0,0 +1,507 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package apifakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+)
+
+type FakeAuthService struct {
+	AuthenticateStub        func(context.Context, string) (*model.AuthUser, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	authenticateReturns struct {
+		result1 *model.AuthUser
+		result2 error
+	}
+	authenticateReturnsOnCall map[int]struct {
+		result1 *model.AuthUser
+		result2 error
+	}
+	ForgotPasswordStub        func(context.Context, *model.ForgotPassword) error
+	forgotPasswordMutex       sync.RWMutex
+	forgotPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.ForgotPassword
+	}
+	forgotPasswordReturns struct {
+		result1 error
+	}
+	forgotPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	LoginStub        func(context.Context, *model.Login) (*model.AuthTokens, error)
+	loginMutex       sync.RWMutex
+	loginArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.Login
+	}
+	loginReturns struct {
+		result1 *model.AuthTokens
+		result2 error
+	}
+	loginReturnsOnCall map[int]struct {
+		result1 *model.AuthTokens
+		result2 error
+	}
+	LogoutStub        func(context.Context, *model.Logout) error
+	logoutMutex       sync.RWMutex
+	logoutArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.Logout
+	}
+	logoutReturns struct {
+		result1 error
+	}
+	logoutReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RefreshStub        func(context.Context, *model.RefreshSession) (*model.AuthTokens, error)
+	refreshMutex       sync.RWMutex
+	refreshArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.RefreshSession
+	}
+	refreshReturns struct {
+		result1 *model.AuthTokens
+		result2 error
+	}
+	refreshReturnsOnCall map[int]struct {
+		result1 *model.AuthTokens
+		result2 error
+	}
+	ResetPasswordStub        func(context.Context, *model.ResetPassword) error
+	resetPasswordMutex       sync.RWMutex
+	resetPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.ResetPassword
+	}
+	resetPasswordReturns struct {
+		result1 error
+	}
+	resetPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthService) Authenticate(arg1 context.Context, arg2 string) (*model.AuthUser, error) {
+	fake.authenticateMutex.Lock()
+	ret, specificReturn := fake.authenticateReturnsOnCall[len(fake.authenticateArgsForCall)]
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AuthenticateStub
+	fakeReturns := fake.authenticateReturns
+	fake.recordInvocation("Authenticate", []interface{}{arg1, arg2})
+	fake.authenticateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuthService) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeAuthService) AuthenticateCalls(stub func(context.Context, string) (*model.AuthUser, error)) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = stub
+}
+
+func (fake *FakeAuthService) AuthenticateArgsForCall(i int) (context.Context, string) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	argsForCall := fake.authenticateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuthService) AuthenticateReturns(result1 *model.AuthUser, result2 error) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 *model.AuthUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthService) AuthenticateReturnsOnCall(i int, result1 *model.AuthUser, result2 error) {
+	fake.authenticateMutex.Lock()
+	defer fake.authenticateMutex.Unlock()
+	fake.AuthenticateStub = nil
+	if fake.authenticateReturnsOnCall == nil {
+		fake.authenticateReturnsOnCall = make(map[int]struct {
+			result1 *model.AuthUser
+			result2 error
+		})
+	}
+	fake.authenticateReturnsOnCall[i] = struct {
+		result1 *model.AuthUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthService) ForgotPassword(arg1 context.Context, arg2 *model.ForgotPassword) error {
+	fake.forgotPasswordMutex.Lock()
+	ret, specificReturn := fake.forgotPasswordReturnsOnCall[len(fake.forgotPasswordArgsForCall)]
+	fake.forgotPasswordArgsForCall = append(fake.forgotPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.ForgotPassword
+	}{arg1, arg2})
+	stub := fake.ForgotPasswordStub
+	fakeReturns := fake.forgotPasswordReturns
+	fake.recordInvocation("ForgotPassword", []interface{}{arg1, arg2})
+	fake.forgotPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAuthService) ForgotPasswordCallCount() int {
+	fake.forgotPasswordMutex.RLock()
+	defer fake.forgotPasswordMutex.RUnlock()
+	return len(fake.forgotPasswordArgsForCall)
+}
+
+func (fake *FakeAuthService) ForgotPasswordCalls(stub func(context.Context, *model.ForgotPassword) error) {
+	fake.forgotPasswordMutex.Lock()
+	defer fake.forgotPasswordMutex.Unlock()
+	fake.ForgotPasswordStub = stub
+}
+
+func (fake *FakeAuthService) ForgotPasswordArgsForCall(i int) (context.Context, *model.ForgotPassword) {
+	fake.forgotPasswordMutex.RLock()
+	defer fake.forgotPasswordMutex.RUnlock()
+	argsForCall := fake.forgotPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuthService) ForgotPasswordReturns(result1 error) {
+	fake.forgotPasswordMutex.Lock()
+	defer fake.forgotPasswordMutex.Unlock()
+	fake.ForgotPasswordStub = nil
+	fake.forgotPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthService) ForgotPasswordReturnsOnCall(i int, result1 error) {
+	fake.forgotPasswordMutex.Lock()
+	defer fake.forgotPasswordMutex.Unlock()
+	fake.ForgotPasswordStub = nil
+	if fake.forgotPasswordReturnsOnCall == nil {
+		fake.forgotPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.forgotPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthService) Login(arg1 context.Context, arg2 *model.Login) (*model.AuthTokens, error) {
+	fake.loginMutex.Lock()
+	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
+	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.Login
+	}{arg1, arg2})
+	stub := fake.LoginStub
+	fakeReturns := fake.loginReturns
+	fake.recordInvocation("Login", []interface{}{arg1, arg2})
+	fake.loginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuthService) LoginCallCount() int {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	return len(fake.loginArgsForCall)
+}
+
+func (fake *FakeAuthService) LoginCalls(stub func(context.Context, *model.Login) (*model.AuthTokens, error)) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = stub
+}
+
+func (fake *FakeAuthService) LoginArgsForCall(i int) (context.Context, *model.Login) {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	argsForCall := fake.loginArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuthService) LoginReturns(result1 *model.AuthTokens, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	fake.loginReturns = struct {
+		result1 *model.AuthTokens
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthService) LoginReturnsOnCall(i int, result1 *model.AuthTokens, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	if fake.loginReturnsOnCall == nil {
+		fake.loginReturnsOnCall = make(map[int]struct {
+			result1 *model.AuthTokens
+			result2 error
+		})
+	}
+	fake.loginReturnsOnCall[i] = struct {
+		result1 *model.AuthTokens
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthService) Logout(arg1 context.Context, arg2 *model.Logout) error {
+	fake.logoutMutex.Lock()
+	ret, specificReturn := fake.logoutReturnsOnCall[len(fake.logoutArgsForCall)]
+	fake.logoutArgsForCall = append(fake.logoutArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.Logout
+	}{arg1, arg2})
+	stub := fake.LogoutStub
+	fakeReturns := fake.logoutReturns
+	fake.recordInvocation("Logout", []interface{}{arg1, arg2})
+	fake.logoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAuthService) LogoutCallCount() int {
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	return len(fake.logoutArgsForCall)
+}
+
+func (fake *FakeAuthService) LogoutCalls(stub func(context.Context, *model.Logout) error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = stub
+}
+
+func (fake *FakeAuthService) LogoutArgsForCall(i int) (context.Context, *model.Logout) {
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	argsForCall := fake.logoutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuthService) LogoutReturns(result1 error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = nil
+	fake.logoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthService) LogoutReturnsOnCall(i int, result1 error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = nil
+	if fake.logoutReturnsOnCall == nil {
+		fake.logoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.logoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthService) Refresh(arg1 context.Context, arg2 *model.RefreshSession) (*model.AuthTokens, error) {
+	fake.refreshMutex.Lock()
+	ret, specificReturn := fake.refreshReturnsOnCall[len(fake.refreshArgsForCall)]
+	fake.refreshArgsForCall = append(fake.refreshArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.RefreshSession
+	}{arg1, arg2})
+	stub := fake.RefreshStub
+	fakeReturns := fake.refreshReturns
+	fake.recordInvocation("Refresh", []interface{}{arg1, arg2})
+	fake.refreshMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuthService) RefreshCallCount() int {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	return len(fake.refreshArgsForCall)
+}
+
+func (fake *FakeAuthService) RefreshCalls(stub func(context.Context, *model.RefreshSession) (*model.AuthTokens, error)) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = stub
+}
+
+func (fake *FakeAuthService) RefreshArgsForCall(i int) (context.Context, *model.RefreshSession) {
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	argsForCall := fake.refreshArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuthService) RefreshReturns(result1 *model.AuthTokens, result2 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	fake.refreshReturns = struct {
+		result1 *model.AuthTokens
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthService) RefreshReturnsOnCall(i int, result1 *model.AuthTokens, result2 error) {
+	fake.refreshMutex.Lock()
+	defer fake.refreshMutex.Unlock()
+	fake.RefreshStub = nil
+	if fake.refreshReturnsOnCall == nil {
+		fake.refreshReturnsOnCall = make(map[int]struct {
+			result1 *model.AuthTokens
+			result2 error
+		})
+	}
+	fake.refreshReturnsOnCall[i] = struct {
+		result1 *model.AuthTokens
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthService) ResetPassword(arg1 context.Context, arg2 *model.ResetPassword) error {
+	fake.resetPasswordMutex.Lock()
+	ret, specificReturn := fake.resetPasswordReturnsOnCall[len(fake.resetPasswordArgsForCall)]
+	fake.resetPasswordArgsForCall = append(fake.resetPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.ResetPassword
+	}{arg1, arg2})
+	stub := fake.ResetPasswordStub
+	fakeReturns := fake.resetPasswordReturns
+	fake.recordInvocation("ResetPassword", []interface{}{arg1, arg2})
+	fake.resetPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeAuthService) ResetPasswordCallCount() int {
+	fake.resetPasswordMutex.RLock()
+	defer fake.resetPasswordMutex.RUnlock()
+	return len(fake.resetPasswordArgsForCall)
+}
+
+func (fake *FakeAuthService) ResetPasswordCalls(stub func(context.Context, *model.ResetPassword) error) {
+	fake.resetPasswordMutex.Lock()
+	defer fake.resetPasswordMutex.Unlock()
+	fake.ResetPasswordStub = stub
+}
+
+func (fake *FakeAuthService) ResetPasswordArgsForCall(i int) (context.Context, *model.ResetPassword) {
+	fake.resetPasswordMutex.RLock()
+	defer fake.resetPasswordMutex.RUnlock()
+	argsForCall := fake.resetPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuthService) ResetPasswordReturns(result1 error) {
+	fake.resetPasswordMutex.Lock()
+	defer fake.resetPasswordMutex.Unlock()
+	fake.ResetPasswordStub = nil
+	fake.resetPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthService) ResetPasswordReturnsOnCall(i int, result1 error) {
+	fake.resetPasswordMutex.Lock()
+	defer fake.resetPasswordMutex.Unlock()
+	fake.ResetPasswordStub = nil
+	if fake.resetPasswordReturnsOnCall == nil {
+		fake.resetPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	fake.forgotPasswordMutex.RLock()
+	defer fake.forgotPasswordMutex.RUnlock()
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	fake.refreshMutex.RLock()
+	defer fake.refreshMutex.RUnlock()
+	fake.resetPasswordMutex.RLock()
+	defer fake.resetPasswordMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuthService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,432 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package apifakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+)
+
+type FakeRbacService struct {
+	AuthorizeStub        func(context.Context, string) error
+	authorizeMutex       sync.RWMutex
+	authorizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	authorizeReturns struct {
+		result1 error
+	}
+	authorizeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GrantPermissionStub        func(context.Context, *model.RolePermission) (*model.Role, error)
+	grantPermissionMutex       sync.RWMutex
+	grantPermissionArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.RolePermission
+	}
+	grantPermissionReturns struct {
+		result1 *model.Role
+		result2 error
+	}
+	grantPermissionReturnsOnCall map[int]struct {
+		result1 *model.Role
+		result2 error
+	}
+	ListPermissionsStub        func(context.Context) ([]model.Permission, error)
+	listPermissionsMutex       sync.RWMutex
+	listPermissionsArgsForCall []struct {
+		arg1 context.Context
+	}
+	listPermissionsReturns struct {
+		result1 []model.Permission
+		result2 error
+	}
+	listPermissionsReturnsOnCall map[int]struct {
+		result1 []model.Permission
+		result2 error
+	}
+	ListRolesStub        func(context.Context) ([]model.Role, error)
+	listRolesMutex       sync.RWMutex
+	listRolesArgsForCall []struct {
+		arg1 context.Context
+	}
+	listRolesReturns struct {
+		result1 []model.Role
+		result2 error
+	}
+	listRolesReturnsOnCall map[int]struct {
+		result1 []model.Role
+		result2 error
+	}
+	RevokePermissionStub        func(context.Context, *model.RolePermission) (*model.Role, error)
+	revokePermissionMutex       sync.RWMutex
+	revokePermissionArgsForCall []struct {
+		arg1 context.Context
+		arg2 *model.RolePermission
+	}
+	revokePermissionReturns struct {
+		result1 *model.Role
+		result2 error
+	}
+	revokePermissionReturnsOnCall map[int]struct {
+		result1 *model.Role
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRbacService) Authorize(arg1 context.Context, arg2 string) error {
+	fake.authorizeMutex.Lock()
+	ret, specificReturn := fake.authorizeReturnsOnCall[len(fake.authorizeArgsForCall)]
+	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AuthorizeStub
+	fakeReturns := fake.authorizeReturns
+	fake.recordInvocation("Authorize", []interface{}{arg1, arg2})
+	fake.authorizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeRbacService) AuthorizeCallCount() int {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	return len(fake.authorizeArgsForCall)
+}
+
+func (fake *FakeRbacService) AuthorizeCalls(stub func(context.Context, string) error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = stub
+}
+
+func (fake *FakeRbacService) AuthorizeArgsForCall(i int) (context.Context, string) {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	argsForCall := fake.authorizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRbacService) AuthorizeReturns(result1 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	fake.authorizeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRbacService) AuthorizeReturnsOnCall(i int, result1 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	if fake.authorizeReturnsOnCall == nil {
+		fake.authorizeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.authorizeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRbacService) GrantPermission(arg1 context.Context, arg2 *model.RolePermission) (*model.Role, error) {
+	fake.grantPermissionMutex.Lock()
+	ret, specificReturn := fake.grantPermissionReturnsOnCall[len(fake.grantPermissionArgsForCall)]
+	fake.grantPermissionArgsForCall = append(fake.grantPermissionArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.RolePermission
+	}{arg1, arg2})
+	stub := fake.GrantPermissionStub
+	fakeReturns := fake.grantPermissionReturns
+	fake.recordInvocation("GrantPermission", []interface{}{arg1, arg2})
+	fake.grantPermissionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRbacService) GrantPermissionCallCount() int {
+	fake.grantPermissionMutex.RLock()
+	defer fake.grantPermissionMutex.RUnlock()
+	return len(fake.grantPermissionArgsForCall)
+}
+
+func (fake *FakeRbacService) GrantPermissionCalls(stub func(context.Context, *model.RolePermission) (*model.Role, error)) {
+	fake.grantPermissionMutex.Lock()
+	defer fake.grantPermissionMutex.Unlock()
+	fake.GrantPermissionStub = stub
+}
+
+func (fake *FakeRbacService) GrantPermissionArgsForCall(i int) (context.Context, *model.RolePermission) {
+	fake.grantPermissionMutex.RLock()
+	defer fake.grantPermissionMutex.RUnlock()
+	argsForCall := fake.grantPermissionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRbacService) GrantPermissionReturns(result1 *model.Role, result2 error) {
+	fake.grantPermissionMutex.Lock()
+	defer fake.grantPermissionMutex.Unlock()
+	fake.GrantPermissionStub = nil
+	fake.grantPermissionReturns = struct {
+		result1 *model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) GrantPermissionReturnsOnCall(i int, result1 *model.Role, result2 error) {
+	fake.grantPermissionMutex.Lock()
+	defer fake.grantPermissionMutex.Unlock()
+	fake.GrantPermissionStub = nil
+	if fake.grantPermissionReturnsOnCall == nil {
+		fake.grantPermissionReturnsOnCall = make(map[int]struct {
+			result1 *model.Role
+			result2 error
+		})
+	}
+	fake.grantPermissionReturnsOnCall[i] = struct {
+		result1 *model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) ListPermissions(arg1 context.Context) ([]model.Permission, error) {
+	fake.listPermissionsMutex.Lock()
+	ret, specificReturn := fake.listPermissionsReturnsOnCall[len(fake.listPermissionsArgsForCall)]
+	fake.listPermissionsArgsForCall = append(fake.listPermissionsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListPermissionsStub
+	fakeReturns := fake.listPermissionsReturns
+	fake.recordInvocation("ListPermissions", []interface{}{arg1})
+	fake.listPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRbacService) ListPermissionsCallCount() int {
+	fake.listPermissionsMutex.RLock()
+	defer fake.listPermissionsMutex.RUnlock()
+	return len(fake.listPermissionsArgsForCall)
+}
+
+func (fake *FakeRbacService) ListPermissionsCalls(stub func(context.Context) ([]model.Permission, error)) {
+	fake.listPermissionsMutex.Lock()
+	defer fake.listPermissionsMutex.Unlock()
+	fake.ListPermissionsStub = stub
+}
+
+func (fake *FakeRbacService) ListPermissionsArgsForCall(i int) context.Context {
+	fake.listPermissionsMutex.RLock()
+	defer fake.listPermissionsMutex.RUnlock()
+	argsForCall := fake.listPermissionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRbacService) ListPermissionsReturns(result1 []model.Permission, result2 error) {
+	fake.listPermissionsMutex.Lock()
+	defer fake.listPermissionsMutex.Unlock()
+	fake.ListPermissionsStub = nil
+	fake.listPermissionsReturns = struct {
+		result1 []model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) ListPermissionsReturnsOnCall(i int, result1 []model.Permission, result2 error) {
+	fake.listPermissionsMutex.Lock()
+	defer fake.listPermissionsMutex.Unlock()
+	fake.ListPermissionsStub = nil
+	if fake.listPermissionsReturnsOnCall == nil {
+		fake.listPermissionsReturnsOnCall = make(map[int]struct {
+			result1 []model.Permission
+			result2 error
+		})
+	}
+	fake.listPermissionsReturnsOnCall[i] = struct {
+		result1 []model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) ListRoles(arg1 context.Context) ([]model.Role, error) {
+	fake.listRolesMutex.Lock()
+	ret, specificReturn := fake.listRolesReturnsOnCall[len(fake.listRolesArgsForCall)]
+	fake.listRolesArgsForCall = append(fake.listRolesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListRolesStub
+	fakeReturns := fake.listRolesReturns
+	fake.recordInvocation("ListRoles", []interface{}{arg1})
+	fake.listRolesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRbacService) ListRolesCallCount() int {
+	fake.listRolesMutex.RLock()
+	defer fake.listRolesMutex.RUnlock()
+	return len(fake.listRolesArgsForCall)
+}
+
+func (fake *FakeRbacService) ListRolesCalls(stub func(context.Context) ([]model.Role, error)) {
+	fake.listRolesMutex.Lock()
+	defer fake.listRolesMutex.Unlock()
+	fake.ListRolesStub = stub
+}
+
+func (fake *FakeRbacService) ListRolesArgsForCall(i int) context.Context {
+	fake.listRolesMutex.RLock()
+	defer fake.listRolesMutex.RUnlock()
+	argsForCall := fake.listRolesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeRbacService) ListRolesReturns(result1 []model.Role, result2 error) {
+	fake.listRolesMutex.Lock()
+	defer fake.listRolesMutex.Unlock()
+	fake.ListRolesStub = nil
+	fake.listRolesReturns = struct {
+		result1 []model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) ListRolesReturnsOnCall(i int, result1 []model.Role, result2 error) {
+	fake.listRolesMutex.Lock()
+	defer fake.listRolesMutex.Unlock()
+	fake.ListRolesStub = nil
+	if fake.listRolesReturnsOnCall == nil {
+		fake.listRolesReturnsOnCall = make(map[int]struct {
+			result1 []model.Role
+			result2 error
+		})
+	}
+	fake.listRolesReturnsOnCall[i] = struct {
+		result1 []model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) RevokePermission(arg1 context.Context, arg2 *model.RolePermission) (*model.Role, error) {
+	fake.revokePermissionMutex.Lock()
+	ret, specificReturn := fake.revokePermissionReturnsOnCall[len(fake.revokePermissionArgsForCall)]
+	fake.revokePermissionArgsForCall = append(fake.revokePermissionArgsForCall, struct {
+		arg1 context.Context
+		arg2 *model.RolePermission
+	}{arg1, arg2})
+	stub := fake.RevokePermissionStub
+	fakeReturns := fake.revokePermissionReturns
+	fake.recordInvocation("RevokePermission", []interface{}{arg1, arg2})
+	fake.revokePermissionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeRbacService) RevokePermissionCallCount() int {
+	fake.revokePermissionMutex.RLock()
+	defer fake.revokePermissionMutex.RUnlock()
+	return len(fake.revokePermissionArgsForCall)
+}
+
+func (fake *FakeRbacService) RevokePermissionCalls(stub func(context.Context, *model.RolePermission) (*model.Role, error)) {
+	fake.revokePermissionMutex.Lock()
+	defer fake.revokePermissionMutex.Unlock()
+	fake.RevokePermissionStub = stub
+}
+
+func (fake *FakeRbacService) RevokePermissionArgsForCall(i int) (context.Context, *model.RolePermission) {
+	fake.revokePermissionMutex.RLock()
+	defer fake.revokePermissionMutex.RUnlock()
+	argsForCall := fake.revokePermissionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeRbacService) RevokePermissionReturns(result1 *model.Role, result2 error) {
+	fake.revokePermissionMutex.Lock()
+	defer fake.revokePermissionMutex.Unlock()
+	fake.RevokePermissionStub = nil
+	fake.revokePermissionReturns = struct {
+		result1 *model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) RevokePermissionReturnsOnCall(i int, result1 *model.Role, result2 error) {
+	fake.revokePermissionMutex.Lock()
+	defer fake.revokePermissionMutex.Unlock()
+	fake.RevokePermissionStub = nil
+	if fake.revokePermissionReturnsOnCall == nil {
+		fake.revokePermissionReturnsOnCall = make(map[int]struct {
+			result1 *model.Role
+			result2 error
+		})
+	}
+	fake.revokePermissionReturnsOnCall[i] = struct {
+		result1 *model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRbacService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	fake.grantPermissionMutex.RLock()
+	defer fake.grantPermissionMutex.RUnlock()
+	fake.listPermissionsMutex.RLock()
+	defer fake.listPermissionsMutex.RUnlock()
+	fake.listRolesMutex.RLock()
+	defer fake.listRolesMutex.RUnlock()
+	fake.revokePermissionMutex.RLock()
+	defer fake.revokePermissionMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRbacService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
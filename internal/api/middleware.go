@@ -0,0 +1,68 @@
+package api
+
+import (
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strings"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	headerAPIKey = "X-API-Key"
+)
+
+var (
+	errMissingBearer = errors.New("missing bearer token in the Authorization header, or api key in the " + headerAPIKey + " header")
+)
+
+// Authenticated rejects requests without a valid access token or API key, and
+// puts the user on the request context for the handlers after it. The logic
+// layer reads it back with model.AuthUserFromContext. API keys are sent in
+// the X-API-Key header, or as bearer tokens, told apart by their prefix.
+func (a *Api) Authenticated(ctx *fiber.Ctx) error {
+	var (
+		user *model.AuthUser
+		err  error
+	)
+
+	if key := strings.TrimSpace(ctx.Get(headerAPIKey)); key != "" {
+		user, err = a.cfg.APIKeyService.Authenticate(ctx.Context(), key)
+	} else if token, ok := bearerToken(ctx); !ok {
+		return ctx.Status(http.StatusUnauthorized).JSON([]string{errMissingBearer.Error()})
+	} else if strings.HasPrefix(token, model.APIKeyPrefix) {
+		user, err = a.cfg.APIKeyService.Authenticate(ctx.Context(), token)
+	} else {
+		user, err = a.cfg.AuthService.Authenticate(ctx.Context(), token)
+	}
+	if err != nil {
+		return a.WriteResponse(ctx, http.StatusUnauthorized, nil, err)
+	}
+
+	ctx.Locals(model.AuthUserKey(), user)
+	return ctx.Next()
+}
+
+// Permitted rejects requests whose user's role lacks the permission, or whose
+// API key wasn't granted it. It runs
+// after Authenticated, which puts the user on the request context.
+func (a *Api) Permitted(permission string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if err := a.cfg.RBACService.Authorize(ctx.Context(), permission); err != nil {
+			return a.WriteResponse(ctx, http.StatusForbidden, nil, err)
+		}
+		return ctx.Next()
+	}
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header.
+func bearerToken(ctx *fiber.Ctx) (string, bool) {
+	header := ctx.Get(fiber.HeaderAuthorization)
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	return token, token != ""
+}
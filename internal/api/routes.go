@@ -5,6 +5,7 @@ import (
 	"github.com/dembygenesis/local.tools/internal/docs"
 	"github.com/dembygenesis/local.tools/internal/global"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/dembygenesis/local.tools/internal/model"
 	"os"
 	"regexp"
 )
@@ -40,13 +41,35 @@ func (a *Api) Routes() error {
 	apiV1 := a.app.Group("/api")
 	v1 := apiV1.Group("/v1")
 
+	// Auth
+	groupAuth := v1.Group("/auth")
+	groupAuth.Name("Login").Post("/login", a.Login)
+	groupAuth.Name("Refresh").Post("/refresh", a.Refresh)
+	groupAuth.Name("Logout").Post("/logout", a.Logout)
+	groupAuth.Name("Forgot Password").Post("/forgot-password", a.ForgotPassword)
+	groupAuth.Name("Reset Password").Post("/reset-password", a.ResetPassword)
+	groupAuth.Name("Current User").Get("/me", a.Authenticated, a.Me)
+
 	// Category
-	groupCategory := v1.Group("/category")
-	groupCategory.Name("List Categories").Get("", a.ListCategories)
-	groupCategory.Name("Create Category").Post("", a.CreateCategory)
-	groupCategory.Name("Update Category").Patch("", a.UpdateCategory)
-	groupCategory.Name("Delete Category").Delete("/:id", a.DeleteCategory)
-	groupCategory.Name("Restore Category").Patch("/:id", a.RestoreCategory)
+	groupCategory := v1.Group("/category", a.Authenticated)
+	groupCategory.Name("List Categories").Get("", a.Permitted(model.PermissionCategoryRead), a.ListCategories)
+	groupCategory.Name("Create Category").Post("", a.Permitted(model.PermissionCategoryWrite), a.CreateCategory)
+	groupCategory.Name("Update Category").Patch("", a.Permitted(model.PermissionCategoryWrite), a.UpdateCategory)
+	groupCategory.Name("Delete Category").Delete("/:id", a.Permitted(model.PermissionCategoryDelete), a.DeleteCategory)
+	groupCategory.Name("Restore Category").Patch("/:id", a.Permitted(model.PermissionCategoryWrite), a.RestoreCategory)
+
+	// RBAC
+	groupRBAC := v1.Group("/rbac", a.Authenticated)
+	groupRBAC.Name("List Permissions").Get("/permissions", a.Permitted(model.PermissionRoleRead), a.ListPermissions)
+	groupRBAC.Name("List Roles").Get("/roles", a.Permitted(model.PermissionRoleRead), a.ListRoles)
+	groupRBAC.Name("Grant Permission").Put("/roles/:id/permissions/:permission", a.Permitted(model.PermissionRoleWrite), a.GrantPermission)
+	groupRBAC.Name("Revoke Permission").Delete("/roles/:id/permissions/:permission", a.Permitted(model.PermissionRoleWrite), a.RevokePermission)
+
+	// API keys
+	groupAPIKey := v1.Group("/api-keys", a.Authenticated)
+	groupAPIKey.Name("List API Keys").Get("", a.ListAPIKeys)
+	groupAPIKey.Name("Create API Key").Post("", a.CreateAPIKey)
+	groupAPIKey.Name("Revoke API Key").Delete("/:id", a.RevokeAPIKey)
 
 	// Docs
 	if err := a.loadStaticRoutes(); err != nil {
@@ -1,22 +1,56 @@
 package cli
 
 import (
+	"context"
+	"github.com/dembygenesis/local.tools/internal/lib/cliphistory"
+	"github.com/dembygenesis/local.tools/internal/lib/copyprofile"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/dembygenesis/local.tools/internal/lib/pasteback"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/lib/prompt"
+	"github.com/dembygenesis/local.tools/internal/lib/snippet"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //counterfeiter:generate . stringService
 type stringService interface {
-	CopyRootPathToClipboard(root string, exclude []string) ([]string, error)
+	CopyRootPathToClipboard(opts *cliputil.ClipOptions) (*cliputil.ClipReport, error)
+	CopyContextToClipboard(opts *cliputil.ContextOptions) (*cliputil.ContextReport, error)
+	CopyPromptToClipboard(opts *prompt.Options) (*cliputil.ClipReport, error)
+	ClipPreface(opts *preface.Options) error
+	ClipHistory() ([]cliphistory.Entry, error)
+	GetClip(n int) (*cliphistory.Entry, error)
+	RestoreClip(n int) (*cliphistory.Entry, error)
+	PlanApplyClipboard(opts *pasteback.Options) (*pasteback.Plan, error)
+	ApplyClipboard(plan *pasteback.Plan) (*pasteback.Result, error)
+	ListSnippets() ([]snippet.Snippet, error)
+	GetSnippet(name string) (*snippet.Snippet, error)
+	AddSnippets(overwrite bool, snippets ...snippet.Snippet) error
+	RemoveSnippet(name string) error
+	SearchSnippets(query string) ([]snippet.Match, error)
+	ClipSnippet(name string, values map[string]string, output string) error
 }
 
 //counterfeiter:generate . gptService
 type gptService interface {
-	ClipCodingStandardsPreface() error
+	RenderPreface(opts *preface.Options) (string, error)
+	ListPrefaces() ([]preface.Template, error)
+	GetPreface(name string) (*preface.Template, error)
+	AddPreface(name, text string) error
+	SavePreface(name, text string) error
+	RemovePreface(name string) error
+	PrefacePath(name string) (string, error)
 }
 
 //counterfeiter:generate . fileService
 type fileService interface {
-	CopyDirToAnother(opts *fslib.CopyOptions) error
+	CopyDirToAnother(opts *fslib.CopyOptions) (*fslib.CopyPlan, error)
+	WatchDirToAnother(ctx context.Context, opts *fslib.CopyOptions, watchOpts *fslib.WatchOptions) error
+	ListCopyProfiles() ([]copyprofile.Profile, error)
+	GetCopyProfile(name string) (*copyprofile.Profile, error)
+	AddCopyProfile(profile *copyprofile.Profile, overwrite bool) error
+	CopyProfilesPath() string
+	RunCopyProfile(ctx context.Context, name string, opts *copyprofile.RunOptions) (*fslib.CopyPlan, error)
 }
@@ -2,28 +2,169 @@
 package clifakes
 
 import (
+	"context"
 	"sync"
 
+	"github.com/dembygenesis/local.tools/internal/lib/copyprofile"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
 )
 
 type FakeFileService struct {
-	CopyDirToAnotherStub        func(*fslib.CopyOptions) error
+	AddCopyProfileStub        func(*copyprofile.Profile, bool) error
+	addCopyProfileMutex       sync.RWMutex
+	addCopyProfileArgsForCall []struct {
+		arg1 *copyprofile.Profile
+		arg2 bool
+	}
+	addCopyProfileReturns struct {
+		result1 error
+	}
+	addCopyProfileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CopyDirToAnotherStub        func(*fslib.CopyOptions) (*fslib.CopyPlan, error)
 	copyDirToAnotherMutex       sync.RWMutex
 	copyDirToAnotherArgsForCall []struct {
 		arg1 *fslib.CopyOptions
 	}
 	copyDirToAnotherReturns struct {
-		result1 error
+		result1 *fslib.CopyPlan
+		result2 error
 	}
 	copyDirToAnotherReturnsOnCall map[int]struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}
+	CopyProfilesPathStub        func() string
+	copyProfilesPathMutex       sync.RWMutex
+	copyProfilesPathArgsForCall []struct {
+	}
+	copyProfilesPathReturns struct {
+		result1 string
+	}
+	copyProfilesPathReturnsOnCall map[int]struct {
+		result1 string
+	}
+	GetCopyProfileStub        func(string) (*copyprofile.Profile, error)
+	getCopyProfileMutex       sync.RWMutex
+	getCopyProfileArgsForCall []struct {
+		arg1 string
+	}
+	getCopyProfileReturns struct {
+		result1 *copyprofile.Profile
+		result2 error
+	}
+	getCopyProfileReturnsOnCall map[int]struct {
+		result1 *copyprofile.Profile
+		result2 error
+	}
+	ListCopyProfilesStub        func() ([]copyprofile.Profile, error)
+	listCopyProfilesMutex       sync.RWMutex
+	listCopyProfilesArgsForCall []struct {
+	}
+	listCopyProfilesReturns struct {
+		result1 []copyprofile.Profile
+		result2 error
+	}
+	listCopyProfilesReturnsOnCall map[int]struct {
+		result1 []copyprofile.Profile
+		result2 error
+	}
+	RunCopyProfileStub        func(context.Context, string, *copyprofile.RunOptions) (*fslib.CopyPlan, error)
+	runCopyProfileMutex       sync.RWMutex
+	runCopyProfileArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *copyprofile.RunOptions
+	}
+	runCopyProfileReturns struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}
+	runCopyProfileReturnsOnCall map[int]struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}
+	WatchDirToAnotherStub        func(context.Context, *fslib.CopyOptions, *fslib.WatchOptions) error
+	watchDirToAnotherMutex       sync.RWMutex
+	watchDirToAnotherArgsForCall []struct {
+		arg1 context.Context
+		arg2 *fslib.CopyOptions
+		arg3 *fslib.WatchOptions
+	}
+	watchDirToAnotherReturns struct {
+		result1 error
+	}
+	watchDirToAnotherReturnsOnCall map[int]struct {
 		result1 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeFileService) CopyDirToAnother(arg1 *fslib.CopyOptions) error {
+func (fake *FakeFileService) AddCopyProfile(arg1 *copyprofile.Profile, arg2 bool) error {
+	fake.addCopyProfileMutex.Lock()
+	ret, specificReturn := fake.addCopyProfileReturnsOnCall[len(fake.addCopyProfileArgsForCall)]
+	fake.addCopyProfileArgsForCall = append(fake.addCopyProfileArgsForCall, struct {
+		arg1 *copyprofile.Profile
+		arg2 bool
+	}{arg1, arg2})
+	stub := fake.AddCopyProfileStub
+	fakeReturns := fake.addCopyProfileReturns
+	fake.recordInvocation("AddCopyProfile", []interface{}{arg1, arg2})
+	fake.addCopyProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileService) AddCopyProfileCallCount() int {
+	fake.addCopyProfileMutex.RLock()
+	defer fake.addCopyProfileMutex.RUnlock()
+	return len(fake.addCopyProfileArgsForCall)
+}
+
+func (fake *FakeFileService) AddCopyProfileCalls(stub func(*copyprofile.Profile, bool) error) {
+	fake.addCopyProfileMutex.Lock()
+	defer fake.addCopyProfileMutex.Unlock()
+	fake.AddCopyProfileStub = stub
+}
+
+func (fake *FakeFileService) AddCopyProfileArgsForCall(i int) (*copyprofile.Profile, bool) {
+	fake.addCopyProfileMutex.RLock()
+	defer fake.addCopyProfileMutex.RUnlock()
+	argsForCall := fake.addCopyProfileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFileService) AddCopyProfileReturns(result1 error) {
+	fake.addCopyProfileMutex.Lock()
+	defer fake.addCopyProfileMutex.Unlock()
+	fake.AddCopyProfileStub = nil
+	fake.addCopyProfileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileService) AddCopyProfileReturnsOnCall(i int, result1 error) {
+	fake.addCopyProfileMutex.Lock()
+	defer fake.addCopyProfileMutex.Unlock()
+	fake.AddCopyProfileStub = nil
+	if fake.addCopyProfileReturnsOnCall == nil {
+		fake.addCopyProfileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addCopyProfileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileService) CopyDirToAnother(arg1 *fslib.CopyOptions) (*fslib.CopyPlan, error) {
 	fake.copyDirToAnotherMutex.Lock()
 	ret, specificReturn := fake.copyDirToAnotherReturnsOnCall[len(fake.copyDirToAnotherArgsForCall)]
 	fake.copyDirToAnotherArgsForCall = append(fake.copyDirToAnotherArgsForCall, struct {
@@ -37,9 +178,9 @@ func (fake *FakeFileService) CopyDirToAnother(arg1 *fslib.CopyOptions) error {
 		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeFileService) CopyDirToAnotherCallCount() int {
@@ -48,7 +189,7 @@ func (fake *FakeFileService) CopyDirToAnotherCallCount() int {
 	return len(fake.copyDirToAnotherArgsForCall)
 }
 
-func (fake *FakeFileService) CopyDirToAnotherCalls(stub func(*fslib.CopyOptions) error) {
+func (fake *FakeFileService) CopyDirToAnotherCalls(stub func(*fslib.CopyOptions) (*fslib.CopyPlan, error)) {
 	fake.copyDirToAnotherMutex.Lock()
 	defer fake.copyDirToAnotherMutex.Unlock()
 	fake.CopyDirToAnotherStub = stub
@@ -61,25 +202,330 @@ func (fake *FakeFileService) CopyDirToAnotherArgsForCall(i int) *fslib.CopyOptio
 	return argsForCall.arg1
 }
 
-func (fake *FakeFileService) CopyDirToAnotherReturns(result1 error) {
+func (fake *FakeFileService) CopyDirToAnotherReturns(result1 *fslib.CopyPlan, result2 error) {
 	fake.copyDirToAnotherMutex.Lock()
 	defer fake.copyDirToAnotherMutex.Unlock()
 	fake.CopyDirToAnotherStub = nil
 	fake.copyDirToAnotherReturns = struct {
-		result1 error
-	}{result1}
+		result1 *fslib.CopyPlan
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeFileService) CopyDirToAnotherReturnsOnCall(i int, result1 error) {
+func (fake *FakeFileService) CopyDirToAnotherReturnsOnCall(i int, result1 *fslib.CopyPlan, result2 error) {
 	fake.copyDirToAnotherMutex.Lock()
 	defer fake.copyDirToAnotherMutex.Unlock()
 	fake.CopyDirToAnotherStub = nil
 	if fake.copyDirToAnotherReturnsOnCall == nil {
 		fake.copyDirToAnotherReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 *fslib.CopyPlan
+			result2 error
 		})
 	}
 	fake.copyDirToAnotherReturnsOnCall[i] = struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileService) CopyProfilesPath() string {
+	fake.copyProfilesPathMutex.Lock()
+	ret, specificReturn := fake.copyProfilesPathReturnsOnCall[len(fake.copyProfilesPathArgsForCall)]
+	fake.copyProfilesPathArgsForCall = append(fake.copyProfilesPathArgsForCall, struct {
+	}{})
+	stub := fake.CopyProfilesPathStub
+	fakeReturns := fake.copyProfilesPathReturns
+	fake.recordInvocation("CopyProfilesPath", []interface{}{})
+	fake.copyProfilesPathMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileService) CopyProfilesPathCallCount() int {
+	fake.copyProfilesPathMutex.RLock()
+	defer fake.copyProfilesPathMutex.RUnlock()
+	return len(fake.copyProfilesPathArgsForCall)
+}
+
+func (fake *FakeFileService) CopyProfilesPathCalls(stub func() string) {
+	fake.copyProfilesPathMutex.Lock()
+	defer fake.copyProfilesPathMutex.Unlock()
+	fake.CopyProfilesPathStub = stub
+}
+
+func (fake *FakeFileService) CopyProfilesPathReturns(result1 string) {
+	fake.copyProfilesPathMutex.Lock()
+	defer fake.copyProfilesPathMutex.Unlock()
+	fake.CopyProfilesPathStub = nil
+	fake.copyProfilesPathReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeFileService) CopyProfilesPathReturnsOnCall(i int, result1 string) {
+	fake.copyProfilesPathMutex.Lock()
+	defer fake.copyProfilesPathMutex.Unlock()
+	fake.CopyProfilesPathStub = nil
+	if fake.copyProfilesPathReturnsOnCall == nil {
+		fake.copyProfilesPathReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.copyProfilesPathReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeFileService) GetCopyProfile(arg1 string) (*copyprofile.Profile, error) {
+	fake.getCopyProfileMutex.Lock()
+	ret, specificReturn := fake.getCopyProfileReturnsOnCall[len(fake.getCopyProfileArgsForCall)]
+	fake.getCopyProfileArgsForCall = append(fake.getCopyProfileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetCopyProfileStub
+	fakeReturns := fake.getCopyProfileReturns
+	fake.recordInvocation("GetCopyProfile", []interface{}{arg1})
+	fake.getCopyProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileService) GetCopyProfileCallCount() int {
+	fake.getCopyProfileMutex.RLock()
+	defer fake.getCopyProfileMutex.RUnlock()
+	return len(fake.getCopyProfileArgsForCall)
+}
+
+func (fake *FakeFileService) GetCopyProfileCalls(stub func(string) (*copyprofile.Profile, error)) {
+	fake.getCopyProfileMutex.Lock()
+	defer fake.getCopyProfileMutex.Unlock()
+	fake.GetCopyProfileStub = stub
+}
+
+func (fake *FakeFileService) GetCopyProfileArgsForCall(i int) string {
+	fake.getCopyProfileMutex.RLock()
+	defer fake.getCopyProfileMutex.RUnlock()
+	argsForCall := fake.getCopyProfileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeFileService) GetCopyProfileReturns(result1 *copyprofile.Profile, result2 error) {
+	fake.getCopyProfileMutex.Lock()
+	defer fake.getCopyProfileMutex.Unlock()
+	fake.GetCopyProfileStub = nil
+	fake.getCopyProfileReturns = struct {
+		result1 *copyprofile.Profile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileService) GetCopyProfileReturnsOnCall(i int, result1 *copyprofile.Profile, result2 error) {
+	fake.getCopyProfileMutex.Lock()
+	defer fake.getCopyProfileMutex.Unlock()
+	fake.GetCopyProfileStub = nil
+	if fake.getCopyProfileReturnsOnCall == nil {
+		fake.getCopyProfileReturnsOnCall = make(map[int]struct {
+			result1 *copyprofile.Profile
+			result2 error
+		})
+	}
+	fake.getCopyProfileReturnsOnCall[i] = struct {
+		result1 *copyprofile.Profile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileService) ListCopyProfiles() ([]copyprofile.Profile, error) {
+	fake.listCopyProfilesMutex.Lock()
+	ret, specificReturn := fake.listCopyProfilesReturnsOnCall[len(fake.listCopyProfilesArgsForCall)]
+	fake.listCopyProfilesArgsForCall = append(fake.listCopyProfilesArgsForCall, struct {
+	}{})
+	stub := fake.ListCopyProfilesStub
+	fakeReturns := fake.listCopyProfilesReturns
+	fake.recordInvocation("ListCopyProfiles", []interface{}{})
+	fake.listCopyProfilesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileService) ListCopyProfilesCallCount() int {
+	fake.listCopyProfilesMutex.RLock()
+	defer fake.listCopyProfilesMutex.RUnlock()
+	return len(fake.listCopyProfilesArgsForCall)
+}
+
+func (fake *FakeFileService) ListCopyProfilesCalls(stub func() ([]copyprofile.Profile, error)) {
+	fake.listCopyProfilesMutex.Lock()
+	defer fake.listCopyProfilesMutex.Unlock()
+	fake.ListCopyProfilesStub = stub
+}
+
+func (fake *FakeFileService) ListCopyProfilesReturns(result1 []copyprofile.Profile, result2 error) {
+	fake.listCopyProfilesMutex.Lock()
+	defer fake.listCopyProfilesMutex.Unlock()
+	fake.ListCopyProfilesStub = nil
+	fake.listCopyProfilesReturns = struct {
+		result1 []copyprofile.Profile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileService) ListCopyProfilesReturnsOnCall(i int, result1 []copyprofile.Profile, result2 error) {
+	fake.listCopyProfilesMutex.Lock()
+	defer fake.listCopyProfilesMutex.Unlock()
+	fake.ListCopyProfilesStub = nil
+	if fake.listCopyProfilesReturnsOnCall == nil {
+		fake.listCopyProfilesReturnsOnCall = make(map[int]struct {
+			result1 []copyprofile.Profile
+			result2 error
+		})
+	}
+	fake.listCopyProfilesReturnsOnCall[i] = struct {
+		result1 []copyprofile.Profile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileService) RunCopyProfile(arg1 context.Context, arg2 string, arg3 *copyprofile.RunOptions) (*fslib.CopyPlan, error) {
+	fake.runCopyProfileMutex.Lock()
+	ret, specificReturn := fake.runCopyProfileReturnsOnCall[len(fake.runCopyProfileArgsForCall)]
+	fake.runCopyProfileArgsForCall = append(fake.runCopyProfileArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *copyprofile.RunOptions
+	}{arg1, arg2, arg3})
+	stub := fake.RunCopyProfileStub
+	fakeReturns := fake.runCopyProfileReturns
+	fake.recordInvocation("RunCopyProfile", []interface{}{arg1, arg2, arg3})
+	fake.runCopyProfileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFileService) RunCopyProfileCallCount() int {
+	fake.runCopyProfileMutex.RLock()
+	defer fake.runCopyProfileMutex.RUnlock()
+	return len(fake.runCopyProfileArgsForCall)
+}
+
+func (fake *FakeFileService) RunCopyProfileCalls(stub func(context.Context, string, *copyprofile.RunOptions) (*fslib.CopyPlan, error)) {
+	fake.runCopyProfileMutex.Lock()
+	defer fake.runCopyProfileMutex.Unlock()
+	fake.RunCopyProfileStub = stub
+}
+
+func (fake *FakeFileService) RunCopyProfileArgsForCall(i int) (context.Context, string, *copyprofile.RunOptions) {
+	fake.runCopyProfileMutex.RLock()
+	defer fake.runCopyProfileMutex.RUnlock()
+	argsForCall := fake.runCopyProfileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFileService) RunCopyProfileReturns(result1 *fslib.CopyPlan, result2 error) {
+	fake.runCopyProfileMutex.Lock()
+	defer fake.runCopyProfileMutex.Unlock()
+	fake.RunCopyProfileStub = nil
+	fake.runCopyProfileReturns = struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileService) RunCopyProfileReturnsOnCall(i int, result1 *fslib.CopyPlan, result2 error) {
+	fake.runCopyProfileMutex.Lock()
+	defer fake.runCopyProfileMutex.Unlock()
+	fake.RunCopyProfileStub = nil
+	if fake.runCopyProfileReturnsOnCall == nil {
+		fake.runCopyProfileReturnsOnCall = make(map[int]struct {
+			result1 *fslib.CopyPlan
+			result2 error
+		})
+	}
+	fake.runCopyProfileReturnsOnCall[i] = struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFileService) WatchDirToAnother(arg1 context.Context, arg2 *fslib.CopyOptions, arg3 *fslib.WatchOptions) error {
+	fake.watchDirToAnotherMutex.Lock()
+	ret, specificReturn := fake.watchDirToAnotherReturnsOnCall[len(fake.watchDirToAnotherArgsForCall)]
+	fake.watchDirToAnotherArgsForCall = append(fake.watchDirToAnotherArgsForCall, struct {
+		arg1 context.Context
+		arg2 *fslib.CopyOptions
+		arg3 *fslib.WatchOptions
+	}{arg1, arg2, arg3})
+	stub := fake.WatchDirToAnotherStub
+	fakeReturns := fake.watchDirToAnotherReturns
+	fake.recordInvocation("WatchDirToAnother", []interface{}{arg1, arg2, arg3})
+	fake.watchDirToAnotherMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeFileService) WatchDirToAnotherCallCount() int {
+	fake.watchDirToAnotherMutex.RLock()
+	defer fake.watchDirToAnotherMutex.RUnlock()
+	return len(fake.watchDirToAnotherArgsForCall)
+}
+
+func (fake *FakeFileService) WatchDirToAnotherCalls(stub func(context.Context, *fslib.CopyOptions, *fslib.WatchOptions) error) {
+	fake.watchDirToAnotherMutex.Lock()
+	defer fake.watchDirToAnotherMutex.Unlock()
+	fake.WatchDirToAnotherStub = stub
+}
+
+func (fake *FakeFileService) WatchDirToAnotherArgsForCall(i int) (context.Context, *fslib.CopyOptions, *fslib.WatchOptions) {
+	fake.watchDirToAnotherMutex.RLock()
+	defer fake.watchDirToAnotherMutex.RUnlock()
+	argsForCall := fake.watchDirToAnotherArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeFileService) WatchDirToAnotherReturns(result1 error) {
+	fake.watchDirToAnotherMutex.Lock()
+	defer fake.watchDirToAnotherMutex.Unlock()
+	fake.WatchDirToAnotherStub = nil
+	fake.watchDirToAnotherReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFileService) WatchDirToAnotherReturnsOnCall(i int, result1 error) {
+	fake.watchDirToAnotherMutex.Lock()
+	defer fake.watchDirToAnotherMutex.Unlock()
+	fake.WatchDirToAnotherStub = nil
+	if fake.watchDirToAnotherReturnsOnCall == nil {
+		fake.watchDirToAnotherReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.watchDirToAnotherReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -87,8 +533,20 @@ func (fake *FakeFileService) CopyDirToAnotherReturnsOnCall(i int, result1 error)
 func (fake *FakeFileService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.addCopyProfileMutex.RLock()
+	defer fake.addCopyProfileMutex.RUnlock()
 	fake.copyDirToAnotherMutex.RLock()
 	defer fake.copyDirToAnotherMutex.RUnlock()
+	fake.copyProfilesPathMutex.RLock()
+	defer fake.copyProfilesPathMutex.RUnlock()
+	fake.getCopyProfileMutex.RLock()
+	defer fake.getCopyProfileMutex.RUnlock()
+	fake.listCopyProfilesMutex.RLock()
+	defer fake.listCopyProfilesMutex.RUnlock()
+	fake.runCopyProfileMutex.RLock()
+	defer fake.runCopyProfileMutex.RUnlock()
+	fake.watchDirToAnotherMutex.RLock()
+	defer fake.watchDirToAnotherMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,72 +3,530 @@ package clifakes
 
 import (
 	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
 )
 
 type FakeGptService struct {
-	ClipCodingStandardsPrefaceStub        func() error
-	clipCodingStandardsPrefaceMutex       sync.RWMutex
-	clipCodingStandardsPrefaceArgsForCall []struct {
+	AddPrefaceStub        func(string, string) error
+	addPrefaceMutex       sync.RWMutex
+	addPrefaceArgsForCall []struct {
+		arg1 string
+		arg2 string
 	}
-	clipCodingStandardsPrefaceReturns struct {
+	addPrefaceReturns struct {
 		result1 error
 	}
-	clipCodingStandardsPrefaceReturnsOnCall map[int]struct {
+	addPrefaceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetPrefaceStub        func(string) (*preface.Template, error)
+	getPrefaceMutex       sync.RWMutex
+	getPrefaceArgsForCall []struct {
+		arg1 string
+	}
+	getPrefaceReturns struct {
+		result1 *preface.Template
+		result2 error
+	}
+	getPrefaceReturnsOnCall map[int]struct {
+		result1 *preface.Template
+		result2 error
+	}
+	ListPrefacesStub        func() ([]preface.Template, error)
+	listPrefacesMutex       sync.RWMutex
+	listPrefacesArgsForCall []struct {
+	}
+	listPrefacesReturns struct {
+		result1 []preface.Template
+		result2 error
+	}
+	listPrefacesReturnsOnCall map[int]struct {
+		result1 []preface.Template
+		result2 error
+	}
+	PrefacePathStub        func(string) (string, error)
+	prefacePathMutex       sync.RWMutex
+	prefacePathArgsForCall []struct {
+		arg1 string
+	}
+	prefacePathReturns struct {
+		result1 string
+		result2 error
+	}
+	prefacePathReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RemovePrefaceStub        func(string) error
+	removePrefaceMutex       sync.RWMutex
+	removePrefaceArgsForCall []struct {
+		arg1 string
+	}
+	removePrefaceReturns struct {
+		result1 error
+	}
+	removePrefaceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RenderPrefaceStub        func(*preface.Options) (string, error)
+	renderPrefaceMutex       sync.RWMutex
+	renderPrefaceArgsForCall []struct {
+		arg1 *preface.Options
+	}
+	renderPrefaceReturns struct {
+		result1 string
+		result2 error
+	}
+	renderPrefaceReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	SavePrefaceStub        func(string, string) error
+	savePrefaceMutex       sync.RWMutex
+	savePrefaceArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	savePrefaceReturns struct {
+		result1 error
+	}
+	savePrefaceReturnsOnCall map[int]struct {
 		result1 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeGptService) ClipCodingStandardsPreface() error {
-	fake.clipCodingStandardsPrefaceMutex.Lock()
-	ret, specificReturn := fake.clipCodingStandardsPrefaceReturnsOnCall[len(fake.clipCodingStandardsPrefaceArgsForCall)]
-	fake.clipCodingStandardsPrefaceArgsForCall = append(fake.clipCodingStandardsPrefaceArgsForCall, struct {
+func (fake *FakeGptService) AddPreface(arg1 string, arg2 string) error {
+	fake.addPrefaceMutex.Lock()
+	ret, specificReturn := fake.addPrefaceReturnsOnCall[len(fake.addPrefaceArgsForCall)]
+	fake.addPrefaceArgsForCall = append(fake.addPrefaceArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AddPrefaceStub
+	fakeReturns := fake.addPrefaceReturns
+	fake.recordInvocation("AddPreface", []interface{}{arg1, arg2})
+	fake.addPrefaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGptService) AddPrefaceCallCount() int {
+	fake.addPrefaceMutex.RLock()
+	defer fake.addPrefaceMutex.RUnlock()
+	return len(fake.addPrefaceArgsForCall)
+}
+
+func (fake *FakeGptService) AddPrefaceCalls(stub func(string, string) error) {
+	fake.addPrefaceMutex.Lock()
+	defer fake.addPrefaceMutex.Unlock()
+	fake.AddPrefaceStub = stub
+}
+
+func (fake *FakeGptService) AddPrefaceArgsForCall(i int) (string, string) {
+	fake.addPrefaceMutex.RLock()
+	defer fake.addPrefaceMutex.RUnlock()
+	argsForCall := fake.addPrefaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGptService) AddPrefaceReturns(result1 error) {
+	fake.addPrefaceMutex.Lock()
+	defer fake.addPrefaceMutex.Unlock()
+	fake.AddPrefaceStub = nil
+	fake.addPrefaceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGptService) AddPrefaceReturnsOnCall(i int, result1 error) {
+	fake.addPrefaceMutex.Lock()
+	defer fake.addPrefaceMutex.Unlock()
+	fake.AddPrefaceStub = nil
+	if fake.addPrefaceReturnsOnCall == nil {
+		fake.addPrefaceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addPrefaceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGptService) GetPreface(arg1 string) (*preface.Template, error) {
+	fake.getPrefaceMutex.Lock()
+	ret, specificReturn := fake.getPrefaceReturnsOnCall[len(fake.getPrefaceArgsForCall)]
+	fake.getPrefaceArgsForCall = append(fake.getPrefaceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetPrefaceStub
+	fakeReturns := fake.getPrefaceReturns
+	fake.recordInvocation("GetPreface", []interface{}{arg1})
+	fake.getPrefaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGptService) GetPrefaceCallCount() int {
+	fake.getPrefaceMutex.RLock()
+	defer fake.getPrefaceMutex.RUnlock()
+	return len(fake.getPrefaceArgsForCall)
+}
+
+func (fake *FakeGptService) GetPrefaceCalls(stub func(string) (*preface.Template, error)) {
+	fake.getPrefaceMutex.Lock()
+	defer fake.getPrefaceMutex.Unlock()
+	fake.GetPrefaceStub = stub
+}
+
+func (fake *FakeGptService) GetPrefaceArgsForCall(i int) string {
+	fake.getPrefaceMutex.RLock()
+	defer fake.getPrefaceMutex.RUnlock()
+	argsForCall := fake.getPrefaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGptService) GetPrefaceReturns(result1 *preface.Template, result2 error) {
+	fake.getPrefaceMutex.Lock()
+	defer fake.getPrefaceMutex.Unlock()
+	fake.GetPrefaceStub = nil
+	fake.getPrefaceReturns = struct {
+		result1 *preface.Template
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) GetPrefaceReturnsOnCall(i int, result1 *preface.Template, result2 error) {
+	fake.getPrefaceMutex.Lock()
+	defer fake.getPrefaceMutex.Unlock()
+	fake.GetPrefaceStub = nil
+	if fake.getPrefaceReturnsOnCall == nil {
+		fake.getPrefaceReturnsOnCall = make(map[int]struct {
+			result1 *preface.Template
+			result2 error
+		})
+	}
+	fake.getPrefaceReturnsOnCall[i] = struct {
+		result1 *preface.Template
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) ListPrefaces() ([]preface.Template, error) {
+	fake.listPrefacesMutex.Lock()
+	ret, specificReturn := fake.listPrefacesReturnsOnCall[len(fake.listPrefacesArgsForCall)]
+	fake.listPrefacesArgsForCall = append(fake.listPrefacesArgsForCall, struct {
 	}{})
-	stub := fake.ClipCodingStandardsPrefaceStub
-	fakeReturns := fake.clipCodingStandardsPrefaceReturns
-	fake.recordInvocation("ClipCodingStandardsPreface", []interface{}{})
-	fake.clipCodingStandardsPrefaceMutex.Unlock()
+	stub := fake.ListPrefacesStub
+	fakeReturns := fake.listPrefacesReturns
+	fake.recordInvocation("ListPrefaces", []interface{}{})
+	fake.listPrefacesMutex.Unlock()
 	if stub != nil {
 		return stub()
 	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGptService) ListPrefacesCallCount() int {
+	fake.listPrefacesMutex.RLock()
+	defer fake.listPrefacesMutex.RUnlock()
+	return len(fake.listPrefacesArgsForCall)
+}
+
+func (fake *FakeGptService) ListPrefacesCalls(stub func() ([]preface.Template, error)) {
+	fake.listPrefacesMutex.Lock()
+	defer fake.listPrefacesMutex.Unlock()
+	fake.ListPrefacesStub = stub
+}
+
+func (fake *FakeGptService) ListPrefacesReturns(result1 []preface.Template, result2 error) {
+	fake.listPrefacesMutex.Lock()
+	defer fake.listPrefacesMutex.Unlock()
+	fake.ListPrefacesStub = nil
+	fake.listPrefacesReturns = struct {
+		result1 []preface.Template
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) ListPrefacesReturnsOnCall(i int, result1 []preface.Template, result2 error) {
+	fake.listPrefacesMutex.Lock()
+	defer fake.listPrefacesMutex.Unlock()
+	fake.ListPrefacesStub = nil
+	if fake.listPrefacesReturnsOnCall == nil {
+		fake.listPrefacesReturnsOnCall = make(map[int]struct {
+			result1 []preface.Template
+			result2 error
+		})
+	}
+	fake.listPrefacesReturnsOnCall[i] = struct {
+		result1 []preface.Template
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) PrefacePath(arg1 string) (string, error) {
+	fake.prefacePathMutex.Lock()
+	ret, specificReturn := fake.prefacePathReturnsOnCall[len(fake.prefacePathArgsForCall)]
+	fake.prefacePathArgsForCall = append(fake.prefacePathArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.PrefacePathStub
+	fakeReturns := fake.prefacePathReturns
+	fake.recordInvocation("PrefacePath", []interface{}{arg1})
+	fake.prefacePathMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGptService) PrefacePathCallCount() int {
+	fake.prefacePathMutex.RLock()
+	defer fake.prefacePathMutex.RUnlock()
+	return len(fake.prefacePathArgsForCall)
+}
+
+func (fake *FakeGptService) PrefacePathCalls(stub func(string) (string, error)) {
+	fake.prefacePathMutex.Lock()
+	defer fake.prefacePathMutex.Unlock()
+	fake.PrefacePathStub = stub
+}
+
+func (fake *FakeGptService) PrefacePathArgsForCall(i int) string {
+	fake.prefacePathMutex.RLock()
+	defer fake.prefacePathMutex.RUnlock()
+	argsForCall := fake.prefacePathArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGptService) PrefacePathReturns(result1 string, result2 error) {
+	fake.prefacePathMutex.Lock()
+	defer fake.prefacePathMutex.Unlock()
+	fake.PrefacePathStub = nil
+	fake.prefacePathReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) PrefacePathReturnsOnCall(i int, result1 string, result2 error) {
+	fake.prefacePathMutex.Lock()
+	defer fake.prefacePathMutex.Unlock()
+	fake.PrefacePathStub = nil
+	if fake.prefacePathReturnsOnCall == nil {
+		fake.prefacePathReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.prefacePathReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) RemovePreface(arg1 string) error {
+	fake.removePrefaceMutex.Lock()
+	ret, specificReturn := fake.removePrefaceReturnsOnCall[len(fake.removePrefaceArgsForCall)]
+	fake.removePrefaceArgsForCall = append(fake.removePrefaceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemovePrefaceStub
+	fakeReturns := fake.removePrefaceReturns
+	fake.recordInvocation("RemovePreface", []interface{}{arg1})
+	fake.removePrefaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGptService) RemovePrefaceCallCount() int {
+	fake.removePrefaceMutex.RLock()
+	defer fake.removePrefaceMutex.RUnlock()
+	return len(fake.removePrefaceArgsForCall)
+}
+
+func (fake *FakeGptService) RemovePrefaceCalls(stub func(string) error) {
+	fake.removePrefaceMutex.Lock()
+	defer fake.removePrefaceMutex.Unlock()
+	fake.RemovePrefaceStub = stub
+}
+
+func (fake *FakeGptService) RemovePrefaceArgsForCall(i int) string {
+	fake.removePrefaceMutex.RLock()
+	defer fake.removePrefaceMutex.RUnlock()
+	argsForCall := fake.removePrefaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGptService) RemovePrefaceReturns(result1 error) {
+	fake.removePrefaceMutex.Lock()
+	defer fake.removePrefaceMutex.Unlock()
+	fake.RemovePrefaceStub = nil
+	fake.removePrefaceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGptService) RemovePrefaceReturnsOnCall(i int, result1 error) {
+	fake.removePrefaceMutex.Lock()
+	defer fake.removePrefaceMutex.Unlock()
+	fake.RemovePrefaceStub = nil
+	if fake.removePrefaceReturnsOnCall == nil {
+		fake.removePrefaceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removePrefaceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGptService) RenderPreface(arg1 *preface.Options) (string, error) {
+	fake.renderPrefaceMutex.Lock()
+	ret, specificReturn := fake.renderPrefaceReturnsOnCall[len(fake.renderPrefaceArgsForCall)]
+	fake.renderPrefaceArgsForCall = append(fake.renderPrefaceArgsForCall, struct {
+		arg1 *preface.Options
+	}{arg1})
+	stub := fake.RenderPrefaceStub
+	fakeReturns := fake.renderPrefaceReturns
+	fake.recordInvocation("RenderPreface", []interface{}{arg1})
+	fake.renderPrefaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGptService) RenderPrefaceCallCount() int {
+	fake.renderPrefaceMutex.RLock()
+	defer fake.renderPrefaceMutex.RUnlock()
+	return len(fake.renderPrefaceArgsForCall)
+}
+
+func (fake *FakeGptService) RenderPrefaceCalls(stub func(*preface.Options) (string, error)) {
+	fake.renderPrefaceMutex.Lock()
+	defer fake.renderPrefaceMutex.Unlock()
+	fake.RenderPrefaceStub = stub
+}
+
+func (fake *FakeGptService) RenderPrefaceArgsForCall(i int) *preface.Options {
+	fake.renderPrefaceMutex.RLock()
+	defer fake.renderPrefaceMutex.RUnlock()
+	argsForCall := fake.renderPrefaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeGptService) RenderPrefaceReturns(result1 string, result2 error) {
+	fake.renderPrefaceMutex.Lock()
+	defer fake.renderPrefaceMutex.Unlock()
+	fake.RenderPrefaceStub = nil
+	fake.renderPrefaceReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) RenderPrefaceReturnsOnCall(i int, result1 string, result2 error) {
+	fake.renderPrefaceMutex.Lock()
+	defer fake.renderPrefaceMutex.Unlock()
+	fake.RenderPrefaceStub = nil
+	if fake.renderPrefaceReturnsOnCall == nil {
+		fake.renderPrefaceReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.renderPrefaceReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGptService) SavePreface(arg1 string, arg2 string) error {
+	fake.savePrefaceMutex.Lock()
+	ret, specificReturn := fake.savePrefaceReturnsOnCall[len(fake.savePrefaceArgsForCall)]
+	fake.savePrefaceArgsForCall = append(fake.savePrefaceArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.SavePrefaceStub
+	fakeReturns := fake.savePrefaceReturns
+	fake.recordInvocation("SavePreface", []interface{}{arg1, arg2})
+	fake.savePrefaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
 	if specificReturn {
 		return ret.result1
 	}
 	return fakeReturns.result1
 }
 
-func (fake *FakeGptService) ClipCodingStandardsPrefaceCallCount() int {
-	fake.clipCodingStandardsPrefaceMutex.RLock()
-	defer fake.clipCodingStandardsPrefaceMutex.RUnlock()
-	return len(fake.clipCodingStandardsPrefaceArgsForCall)
+func (fake *FakeGptService) SavePrefaceCallCount() int {
+	fake.savePrefaceMutex.RLock()
+	defer fake.savePrefaceMutex.RUnlock()
+	return len(fake.savePrefaceArgsForCall)
+}
+
+func (fake *FakeGptService) SavePrefaceCalls(stub func(string, string) error) {
+	fake.savePrefaceMutex.Lock()
+	defer fake.savePrefaceMutex.Unlock()
+	fake.SavePrefaceStub = stub
 }
 
-func (fake *FakeGptService) ClipCodingStandardsPrefaceCalls(stub func() error) {
-	fake.clipCodingStandardsPrefaceMutex.Lock()
-	defer fake.clipCodingStandardsPrefaceMutex.Unlock()
-	fake.ClipCodingStandardsPrefaceStub = stub
+func (fake *FakeGptService) SavePrefaceArgsForCall(i int) (string, string) {
+	fake.savePrefaceMutex.RLock()
+	defer fake.savePrefaceMutex.RUnlock()
+	argsForCall := fake.savePrefaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeGptService) ClipCodingStandardsPrefaceReturns(result1 error) {
-	fake.clipCodingStandardsPrefaceMutex.Lock()
-	defer fake.clipCodingStandardsPrefaceMutex.Unlock()
-	fake.ClipCodingStandardsPrefaceStub = nil
-	fake.clipCodingStandardsPrefaceReturns = struct {
+func (fake *FakeGptService) SavePrefaceReturns(result1 error) {
+	fake.savePrefaceMutex.Lock()
+	defer fake.savePrefaceMutex.Unlock()
+	fake.SavePrefaceStub = nil
+	fake.savePrefaceReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *FakeGptService) ClipCodingStandardsPrefaceReturnsOnCall(i int, result1 error) {
-	fake.clipCodingStandardsPrefaceMutex.Lock()
-	defer fake.clipCodingStandardsPrefaceMutex.Unlock()
-	fake.ClipCodingStandardsPrefaceStub = nil
-	if fake.clipCodingStandardsPrefaceReturnsOnCall == nil {
-		fake.clipCodingStandardsPrefaceReturnsOnCall = make(map[int]struct {
+func (fake *FakeGptService) SavePrefaceReturnsOnCall(i int, result1 error) {
+	fake.savePrefaceMutex.Lock()
+	defer fake.savePrefaceMutex.Unlock()
+	fake.SavePrefaceStub = nil
+	if fake.savePrefaceReturnsOnCall == nil {
+		fake.savePrefaceReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.clipCodingStandardsPrefaceReturnsOnCall[i] = struct {
+	fake.savePrefaceReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -76,8 +534,20 @@ func (fake *FakeGptService) ClipCodingStandardsPrefaceReturnsOnCall(i int, resul
 func (fake *FakeGptService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.clipCodingStandardsPrefaceMutex.RLock()
-	defer fake.clipCodingStandardsPrefaceMutex.RUnlock()
+	fake.addPrefaceMutex.RLock()
+	defer fake.addPrefaceMutex.RUnlock()
+	fake.getPrefaceMutex.RLock()
+	defer fake.getPrefaceMutex.RUnlock()
+	fake.listPrefacesMutex.RLock()
+	defer fake.listPrefacesMutex.RUnlock()
+	fake.prefacePathMutex.RLock()
+	defer fake.prefacePathMutex.RUnlock()
+	fake.removePrefaceMutex.RLock()
+	defer fake.removePrefaceMutex.RUnlock()
+	fake.renderPrefaceMutex.RLock()
+	defer fake.renderPrefaceMutex.RUnlock()
+	fake.savePrefaceMutex.RLock()
+	defer fake.savePrefaceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
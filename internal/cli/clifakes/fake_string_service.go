@@ -3,45 +3,654 @@ package clifakes
 
 import (
 	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/lib/cliphistory"
+	"github.com/dembygenesis/local.tools/internal/lib/pasteback"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/lib/prompt"
+	"github.com/dembygenesis/local.tools/internal/lib/snippet"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
 )
 
 type FakeStringService struct {
-	CopyRootPathToClipboardStub        func(string, []string) ([]string, error)
+	AddSnippetsStub        func(bool, ...snippet.Snippet) error
+	addSnippetsMutex       sync.RWMutex
+	addSnippetsArgsForCall []struct {
+		arg1 bool
+		arg2 []snippet.Snippet
+	}
+	addSnippetsReturns struct {
+		result1 error
+	}
+	addSnippetsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ApplyClipboardStub        func(*pasteback.Plan) (*pasteback.Result, error)
+	applyClipboardMutex       sync.RWMutex
+	applyClipboardArgsForCall []struct {
+		arg1 *pasteback.Plan
+	}
+	applyClipboardReturns struct {
+		result1 *pasteback.Result
+		result2 error
+	}
+	applyClipboardReturnsOnCall map[int]struct {
+		result1 *pasteback.Result
+		result2 error
+	}
+	ClipHistoryStub        func() ([]cliphistory.Entry, error)
+	clipHistoryMutex       sync.RWMutex
+	clipHistoryArgsForCall []struct {
+	}
+	clipHistoryReturns struct {
+		result1 []cliphistory.Entry
+		result2 error
+	}
+	clipHistoryReturnsOnCall map[int]struct {
+		result1 []cliphistory.Entry
+		result2 error
+	}
+	ClipPrefaceStub        func(*preface.Options) error
+	clipPrefaceMutex       sync.RWMutex
+	clipPrefaceArgsForCall []struct {
+		arg1 *preface.Options
+	}
+	clipPrefaceReturns struct {
+		result1 error
+	}
+	clipPrefaceReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ClipSnippetStub        func(string, map[string]string, string) error
+	clipSnippetMutex       sync.RWMutex
+	clipSnippetArgsForCall []struct {
+		arg1 string
+		arg2 map[string]string
+		arg3 string
+	}
+	clipSnippetReturns struct {
+		result1 error
+	}
+	clipSnippetReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CopyContextToClipboardStub        func(*cliputil.ContextOptions) (*cliputil.ContextReport, error)
+	copyContextToClipboardMutex       sync.RWMutex
+	copyContextToClipboardArgsForCall []struct {
+		arg1 *cliputil.ContextOptions
+	}
+	copyContextToClipboardReturns struct {
+		result1 *cliputil.ContextReport
+		result2 error
+	}
+	copyContextToClipboardReturnsOnCall map[int]struct {
+		result1 *cliputil.ContextReport
+		result2 error
+	}
+	CopyPromptToClipboardStub        func(*prompt.Options) (*cliputil.ClipReport, error)
+	copyPromptToClipboardMutex       sync.RWMutex
+	copyPromptToClipboardArgsForCall []struct {
+		arg1 *prompt.Options
+	}
+	copyPromptToClipboardReturns struct {
+		result1 *cliputil.ClipReport
+		result2 error
+	}
+	copyPromptToClipboardReturnsOnCall map[int]struct {
+		result1 *cliputil.ClipReport
+		result2 error
+	}
+	CopyRootPathToClipboardStub        func(*cliputil.ClipOptions) (*cliputil.ClipReport, error)
 	copyRootPathToClipboardMutex       sync.RWMutex
 	copyRootPathToClipboardArgsForCall []struct {
-		arg1 string
-		arg2 []string
+		arg1 *cliputil.ClipOptions
 	}
 	copyRootPathToClipboardReturns struct {
-		result1 []string
+		result1 *cliputil.ClipReport
 		result2 error
 	}
 	copyRootPathToClipboardReturnsOnCall map[int]struct {
-		result1 []string
+		result1 *cliputil.ClipReport
+		result2 error
+	}
+	GetClipStub        func(int) (*cliphistory.Entry, error)
+	getClipMutex       sync.RWMutex
+	getClipArgsForCall []struct {
+		arg1 int
+	}
+	getClipReturns struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}
+	getClipReturnsOnCall map[int]struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}
+	GetSnippetStub        func(string) (*snippet.Snippet, error)
+	getSnippetMutex       sync.RWMutex
+	getSnippetArgsForCall []struct {
+		arg1 string
+	}
+	getSnippetReturns struct {
+		result1 *snippet.Snippet
+		result2 error
+	}
+	getSnippetReturnsOnCall map[int]struct {
+		result1 *snippet.Snippet
+		result2 error
+	}
+	ListSnippetsStub        func() ([]snippet.Snippet, error)
+	listSnippetsMutex       sync.RWMutex
+	listSnippetsArgsForCall []struct {
+	}
+	listSnippetsReturns struct {
+		result1 []snippet.Snippet
+		result2 error
+	}
+	listSnippetsReturnsOnCall map[int]struct {
+		result1 []snippet.Snippet
+		result2 error
+	}
+	PlanApplyClipboardStub        func(*pasteback.Options) (*pasteback.Plan, error)
+	planApplyClipboardMutex       sync.RWMutex
+	planApplyClipboardArgsForCall []struct {
+		arg1 *pasteback.Options
+	}
+	planApplyClipboardReturns struct {
+		result1 *pasteback.Plan
+		result2 error
+	}
+	planApplyClipboardReturnsOnCall map[int]struct {
+		result1 *pasteback.Plan
+		result2 error
+	}
+	RemoveSnippetStub        func(string) error
+	removeSnippetMutex       sync.RWMutex
+	removeSnippetArgsForCall []struct {
+		arg1 string
+	}
+	removeSnippetReturns struct {
+		result1 error
+	}
+	removeSnippetReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RestoreClipStub        func(int) (*cliphistory.Entry, error)
+	restoreClipMutex       sync.RWMutex
+	restoreClipArgsForCall []struct {
+		arg1 int
+	}
+	restoreClipReturns struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}
+	restoreClipReturnsOnCall map[int]struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}
+	SearchSnippetsStub        func(string) ([]snippet.Match, error)
+	searchSnippetsMutex       sync.RWMutex
+	searchSnippetsArgsForCall []struct {
+		arg1 string
+	}
+	searchSnippetsReturns struct {
+		result1 []snippet.Match
+		result2 error
+	}
+	searchSnippetsReturnsOnCall map[int]struct {
+		result1 []snippet.Match
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeStringService) CopyRootPathToClipboard(arg1 string, arg2 []string) ([]string, error) {
-	var arg2Copy []string
-	if arg2 != nil {
-		arg2Copy = make([]string, len(arg2))
-		copy(arg2Copy, arg2)
+func (fake *FakeStringService) AddSnippets(arg1 bool, arg2 ...snippet.Snippet) error {
+	fake.addSnippetsMutex.Lock()
+	ret, specificReturn := fake.addSnippetsReturnsOnCall[len(fake.addSnippetsArgsForCall)]
+	fake.addSnippetsArgsForCall = append(fake.addSnippetsArgsForCall, struct {
+		arg1 bool
+		arg2 []snippet.Snippet
+	}{arg1, arg2})
+	stub := fake.AddSnippetsStub
+	fakeReturns := fake.addSnippetsReturns
+	fake.recordInvocation("AddSnippets", []interface{}{arg1, arg2})
+	fake.addSnippetsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStringService) AddSnippetsCallCount() int {
+	fake.addSnippetsMutex.RLock()
+	defer fake.addSnippetsMutex.RUnlock()
+	return len(fake.addSnippetsArgsForCall)
+}
+
+func (fake *FakeStringService) AddSnippetsCalls(stub func(bool, ...snippet.Snippet) error) {
+	fake.addSnippetsMutex.Lock()
+	defer fake.addSnippetsMutex.Unlock()
+	fake.AddSnippetsStub = stub
+}
+
+func (fake *FakeStringService) AddSnippetsArgsForCall(i int) (bool, []snippet.Snippet) {
+	fake.addSnippetsMutex.RLock()
+	defer fake.addSnippetsMutex.RUnlock()
+	argsForCall := fake.addSnippetsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStringService) AddSnippetsReturns(result1 error) {
+	fake.addSnippetsMutex.Lock()
+	defer fake.addSnippetsMutex.Unlock()
+	fake.AddSnippetsStub = nil
+	fake.addSnippetsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) AddSnippetsReturnsOnCall(i int, result1 error) {
+	fake.addSnippetsMutex.Lock()
+	defer fake.addSnippetsMutex.Unlock()
+	fake.AddSnippetsStub = nil
+	if fake.addSnippetsReturnsOnCall == nil {
+		fake.addSnippetsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addSnippetsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) ApplyClipboard(arg1 *pasteback.Plan) (*pasteback.Result, error) {
+	fake.applyClipboardMutex.Lock()
+	ret, specificReturn := fake.applyClipboardReturnsOnCall[len(fake.applyClipboardArgsForCall)]
+	fake.applyClipboardArgsForCall = append(fake.applyClipboardArgsForCall, struct {
+		arg1 *pasteback.Plan
+	}{arg1})
+	stub := fake.ApplyClipboardStub
+	fakeReturns := fake.applyClipboardReturns
+	fake.recordInvocation("ApplyClipboard", []interface{}{arg1})
+	fake.applyClipboardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) ApplyClipboardCallCount() int {
+	fake.applyClipboardMutex.RLock()
+	defer fake.applyClipboardMutex.RUnlock()
+	return len(fake.applyClipboardArgsForCall)
+}
+
+func (fake *FakeStringService) ApplyClipboardCalls(stub func(*pasteback.Plan) (*pasteback.Result, error)) {
+	fake.applyClipboardMutex.Lock()
+	defer fake.applyClipboardMutex.Unlock()
+	fake.ApplyClipboardStub = stub
+}
+
+func (fake *FakeStringService) ApplyClipboardArgsForCall(i int) *pasteback.Plan {
+	fake.applyClipboardMutex.RLock()
+	defer fake.applyClipboardMutex.RUnlock()
+	argsForCall := fake.applyClipboardArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) ApplyClipboardReturns(result1 *pasteback.Result, result2 error) {
+	fake.applyClipboardMutex.Lock()
+	defer fake.applyClipboardMutex.Unlock()
+	fake.ApplyClipboardStub = nil
+	fake.applyClipboardReturns = struct {
+		result1 *pasteback.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) ApplyClipboardReturnsOnCall(i int, result1 *pasteback.Result, result2 error) {
+	fake.applyClipboardMutex.Lock()
+	defer fake.applyClipboardMutex.Unlock()
+	fake.ApplyClipboardStub = nil
+	if fake.applyClipboardReturnsOnCall == nil {
+		fake.applyClipboardReturnsOnCall = make(map[int]struct {
+			result1 *pasteback.Result
+			result2 error
+		})
+	}
+	fake.applyClipboardReturnsOnCall[i] = struct {
+		result1 *pasteback.Result
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) ClipHistory() ([]cliphistory.Entry, error) {
+	fake.clipHistoryMutex.Lock()
+	ret, specificReturn := fake.clipHistoryReturnsOnCall[len(fake.clipHistoryArgsForCall)]
+	fake.clipHistoryArgsForCall = append(fake.clipHistoryArgsForCall, struct {
+	}{})
+	stub := fake.ClipHistoryStub
+	fakeReturns := fake.clipHistoryReturns
+	fake.recordInvocation("ClipHistory", []interface{}{})
+	fake.clipHistoryMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) ClipHistoryCallCount() int {
+	fake.clipHistoryMutex.RLock()
+	defer fake.clipHistoryMutex.RUnlock()
+	return len(fake.clipHistoryArgsForCall)
+}
+
+func (fake *FakeStringService) ClipHistoryCalls(stub func() ([]cliphistory.Entry, error)) {
+	fake.clipHistoryMutex.Lock()
+	defer fake.clipHistoryMutex.Unlock()
+	fake.ClipHistoryStub = stub
+}
+
+func (fake *FakeStringService) ClipHistoryReturns(result1 []cliphistory.Entry, result2 error) {
+	fake.clipHistoryMutex.Lock()
+	defer fake.clipHistoryMutex.Unlock()
+	fake.ClipHistoryStub = nil
+	fake.clipHistoryReturns = struct {
+		result1 []cliphistory.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) ClipHistoryReturnsOnCall(i int, result1 []cliphistory.Entry, result2 error) {
+	fake.clipHistoryMutex.Lock()
+	defer fake.clipHistoryMutex.Unlock()
+	fake.ClipHistoryStub = nil
+	if fake.clipHistoryReturnsOnCall == nil {
+		fake.clipHistoryReturnsOnCall = make(map[int]struct {
+			result1 []cliphistory.Entry
+			result2 error
+		})
+	}
+	fake.clipHistoryReturnsOnCall[i] = struct {
+		result1 []cliphistory.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) ClipPreface(arg1 *preface.Options) error {
+	fake.clipPrefaceMutex.Lock()
+	ret, specificReturn := fake.clipPrefaceReturnsOnCall[len(fake.clipPrefaceArgsForCall)]
+	fake.clipPrefaceArgsForCall = append(fake.clipPrefaceArgsForCall, struct {
+		arg1 *preface.Options
+	}{arg1})
+	stub := fake.ClipPrefaceStub
+	fakeReturns := fake.clipPrefaceReturns
+	fake.recordInvocation("ClipPreface", []interface{}{arg1})
+	fake.clipPrefaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStringService) ClipPrefaceCallCount() int {
+	fake.clipPrefaceMutex.RLock()
+	defer fake.clipPrefaceMutex.RUnlock()
+	return len(fake.clipPrefaceArgsForCall)
+}
+
+func (fake *FakeStringService) ClipPrefaceCalls(stub func(*preface.Options) error) {
+	fake.clipPrefaceMutex.Lock()
+	defer fake.clipPrefaceMutex.Unlock()
+	fake.ClipPrefaceStub = stub
+}
+
+func (fake *FakeStringService) ClipPrefaceArgsForCall(i int) *preface.Options {
+	fake.clipPrefaceMutex.RLock()
+	defer fake.clipPrefaceMutex.RUnlock()
+	argsForCall := fake.clipPrefaceArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) ClipPrefaceReturns(result1 error) {
+	fake.clipPrefaceMutex.Lock()
+	defer fake.clipPrefaceMutex.Unlock()
+	fake.ClipPrefaceStub = nil
+	fake.clipPrefaceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) ClipPrefaceReturnsOnCall(i int, result1 error) {
+	fake.clipPrefaceMutex.Lock()
+	defer fake.clipPrefaceMutex.Unlock()
+	fake.ClipPrefaceStub = nil
+	if fake.clipPrefaceReturnsOnCall == nil {
+		fake.clipPrefaceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clipPrefaceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) ClipSnippet(arg1 string, arg2 map[string]string, arg3 string) error {
+	fake.clipSnippetMutex.Lock()
+	ret, specificReturn := fake.clipSnippetReturnsOnCall[len(fake.clipSnippetArgsForCall)]
+	fake.clipSnippetArgsForCall = append(fake.clipSnippetArgsForCall, struct {
+		arg1 string
+		arg2 map[string]string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ClipSnippetStub
+	fakeReturns := fake.clipSnippetReturns
+	fake.recordInvocation("ClipSnippet", []interface{}{arg1, arg2, arg3})
+	fake.clipSnippetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStringService) ClipSnippetCallCount() int {
+	fake.clipSnippetMutex.RLock()
+	defer fake.clipSnippetMutex.RUnlock()
+	return len(fake.clipSnippetArgsForCall)
+}
+
+func (fake *FakeStringService) ClipSnippetCalls(stub func(string, map[string]string, string) error) {
+	fake.clipSnippetMutex.Lock()
+	defer fake.clipSnippetMutex.Unlock()
+	fake.ClipSnippetStub = stub
+}
+
+func (fake *FakeStringService) ClipSnippetArgsForCall(i int) (string, map[string]string, string) {
+	fake.clipSnippetMutex.RLock()
+	defer fake.clipSnippetMutex.RUnlock()
+	argsForCall := fake.clipSnippetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeStringService) ClipSnippetReturns(result1 error) {
+	fake.clipSnippetMutex.Lock()
+	defer fake.clipSnippetMutex.Unlock()
+	fake.ClipSnippetStub = nil
+	fake.clipSnippetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) ClipSnippetReturnsOnCall(i int, result1 error) {
+	fake.clipSnippetMutex.Lock()
+	defer fake.clipSnippetMutex.Unlock()
+	fake.ClipSnippetStub = nil
+	if fake.clipSnippetReturnsOnCall == nil {
+		fake.clipSnippetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clipSnippetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) CopyContextToClipboard(arg1 *cliputil.ContextOptions) (*cliputil.ContextReport, error) {
+	fake.copyContextToClipboardMutex.Lock()
+	ret, specificReturn := fake.copyContextToClipboardReturnsOnCall[len(fake.copyContextToClipboardArgsForCall)]
+	fake.copyContextToClipboardArgsForCall = append(fake.copyContextToClipboardArgsForCall, struct {
+		arg1 *cliputil.ContextOptions
+	}{arg1})
+	stub := fake.CopyContextToClipboardStub
+	fakeReturns := fake.copyContextToClipboardReturns
+	fake.recordInvocation("CopyContextToClipboard", []interface{}{arg1})
+	fake.copyContextToClipboardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) CopyContextToClipboardCallCount() int {
+	fake.copyContextToClipboardMutex.RLock()
+	defer fake.copyContextToClipboardMutex.RUnlock()
+	return len(fake.copyContextToClipboardArgsForCall)
+}
+
+func (fake *FakeStringService) CopyContextToClipboardCalls(stub func(*cliputil.ContextOptions) (*cliputil.ContextReport, error)) {
+	fake.copyContextToClipboardMutex.Lock()
+	defer fake.copyContextToClipboardMutex.Unlock()
+	fake.CopyContextToClipboardStub = stub
+}
+
+func (fake *FakeStringService) CopyContextToClipboardArgsForCall(i int) *cliputil.ContextOptions {
+	fake.copyContextToClipboardMutex.RLock()
+	defer fake.copyContextToClipboardMutex.RUnlock()
+	argsForCall := fake.copyContextToClipboardArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) CopyContextToClipboardReturns(result1 *cliputil.ContextReport, result2 error) {
+	fake.copyContextToClipboardMutex.Lock()
+	defer fake.copyContextToClipboardMutex.Unlock()
+	fake.CopyContextToClipboardStub = nil
+	fake.copyContextToClipboardReturns = struct {
+		result1 *cliputil.ContextReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) CopyContextToClipboardReturnsOnCall(i int, result1 *cliputil.ContextReport, result2 error) {
+	fake.copyContextToClipboardMutex.Lock()
+	defer fake.copyContextToClipboardMutex.Unlock()
+	fake.CopyContextToClipboardStub = nil
+	if fake.copyContextToClipboardReturnsOnCall == nil {
+		fake.copyContextToClipboardReturnsOnCall = make(map[int]struct {
+			result1 *cliputil.ContextReport
+			result2 error
+		})
+	}
+	fake.copyContextToClipboardReturnsOnCall[i] = struct {
+		result1 *cliputil.ContextReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) CopyPromptToClipboard(arg1 *prompt.Options) (*cliputil.ClipReport, error) {
+	fake.copyPromptToClipboardMutex.Lock()
+	ret, specificReturn := fake.copyPromptToClipboardReturnsOnCall[len(fake.copyPromptToClipboardArgsForCall)]
+	fake.copyPromptToClipboardArgsForCall = append(fake.copyPromptToClipboardArgsForCall, struct {
+		arg1 *prompt.Options
+	}{arg1})
+	stub := fake.CopyPromptToClipboardStub
+	fakeReturns := fake.copyPromptToClipboardReturns
+	fake.recordInvocation("CopyPromptToClipboard", []interface{}{arg1})
+	fake.copyPromptToClipboardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
 	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) CopyPromptToClipboardCallCount() int {
+	fake.copyPromptToClipboardMutex.RLock()
+	defer fake.copyPromptToClipboardMutex.RUnlock()
+	return len(fake.copyPromptToClipboardArgsForCall)
+}
+
+func (fake *FakeStringService) CopyPromptToClipboardCalls(stub func(*prompt.Options) (*cliputil.ClipReport, error)) {
+	fake.copyPromptToClipboardMutex.Lock()
+	defer fake.copyPromptToClipboardMutex.Unlock()
+	fake.CopyPromptToClipboardStub = stub
+}
+
+func (fake *FakeStringService) CopyPromptToClipboardArgsForCall(i int) *prompt.Options {
+	fake.copyPromptToClipboardMutex.RLock()
+	defer fake.copyPromptToClipboardMutex.RUnlock()
+	argsForCall := fake.copyPromptToClipboardArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) CopyPromptToClipboardReturns(result1 *cliputil.ClipReport, result2 error) {
+	fake.copyPromptToClipboardMutex.Lock()
+	defer fake.copyPromptToClipboardMutex.Unlock()
+	fake.CopyPromptToClipboardStub = nil
+	fake.copyPromptToClipboardReturns = struct {
+		result1 *cliputil.ClipReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) CopyPromptToClipboardReturnsOnCall(i int, result1 *cliputil.ClipReport, result2 error) {
+	fake.copyPromptToClipboardMutex.Lock()
+	defer fake.copyPromptToClipboardMutex.Unlock()
+	fake.CopyPromptToClipboardStub = nil
+	if fake.copyPromptToClipboardReturnsOnCall == nil {
+		fake.copyPromptToClipboardReturnsOnCall = make(map[int]struct {
+			result1 *cliputil.ClipReport
+			result2 error
+		})
+	}
+	fake.copyPromptToClipboardReturnsOnCall[i] = struct {
+		result1 *cliputil.ClipReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) CopyRootPathToClipboard(arg1 *cliputil.ClipOptions) (*cliputil.ClipReport, error) {
 	fake.copyRootPathToClipboardMutex.Lock()
 	ret, specificReturn := fake.copyRootPathToClipboardReturnsOnCall[len(fake.copyRootPathToClipboardArgsForCall)]
 	fake.copyRootPathToClipboardArgsForCall = append(fake.copyRootPathToClipboardArgsForCall, struct {
-		arg1 string
-		arg2 []string
-	}{arg1, arg2Copy})
+		arg1 *cliputil.ClipOptions
+	}{arg1})
 	stub := fake.CopyRootPathToClipboardStub
 	fakeReturns := fake.copyRootPathToClipboardReturns
-	fake.recordInvocation("CopyRootPathToClipboard", []interface{}{arg1, arg2Copy})
+	fake.recordInvocation("CopyRootPathToClipboard", []interface{}{arg1})
 	fake.copyRootPathToClipboardMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,41 +664,478 @@ func (fake *FakeStringService) CopyRootPathToClipboardCallCount() int {
 	return len(fake.copyRootPathToClipboardArgsForCall)
 }
 
-func (fake *FakeStringService) CopyRootPathToClipboardCalls(stub func(string, []string) ([]string, error)) {
+func (fake *FakeStringService) CopyRootPathToClipboardCalls(stub func(*cliputil.ClipOptions) (*cliputil.ClipReport, error)) {
 	fake.copyRootPathToClipboardMutex.Lock()
 	defer fake.copyRootPathToClipboardMutex.Unlock()
 	fake.CopyRootPathToClipboardStub = stub
 }
 
-func (fake *FakeStringService) CopyRootPathToClipboardArgsForCall(i int) (string, []string) {
+func (fake *FakeStringService) CopyRootPathToClipboardArgsForCall(i int) *cliputil.ClipOptions {
 	fake.copyRootPathToClipboardMutex.RLock()
 	defer fake.copyRootPathToClipboardMutex.RUnlock()
 	argsForCall := fake.copyRootPathToClipboardArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1
 }
 
-func (fake *FakeStringService) CopyRootPathToClipboardReturns(result1 []string, result2 error) {
+func (fake *FakeStringService) CopyRootPathToClipboardReturns(result1 *cliputil.ClipReport, result2 error) {
 	fake.copyRootPathToClipboardMutex.Lock()
 	defer fake.copyRootPathToClipboardMutex.Unlock()
 	fake.CopyRootPathToClipboardStub = nil
 	fake.copyRootPathToClipboardReturns = struct {
-		result1 []string
+		result1 *cliputil.ClipReport
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeStringService) CopyRootPathToClipboardReturnsOnCall(i int, result1 []string, result2 error) {
+func (fake *FakeStringService) CopyRootPathToClipboardReturnsOnCall(i int, result1 *cliputil.ClipReport, result2 error) {
 	fake.copyRootPathToClipboardMutex.Lock()
 	defer fake.copyRootPathToClipboardMutex.Unlock()
 	fake.CopyRootPathToClipboardStub = nil
 	if fake.copyRootPathToClipboardReturnsOnCall == nil {
 		fake.copyRootPathToClipboardReturnsOnCall = make(map[int]struct {
-			result1 []string
+			result1 *cliputil.ClipReport
 			result2 error
 		})
 	}
 	fake.copyRootPathToClipboardReturnsOnCall[i] = struct {
-		result1 []string
+		result1 *cliputil.ClipReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) GetClip(arg1 int) (*cliphistory.Entry, error) {
+	fake.getClipMutex.Lock()
+	ret, specificReturn := fake.getClipReturnsOnCall[len(fake.getClipArgsForCall)]
+	fake.getClipArgsForCall = append(fake.getClipArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.GetClipStub
+	fakeReturns := fake.getClipReturns
+	fake.recordInvocation("GetClip", []interface{}{arg1})
+	fake.getClipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) GetClipCallCount() int {
+	fake.getClipMutex.RLock()
+	defer fake.getClipMutex.RUnlock()
+	return len(fake.getClipArgsForCall)
+}
+
+func (fake *FakeStringService) GetClipCalls(stub func(int) (*cliphistory.Entry, error)) {
+	fake.getClipMutex.Lock()
+	defer fake.getClipMutex.Unlock()
+	fake.GetClipStub = stub
+}
+
+func (fake *FakeStringService) GetClipArgsForCall(i int) int {
+	fake.getClipMutex.RLock()
+	defer fake.getClipMutex.RUnlock()
+	argsForCall := fake.getClipArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) GetClipReturns(result1 *cliphistory.Entry, result2 error) {
+	fake.getClipMutex.Lock()
+	defer fake.getClipMutex.Unlock()
+	fake.GetClipStub = nil
+	fake.getClipReturns = struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) GetClipReturnsOnCall(i int, result1 *cliphistory.Entry, result2 error) {
+	fake.getClipMutex.Lock()
+	defer fake.getClipMutex.Unlock()
+	fake.GetClipStub = nil
+	if fake.getClipReturnsOnCall == nil {
+		fake.getClipReturnsOnCall = make(map[int]struct {
+			result1 *cliphistory.Entry
+			result2 error
+		})
+	}
+	fake.getClipReturnsOnCall[i] = struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) GetSnippet(arg1 string) (*snippet.Snippet, error) {
+	fake.getSnippetMutex.Lock()
+	ret, specificReturn := fake.getSnippetReturnsOnCall[len(fake.getSnippetArgsForCall)]
+	fake.getSnippetArgsForCall = append(fake.getSnippetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetSnippetStub
+	fakeReturns := fake.getSnippetReturns
+	fake.recordInvocation("GetSnippet", []interface{}{arg1})
+	fake.getSnippetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) GetSnippetCallCount() int {
+	fake.getSnippetMutex.RLock()
+	defer fake.getSnippetMutex.RUnlock()
+	return len(fake.getSnippetArgsForCall)
+}
+
+func (fake *FakeStringService) GetSnippetCalls(stub func(string) (*snippet.Snippet, error)) {
+	fake.getSnippetMutex.Lock()
+	defer fake.getSnippetMutex.Unlock()
+	fake.GetSnippetStub = stub
+}
+
+func (fake *FakeStringService) GetSnippetArgsForCall(i int) string {
+	fake.getSnippetMutex.RLock()
+	defer fake.getSnippetMutex.RUnlock()
+	argsForCall := fake.getSnippetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) GetSnippetReturns(result1 *snippet.Snippet, result2 error) {
+	fake.getSnippetMutex.Lock()
+	defer fake.getSnippetMutex.Unlock()
+	fake.GetSnippetStub = nil
+	fake.getSnippetReturns = struct {
+		result1 *snippet.Snippet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) GetSnippetReturnsOnCall(i int, result1 *snippet.Snippet, result2 error) {
+	fake.getSnippetMutex.Lock()
+	defer fake.getSnippetMutex.Unlock()
+	fake.GetSnippetStub = nil
+	if fake.getSnippetReturnsOnCall == nil {
+		fake.getSnippetReturnsOnCall = make(map[int]struct {
+			result1 *snippet.Snippet
+			result2 error
+		})
+	}
+	fake.getSnippetReturnsOnCall[i] = struct {
+		result1 *snippet.Snippet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) ListSnippets() ([]snippet.Snippet, error) {
+	fake.listSnippetsMutex.Lock()
+	ret, specificReturn := fake.listSnippetsReturnsOnCall[len(fake.listSnippetsArgsForCall)]
+	fake.listSnippetsArgsForCall = append(fake.listSnippetsArgsForCall, struct {
+	}{})
+	stub := fake.ListSnippetsStub
+	fakeReturns := fake.listSnippetsReturns
+	fake.recordInvocation("ListSnippets", []interface{}{})
+	fake.listSnippetsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) ListSnippetsCallCount() int {
+	fake.listSnippetsMutex.RLock()
+	defer fake.listSnippetsMutex.RUnlock()
+	return len(fake.listSnippetsArgsForCall)
+}
+
+func (fake *FakeStringService) ListSnippetsCalls(stub func() ([]snippet.Snippet, error)) {
+	fake.listSnippetsMutex.Lock()
+	defer fake.listSnippetsMutex.Unlock()
+	fake.ListSnippetsStub = stub
+}
+
+func (fake *FakeStringService) ListSnippetsReturns(result1 []snippet.Snippet, result2 error) {
+	fake.listSnippetsMutex.Lock()
+	defer fake.listSnippetsMutex.Unlock()
+	fake.ListSnippetsStub = nil
+	fake.listSnippetsReturns = struct {
+		result1 []snippet.Snippet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) ListSnippetsReturnsOnCall(i int, result1 []snippet.Snippet, result2 error) {
+	fake.listSnippetsMutex.Lock()
+	defer fake.listSnippetsMutex.Unlock()
+	fake.ListSnippetsStub = nil
+	if fake.listSnippetsReturnsOnCall == nil {
+		fake.listSnippetsReturnsOnCall = make(map[int]struct {
+			result1 []snippet.Snippet
+			result2 error
+		})
+	}
+	fake.listSnippetsReturnsOnCall[i] = struct {
+		result1 []snippet.Snippet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) PlanApplyClipboard(arg1 *pasteback.Options) (*pasteback.Plan, error) {
+	fake.planApplyClipboardMutex.Lock()
+	ret, specificReturn := fake.planApplyClipboardReturnsOnCall[len(fake.planApplyClipboardArgsForCall)]
+	fake.planApplyClipboardArgsForCall = append(fake.planApplyClipboardArgsForCall, struct {
+		arg1 *pasteback.Options
+	}{arg1})
+	stub := fake.PlanApplyClipboardStub
+	fakeReturns := fake.planApplyClipboardReturns
+	fake.recordInvocation("PlanApplyClipboard", []interface{}{arg1})
+	fake.planApplyClipboardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) PlanApplyClipboardCallCount() int {
+	fake.planApplyClipboardMutex.RLock()
+	defer fake.planApplyClipboardMutex.RUnlock()
+	return len(fake.planApplyClipboardArgsForCall)
+}
+
+func (fake *FakeStringService) PlanApplyClipboardCalls(stub func(*pasteback.Options) (*pasteback.Plan, error)) {
+	fake.planApplyClipboardMutex.Lock()
+	defer fake.planApplyClipboardMutex.Unlock()
+	fake.PlanApplyClipboardStub = stub
+}
+
+func (fake *FakeStringService) PlanApplyClipboardArgsForCall(i int) *pasteback.Options {
+	fake.planApplyClipboardMutex.RLock()
+	defer fake.planApplyClipboardMutex.RUnlock()
+	argsForCall := fake.planApplyClipboardArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) PlanApplyClipboardReturns(result1 *pasteback.Plan, result2 error) {
+	fake.planApplyClipboardMutex.Lock()
+	defer fake.planApplyClipboardMutex.Unlock()
+	fake.PlanApplyClipboardStub = nil
+	fake.planApplyClipboardReturns = struct {
+		result1 *pasteback.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) PlanApplyClipboardReturnsOnCall(i int, result1 *pasteback.Plan, result2 error) {
+	fake.planApplyClipboardMutex.Lock()
+	defer fake.planApplyClipboardMutex.Unlock()
+	fake.PlanApplyClipboardStub = nil
+	if fake.planApplyClipboardReturnsOnCall == nil {
+		fake.planApplyClipboardReturnsOnCall = make(map[int]struct {
+			result1 *pasteback.Plan
+			result2 error
+		})
+	}
+	fake.planApplyClipboardReturnsOnCall[i] = struct {
+		result1 *pasteback.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) RemoveSnippet(arg1 string) error {
+	fake.removeSnippetMutex.Lock()
+	ret, specificReturn := fake.removeSnippetReturnsOnCall[len(fake.removeSnippetArgsForCall)]
+	fake.removeSnippetArgsForCall = append(fake.removeSnippetArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveSnippetStub
+	fakeReturns := fake.removeSnippetReturns
+	fake.recordInvocation("RemoveSnippet", []interface{}{arg1})
+	fake.removeSnippetMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStringService) RemoveSnippetCallCount() int {
+	fake.removeSnippetMutex.RLock()
+	defer fake.removeSnippetMutex.RUnlock()
+	return len(fake.removeSnippetArgsForCall)
+}
+
+func (fake *FakeStringService) RemoveSnippetCalls(stub func(string) error) {
+	fake.removeSnippetMutex.Lock()
+	defer fake.removeSnippetMutex.Unlock()
+	fake.RemoveSnippetStub = stub
+}
+
+func (fake *FakeStringService) RemoveSnippetArgsForCall(i int) string {
+	fake.removeSnippetMutex.RLock()
+	defer fake.removeSnippetMutex.RUnlock()
+	argsForCall := fake.removeSnippetArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) RemoveSnippetReturns(result1 error) {
+	fake.removeSnippetMutex.Lock()
+	defer fake.removeSnippetMutex.Unlock()
+	fake.RemoveSnippetStub = nil
+	fake.removeSnippetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) RemoveSnippetReturnsOnCall(i int, result1 error) {
+	fake.removeSnippetMutex.Lock()
+	defer fake.removeSnippetMutex.Unlock()
+	fake.RemoveSnippetStub = nil
+	if fake.removeSnippetReturnsOnCall == nil {
+		fake.removeSnippetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeSnippetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStringService) RestoreClip(arg1 int) (*cliphistory.Entry, error) {
+	fake.restoreClipMutex.Lock()
+	ret, specificReturn := fake.restoreClipReturnsOnCall[len(fake.restoreClipArgsForCall)]
+	fake.restoreClipArgsForCall = append(fake.restoreClipArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	stub := fake.RestoreClipStub
+	fakeReturns := fake.restoreClipReturns
+	fake.recordInvocation("RestoreClip", []interface{}{arg1})
+	fake.restoreClipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) RestoreClipCallCount() int {
+	fake.restoreClipMutex.RLock()
+	defer fake.restoreClipMutex.RUnlock()
+	return len(fake.restoreClipArgsForCall)
+}
+
+func (fake *FakeStringService) RestoreClipCalls(stub func(int) (*cliphistory.Entry, error)) {
+	fake.restoreClipMutex.Lock()
+	defer fake.restoreClipMutex.Unlock()
+	fake.RestoreClipStub = stub
+}
+
+func (fake *FakeStringService) RestoreClipArgsForCall(i int) int {
+	fake.restoreClipMutex.RLock()
+	defer fake.restoreClipMutex.RUnlock()
+	argsForCall := fake.restoreClipArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) RestoreClipReturns(result1 *cliphistory.Entry, result2 error) {
+	fake.restoreClipMutex.Lock()
+	defer fake.restoreClipMutex.Unlock()
+	fake.RestoreClipStub = nil
+	fake.restoreClipReturns = struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) RestoreClipReturnsOnCall(i int, result1 *cliphistory.Entry, result2 error) {
+	fake.restoreClipMutex.Lock()
+	defer fake.restoreClipMutex.Unlock()
+	fake.RestoreClipStub = nil
+	if fake.restoreClipReturnsOnCall == nil {
+		fake.restoreClipReturnsOnCall = make(map[int]struct {
+			result1 *cliphistory.Entry
+			result2 error
+		})
+	}
+	fake.restoreClipReturnsOnCall[i] = struct {
+		result1 *cliphistory.Entry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) SearchSnippets(arg1 string) ([]snippet.Match, error) {
+	fake.searchSnippetsMutex.Lock()
+	ret, specificReturn := fake.searchSnippetsReturnsOnCall[len(fake.searchSnippetsArgsForCall)]
+	fake.searchSnippetsArgsForCall = append(fake.searchSnippetsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SearchSnippetsStub
+	fakeReturns := fake.searchSnippetsReturns
+	fake.recordInvocation("SearchSnippets", []interface{}{arg1})
+	fake.searchSnippetsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStringService) SearchSnippetsCallCount() int {
+	fake.searchSnippetsMutex.RLock()
+	defer fake.searchSnippetsMutex.RUnlock()
+	return len(fake.searchSnippetsArgsForCall)
+}
+
+func (fake *FakeStringService) SearchSnippetsCalls(stub func(string) ([]snippet.Match, error)) {
+	fake.searchSnippetsMutex.Lock()
+	defer fake.searchSnippetsMutex.Unlock()
+	fake.SearchSnippetsStub = stub
+}
+
+func (fake *FakeStringService) SearchSnippetsArgsForCall(i int) string {
+	fake.searchSnippetsMutex.RLock()
+	defer fake.searchSnippetsMutex.RUnlock()
+	argsForCall := fake.searchSnippetsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStringService) SearchSnippetsReturns(result1 []snippet.Match, result2 error) {
+	fake.searchSnippetsMutex.Lock()
+	defer fake.searchSnippetsMutex.Unlock()
+	fake.SearchSnippetsStub = nil
+	fake.searchSnippetsReturns = struct {
+		result1 []snippet.Match
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStringService) SearchSnippetsReturnsOnCall(i int, result1 []snippet.Match, result2 error) {
+	fake.searchSnippetsMutex.Lock()
+	defer fake.searchSnippetsMutex.Unlock()
+	fake.SearchSnippetsStub = nil
+	if fake.searchSnippetsReturnsOnCall == nil {
+		fake.searchSnippetsReturnsOnCall = make(map[int]struct {
+			result1 []snippet.Match
+			result2 error
+		})
+	}
+	fake.searchSnippetsReturnsOnCall[i] = struct {
+		result1 []snippet.Match
 		result2 error
 	}{result1, result2}
 }
@@ -97,8 +1143,36 @@ func (fake *FakeStringService) CopyRootPathToClipboardReturnsOnCall(i int, resul
 func (fake *FakeStringService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.addSnippetsMutex.RLock()
+	defer fake.addSnippetsMutex.RUnlock()
+	fake.applyClipboardMutex.RLock()
+	defer fake.applyClipboardMutex.RUnlock()
+	fake.clipHistoryMutex.RLock()
+	defer fake.clipHistoryMutex.RUnlock()
+	fake.clipPrefaceMutex.RLock()
+	defer fake.clipPrefaceMutex.RUnlock()
+	fake.clipSnippetMutex.RLock()
+	defer fake.clipSnippetMutex.RUnlock()
+	fake.copyContextToClipboardMutex.RLock()
+	defer fake.copyContextToClipboardMutex.RUnlock()
+	fake.copyPromptToClipboardMutex.RLock()
+	defer fake.copyPromptToClipboardMutex.RUnlock()
 	fake.copyRootPathToClipboardMutex.RLock()
 	defer fake.copyRootPathToClipboardMutex.RUnlock()
+	fake.getClipMutex.RLock()
+	defer fake.getClipMutex.RUnlock()
+	fake.getSnippetMutex.RLock()
+	defer fake.getSnippetMutex.RUnlock()
+	fake.listSnippetsMutex.RLock()
+	defer fake.listSnippetsMutex.RUnlock()
+	fake.planApplyClipboardMutex.RLock()
+	defer fake.planApplyClipboardMutex.RUnlock()
+	fake.removeSnippetMutex.RLock()
+	defer fake.removeSnippetMutex.RUnlock()
+	fake.restoreClipMutex.RLock()
+	defer fake.restoreClipMutex.RUnlock()
+	fake.searchSnippetsMutex.RLock()
+	defer fake.searchSnippetsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
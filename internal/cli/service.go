@@ -1,8 +1,16 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/cliphistory"
+	"github.com/dembygenesis/local.tools/internal/lib/copyprofile"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/dembygenesis/local.tools/internal/lib/pasteback"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/lib/prompt"
+	"github.com/dembygenesis/local.tools/internal/lib/snippet"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
 )
 
 type Service struct {
@@ -23,30 +31,271 @@ func NewService(
 	}
 }
 
-func (s *Service) CopyToClipboard(root string, exclude []string) ([]string, error) {
-	files, err := s.stringUtils.CopyRootPathToClipboard(root, exclude)
+func (s *Service) CopyToClipboard(opts *cliputil.ClipOptions) (*cliputil.ClipReport, error) {
+	report, err := s.stringUtils.CopyRootPathToClipboard(opts)
 	if err != nil {
 		return nil, fmt.Errorf("copy to clipboard: %v", err)
 	}
-	return files, nil
+	return report, nil
 }
 
-func (s *Service) ClipCodingStandardsPreface() error {
-	err := s.gptUtils.ClipCodingStandardsPreface()
+func (s *Service) CopyContextToClipboard(opts *cliputil.ContextOptions) (*cliputil.ContextReport, error) {
+	report, err := s.stringUtils.CopyContextToClipboard(opts)
 	if err != nil {
-		return fmt.Errorf("clip coding standards: %v", err)
+		return nil, fmt.Errorf("copy context to clipboard: %v", err)
+	}
+	return report, nil
+}
+
+// CopyPromptToClipboard composes the preface, the selected files and the question
+// into a single payload, and writes it to the requested output.
+func (s *Service) CopyPromptToClipboard(opts *prompt.Options) (*cliputil.ClipReport, error) {
+	report, err := s.stringUtils.CopyPromptToClipboard(opts)
+	if err != nil {
+		return nil, fmt.Errorf("copy prompt to clipboard: %v", err)
+	}
+	return report, nil
+}
+
+// ClipHistory lists the recorded clips, most recent first.
+func (s *Service) ClipHistory() ([]cliphistory.Entry, error) {
+	entries, err := s.stringUtils.ClipHistory()
+	if err != nil {
+		return nil, fmt.Errorf("clip history: %v", err)
+	}
+	return entries, nil
+}
+
+// GetClip returns the n-th most recent clip, starting at 1.
+func (s *Service) GetClip(n int) (*cliphistory.Entry, error) {
+	entry, err := s.stringUtils.GetClip(n)
+	if err != nil {
+		return nil, fmt.Errorf("get clip: %v", err)
+	}
+	return entry, nil
+}
+
+// RestoreClip copies the n-th most recent clip back to the clipboard.
+func (s *Service) RestoreClip(n int) (*cliphistory.Entry, error) {
+	entry, err := s.stringUtils.RestoreClip(n)
+	if err != nil {
+		return nil, fmt.Errorf("restore clip: %v", err)
+	}
+	return entry, nil
+}
+
+// PlanApplyClipboard reads the files of a response, and diffs them against the working tree.
+func (s *Service) PlanApplyClipboard(opts *pasteback.Options) (*pasteback.Plan, error) {
+	plan, err := s.stringUtils.PlanApplyClipboard(opts)
+	if err != nil {
+		return nil, fmt.Errorf("plan apply clipboard: %v", err)
+	}
+	return plan, nil
+}
+
+// ApplyClipboard writes the changes of the plan, the caller leaves out the rejected ones.
+func (s *Service) ApplyClipboard(plan *pasteback.Plan) (*pasteback.Result, error) {
+	result, err := s.stringUtils.ApplyClipboard(plan)
+	if err != nil {
+		return result, fmt.Errorf("apply clipboard: %v", err)
+	}
+	return result, nil
+}
+
+func (s *Service) ListSnippets() ([]snippet.Snippet, error) {
+	snippets, err := s.stringUtils.ListSnippets()
+	if err != nil {
+		return nil, fmt.Errorf("list snippets: %v", err)
+	}
+	return snippets, nil
+}
+
+func (s *Service) GetSnippet(name string) (*snippet.Snippet, error) {
+	snip, err := s.stringUtils.GetSnippet(name)
+	if err != nil {
+		return nil, fmt.Errorf("get snippet: %v", err)
+	}
+	return snip, nil
+}
+
+func (s *Service) AddSnippet(snip snippet.Snippet, overwrite bool) error {
+	if err := s.stringUtils.AddSnippets(overwrite, snip); err != nil {
+		return fmt.Errorf("add snippet: %v", err)
+	}
+	return nil
+}
+
+func (s *Service) RemoveSnippet(name string) error {
+	if err := s.stringUtils.RemoveSnippet(name); err != nil {
+		return fmt.Errorf("remove snippet: %v", err)
+	}
+	return nil
+}
+
+// SearchSnippets ranks the snippets matching the query, best first.
+func (s *Service) SearchSnippets(query string) ([]snippet.Match, error) {
+	matches, err := s.stringUtils.SearchSnippets(query)
+	if err != nil {
+		return nil, fmt.Errorf("search snippets: %v", err)
+	}
+	return matches, nil
+}
+
+// ClipSnippet fills in the placeholders of the snippet with the values, and
+// copies it to the clipboard, or writes it to the output.
+func (s *Service) ClipSnippet(name string, values map[string]string, output string) error {
+	if err := s.stringUtils.ClipSnippet(name, values, output); err != nil {
+		return fmt.Errorf("clip snippet: %v", err)
+	}
+	return nil
+}
+
+// ExportSnippets returns the snippets with the names, or all of them when none are given.
+func (s *Service) ExportSnippets(names ...string) ([]snippet.Snippet, error) {
+	if len(names) == 0 {
+		snippets, err := s.stringUtils.ListSnippets()
+		if err != nil {
+			return nil, fmt.Errorf("export snippets: %v", err)
+		}
+		return snippets, nil
+	}
+
+	snippets := make([]snippet.Snippet, 0, len(names))
+	for _, name := range names {
+		snip, err := s.stringUtils.GetSnippet(name)
+		if err != nil {
+			return nil, fmt.Errorf("export snippets: %v", err)
+		}
+		snippets = append(snippets, *snip)
+	}
+	return snippets, nil
+}
+
+// ImportSnippets stores all of the snippets or none, existing names fail
+// unless overwriting.
+func (s *Service) ImportSnippets(snippets []snippet.Snippet, overwrite bool) error {
+	if err := s.stringUtils.AddSnippets(overwrite, snippets...); err != nil {
+		return fmt.Errorf("import snippets: %v", err)
 	}
 	return nil
 }
 
-func (s *Service) CopyDirToAnother(opts *fslib.CopyOptions) error {
+func (s *Service) ClipPreface(opts *preface.Options) error {
+	err := s.stringUtils.ClipPreface(opts)
+	if err != nil {
+		return fmt.Errorf("clip preface: %v", err)
+	}
+	return nil
+}
+
+func (s *Service) RenderPreface(opts *preface.Options) (string, error) {
+	text, err := s.gptUtils.RenderPreface(opts)
+	if err != nil {
+		return "", fmt.Errorf("render preface: %v", err)
+	}
+	return text, nil
+}
+
+func (s *Service) ListPrefaces() ([]preface.Template, error) {
+	templates, err := s.gptUtils.ListPrefaces()
+	if err != nil {
+		return nil, fmt.Errorf("list prefaces: %v", err)
+	}
+	return templates, nil
+}
+
+func (s *Service) GetPreface(name string) (*preface.Template, error) {
+	tmpl, err := s.gptUtils.GetPreface(name)
+	if err != nil {
+		return nil, fmt.Errorf("get preface: %v", err)
+	}
+	return tmpl, nil
+}
+
+func (s *Service) AddPreface(name, text string) error {
+	if err := s.gptUtils.AddPreface(name, text); err != nil {
+		return fmt.Errorf("add preface: %v", err)
+	}
+	return nil
+}
+
+func (s *Service) SavePreface(name, text string) error {
+	if err := s.gptUtils.SavePreface(name, text); err != nil {
+		return fmt.Errorf("save preface: %v", err)
+	}
+	return nil
+}
+
+func (s *Service) RemovePreface(name string) error {
+	if err := s.gptUtils.RemovePreface(name); err != nil {
+		return fmt.Errorf("remove preface: %v", err)
+	}
+	return nil
+}
+
+// PrefacePath returns the file of the preface, so it can be edited in place.
+func (s *Service) PrefacePath(name string) (string, error) {
+	path, err := s.gptUtils.PrefacePath(name)
+	if err != nil {
+		return "", fmt.Errorf("preface path: %v", err)
+	}
+	return path, nil
+}
+
+func (s *Service) CopyDirToAnother(opts *fslib.CopyOptions) (*fslib.CopyPlan, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %v", err)
+	}
+
+	plan, err := s.fileUtils.CopyDirToAnother(opts)
+	if err != nil {
+		return nil, fmt.Errorf("copy folder A to B: %v", err)
+	}
+	return plan, nil
+}
+
+func (s *Service) WatchDirToAnother(ctx context.Context, opts *fslib.CopyOptions, watchOpts *fslib.WatchOptions) error {
 	if err := opts.Validate(); err != nil {
 		return fmt.Errorf("validate: %v", err)
 	}
 
-	err := s.fileUtils.CopyDirToAnother(opts)
+	if err := s.fileUtils.WatchDirToAnother(ctx, opts, watchOpts); err != nil {
+		return fmt.Errorf("watch folder A to B: %v", err)
+	}
+	return nil
+}
+
+func (s *Service) ListCopyProfiles() ([]copyprofile.Profile, error) {
+	profiles, err := s.fileUtils.ListCopyProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("list copy profiles: %v", err)
+	}
+	return profiles, nil
+}
+
+func (s *Service) GetCopyProfile(name string) (*copyprofile.Profile, error) {
+	profile, err := s.fileUtils.GetCopyProfile(name)
 	if err != nil {
-		return fmt.Errorf("copy folder A to B: %v", err)
+		return nil, fmt.Errorf("get copy profile: %v", err)
+	}
+	return profile, nil
+}
+
+func (s *Service) AddCopyProfile(profile *copyprofile.Profile, overwrite bool) error {
+	if err := s.fileUtils.AddCopyProfile(profile, overwrite); err != nil {
+		return fmt.Errorf("add copy profile: %v", err)
 	}
 	return nil
 }
+
+func (s *Service) CopyProfilesPath() string {
+	return s.fileUtils.CopyProfilesPath()
+}
+
+func (s *Service) RunCopyProfile(ctx context.Context, name string, opts *copyprofile.RunOptions) (*fslib.CopyPlan, error) {
+	plan, err := s.fileUtils.RunCopyProfile(ctx, name, opts)
+	if err != nil {
+		return plan, fmt.Errorf("run copy profile '%s': %v", name, err)
+	}
+	return plan, nil
+}
@@ -1,9 +1,17 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"github.com/dembygenesis/local.tools/internal/cli/clifakes"
+	"github.com/dembygenesis/local.tools/internal/lib/cliphistory"
+	"github.com/dembygenesis/local.tools/internal/lib/copyprofile"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/dembygenesis/local.tools/internal/lib/pasteback"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/lib/prompt"
+	"github.com/dembygenesis/local.tools/internal/lib/snippet"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -19,7 +27,7 @@ func TestServices_CopyToClipboard_Success(t *testing.T) {
 		fileUtils:   &mockFileUtils,
 	}
 
-	_, err := srv.CopyToClipboard(".", nil)
+	_, err := srv.CopyToClipboard(&cliputil.ClipOptions{Root: "."})
 	require.NoError(t, err, "should have no error")
 }
 
@@ -36,13 +44,13 @@ func TestServices_CopyToClipboard_Fail(t *testing.T) {
 		fileUtils:   &mockFileUtils,
 	}
 
-	_, err := srv.CopyToClipboard(".", nil)
+	_, err := srv.CopyToClipboard(&cliputil.ClipOptions{Root: "."})
 	require.Error(t, err, "should have no error")
 	require.Contains(t, err.Error(), "mock error")
 	require.Contains(t, err.Error(), "copy to clipboard:")
 }
 
-func TestServices_ClipCodingStandardsPreface_Success(t *testing.T) {
+func TestServices_ClipPreface_Success(t *testing.T) {
 	mockStringUtils := clifakes.FakeStringService{}
 	mockGptUtils := clifakes.FakeGptService{}
 	mockFileUtils := clifakes.FakeFileService{}
@@ -53,8 +61,10 @@ func TestServices_ClipCodingStandardsPreface_Success(t *testing.T) {
 		fileUtils:   &mockFileUtils,
 	}
 
-	err := srv.ClipCodingStandardsPreface()
+	opts := &preface.Options{Name: "go-review"}
+	err := srv.ClipPreface(opts)
 	require.NoError(t, err, "should have no error")
+	require.Equal(t, opts, mockStringUtils.ClipPrefaceArgsForCall(0))
 }
 
 func TestServices_New(t *testing.T) {
@@ -69,12 +79,12 @@ func TestServices_New(t *testing.T) {
 	)
 }
 
-func TestServices_ClipCodingStandardsPreface_Fail(t *testing.T) {
+func TestServices_ClipPreface_Fail(t *testing.T) {
 	mockStringUtils := clifakes.FakeStringService{}
 	mockGptUtils := clifakes.FakeGptService{}
 	mockFileUtils := clifakes.FakeFileService{}
 
-	mockGptUtils.ClipCodingStandardsPrefaceReturns(errors.New("mock error"))
+	mockStringUtils.ClipPrefaceReturns(errors.New("mock error"))
 
 	srv := Service{
 		stringUtils: &mockStringUtils,
@@ -82,10 +92,10 @@ func TestServices_ClipCodingStandardsPreface_Fail(t *testing.T) {
 		fileUtils:   &mockFileUtils,
 	}
 
-	err := srv.ClipCodingStandardsPreface()
+	err := srv.ClipPreface(&preface.Options{})
 	require.Error(t, err, "should have an error")
 	require.Contains(t, err.Error(), "mock error")
-	require.Contains(t, err.Error(), "clip coding standards:")
+	require.Contains(t, err.Error(), "clip preface:")
 }
 
 func TestServices_CopyDirToAnother_Success(t *testing.T) {
@@ -107,7 +117,7 @@ func TestServices_CopyDirToAnother_Success(t *testing.T) {
 		WipeDestinationExclusions: nil,
 	}
 
-	err := srv.CopyDirToAnother(opts)
+	_, err := srv.CopyDirToAnother(opts)
 
 	require.NoError(t, err, "should have no error")
 }
@@ -125,7 +135,7 @@ func TestServices_CopyDirToAnother_Validate_Fail(t *testing.T) {
 
 	opts := &fslib.CopyOptions{}
 
-	err := srv.CopyDirToAnother(opts)
+	_, err := srv.CopyDirToAnother(opts)
 
 	require.Error(t, err, "expected an error due to missing source and destination")
 	require.Contains(t, err.Error(), "validate:")
@@ -138,7 +148,7 @@ func TestServices_CopyDirToAnother_Opts_Fail(t *testing.T) {
 	mockGptUtils := clifakes.FakeGptService{}
 	mockFileUtils := clifakes.FakeFileService{}
 
-	mockFileUtils.CopyDirToAnotherReturns(errors.New("forced error in copy operation"))
+	mockFileUtils.CopyDirToAnotherReturns(nil, errors.New("forced error in copy operation"))
 	srv := Service{
 		stringUtils: &mockStringUtils,
 		gptUtils:    &mockGptUtils,
@@ -153,7 +163,277 @@ func TestServices_CopyDirToAnother_Opts_Fail(t *testing.T) {
 		WipeDestinationExclusions: nil,
 	}
 
-	err := srv.CopyDirToAnother(opts)
+	_, err := srv.CopyDirToAnother(opts)
 
 	require.Error(t, err, "expected an error from copy operation")
 }
+
+func TestServices_CopyContextToClipboard_Success(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	_, err := srv.CopyContextToClipboard(&cliputil.ContextOptions{})
+	require.NoError(t, err, "should have no error")
+}
+
+func TestServices_CopyContextToClipboard_Fail(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	mockStringUtils.CopyContextToClipboardReturns(nil, errors.New("mock error"))
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	_, err := srv.CopyContextToClipboard(&cliputil.ContextOptions{})
+	require.Error(t, err, "should have an error")
+	require.Contains(t, err.Error(), "mock error")
+	require.Contains(t, err.Error(), "copy context to clipboard:")
+}
+
+func TestServices_Prefaces_Success(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	mockGptUtils.ListPrefacesReturns([]preface.Template{{Name: "go-review", BuiltIn: true}}, nil)
+	mockGptUtils.GetPrefaceReturns(&preface.Template{Name: "go-review"}, nil)
+	mockGptUtils.RenderPrefaceReturns("rendered", nil)
+	mockGptUtils.PrefacePathReturns("/app/prefaces/go-review.tmpl", nil)
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	templates, err := srv.ListPrefaces()
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+
+	tmpl, err := srv.GetPreface("go-review")
+	require.NoError(t, err)
+	require.Equal(t, "go-review", tmpl.Name)
+
+	text, err := srv.RenderPreface(&preface.Options{Name: "go-review"})
+	require.NoError(t, err)
+	require.Equal(t, "rendered", text)
+
+	path, err := srv.PrefacePath("go-review")
+	require.NoError(t, err)
+	require.Equal(t, "/app/prefaces/go-review.tmpl", path)
+
+	require.NoError(t, srv.AddPreface("mine", "text"))
+	require.NoError(t, srv.SavePreface("mine", "edited"))
+	require.NoError(t, srv.RemovePreface("mine"))
+
+	name, text := mockGptUtils.SavePrefaceArgsForCall(0)
+	require.Equal(t, "mine", name)
+	require.Equal(t, "edited", text)
+}
+
+func TestServices_Prefaces_Fail(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	mockErr := errors.New("mock error")
+	mockGptUtils.ListPrefacesReturns(nil, mockErr)
+	mockGptUtils.GetPrefaceReturns(nil, mockErr)
+	mockGptUtils.RenderPrefaceReturns("", mockErr)
+	mockGptUtils.PrefacePathReturns("", mockErr)
+	mockGptUtils.AddPrefaceReturns(mockErr)
+	mockGptUtils.SavePrefaceReturns(mockErr)
+	mockGptUtils.RemovePrefaceReturns(mockErr)
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	_, err := srv.ListPrefaces()
+	require.ErrorContains(t, err, "list prefaces:")
+
+	_, err = srv.GetPreface("x")
+	require.ErrorContains(t, err, "get preface:")
+
+	_, err = srv.RenderPreface(&preface.Options{})
+	require.ErrorContains(t, err, "render preface:")
+
+	_, err = srv.PrefacePath("x")
+	require.ErrorContains(t, err, "preface path:")
+
+	require.ErrorContains(t, srv.AddPreface("x", "y"), "add preface:")
+	require.ErrorContains(t, srv.SavePreface("x", "y"), "save preface:")
+	require.ErrorContains(t, srv.RemovePreface("x"), "remove preface:")
+}
+
+func TestServices_CopyPromptToClipboard(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	mockStringUtils.CopyPromptToClipboardReturns(&cliputil.ClipReport{Files: []string{"main.go"}}, nil)
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	opts := &prompt.Options{Question: "the question"}
+	report, err := srv.CopyPromptToClipboard(opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"main.go"}, report.Files)
+	require.Equal(t, opts, mockStringUtils.CopyPromptToClipboardArgsForCall(0))
+
+	mockStringUtils.CopyPromptToClipboardReturns(nil, errors.New("mock error"))
+	_, err = srv.CopyPromptToClipboard(opts)
+	require.ErrorContains(t, err, "copy prompt to clipboard: mock error")
+}
+
+func TestServices_RunCopyProfile(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	mockFileUtils.RunCopyProfileReturns(&fslib.CopyPlan{}, nil)
+	plan, err := srv.RunCopyProfile(context.Background(), "api", &copyprofile.RunOptions{DryRun: true})
+	require.NoError(t, err)
+	require.NotNil(t, plan)
+
+	_, name, opts := mockFileUtils.RunCopyProfileArgsForCall(0)
+	require.Equal(t, "api", name)
+	require.True(t, opts.DryRun)
+
+	mockFileUtils.RunCopyProfileReturns(&fslib.CopyPlan{}, errors.New("mock error"))
+	plan, err = srv.RunCopyProfile(context.Background(), "api", nil)
+	require.ErrorContains(t, err, "run copy profile 'api':")
+	require.NotNil(t, plan, "the plan is kept when only the hook failed")
+
+	mockFileUtils.AddCopyProfileReturns(errors.New("mock error"))
+	require.ErrorContains(t, srv.AddCopyProfile(&copyprofile.Profile{Name: "api"}, false), "add copy profile:")
+
+	mockFileUtils.ListCopyProfilesReturns(nil, errors.New("mock error"))
+	_, err = srv.ListCopyProfiles()
+	require.ErrorContains(t, err, "list copy profiles:")
+}
+
+func TestServices_RestoreClip(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	mockStringUtils.RestoreClipReturns(&cliphistory.Entry{Payload: "old"}, nil)
+	entry, err := srv.RestoreClip(2)
+	require.NoError(t, err)
+	require.Equal(t, "old", entry.Payload)
+	require.Equal(t, 2, mockStringUtils.RestoreClipArgsForCall(0))
+
+	mockStringUtils.RestoreClipReturns(nil, errors.New("mock error"))
+	_, err = srv.RestoreClip(9)
+	require.ErrorContains(t, err, "restore clip:")
+
+	mockStringUtils.ClipHistoryReturns(nil, errors.New("mock error"))
+	_, err = srv.ClipHistory()
+	require.ErrorContains(t, err, "clip history:")
+}
+
+func TestServices_ApplyClipboard(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	mockStringUtils.PlanApplyClipboardReturns(&pasteback.Plan{Root: "/repo"}, nil)
+	plan, err := srv.PlanApplyClipboard(&pasteback.Options{Root: ".", Input: "-"})
+	require.NoError(t, err)
+	require.Equal(t, "/repo", plan.Root)
+	require.Equal(t, "-", mockStringUtils.PlanApplyClipboardArgsForCall(0).Input)
+
+	mockStringUtils.PlanApplyClipboardReturns(nil, errors.New("mock error"))
+	_, err = srv.PlanApplyClipboard(&pasteback.Options{Root: "."})
+	require.ErrorContains(t, err, "plan apply clipboard:")
+
+	mockStringUtils.ApplyClipboardReturns(&pasteback.Result{Written: []string{"a.go"}}, errors.New("mock error"))
+	result, err := srv.ApplyClipboard(plan)
+	require.ErrorContains(t, err, "apply clipboard:")
+	require.Equal(t, []string{"a.go"}, result.Written, "what was written is kept on failure")
+}
+
+func TestServices_Snippets(t *testing.T) {
+	mockStringUtils := clifakes.FakeStringService{}
+	mockGptUtils := clifakes.FakeGptService{}
+	mockFileUtils := clifakes.FakeFileService{}
+
+	srv := Service{
+		stringUtils: &mockStringUtils,
+		gptUtils:    &mockGptUtils,
+		fileUtils:   &mockFileUtils,
+	}
+
+	err := srv.ClipSnippet("greet", map[string]string{"name": "bob"}, "-")
+	require.NoError(t, err)
+	name, values, output := mockStringUtils.ClipSnippetArgsForCall(0)
+	require.Equal(t, "greet", name)
+	require.Equal(t, map[string]string{"name": "bob"}, values)
+	require.Equal(t, "-", output)
+
+	mockStringUtils.ClipSnippetReturns(errors.New("mock error"))
+	err = srv.ClipSnippet("greet", nil, "")
+	require.ErrorContains(t, err, "clip snippet:")
+
+	mockStringUtils.ListSnippetsReturns([]snippet.Snippet{{Name: "a"}, {Name: "b"}}, nil)
+	snippets, err := srv.ExportSnippets()
+	require.NoError(t, err)
+	require.Len(t, snippets, 2)
+
+	mockStringUtils.GetSnippetReturns(&snippet.Snippet{Name: "b"}, nil)
+	snippets, err = srv.ExportSnippets("b")
+	require.NoError(t, err)
+	require.Equal(t, []snippet.Snippet{{Name: "b"}}, snippets)
+	require.Equal(t, "b", mockStringUtils.GetSnippetArgsForCall(0))
+
+	mockStringUtils.GetSnippetReturns(nil, errors.New("mock error"))
+	_, err = srv.ExportSnippets("missing")
+	require.ErrorContains(t, err, "export snippets:")
+
+	err = srv.ImportSnippets(snippets, true)
+	require.NoError(t, err)
+	overwrite, imported := mockStringUtils.AddSnippetsArgsForCall(0)
+	require.True(t, overwrite)
+	require.Equal(t, snippets, imported)
+
+	mockStringUtils.AddSnippetsReturns(errors.New("mock error"))
+	err = srv.ImportSnippets(snippets, false)
+	require.ErrorContains(t, err, "import snippets:")
+}
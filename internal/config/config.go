@@ -3,10 +3,14 @@ package config
 import (
 	"errors"
 	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
 	"github.com/dembygenesis/local.tools/internal/utilities/errs"
 	"github.com/dembygenesis/local.tools/internal/utilities/sliceutil"
 	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
 	"github.com/spf13/viper"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -29,6 +33,34 @@ type MysqlDatabaseCredentials struct {
 
 type CopyToClipboard struct {
 	Exclusions []string `json:"exclusions"`
+
+	// MaxFileBytes skips files larger than this, 0 disables the limit.
+	MaxFileBytes int64 `json:"max_file_bytes" mapstructure:"CLIP_MAX_FILE_BYTES"`
+
+	// MaxTotalBytes caps the size of everything clipped, 0 disables the limit.
+	MaxTotalBytes int64 `json:"max_total_bytes" mapstructure:"CLIP_MAX_TOTAL_BYTES"`
+
+	// MaxTokens is the default token budget of "clip-context".
+	MaxTokens int `json:"max_tokens" mapstructure:"CLIP_MAX_TOKENS"`
+
+	// Tokenizer is the default token estimator, either "chars" or "bpe".
+	Tokenizer string `json:"tokenizer" mapstructure:"CLIP_TOKENIZER"`
+
+	// BPEFile is the tiktoken style rank file used by the "bpe" tokenizer.
+	BPEFile string `json:"bpe_file" mapstructure:"CLIP_BPE_FILE"`
+
+	// HistoryLimit is how many clipped payloads the history keeps, 0 disables it.
+	HistoryLimit int `json:"history_limit" mapstructure:"CLIP_HISTORY_LIMIT"`
+
+	// Backend picks the clipboard, "auto" uses the first one that works.
+	Backend string `json:"backend" mapstructure:"CLIP_BACKEND"`
+
+	// File is written by the "file" clipboard backend, defaults to a file in the app dir.
+	File string `json:"file" mapstructure:"CLIP_FILE"`
+
+	// RedactPatternsFile adds regular expressions to the secret redaction, one per
+	// line, defaults to a file in the app dir.
+	RedactPatternsFile string `json:"redact_patterns_file" mapstructure:"CLIP_REDACT_PATTERNS_FILE"`
 }
 
 func (c *CopyToClipboard) ParseExclusions(s string) error {
@@ -64,6 +96,59 @@ type API struct {
 	RequestTimeout time.Duration `json:"request_timeout" mapstructure:"API_REQUEST_TIMEOUT_SECS" validate:"required,is_positive_time_duration"`
 }
 
+type Auth struct {
+	// SigningMethod signs the access tokens, either "HS256" or "RS256".
+	SigningMethod string `json:"signing_method" mapstructure:"AUTH_SIGNING_METHOD" validate:"required,oneof=HS256 RS256"`
+
+	// HMACSecret signs the access tokens with HS256.
+	HMACSecret string `json:"-" mapstructure:"AUTH_HMAC_SECRET"`
+
+	// RSAPrivateKeyFile is the PEM encoded key that signs the access tokens with RS256.
+	RSAPrivateKeyFile string `json:"rsa_private_key_file" mapstructure:"AUTH_RSA_PRIVATE_KEY_FILE"`
+
+	// Issuer is set on, and checked against, the access tokens.
+	Issuer string `json:"issuer" mapstructure:"AUTH_ISSUER" validate:"required"`
+
+	AccessTokenTTL  time.Duration `json:"access_token_ttl" mapstructure:"AUTH_ACCESS_TOKEN_TTL" validate:"required,is_positive_time_duration"`
+	RefreshTokenTTL time.Duration `json:"refresh_token_ttl" mapstructure:"AUTH_REFRESH_TOKEN_TTL" validate:"required,is_positive_time_duration"`
+
+	// PasswordResetURL is the page the password reset links open, with the token as "token" query parameter.
+	PasswordResetURL string        `json:"password_reset_url" mapstructure:"AUTH_PASSWORD_RESET_URL" validate:"required,url"`
+	PasswordResetTTL time.Duration `json:"password_reset_ttl" mapstructure:"AUTH_PASSWORD_RESET_TTL" validate:"required,is_positive_time_duration"`
+}
+
+type Mail struct {
+	// Driver sends the emails over "smtp", or appends them to a "file" or the "log" for development.
+	// The "log" driver, the default, is refused when the API starts in production.
+	Driver string `json:"driver" mapstructure:"MAIL_DRIVER" validate:"required,oneof=smtp file log"`
+	From   string `json:"from" mapstructure:"MAIL_FROM" validate:"required"`
+
+	// File is written by the "file" driver, mail.log of the app dir by default.
+	File string `json:"file" mapstructure:"MAIL_FILE"`
+
+	SMTPHost     string `json:"smtp_host" mapstructure:"MAIL_SMTP_HOST"`
+	SMTPPort     int    `json:"smtp_port" mapstructure:"MAIL_SMTP_PORT"`
+	SMTPUsername string `json:"smtp_username" mapstructure:"MAIL_SMTP_USERNAME"`
+	SMTPPassword string `json:"-" mapstructure:"MAIL_SMTP_PASSWORD"`
+
+	// SMTPImplicitTLS connects over TLS from the start, implied on port 465.
+	SMTPImplicitTLS bool `json:"smtp_implicit_tls" mapstructure:"MAIL_SMTP_IMPLICIT_TLS"`
+
+	// SMTPInsecure sends unencrypted when the server lacks STARTTLS, localhost only.
+	SMTPInsecure bool `json:"smtp_insecure" mapstructure:"MAIL_SMTP_INSECURE"`
+}
+
+type Password struct {
+	// Algorithm hashes new and rehashed passwords, either "bcrypt" or "argon2id".
+	Algorithm string `json:"algorithm" mapstructure:"PASSWORD_ALGORITHM" validate:"required,oneof=bcrypt argon2id"`
+
+	BcryptCost int `json:"bcrypt_cost" mapstructure:"PASSWORD_BCRYPT_COST" validate:"min=4,max=31"`
+
+	Argon2MemoryKiB   uint32 `json:"argon2_memory_kib" mapstructure:"PASSWORD_ARGON2_MEMORY_KIB" validate:"min=8"`
+	Argon2Iterations  uint32 `json:"argon2_iterations" mapstructure:"PASSWORD_ARGON2_ITERATIONS" validate:"min=1"`
+	Argon2Parallelism uint8  `json:"argon2_parallelism" mapstructure:"PASSWORD_ARGON2_PARALLELISM" validate:"min=1"`
+}
+
 type Settings struct {
 	IsProduction bool   `json:"PRODUCTION" mapstructure:"PRODUCTION" validate:"boolean"`
 	AppDir       string `json:"APP_DIR" mapstructure:"APP_DIR" validate:"required"`
@@ -75,33 +160,92 @@ type App struct {
 	CopyToClipboard          CopyToClipboard          `json:"copy_to_clipboard"`
 	MysqlDatabaseCredentials MysqlDatabaseCredentials `json:"mysql_database_credentials"`
 	API                      API                      `json:"API"`
+	Auth                     Auth                     `json:"auth"`
+	Password                 Password                 `json:"password"`
+	Mail                     Mail                     `json:"mail"`
 	Timeouts                 Timeouts                 `json:"Timeouts"`
 }
 
-func New() (*App, error) {
-	viper.Reset()
+// EnvKeys lists the env vars the config reads, with their prefix, sorted.
+func EnvKeys() []string {
+	v := viper.New()
+	setDefaults(v)
 
-	// Set env prefix
-	viper.SetEnvPrefix("THEOVERWATCHTOOLS")
+	keys := make([]string, 0, len(v.AllKeys())+len(extraEnvKeys))
+	for _, key := range append(v.AllKeys(), extraEnvKeys...) {
+		keys = append(keys, EnvPrefix+strings.ToUpper(key))
+	}
+	sort.Strings(keys)
+	return keys
+}
 
+func setDefaults(v *viper.Viper) {
 	// Set app details
-	viper.SetDefault("APP_DIR", "/app")
-	viper.SetDefault("PRODUCTION", false)
+	v.SetDefault("APP_DIR", "/app")
+	v.SetDefault("PRODUCTION", false)
 
 	// Set database defaults
-	viper.SetDefault("DB_HOST", "localhost")
-	viper.SetDefault("DB_USER", "demby")
-	viper.SetDefault("DB_PASS", "secret")
-	viper.SetDefault("DB_PORT", 3306)
-	viper.SetDefault("DB_DATABASE", "example")
-	viper.SetDefault("DB_EXEC_TIMEOUT", "10s")
-	viper.SetDefault("DB_QUERY_TIMEOUT", "10s")
+	v.SetDefault("DB_HOST", "localhost")
+	v.SetDefault("DB_USER", "demby")
+	v.SetDefault("DB_PASS", "secret")
+	v.SetDefault("DB_PORT", 3306)
+	v.SetDefault("DB_DATABASE", "example")
+	v.SetDefault("DB_EXEC_TIMEOUT", "10s")
+	v.SetDefault("DB_QUERY_TIMEOUT", "10s")
 
 	// Set API defaults
-	viper.SetDefault("API_PORT", 3000)
-	viper.SetDefault("API_LISTEN_TIMEOUT_SECS", "10s")
-	viper.SetDefault("API_REQUEST_TIMEOUT_SECS", "10s")
-	viper.SetDefault("API_BASE_URL", "http://localhost")
+	v.SetDefault("API_PORT", 3000)
+	v.SetDefault("API_LISTEN_TIMEOUT_SECS", "10s")
+	v.SetDefault("API_REQUEST_TIMEOUT_SECS", "10s")
+	v.SetDefault("API_BASE_URL", "http://localhost")
+
+	// Set auth defaults, the signing key has none and is checked when the API starts
+	v.SetDefault("AUTH_SIGNING_METHOD", "HS256")
+	v.SetDefault("AUTH_HMAC_SECRET", "")
+	v.SetDefault("AUTH_RSA_PRIVATE_KEY_FILE", "")
+	v.SetDefault("AUTH_ISSUER", "local.tools")
+	v.SetDefault("AUTH_ACCESS_TOKEN_TTL", "15m")
+	v.SetDefault("AUTH_REFRESH_TOKEN_TTL", "720h")
+	v.SetDefault("AUTH_PASSWORD_RESET_URL", "http://localhost:3000/reset-password")
+	v.SetDefault("AUTH_PASSWORD_RESET_TTL", "1h")
+
+	// Set mail defaults, emails are only logged until a driver is set
+	v.SetDefault("MAIL_DRIVER", "log")
+	v.SetDefault("MAIL_FROM", "no-reply@local.tools")
+	v.SetDefault("MAIL_FILE", "")
+	v.SetDefault("MAIL_SMTP_HOST", "")
+	v.SetDefault("MAIL_SMTP_PORT", 587)
+	v.SetDefault("MAIL_SMTP_USERNAME", "")
+	v.SetDefault("MAIL_SMTP_PASSWORD", "")
+	v.SetDefault("MAIL_SMTP_IMPLICIT_TLS", false)
+	v.SetDefault("MAIL_SMTP_INSECURE", false)
+
+	// Set password hashing defaults, changing them rehashes the passwords on login
+	v.SetDefault("PASSWORD_ALGORITHM", "argon2id")
+	v.SetDefault("PASSWORD_BCRYPT_COST", 12)
+	v.SetDefault("PASSWORD_ARGON2_MEMORY_KIB", 19456)
+	v.SetDefault("PASSWORD_ARGON2_ITERATIONS", 2)
+	v.SetDefault("PASSWORD_ARGON2_PARALLELISM", 1)
+
+	// Set clipboard defaults
+	v.SetDefault("CLIP_MAX_FILE_BYTES", defaultClipMaxFileBytes)
+	v.SetDefault("CLIP_MAX_TOTAL_BYTES", defaultClipMaxTotalBytes)
+	v.SetDefault("CLIP_MAX_TOKENS", defaultClipMaxTokens)
+	v.SetDefault("CLIP_TOKENIZER", "chars")
+	v.SetDefault("CLIP_BPE_FILE", "")
+	v.SetDefault("CLIP_HISTORY_LIMIT", defaultClipHistoryLimit)
+	v.SetDefault("CLIP_BACKEND", "auto")
+	v.SetDefault("CLIP_FILE", "")
+	v.SetDefault("CLIP_REDACT_PATTERNS_FILE", "")
+}
+
+func New() (*App, error) {
+	viper.Reset()
+
+	// Set env prefix
+	viper.SetEnvPrefix("THEOVERWATCHTOOLS")
+
+	setDefaults(viper.GetViper())
 
 	viper.AutomaticEnv()
 
@@ -116,6 +260,10 @@ func New() (*App, error) {
 		return &config, fmt.Errorf("unmarshal transfer files: %v", err)
 	}
 
+	if err := viper.Unmarshal(&config.CopyToClipboard); err != nil {
+		return &config, fmt.Errorf("unmarshal copy to clipboard budgets: %v", err)
+	}
+
 	err := viper.Unmarshal(&config.MysqlDatabaseCredentials)
 	if err != nil {
 		return &config, fmt.Errorf("error trying to unmarshal the database credentials: %w", err)
@@ -131,13 +279,43 @@ func New() (*App, error) {
 		return nil, fmt.Errorf("unmarshal API cfg: %v", err)
 	}
 
+	err = viper.Unmarshal(&config.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal auth cfg: %v", err)
+	}
+
+	err = viper.Unmarshal(&config.Password)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal password cfg: %v", err)
+	}
+
+	err = viper.Unmarshal(&config.Mail)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal mail cfg: %v", err)
+	}
+
 	err = viper.Unmarshal(&config.Settings)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal API cfg: %v", err)
 	}
 
+	if config.CopyToClipboard.File == "" {
+		config.CopyToClipboard.File = filepath.Join(config.Settings.AppDir, clipboardFile)
+	}
+
+	if config.Mail.File == "" {
+		config.Mail.File = filepath.Join(config.Settings.AppDir, mailFile)
+	}
+
+	if config.CopyToClipboard.RedactPatternsFile == "" {
+		config.CopyToClipboard.RedactPatternsFile = filepath.Join(config.Settings.AppDir, redact.PatternsFile)
+	}
+
 	cfgProperties := []interface{}{
 		config.API,
+		config.Auth,
+		config.Password,
+		config.Mail,
 		config.MysqlDatabaseCredentials,
 		config.Settings,
 		config.Timeouts,
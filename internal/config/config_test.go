@@ -2,7 +2,9 @@ package config
 
 import (
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func Test_New(t *testing.T) {
@@ -10,3 +12,27 @@ func Test_New(t *testing.T) {
 	assert.NoError(t, err, "unexpected error initialising config")
 	assert.NotNil(t, cfg, "unexpected nil config")
 }
+
+func Test_New_CopyToClipboard_Defaults(t *testing.T) {
+	cfg, err := New()
+	assert.NoError(t, err, "unexpected error initialising config")
+	assert.NotEmpty(t, cfg.CopyToClipboard.Exclusions, "exclusions should survive the budget unmarshal")
+	assert.Equal(t, int64(defaultClipMaxFileBytes), cfg.CopyToClipboard.MaxFileBytes)
+	assert.Equal(t, int64(defaultClipMaxTotalBytes), cfg.CopyToClipboard.MaxTotalBytes)
+}
+
+func Test_EnvKeys(t *testing.T) {
+	keys := EnvKeys()
+	assert.Contains(t, keys, EnvAppDir)
+	assert.Contains(t, keys, "THEOVERWATCHTOOLS_CLIP_BACKEND")
+	assert.Contains(t, keys, "THEOVERWATCHTOOLS_DB_USE_EXISTING_MARIADB")
+	assert.IsIncreasing(t, keys)
+}
+
+func Test_New_Mail_Defaults(t *testing.T) {
+	cfg, err := New()
+	assert.NoError(t, err, "unexpected error initialising config")
+	assert.Equal(t, "log", cfg.Mail.Driver, "emails are only logged until a driver is set")
+	assert.Equal(t, filepath.Join(cfg.Settings.AppDir, mailFile), cfg.Mail.File)
+	assert.Equal(t, time.Hour, cfg.Auth.PasswordResetTTL)
+}
@@ -7,9 +7,32 @@ const genericExclusions = `
     ".git"
   ]
 `
+const (
+	// defaultClipMaxFileBytes keeps lockfiles and generated blobs out of clipped context.
+	defaultClipMaxFileBytes = 256 * 1024
+
+	// defaultClipMaxTotalBytes keeps clipped context pasteable.
+	defaultClipMaxTotalBytes = 4 * 1024 * 1024
+
+	// defaultClipMaxTokens fits comfortably in a 128k context window, leaving room for the prompt.
+	defaultClipMaxTokens = 100000
+
+	// defaultClipHistoryLimit keeps the last few clips, without the history file growing unbounded.
+	defaultClipHistoryLimit = 50
+
+	// clipboardFile is written by the "file" clipboard backend, under the app dir.
+	clipboardFile = "clipboard.txt"
+
+	// mailFile is written by the "file" mail driver, under the app dir.
+	mailFile = "mail.log"
+)
+
 const (
 	EnvAppDir = "THEOVERWATCHTOOLS_APP_DIR"
 
 	envFile   = ".env"
-	envPrefix = "THEOVERWATCHTOOLS_"
+	EnvPrefix = "THEOVERWATCHTOOLS_"
 )
+
+// extraEnvKeys are read outside of the config, by the test database helpers.
+var extraEnvKeys = []string{"DB_USE_EXISTING_MARIADB"}
@@ -0,0 +1,25 @@
+SET FOREIGN_KEY_CHECKS = 0;
+
+CREATE TABLE `refresh_token`
+(
+    `id`          int(11)     NOT NULL AUTO_INCREMENT,
+    `user_ref_id` int(11)     NOT NULL,
+
+    -- Every token rotated out of the same login shares its family, so reusing
+    -- a rotated token revokes the whole session.
+    `family`      varchar(36) NOT NULL,
+
+    -- SHA-256 of the token, the token itself is only known to the client.
+    `token_hash`  char(64)    NOT NULL,
+    `created_at`  timestamp   NOT NULL DEFAULT current_timestamp,
+    `expires_at`  datetime    NOT NULL,
+    `revoked_at`  timestamp NULL DEFAULT NULL,
+
+    CONSTRAINT `refresh_token_user_ref_id_fk` FOREIGN KEY (`user_ref_id`) REFERENCES `user` (`id`) ON DELETE CASCADE,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `token_hash` (`token_hash`),
+    KEY `refresh_token_family` (`family`)
+);
+
+SET FOREIGN_KEY_CHECKS = 1;
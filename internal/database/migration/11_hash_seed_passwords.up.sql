@@ -0,0 +1,6 @@
+-- The seeded users were stored with the plaintext 'password123', this is its
+-- argon2id hash with the default parameters. Other plaintext passwords are
+-- hashed with the hash-passwords command.
+UPDATE `user`
+SET `password` = '$argon2id$v=19$m=19456,t=2,p=1$uqf5tnMtnm7rBWvq9vonPQ$02Viq2cr2SI4tdR0u7QGSLIFBMMcjsVmNZdarV9/G8U'
+WHERE `password` = 'password123';
@@ -0,0 +1,22 @@
+CREATE TABLE `permission`
+(
+    `id`          int(11)      NOT NULL AUTO_INCREMENT,
+
+    -- "<resource>:<action>", checked by name in the code.
+    `name`        varchar(64)  NOT NULL,
+    `description` varchar(255) NOT NULL,
+    `created_at`  timestamp    NOT NULL DEFAULT current_timestamp,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `name` (`name`)
+);
+
+INSERT INTO `permission` (`name`, `description`)
+VALUES ('category:read', 'List the categories'),
+       ('category:write', 'Create, update and restore categories'),
+       ('category:delete', 'Delete categories'),
+       ('user:read', 'List the users'),
+       ('user:write', 'Create and update users'),
+       ('user:delete', 'Delete users'),
+       ('role:read', 'List the roles and their permissions'),
+       ('role:write', 'Grant and revoke the permissions of roles')
+;
@@ -0,0 +1,36 @@
+SET FOREIGN_KEY_CHECKS = 0;
+
+-- Roles are the "User Types" categories, which user.category_type_ref_id
+-- points at.
+CREATE TABLE `role_permission`
+(
+    `id`                int(11)   NOT NULL AUTO_INCREMENT,
+    `role_ref_id`       int(11)   NOT NULL,
+    `permission_ref_id` int(11)   NOT NULL,
+    `created_at`        timestamp NOT NULL DEFAULT current_timestamp,
+
+    CONSTRAINT `role_permission_role_ref_id_fk` FOREIGN KEY (`role_ref_id`) REFERENCES `category` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `role_permission_permission_ref_id_fk` FOREIGN KEY (`permission_ref_id`) REFERENCES `permission` (`id`) ON DELETE CASCADE,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `role_permission` (`role_ref_id`, `permission_ref_id`),
+    KEY `role_permission_permission_ref_id` (`permission_ref_id`)
+);
+
+SET FOREIGN_KEY_CHECKS = 1;
+
+SET @user_types_ref_id = (SELECT id FROM `category_type` WHERE `name` = 'User Types');
+SET @super_admin = (SELECT id FROM `category` WHERE `name` = 'Super Admin' AND `category_type_ref_id` = @user_types_ref_id);
+SET @admin = (SELECT id FROM `category` WHERE `name` = 'Admin' AND `category_type_ref_id` = @user_types_ref_id);
+SET @regular_user = (SELECT id FROM `category` WHERE `name` = 'Regular User' AND `category_type_ref_id` = @user_types_ref_id);
+
+-- Super admins can do everything, admins everything but managing the roles,
+-- and regular users can only read.
+INSERT INTO `role_permission` (`role_ref_id`, `permission_ref_id`)
+SELECT @super_admin, `id` FROM `permission`;
+
+INSERT INTO `role_permission` (`role_ref_id`, `permission_ref_id`)
+SELECT @admin, `id` FROM `permission` WHERE `name` NOT IN ('role:write');
+
+INSERT INTO `role_permission` (`role_ref_id`, `permission_ref_id`)
+SELECT @regular_user, `id` FROM `permission` WHERE `name` IN ('category:read', 'user:read');
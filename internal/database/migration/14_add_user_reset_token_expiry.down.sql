@@ -0,0 +1,3 @@
+ALTER TABLE `user`
+    DROP INDEX `user_reset_token`,
+    DROP COLUMN `reset_token_expires_at`;
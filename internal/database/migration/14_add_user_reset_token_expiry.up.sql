@@ -0,0 +1,5 @@
+-- reset_token holds the SHA-256 of a password reset token, which is only
+-- known to the user it was mailed to, and is cleared once used.
+ALTER TABLE `user`
+    ADD COLUMN `reset_token_expires_at` datetime NULL DEFAULT NULL AFTER `reset_token`,
+    ADD UNIQUE KEY `user_reset_token` (`reset_token`);
@@ -0,0 +1,34 @@
+SET FOREIGN_KEY_CHECKS = 0;
+
+CREATE TABLE `api_key`
+(
+    `id`                  int(11)       NOT NULL AUTO_INCREMENT,
+
+    -- The user the key acts as, limited to its scopes.
+    `user_ref_id`         int(11)       NOT NULL,
+
+    -- Set for the keys of an organization, which only its creator manages.
+    `organization_ref_id` int(11)                DEFAULT NULL,
+    `name`                varchar(255)  NOT NULL,
+
+    -- The start of the key, to tell the keys apart when listed.
+    `prefix`              varchar(16)   NOT NULL,
+
+    -- SHA-256 of the key, the key itself is only shown once, when created.
+    `key_hash`            char(64)      NOT NULL,
+
+    -- Space separated names of the permissions the key is limited to.
+    `scopes`              varchar(1024) NOT NULL,
+    `expires_at`          datetime NULL DEFAULT NULL,
+    `last_used_at`        timestamp NULL DEFAULT NULL,
+    `created_at`          timestamp     NOT NULL DEFAULT current_timestamp,
+    `revoked_at`          timestamp NULL DEFAULT NULL,
+
+    CONSTRAINT `api_key_user_ref_id_fk` FOREIGN KEY (`user_ref_id`) REFERENCES `user` (`id`) ON DELETE CASCADE,
+    CONSTRAINT `api_key_organization_ref_id_fk` FOREIGN KEY (`organization_ref_id`) REFERENCES `organization` (`id`) ON DELETE CASCADE,
+
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `key_hash` (`key_hash`)
+);
+
+SET FOREIGN_KEY_CHECKS = 1;
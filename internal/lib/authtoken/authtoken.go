@@ -0,0 +1,169 @@
+package authtoken
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	MethodHS256 = "HS256"
+	MethodRS256 = "RS256"
+
+	// minHMACSecretLen is the size of the SHA-256 output, a shorter HS256
+	// secret weakens the signature.
+	minHMACSecretLen = 32
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Claims are the claims of an access token, the subject is the user id.
+type Claims struct {
+	jwt.RegisteredClaims
+}
+
+// UserId returns the id of the user the token was issued to.
+func (c *Claims) UserId() (int, error) {
+	id, err := strconv.Atoi(c.Subject)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("subject '%s' is not a user id", c.Subject)
+	}
+	return id, nil
+}
+
+type Config struct {
+	// Method is either MethodHS256 or MethodRS256.
+	Method string
+
+	// HMACSecret signs the tokens with MethodHS256.
+	HMACSecret []byte
+
+	// RSAPrivateKey signs the tokens with MethodRS256, its public key verifies them.
+	RSAPrivateKey *rsa.PrivateKey
+
+	// Issuer is set on the tokens, and required when parsing them.
+	Issuer string
+
+	// TTL is how long the tokens are valid for.
+	TTL time.Duration
+}
+
+func (c *Config) Validate() error {
+	if c.Issuer == "" {
+		return errors.New("issuer is required")
+	}
+	if c.TTL <= 0 {
+		return errors.New("ttl must be greater than 0")
+	}
+
+	switch c.Method {
+	case MethodHS256:
+		if len(c.HMACSecret) < minHMACSecretLen {
+			return fmt.Errorf("the %s secret must be at least %d bytes", MethodHS256, minHMACSecretLen)
+		}
+	case MethodRS256:
+		if c.RSAPrivateKey == nil {
+			return fmt.Errorf("the %s private key is required", MethodRS256)
+		}
+	default:
+		return fmt.Errorf("unsupported signing method '%s', use %s or %s", c.Method, MethodHS256, MethodRS256)
+	}
+	return nil
+}
+
+// Signer issues and verifies short-lived access tokens.
+type Signer struct {
+	cfg       *Config
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	now       func() time.Time
+}
+
+// New creates a signer for the configured method and key.
+func New(cfg *Config) (*Signer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %v", err)
+	}
+
+	s := &Signer{cfg: cfg, now: time.Now}
+	switch cfg.Method {
+	case MethodHS256:
+		s.method, s.signKey, s.verifyKey = jwt.SigningMethodHS256, cfg.HMACSecret, cfg.HMACSecret
+	case MethodRS256:
+		s.method, s.signKey, s.verifyKey = jwt.SigningMethodRS256, cfg.RSAPrivateKey, &cfg.RSAPrivateKey.PublicKey
+	}
+	return s, nil
+}
+
+// TTL is how long the issued tokens are valid for.
+func (s *Signer) TTL() time.Duration {
+	return s.cfg.TTL
+}
+
+// Sign issues a token for the user, returning when it expires.
+func (s *Signer) Sign(userId int) (string, time.Time, error) {
+	now := s.now()
+	expiresAt := now.Add(s.cfg.TTL)
+
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    s.cfg.Issuer,
+			Subject:   strconv.Itoa(userId),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(s.method, claims).SignedString(s.signKey)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign: %v", err)
+	}
+	return token, expiresAt, nil
+}
+
+// Parse verifies the token's signature, method, issuer and expiry, and
+// returns its claims. Every failure wraps ErrInvalidToken.
+func (s *Signer) Parse(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return s.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{s.method.Alg()}),
+		jwt.WithIssuer(s.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(s.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if _, err := claims.UserId(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// LoadRSAPrivateKey reads a PEM encoded PKCS #1 or PKCS #8 RSA private key.
+func LoadRSAPrivateKey(file string) (*rsa.PrivateKey, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %v", err)
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %v", err)
+	}
+	return key, nil
+}
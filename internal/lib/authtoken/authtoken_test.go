@@ -0,0 +1,158 @@
+package authtoken
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newHS256(t *testing.T) *Signer {
+	s, err := New(&Config{Method: MethodHS256, HMACSecret: testSecret, Issuer: "test", TTL: time.Minute})
+	require.NoError(t, err)
+	return s
+}
+
+func TestConfig_Validate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{"hs256", Config{Method: MethodHS256, HMACSecret: testSecret, Issuer: "test", TTL: time.Minute}, ""},
+		{"rs256", Config{Method: MethodRS256, RSAPrivateKey: key, Issuer: "test", TTL: time.Minute}, ""},
+		{"short secret", Config{Method: MethodHS256, HMACSecret: []byte("short"), Issuer: "test", TTL: time.Minute}, "at least 32 bytes"},
+		{"missing key", Config{Method: MethodRS256, Issuer: "test", TTL: time.Minute}, "private key is required"},
+		{"unsupported method", Config{Method: "none", Issuer: "test", TTL: time.Minute}, "unsupported signing method 'none'"},
+		{"missing issuer", Config{Method: MethodHS256, HMACSecret: testSecret, TTL: time.Minute}, "issuer is required"},
+		{"missing ttl", Config{Method: MethodHS256, HMACSecret: testSecret, Issuer: "test"}, "ttl must be greater than 0"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestSigner_HS256(t *testing.T) {
+	s := newHS256(t)
+
+	token, expiresAt, err := s.Sign(42)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiresAt, time.Second)
+
+	claims, err := s.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, "test", claims.Issuer)
+	assert.NotEmpty(t, claims.ID)
+
+	id, err := claims.UserId()
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+}
+
+func TestSigner_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "key.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), 0600))
+
+	loaded, err := LoadRSAPrivateKey(file)
+	require.NoError(t, err)
+
+	s, err := New(&Config{Method: MethodRS256, RSAPrivateKey: loaded, Issuer: "test", TTL: time.Minute})
+	require.NoError(t, err)
+
+	token, _, err := s.Sign(7)
+	require.NoError(t, err)
+
+	claims, err := s.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, "7", claims.Subject)
+
+	_, err = newHS256(t).Parse(token)
+	assert.ErrorIs(t, err, ErrInvalidToken, "the method must match the signer's")
+}
+
+func TestSigner_Parse_Rejects(t *testing.T) {
+	s := newHS256(t)
+	token, _, err := s.Sign(1)
+	require.NoError(t, err)
+
+	t.Run("tampered", func(t *testing.T) {
+		parts := strings.Split(token, ".")
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		parts[1] = base64.RawURLEncoding.EncodeToString([]byte(strings.Replace(string(claims), `"sub":"1"`, `"sub":"2"`, 1)))
+
+		_, err = s.Parse(strings.Join(parts, "."))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("other issuer", func(t *testing.T) {
+		other, err := New(&Config{Method: MethodHS256, HMACSecret: testSecret, Issuer: "other", TTL: time.Minute})
+		require.NoError(t, err)
+
+		_, err = other.Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := newHS256(t)
+		expired.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+		_, err := expired.Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("none alg", func(t *testing.T) {
+		unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+			Issuer:    "test",
+			Subject:   "1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = s.Parse(unsigned)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		_, err := s.Parse("not-a-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestNewOpaque(t *testing.T) {
+	token, hash, err := NewOpaque()
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, Hash(token), hash)
+
+	other, _, err := NewOpaque()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
@@ -0,0 +1,31 @@
+package authtoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// opaqueBytes is the entropy of an opaque token.
+const opaqueBytes = 32
+
+// NewOpaque returns a random token for the client, and the hash to store in
+// its place. Opaque tokens carry no claims, they are only looked up by hash.
+func NewOpaque() (token string, hash string, err error) {
+	b := make([]byte, opaqueBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("read random: %v", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, Hash(token), nil
+}
+
+// Hash is the hex SHA-256 of a token. A fast hash is enough, as opaque tokens
+// have far too much entropy to be brute forced, unlike passwords.
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,136 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/atotto/clipboard"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// systemBackend is the desktop clipboard, through xclip, xsel, wl-clipboard
+// and the like on linux.
+type systemBackend struct{}
+
+func (b *systemBackend) Name() string {
+	return System
+}
+
+// Available needs a display on linux, as the clipboard tools are usually
+// installed on headless machines too, and fail there.
+func (b *systemBackend) Available() bool {
+	if clipboard.Unsupported {
+		return false
+	}
+
+	if runtime.GOOS != "linux" {
+		return true
+	}
+
+	return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != "" || os.Getenv("TERMUX_VERSION") != ""
+}
+
+func (b *systemBackend) Write(text string) error {
+	return clipboard.WriteAll(text)
+}
+
+func (b *systemBackend) Read() (string, error) {
+	return clipboard.ReadAll()
+}
+
+// osc52Backend asks the terminal to set the clipboard with an OSC 52 escape
+// sequence, which works over SSH when the terminal supports it.
+type osc52Backend struct{}
+
+// ttyPath is the controlling terminal, written to directly so the sequence
+// never ends up in piped output.
+const ttyPath = "/dev/tty"
+
+func (b *osc52Backend) Name() string {
+	return OSC52
+}
+
+func (b *osc52Backend) Available() bool {
+	if term := os.Getenv("TERM"); term == "" || term == "dumb" {
+		return false
+	}
+
+	f, err := os.OpenFile(ttyPath, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	return true
+}
+
+func (b *osc52Backend) Write(text string) error {
+	f, err := os.OpenFile(ttyPath, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("open tty: %v", err)
+	}
+	defer f.Close()
+
+	return writeOSC52(f, text, os.Getenv("TMUX") != "")
+}
+
+func (b *osc52Backend) Read() (string, error) {
+	return "", errors.New("the terminal clipboard cannot be read, pipe the text to stdin instead")
+}
+
+// maxOSC52Bytes caps the encoded payload of the sequence. Terminals silently
+// drop or truncate larger ones, around 100 KB for most of them.
+const maxOSC52Bytes = 100000
+
+// writeOSC52 writes the sequence setting the clipboard to "text". Inside tmux,
+// it is wrapped in a passthrough sequence so it reaches the outer terminal.
+// Payloads over maxOSC52Bytes fail, so Auto moves on to the next backend.
+func writeOSC52(w io.Writer, text string, tmux bool) error {
+	encoded := base64.StdEncoding.EncodeToString([]byte(text))
+	if len(encoded) > maxOSC52Bytes {
+		return fmt.Errorf("the payload encodes to %d bytes, over the %d terminals accept", len(encoded), maxOSC52Bytes)
+	}
+
+	seq := "\x1b]52;c;" + encoded + "\a"
+	if tmux {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+
+	_, err := io.WriteString(w, seq)
+	return err
+}
+
+// tmuxBackend loads the text into the tmux paste buffer.
+type tmuxBackend struct{}
+
+func (b *tmuxBackend) Name() string {
+	return Tmux
+}
+
+func (b *tmuxBackend) Available() bool {
+	if os.Getenv("TMUX") == "" {
+		return false
+	}
+	_, err := exec.LookPath("tmux")
+	return err == nil
+}
+
+func (b *tmuxBackend) Write(text string) error {
+	cmd := exec.Command("tmux", "load-buffer", "-")
+	cmd.Stdin = strings.NewReader(text)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("load-buffer: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (b *tmuxBackend) Read() (string, error) {
+	out, err := exec.Command("tmux", "save-buffer", "-").Output()
+	if err != nil {
+		return "", fmt.Errorf("save-buffer: %v", err)
+	}
+	return string(out), nil
+}
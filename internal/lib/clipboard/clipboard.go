@@ -0,0 +1,273 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The backends, by name.
+const (
+	// Auto picks the first working backend, in the order of Backends.
+	Auto   = "auto"
+	System = "system"
+	OSC52  = "osc52"
+	Tmux   = "tmux"
+	File   = "file"
+	Stdout = "stdout"
+)
+
+// Backends lists the backends in the order Auto tries them. Stdout is never
+// picked automatically, as it would mix the payload into the command output.
+var Backends = []string{System, OSC52, Tmux, File}
+
+// DefaultFile is where the file backend writes, when no file is given.
+var DefaultFile = filepath.Join(os.TempDir(), "theoverwatchtools-clipboard.txt")
+
+// Backend writes text somewhere it can be pasted from, and reads it back
+// when it can.
+type Backend interface {
+	Name() string
+
+	// Available reports whether the backend can work in this environment.
+	Available() bool
+	Write(text string) error
+	Read() (string, error)
+}
+
+// readBackends lists the backends Auto reads from, in order. OSC52 is left
+// out, as few terminals answer clipboard queries.
+var readBackends = []string{System, Tmux, File}
+
+// Options select the backend.
+type Options struct {
+	// Backend is one of Backends, Stdout, or Auto when empty.
+	Backend string `json:"backend"`
+
+	// File is written by the file backend, defaults to DefaultFile.
+	File string `json:"file"`
+}
+
+// Names lists every name Options.Backend accepts.
+func Names() []string {
+	return append([]string{Auto}, append(append([]string{}, Backends...), Stdout)...)
+}
+
+// New returns the named backend, without checking it is available.
+func New(opts *Options) (Backend, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	switch name := strings.ToLower(strings.TrimSpace(opts.Backend)); name {
+	case System:
+		return &systemBackend{}, nil
+	case OSC52:
+		return &osc52Backend{}, nil
+	case Tmux:
+		return &tmuxBackend{}, nil
+	case File:
+		file := opts.File
+		if strings.TrimSpace(file) == "" {
+			file = DefaultFile
+		}
+		return &fileBackend{path: file}, nil
+	case Stdout:
+		return &writerBackend{name: Stdout, w: os.Stdout}, nil
+	default:
+		return nil, fmt.Errorf("unknown clipboard backend '%s', expected one of: %s", opts.Backend, strings.Join(Names(), ", "))
+	}
+}
+
+// Write writes the text with the backend of the options, and returns the name
+// of the backend used. With Auto, every available backend is tried in order
+// until one succeeds.
+func Write(opts *Options, text string) (string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	name := strings.ToLower(strings.TrimSpace(opts.Backend))
+	if name != "" && name != Auto {
+		backend, err := New(opts)
+		if err != nil {
+			return "", err
+		}
+		if err := backend.Write(text); err != nil {
+			return "", fmt.Errorf("%s: %v", backend.Name(), err)
+		}
+		return backend.Name(), nil
+	}
+
+	var errs []error
+	for _, name := range Backends {
+		backend, err := New(&Options{Backend: name, File: opts.File})
+		if err != nil {
+			return "", err
+		}
+
+		if !backend.Available() {
+			continue
+		}
+
+		if err := backend.Write(text); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+			continue
+		}
+		return backend.Name(), nil
+	}
+
+	return "", fmt.Errorf("no clipboard backend worked: %v", errors.Join(errs...))
+}
+
+// Detect returns the name of the backend Write would try first, without
+// writing. A named backend that is not available fails.
+func Detect(opts *Options) (string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	name := strings.ToLower(strings.TrimSpace(opts.Backend))
+	if name != "" && name != Auto {
+		backend, err := New(opts)
+		if err != nil {
+			return "", err
+		}
+		if !backend.Available() {
+			return "", fmt.Errorf("clipboard backend '%s' is not available", name)
+		}
+		return backend.Name(), nil
+	}
+
+	for _, name := range Backends {
+		backend, err := New(&Options{Backend: name, File: opts.File})
+		if err != nil {
+			return "", err
+		}
+		if backend.Available() {
+			return backend.Name(), nil
+		}
+	}
+
+	return "", errors.New("no clipboard backend is available")
+}
+
+// Read reads the text back with the backend of the options, and returns the
+// name of the backend used. With Auto, every available backend is tried in
+// order until one succeeds, the file one only when its file exists.
+func Read(opts *Options) (string, string, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	name := strings.ToLower(strings.TrimSpace(opts.Backend))
+	if name != "" && name != Auto {
+		backend, err := New(opts)
+		if err != nil {
+			return "", "", err
+		}
+		text, err := backend.Read()
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %v", backend.Name(), err)
+		}
+		return text, backend.Name(), nil
+	}
+
+	var errs []error
+	for _, name := range readBackends {
+		backend, err := New(&Options{Backend: name, File: opts.File})
+		if err != nil {
+			return "", "", err
+		}
+
+		if !backend.Available() {
+			continue
+		}
+
+		text, err := backend.Read()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", name, err))
+			continue
+		}
+		return text, backend.Name(), nil
+	}
+
+	if len(errs) == 0 {
+		return "", "", errors.New("no clipboard to read from")
+	}
+	return "", "", fmt.Errorf("no clipboard backend could be read: %v", errors.Join(errs...))
+}
+
+type fileBackend struct {
+	path string
+}
+
+func (b *fileBackend) Name() string {
+	return File
+}
+
+func (b *fileBackend) Available() bool {
+	return true
+}
+
+// Write keeps the file private, as payloads may hold source code and secrets.
+// A file created by an older version is narrowed down too.
+func (b *fileBackend) Write(text string) error {
+	if err := os.MkdirAll(filepath.Dir(b.path), 0700); err != nil {
+		return fmt.Errorf("create dir: %v", err)
+	}
+
+	f, err := os.OpenFile(b.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := f.Chmod(0600); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if _, err := io.WriteString(f, text); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (b *fileBackend) Read() (string, error) {
+	data, err := os.ReadFile(b.path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+type writerBackend struct {
+	name string
+	w    io.Writer
+}
+
+func (b *writerBackend) Name() string {
+	return b.name
+}
+
+func (b *writerBackend) Available() bool {
+	return true
+}
+
+func (b *writerBackend) Write(text string) error {
+	_, err := io.WriteString(b.w, text)
+	return err
+}
+
+func (b *writerBackend) Read() (string, error) {
+	return "", fmt.Errorf("%s cannot be read from", b.name)
+}
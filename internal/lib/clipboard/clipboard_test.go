@@ -0,0 +1,134 @@
+package clipboard
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, name := range Names() {
+		if name == Auto {
+			continue
+		}
+		backend, err := New(&Options{Backend: name})
+		require.NoError(t, err)
+		assert.Equal(t, name, backend.Name())
+	}
+
+	_, err := New(&Options{Backend: "x11"})
+	assert.ErrorContains(t, err, "unknown clipboard backend 'x11'")
+}
+
+func TestWrite_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "nested", "clip.txt")
+
+	name, err := Write(&Options{Backend: "FILE", File: file}, "payload")
+	require.NoError(t, err)
+	assert.Equal(t, File, name)
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(b))
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "payloads are kept private")
+
+	info, err = os.Stat(filepath.Dir(file))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	require.NoError(t, os.Chmod(file, 0644))
+	_, err = Write(&Options{Backend: File, File: file}, "again")
+	require.NoError(t, err)
+
+	info, err = os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "existing files are narrowed down")
+}
+
+func TestWrite_Auto_Falls_Back_To_File(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("TERMUX_VERSION", "")
+	t.Setenv("TERM", "dumb")
+	t.Setenv("TMUX", "")
+
+	file := filepath.Join(t.TempDir(), "clip.txt")
+	name, err := Write(&Options{File: file}, "payload")
+	require.NoError(t, err)
+	assert.Equal(t, File, name, "no display, terminal or tmux")
+	assert.FileExists(t, file)
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("TERMUX_VERSION", "")
+	t.Setenv("TERM", "dumb")
+	t.Setenv("TMUX", "")
+
+	file := filepath.Join(t.TempDir(), "clip.txt")
+	name, err := Detect(&Options{File: file})
+	require.NoError(t, err)
+	assert.Equal(t, File, name)
+	assert.NoFileExists(t, file, "detecting does not write")
+
+	_, err = Detect(&Options{Backend: Tmux})
+	require.Error(t, err, "not in tmux")
+
+	_, err = Detect(&Options{Backend: "nope"})
+	require.Error(t, err)
+}
+
+func TestWriteOSC52(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeOSC52(&buf, "hi", false))
+	assert.Equal(t, "\x1b]52;c;aGk=\a", buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeOSC52(&buf, "hi", true))
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGk=\a\x1b\\", buf.String(), "escapes are doubled inside the tmux passthrough")
+
+	buf.Reset()
+	err := writeOSC52(&buf, strings.Repeat("a", maxOSC52Bytes), false)
+	assert.ErrorContains(t, err, "over the")
+	assert.Zero(t, buf.Len(), "nothing is sent over the cap")
+}
+
+func TestRead_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "clip.txt")
+
+	_, err := Write(&Options{Backend: File, File: file}, "payload")
+	require.NoError(t, err)
+
+	text, name, err := Read(&Options{Backend: File, File: file})
+	require.NoError(t, err)
+	assert.Equal(t, File, name)
+	assert.Equal(t, "payload", text)
+
+	_, _, err = Read(&Options{Backend: OSC52})
+	assert.ErrorContains(t, err, "osc52")
+}
+
+func TestRead_Auto(t *testing.T) {
+	t.Setenv("DISPLAY", "")
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("TERMUX_VERSION", "")
+	t.Setenv("TMUX", "")
+
+	file := filepath.Join(t.TempDir(), "clip.txt")
+	_, _, err := Read(&Options{File: file})
+	assert.EqualError(t, err, "no clipboard to read from", "a missing file is not a clipboard")
+
+	require.NoError(t, os.WriteFile(file, []byte("payload"), 0644))
+	text, name, err := Read(&Options{File: file})
+	require.NoError(t, err)
+	assert.Equal(t, File, name)
+	assert.Equal(t, "payload", text)
+}
@@ -0,0 +1,335 @@
+package cliphistory
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// FileName is the history file under the app dir, one JSON entry per line.
+	// The payloads are stored in their own files, in a directory named after it.
+	FileName = "clip-history.jsonl"
+
+	// maxLineBytes fits the entries recorded before payloads had their own
+	// files, which hold them inline, up to the biggest the clip budgets allow.
+	maxLineBytes = 64 * 1024 * 1024
+
+	// maxFirstLineRunes caps the first line kept in the history file for previews.
+	maxFirstLineRunes = 200
+)
+
+// The commands recording their payloads.
+const (
+	CommandFiles   = "clip-file-contents"
+	CommandContext = "clip-context"
+	CommandPrompt  = "prompt"
+	CommandPreface = "clip-gpt-preface"
+	CommandSnippet = "snippet"
+)
+
+// Origin describes what produced a payload.
+type Origin struct {
+	Command string `json:"command"`
+
+	// Source is the root path clipped, or the name of the preface.
+	Source string `json:"source"`
+}
+
+// Entry is a payload copied to the clipboard.
+type Entry struct {
+	Origin
+	Time    time.Time `json:"time"`
+	Bytes   int       `json:"bytes"`
+	Payload string    `json:"payload,omitempty"`
+
+	// File is the payload's file in the payload dir. Entries recorded before
+	// payloads had their own files keep them inline.
+	File string `json:"file,omitempty"`
+
+	// FirstLine is the first non-blank line of the payload, so listing the
+	// entries doesn't read their payloads.
+	FirstLine string `json:"first_line,omitempty"`
+}
+
+// Preview returns the first non-blank line of the payload, cut to "width" runes.
+func (e *Entry) Preview(width int) string {
+	line := e.FirstLine
+	if line == "" {
+		line = firstLine(e.Payload)
+	}
+
+	return truncate(line, width)
+}
+
+func firstLine(payload string) string {
+	for _, l := range strings.Split(payload, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			return l
+		}
+	}
+	return ""
+}
+
+// truncate cuts the line to "width" runes, marking the cut with an ellipsis.
+func truncate(line string, width int) string {
+	runes := []rune(line)
+	if width > 0 && len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return line
+}
+
+// Store keeps the last "limit" entries in a JSON lines file, and their
+// payloads in a file each, so recording a clip only appends a short line.
+type Store struct {
+	path  string
+	limit int
+}
+
+// NewStore returns a store writing to "path". A limit of 0 or less disables
+// recording, without hiding the entries already recorded.
+func NewStore(path string, limit int) *Store {
+	return &Store{path: path, limit: limit}
+}
+
+// Path is the history file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// payloadDir holds the payload files, next to the history file.
+func (s *Store) payloadDir() string {
+	return strings.TrimSuffix(s.path, filepath.Ext(s.path))
+}
+
+// Add records the payload, then drops the oldest entries over the limit.
+func (s *Store) Add(origin Origin, payload string) error {
+	if s.limit <= 0 {
+		return nil
+	}
+
+	file, err := s.writePayload(payload)
+	if err != nil {
+		return err
+	}
+
+	err = s.append(Entry{
+		Origin:    origin,
+		Time:      time.Now(),
+		Bytes:     len(payload),
+		File:      file,
+		FirstLine: truncate(firstLine(payload), maxFirstLineRunes),
+	})
+	if err != nil {
+		_ = os.Remove(filepath.Join(s.payloadDir(), file))
+		return err
+	}
+
+	return s.compact()
+}
+
+// writePayload stores the payload in a new file of the payload dir, and
+// returns its name.
+func (s *Store) writePayload(payload string) (string, error) {
+	// Payloads may hold anything that was clipped, keep them private.
+	if err := os.MkdirAll(s.payloadDir(), 0700); err != nil {
+		return "", fmt.Errorf("create payload dir: %v", err)
+	}
+
+	f, err := os.CreateTemp(s.payloadDir(), "clip-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("create payload: %v", err)
+	}
+
+	if _, err := f.WriteString(payload); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("write payload: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("close payload: %v", err)
+	}
+
+	return filepath.Base(f.Name()), nil
+}
+
+// append adds the entry at the end of the history file.
+func (s *Store) append(entry Entry) error {
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open history: %v", err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(entry); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("append entry: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close history: %v", err)
+	}
+
+	return nil
+}
+
+// compact drops the oldest entries, and their payloads, once the history
+// goes over the limit.
+func (s *Store) compact() error {
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) <= s.limit {
+		return nil
+	}
+
+	dropped := entries[:len(entries)-s.limit]
+	if err := s.write(entries[len(entries)-s.limit:]); err != nil {
+		return err
+	}
+
+	for _, entry := range dropped {
+		if entry.File != "" {
+			_ = os.Remove(filepath.Join(s.payloadDir(), filepath.Base(entry.File)))
+		}
+	}
+
+	return nil
+}
+
+// load reads the payload of the entry from its file.
+func (s *Store) load(entry *Entry) error {
+	if entry.File == "" {
+		return nil
+	}
+
+	b, err := os.ReadFile(filepath.Join(s.payloadDir(), filepath.Base(entry.File)))
+	if err != nil {
+		return fmt.Errorf("read payload: %v", err)
+	}
+	entry.Payload = string(b)
+
+	return nil
+}
+
+// List returns the entries, most recent first. Payloads stored in their own
+// files are left out, Get loads them.
+func (s *Store) List() ([]Entry, error) {
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+
+	return entries, nil
+}
+
+// Get returns the n-th most recent entry, starting at 1, with its payload.
+func (s *Store) Get(n int) (*Entry, error) {
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 1 || n > len(entries) {
+		return nil, fmt.Errorf("no entry %d, the history holds %d", n, len(entries))
+	}
+
+	entry := entries[len(entries)-n]
+	if err := s.load(&entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// read returns the entries oldest first. A missing file is an empty history.
+func (s *Store) read() ([]Entry, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("open history: %v", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("decode history line %d: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %v", err)
+	}
+
+	return entries, nil
+}
+
+// write replaces the history through a temp file, so a failed write keeps the old one.
+func (s *Store) write(entries []Entry) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+		return fmt.Errorf("create dir: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("encode entry: %v", err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write history: %v", err)
+	}
+
+	// Payloads may hold anything that was clipped, keep them private.
+	if err := tmp.Chmod(0600); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("chmod history: %v", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close history: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replace history: %v", err)
+	}
+
+	return nil
+}
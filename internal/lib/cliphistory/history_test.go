@@ -0,0 +1,99 @@
+package cliphistory
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	store := NewStore(path, 2)
+
+	entries, err := store.List()
+	require.NoError(t, err, "a missing file is an empty history")
+	assert.Empty(t, entries)
+
+	require.NoError(t, store.Add(Origin{Command: CommandFiles, Source: "./a"}, "first"))
+	require.NoError(t, store.Add(Origin{Command: CommandContext, Source: "./b"}, "second\nbody"))
+	require.NoError(t, store.Add(Origin{Command: CommandPreface, Source: "go-review"}, "<third> & more"))
+
+	entries, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "the oldest entry is dropped over the limit")
+	assert.Equal(t, "<third> & more", entries[0].Preview(0))
+	assert.Equal(t, "second", entries[1].Preview(0))
+	assert.Empty(t, entries[0].Payload, "listing doesn't read the payloads")
+
+	entry, err := store.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, "<third> & more", entry.Payload)
+	assert.Equal(t, CommandPreface, entry.Command)
+	assert.Equal(t, "go-review", entry.Source)
+	assert.Equal(t, 14, entry.Bytes)
+	assert.False(t, entry.Time.IsZero())
+
+	_, err = store.Get(3)
+	assert.Error(t, err)
+	_, err = store.Get(0)
+	assert.Error(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	index, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(index), "body", "only the first line of the payloads is kept in the history file")
+
+	payloads, err := os.ReadDir(store.payloadDir())
+	require.NoError(t, err)
+	assert.Len(t, payloads, 2, "the dropped entry's payload is removed")
+}
+
+func TestStore_Inline_Payloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	line := `{"command":"files","source":"./a","time":"2024-01-02T03:04:05Z","bytes":6,"payload":"inline"}` + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(line), 0600))
+
+	store := NewStore(path, 2)
+	require.NoError(t, store.Add(Origin{Command: CommandFiles}, "stored"))
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "stored", entries[0].Preview(0))
+	assert.Equal(t, "inline", entries[1].Preview(0))
+
+	entry, err := store.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, "stored", entry.Payload)
+
+	entry, err = store.Get(2)
+	require.NoError(t, err)
+	assert.Equal(t, "inline", entry.Payload)
+}
+
+func TestStore_Disabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	require.NoError(t, NewStore(path, 1).Add(Origin{Command: CommandFiles}, "kept"))
+
+	store := NewStore(path, 0)
+	require.NoError(t, store.Add(Origin{Command: CommandFiles}, "not recorded"))
+
+	entries, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "kept", entries[0].Preview(0))
+}
+
+func TestEntry_Preview(t *testing.T) {
+	e := Entry{Payload: "\n\n  --- main.go ---\npackage main"}
+	assert.Equal(t, "--- main.go ---", e.Preview(0))
+
+	e = Entry{FirstLine: "--- main.go ---"}
+	assert.Equal(t, "--- main.go ---", e.Preview(0))
+	assert.Equal(t, "--- ma…", e.Preview(7))
+}
@@ -0,0 +1,289 @@
+package copyprofile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/spf13/viper"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// FileName is the profiles file under the app dir, without its extension.
+	// Any extension viper reads works, e.g. "copy-profiles.json".
+	FileName = "copy-profiles"
+
+	// DefaultExt is the extension of a profiles file created by Add.
+	DefaultExt = ".yaml"
+
+	profilesKey = "profiles"
+)
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Profile is a named copy, stored under "profiles.<name>" in the profiles file:
+//
+//	profiles:
+//	  api:
+//	    source: ~/code/api
+//	    destination: /mnt/backup/api
+//	    source_exclusions: [node_modules/]
+//	    sync: true
+//	    hook: make -C /mnt/backup/api test
+type Profile struct {
+	Name string `mapstructure:"-" json:"-"`
+
+	fslib.CopyOptions `mapstructure:",squash"`
+
+	// Hook is a shell command run in the destination after a successful copy.
+	Hook string `mapstructure:"hook" json:"hook"`
+}
+
+// RunOptions tune a profile run.
+type RunOptions struct {
+	// DryRun plans the copy, without copying or running the hook.
+	DryRun bool
+
+	// Stdout and Stderr receive the output of the hook.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Options returns the copy options of the profile, with "~" expanded. When no
+// wipe exclusions are set, the source exclusions protect the destination too.
+func (p *Profile) Options() (fslib.CopyOptions, error) {
+	opts := p.CopyOptions
+
+	var err error
+	if opts.Source, err = expandHome(opts.Source); err != nil {
+		return opts, fmt.Errorf("source: %v", err)
+	}
+	if opts.Destination, err = expandHome(opts.Destination); err != nil {
+		return opts, fmt.Errorf("destination: %v", err)
+	}
+
+	if len(opts.WipeDestinationExclusions) == 0 {
+		opts.WipeDestinationExclusions = opts.SourceExclusions
+	}
+
+	return opts, nil
+}
+
+// RunHook runs the hook of the profile with "sh -c" in the destination. The
+// profile, source and destination are passed in COPY_PROFILE, COPY_SOURCE
+// and COPY_DESTINATION.
+func (p *Profile) RunHook(ctx context.Context, stdout, stderr io.Writer) error {
+	if strings.TrimSpace(p.Hook) == "" {
+		return nil
+	}
+
+	opts, err := p.Options()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", p.Hook)
+	cmd.Dir = opts.Destination
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(),
+		"COPY_PROFILE="+p.Name,
+		"COPY_SOURCE="+opts.Source,
+		"COPY_DESTINATION="+opts.Destination,
+	)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("hook '%s': %v", p.Hook, err)
+	}
+	return nil
+}
+
+// Store reads and writes the profiles file of a directory.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Path is the profiles file in use, or the one Add creates when there is none yet.
+func (s *Store) Path() string {
+	v, err := s.read()
+	if err == nil && v.ConfigFileUsed() != "" {
+		return v.ConfigFileUsed()
+	}
+	return filepath.Join(s.dir, FileName+DefaultExt)
+}
+
+// List returns every profile, sorted by name.
+func (s *Store) List() ([]Profile, error) {
+	v, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var byName map[string]Profile
+	if err := v.UnmarshalKey(profilesKey, &byName); err != nil {
+		return nil, fmt.Errorf("decode profiles: %v", err)
+	}
+
+	profiles := make([]Profile, 0, len(byName))
+	for name, p := range byName {
+		p.Name = name
+		profiles = append(profiles, p)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles, nil
+}
+
+// Get returns the named profile.
+func (s *Store) Get(name string) (*Profile, error) {
+	profiles, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("profile '%s' not found", name)
+}
+
+// Add writes the profile to the profiles file. An existing profile of the
+// same name is only replaced when "overwrite" is set.
+func (s *Store) Add(p *Profile, overwrite bool) error {
+	if p == nil {
+		return errors.New("profile nil")
+	}
+
+	if err := ValidateName(p.Name); err != nil {
+		return err
+	}
+
+	if err := p.CopyOptions.Validate(); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+
+	v, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	key := profilesKey + "." + p.Name
+	if v.IsSet(key) && !overwrite {
+		return fmt.Errorf("profile '%s' already exists", p.Name)
+	}
+
+	values, err := toMap(p)
+	if err != nil {
+		return err
+	}
+	v.Set(key, values)
+
+	path := v.ConfigFileUsed()
+	if path == "" {
+		path = filepath.Join(s.dir, FileName+DefaultExt)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("create dir: %v", err)
+	}
+
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("write profiles: %v", err)
+	}
+
+	return nil
+}
+
+// ValidateName only accepts lowercase names, as viper keys are case-insensitive.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s', use lowercase letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// read loads the profiles file, a missing one reads as empty.
+func (s *Store) read() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigName(FileName)
+	v.AddConfigPath(s.dir)
+
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			return v, nil
+		}
+		return nil, fmt.Errorf("read profiles: %v", err)
+	}
+
+	return v, nil
+}
+
+// toMap keeps the set fields of the profile, so the file only holds what was given.
+func toMap(p *Profile) (map[string]interface{}, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("marshal profile: %v", err)
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil, fmt.Errorf("unmarshal profile: %v", err)
+	}
+
+	for key, value := range values {
+		switch value := value.(type) {
+		case nil:
+			delete(values, key)
+		case bool:
+			if !value {
+				delete(values, key)
+			}
+		case string:
+			if value == "" {
+				delete(values, key)
+			}
+		case float64:
+			if value == 0 {
+				delete(values, key)
+			}
+		case []interface{}:
+			if len(value) == 0 {
+				delete(values, key)
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
@@ -0,0 +1,118 @@
+package copyprofile
+
+import (
+	"bytes"
+	"context"
+	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestStore_Add_List_Get(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(dir)
+
+	profiles, err := store.List()
+	require.NoError(t, err, "a missing file reads as empty")
+	assert.Empty(t, profiles)
+
+	api := &Profile{
+		Name: "api",
+		CopyOptions: fslib.CopyOptions{
+			Source:           "/src/api",
+			Destination:      "/dst/api",
+			SourceExclusions: []string{"node_modules/"},
+			WipeDestination:  true,
+			Sync:             true,
+		},
+		Hook: "make test",
+	}
+	require.NoError(t, store.Add(api, false))
+	require.NoError(t, store.Add(&Profile{Name: "web", CopyOptions: fslib.CopyOptions{Source: "/src/web", Destination: "/dst/web"}}, false))
+	assert.Equal(t, filepath.Join(dir, "copy-profiles.yaml"), store.Path())
+
+	assert.Error(t, store.Add(api, false), "existing profiles are kept")
+	assert.NoError(t, store.Add(api, true))
+
+	profiles, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "api", profiles[0].Name)
+	assert.Equal(t, "web", profiles[1].Name)
+
+	got, err := store.Get("api")
+	require.NoError(t, err)
+	assert.Equal(t, api, got)
+
+	_, err = store.Get("missing")
+	assert.Error(t, err)
+
+	b, err := os.ReadFile(store.Path())
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "dry_run", "unset fields are left out of the file")
+}
+
+func TestStore_Reads_JSON(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "copy-profiles.json"), []byte(`{
+		"profiles": {"docs": {"source": "~/docs", "destination": "/backup/docs", "wipe_destination": true}}
+	}`), 0644))
+
+	store := NewStore(dir)
+	p, err := store.Get("docs")
+	require.NoError(t, err)
+	assert.True(t, p.WipeDestination)
+
+	opts, err := p.Options()
+	require.NoError(t, err)
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "docs"), opts.Source)
+
+	require.NoError(t, store.Add(&Profile{Name: "more", CopyOptions: fslib.CopyOptions{Source: "/a", Destination: "/b"}}, false))
+	assert.Equal(t, filepath.Join(dir, "copy-profiles.json"), store.Path(), "the existing file is kept")
+
+	profiles, err := store.List()
+	require.NoError(t, err)
+	assert.Len(t, profiles, 2)
+}
+
+func TestStore_Add_Invalid(t *testing.T) {
+	store := NewStore(t.TempDir())
+	assert.Error(t, store.Add(&Profile{Name: "Bad Name", CopyOptions: fslib.CopyOptions{Source: "/a", Destination: "/b"}}, false))
+	assert.Error(t, store.Add(&Profile{Name: "ok"}, false), "source and destination are required")
+}
+
+func TestProfile_Options_Defaults_Wipe_Exclusions(t *testing.T) {
+	p := &Profile{CopyOptions: fslib.CopyOptions{Source: "/a", Destination: "/b", SourceExclusions: []string{".env"}}}
+	opts, err := p.Options()
+	require.NoError(t, err)
+	assert.Equal(t, []string{".env"}, opts.WipeDestinationExclusions)
+}
+
+func TestProfile_RunHook(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	dst := t.TempDir()
+	p := &Profile{
+		Name:        "api",
+		CopyOptions: fslib.CopyOptions{Source: "/src", Destination: dst},
+		Hook:        `echo "$COPY_PROFILE $COPY_SOURCE $(pwd)"`,
+	}
+
+	var stdout bytes.Buffer
+	require.NoError(t, p.RunHook(context.Background(), &stdout, &stdout))
+
+	wd, err := filepath.EvalSymlinks(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "api /src "+wd+"\n", stdout.String())
+
+	p.Hook = "exit 3"
+	assert.Error(t, p.RunHook(context.Background(), &stdout, &stdout))
+}
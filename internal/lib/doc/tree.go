@@ -0,0 +1,114 @@
+package doc
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+// TreeEntry is a file shown in a tree, with its slash separated path
+// relative to the tree root.
+type TreeEntry struct {
+	Path  string
+	Size  int64
+	Lines int
+}
+
+type treeNode struct {
+	name     string
+	entry    *TreeEntry
+	children map[string]*treeNode
+}
+
+func (n *treeNode) child(name string) *treeNode {
+	if n.children == nil {
+		n.children = make(map[string]*treeNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &treeNode{name: name}
+		n.children[name] = c
+	}
+	return c
+}
+
+// sortedChildren lists directories first, then files, each alphabetically.
+func (n *treeNode) sortedChildren() []*treeNode {
+	children := make([]*treeNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool {
+		iDir, jDir := children[i].entry == nil, children[j].entry == nil
+		if iDir != jDir {
+			return iDir
+		}
+		return children[i].name < children[j].name
+	})
+	return children
+}
+
+// GetAsTree renders the entries as an ASCII tree similar to the "tree" command,
+// annotating each file with its size and line count.
+func GetAsTree(root string, entries []TreeEntry) string {
+	if root == "" {
+		root = "."
+	}
+
+	tree := &treeNode{name: root}
+	for i := range entries {
+		p := strings.Trim(path.Clean("/"+entries[i].Path), "/")
+		if p == "" {
+			continue
+		}
+
+		node := tree
+		for _, segment := range strings.Split(p, "/") {
+			node = node.child(segment)
+		}
+		node.entry = &entries[i]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(root + "\n")
+	writeTree(&sb, tree, "")
+
+	dirs, files := countTree(tree)
+	sb.WriteString(fmt.Sprintf("\n%d directories, %d files\n", dirs, files))
+
+	return sb.String()
+}
+
+func writeTree(sb *strings.Builder, node *treeNode, prefix string) {
+	children := node.sortedChildren()
+	for i, c := range children {
+		connector, nextPrefix := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, nextPrefix = "└── ", "    "
+		}
+
+		sb.WriteString(prefix + connector)
+		if c.entry == nil {
+			sb.WriteString(c.name + "/\n")
+			writeTree(sb, c, prefix+nextPrefix)
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("%s (%s, %d lines)\n", c.name, strutil.FormatBytes(c.entry.Size), c.entry.Lines))
+	}
+}
+
+func countTree(node *treeNode) (dirs int, files int) {
+	for _, c := range node.children {
+		if c.entry != nil {
+			files++
+			continue
+		}
+		d, f := countTree(c)
+		dirs += d + 1
+		files += f
+	}
+	return dirs, files
+}
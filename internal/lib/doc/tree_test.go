@@ -0,0 +1,33 @@
+package doc
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetAsTree(t *testing.T) {
+	entries := []TreeEntry{
+		{Path: "readme.md", Size: 120, Lines: 8},
+		{Path: "cmd/cli/root.go", Size: 2048, Lines: 60},
+		{Path: "cmd/api/main.go", Size: 300, Lines: 20},
+		{Path: "go.mod", Size: 50, Lines: 3},
+	}
+
+	expected := `.
+├── cmd/
+│   ├── api/
+│   │   └── main.go (300 B, 20 lines)
+│   └── cli/
+│       └── root.go (2.0 KiB, 60 lines)
+├── go.mod (50 B, 3 lines)
+└── readme.md (120 B, 8 lines)
+
+3 directories, 4 files
+`
+
+	assert.Equal(t, expected, GetAsTree("", entries))
+}
+
+func TestGetAsTree_Empty(t *testing.T) {
+	assert.Equal(t, "src\n\n0 directories, 0 files\n", GetAsTree("src", nil))
+}
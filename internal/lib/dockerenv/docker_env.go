@@ -226,7 +226,7 @@ func (dm *DockerEnv) waitForLogMessage(ctx context.Context, containerID, message
 
 func (dm *DockerEnv) waitForPort(ctx context.Context, host string, port int, timeout time.Duration) error {
 	deadline := time.Now().Add(timeout)
-	target := fmt.Sprintf("%s:%d", host, port)
+	target := net.JoinHostPort(host, strconv.Itoa(port))
 	for time.Now().Before(deadline) {
 		conn, err := net.DialTimeout("tcp", target, time.Second)
 		if err == nil {
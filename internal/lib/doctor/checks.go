@@ -0,0 +1,214 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"io/fs"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// Env checks the env vars with the prefix, warning about the ones nothing
+// reads, likely typos. Values are never printed, as they hold passwords.
+func Env(environ []string, prefix string, known []string) Check {
+	return Check{
+		Name: "env",
+		Run: func(ctx context.Context) Result {
+			isKnown := make(map[string]bool, len(known))
+			for _, key := range known {
+				isKnown[key] = true
+			}
+
+			set, unknown := 0, make([]string, 0)
+			for _, kv := range environ {
+				key, _, _ := strings.Cut(kv, "=")
+				if !strings.HasPrefix(key, prefix) {
+					continue
+				}
+				set++
+				if !isKnown[key] {
+					unknown = append(unknown, key)
+				}
+			}
+
+			if len(unknown) > 0 {
+				sort.Strings(unknown)
+
+				hints := make([]string, 0, len(unknown))
+				for _, key := range unknown {
+					if closest := closestKey(key, known); closest != "" {
+						hints = append(hints, fmt.Sprintf("%s is likely %s", key, closest))
+					}
+				}
+				hint := "check them for typos"
+				if len(hints) > 0 {
+					hint = strings.Join(hints, ", ")
+				}
+
+				return warn(fmt.Sprintf("%d %s* var(s) set, not used: %s", set, prefix, strings.Join(unknown, ", ")), hint)
+			}
+
+			if set == 0 {
+				return ok(fmt.Sprintf("no %s* vars set, using the defaults", prefix))
+			}
+			return ok(fmt.Sprintf("%d %s* var(s) set", set, prefix))
+		},
+	}
+}
+
+// Config checks the config loads and validates.
+func Config(load func() error) Check {
+	return Check{
+		Name: "config",
+		Run: func(ctx context.Context) Result {
+			if err := load(); err != nil {
+				return fail(err.Error(), "fix the env vars named in the error, every command but this one needs a valid config")
+			}
+			return ok("loaded")
+		},
+	}
+}
+
+// Dir checks the directory exists, or can be created, and is writable.
+func Dir(name, path string) Check {
+	return Check{
+		Name: name,
+		Run: func(ctx context.Context) Result {
+			if strings.TrimSpace(path) == "" {
+				return fail("not set", "")
+			}
+
+			info, err := os.Stat(path)
+			if errors.Is(err, fs.ErrNotExist) {
+				return warn(fmt.Sprintf("'%s' does not exist yet", path), fmt.Sprintf("it is created on the first write, or run: mkdir -p '%s'", path))
+			}
+			if err != nil {
+				return fail(err.Error(), "")
+			}
+			if !info.IsDir() {
+				return fail(fmt.Sprintf("'%s' is not a directory", path), "point it to a directory")
+			}
+
+			f, err := os.CreateTemp(path, ".doctor-*")
+			if err != nil {
+				return fail(fmt.Sprintf("'%s' is not writable: %v", path, err), "fix its permissions, or point it to another directory")
+			}
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+
+			return ok(fmt.Sprintf("'%s' is writable", path))
+		},
+	}
+}
+
+// Clipboard checks which clipboard backend copies go to.
+func Clipboard(opts *clipboard.Options) Check {
+	return Check{
+		Name: "clipboard",
+		Run: func(ctx context.Context) Result {
+			if opts == nil {
+				opts = &clipboard.Options{}
+			}
+
+			name, err := clipboard.Detect(opts)
+			if err != nil {
+				return fail(err.Error(), fmt.Sprintf("pick another backend with --clipboard, one of: %s", strings.Join(clipboard.Names(), ", ")))
+			}
+
+			if name == clipboard.File && opts.Backend != clipboard.File {
+				return warn(
+					fmt.Sprintf("no clipboard found, copies go to '%s'", opts.File),
+					"install xclip, xsel or wl-clipboard and run with a display, use a terminal with OSC 52 support, or run inside tmux",
+				)
+			}
+			return ok(fmt.Sprintf("copies go to the %s backend", name))
+		},
+	}
+}
+
+// Database checks the database answers a ping. It only warns, as only the API
+// needs it.
+func Database(target string, ping func(ctx context.Context) error) Check {
+	return Check{
+		Name: "database",
+		Run: func(ctx context.Context) Result {
+			if err := ping(ctx); err != nil {
+				return warn(
+					fmt.Sprintf("%s is not reachable: %v", target, err),
+					"start it with \"sh ./scripts/docker-start\", or fix the DB_* env vars; only the API needs it",
+				)
+			}
+			return ok(fmt.Sprintf("%s is reachable", target))
+		},
+	}
+}
+
+// Docker checks the docker daemon answers. It only warns, as only the
+// dockerized env and the database tests need it.
+func Docker() Check {
+	return Check{
+		Name: "docker",
+		Run: func(ctx context.Context) Result {
+			if _, err := exec.LookPath("docker"); err != nil {
+				return warn("docker is not installed", "install it from https://docs.docker.com/engine/install/")
+			}
+
+			out, err := exec.CommandContext(ctx, "docker", "info", "--format", "{{.ServerVersion}}").CombinedOutput()
+			if err != nil {
+				detail := strings.TrimSpace(string(out))
+				if detail == "" {
+					detail = err.Error()
+				}
+				return warn(fmt.Sprintf("the daemon is not reachable: %s", firstLine(detail)), "start Docker, and check your user can reach its socket")
+			}
+
+			return ok(fmt.Sprintf("daemon %s is running", strings.TrimSpace(string(out))))
+		},
+	}
+}
+
+// closestKey returns the known key a typo was likely meant for, within a few
+// edits, or an empty string.
+func closestKey(key string, known []string) string {
+	const maxEdits = 3
+
+	closest, best := "", maxEdits+1
+	for _, k := range known {
+		if d := editDistance(key, k); d < best {
+			closest, best = k, d
+		}
+	}
+	return closest
+}
+
+// editDistance is the Levenshtein distance of the strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,97 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Result is the outcome of a check, with a hint on how to fix it when it did not pass.
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+// Check inspects one part of the environment. Run should give up when the
+// context is done.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) Result
+}
+
+func ok(detail string) Result {
+	return Result{Status: StatusOK, Detail: detail}
+}
+
+func warn(detail, hint string) Result {
+	return Result{Status: StatusWarn, Detail: detail, Hint: hint}
+}
+
+func fail(detail, hint string) Result {
+	return Result{Status: StatusFail, Detail: detail, Hint: hint}
+}
+
+// Run runs the checks in order, each within the timeout. A check that does
+// not return in time fails.
+func Run(ctx context.Context, timeout time.Duration, checks ...Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		result := runCheck(ctx, timeout, check)
+		result.Name = check.Name
+		results = append(results, result)
+	}
+	return results
+}
+
+func runCheck(ctx context.Context, timeout time.Duration, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan Result, 1)
+	go func() {
+		done <- check.Run(ctx)
+	}()
+
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return fail(fmt.Sprintf("timed out after %s", timeout), "")
+	}
+}
+
+// Failed reports whether any of the checks failed, warnings aside.
+func Failed(results []Result) bool {
+	for _, result := range results {
+		if result.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Write prints one line per result, followed by its hint.
+func Write(w io.Writer, results []Result) error {
+	var sb strings.Builder
+	for _, result := range results {
+		sb.WriteString(fmt.Sprintf("[%-4s] %s: %s\n", result.Status, result.Name, result.Detail))
+		if result.Hint != "" {
+			sb.WriteString(fmt.Sprintf("       -> %s\n", result.Hint))
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
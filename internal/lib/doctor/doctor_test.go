@@ -0,0 +1,78 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	checks := []Check{
+		{Name: "fine", Run: func(ctx context.Context) Result { return ok("all good") }},
+		{Name: "slow", Run: func(ctx context.Context) Result {
+			time.Sleep(time.Second)
+			return ok("too late")
+		}},
+		{Name: "meh", Run: func(ctx context.Context) Result { return warn("so so", "do this") }},
+	}
+
+	results := Run(context.Background(), 50*time.Millisecond, checks...)
+	require.Len(t, results, 3)
+	assert.Equal(t, Result{Name: "fine", Status: StatusOK, Detail: "all good"}, results[0])
+	assert.Equal(t, StatusFail, results[1].Status)
+	assert.Contains(t, results[1].Detail, "timed out")
+	assert.True(t, Failed(results))
+	assert.False(t, Failed(results[2:]), "warnings do not fail")
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, results[2:]))
+	assert.Equal(t, "[warn] meh: so so\n       -> do this\n", buf.String())
+}
+
+func TestEnv(t *testing.T) {
+	check := Env([]string{"PATH=/bin", "APP_DIR=/x", "APP_DB_PASS=secret", "APP_DB_PAS=secret"}, "APP_", []string{"APP_DIR", "APP_DB_PASS"})
+
+	result := check.Run(context.Background())
+	assert.Equal(t, StatusWarn, result.Status)
+	assert.Contains(t, result.Detail, "3 APP_* var(s) set, not used: APP_DB_PAS")
+	assert.Equal(t, "APP_DB_PAS is likely APP_DB_PASS", result.Hint)
+	assert.NotContains(t, result.Detail+result.Hint, "secret", "values are never printed")
+
+	result = Env([]string{"PATH=/bin"}, "APP_", nil).Run(context.Background())
+	assert.Equal(t, StatusOK, result.Status)
+}
+
+func TestConfig(t *testing.T) {
+	assert.Equal(t, StatusOK, Config(func() error { return nil }).Run(context.Background()).Status)
+	assert.Equal(t, StatusFail, Config(func() error { return errors.New("bad port") }).Run(context.Background()).Status)
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, StatusOK, Dir("app dir", dir).Run(context.Background()).Status)
+	assert.Equal(t, StatusWarn, Dir("app dir", filepath.Join(dir, "missing")).Run(context.Background()).Status)
+	assert.Equal(t, StatusFail, Dir("app dir", "").Run(context.Background()).Status)
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+	assert.Equal(t, StatusFail, Dir("app dir", file).Run(context.Background()).Status)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the write probe is removed")
+}
+
+func TestDatabase(t *testing.T) {
+	result := Database("db", func(ctx context.Context) error { return errors.New("refused") }).Run(context.Background())
+	assert.Equal(t, StatusWarn, result.Status, "only the API needs the database")
+	assert.Contains(t, result.Detail, "refused")
+
+	result = Database("db", func(ctx context.Context) error { return nil }).Run(context.Background())
+	assert.Equal(t, StatusOK, result.Status)
+}
@@ -0,0 +1,111 @@
+package fslib
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+)
+
+// applyPlan creates the planned directories, copies the planned creates and
+// overwrites with a pool of workers, then carries out the planned deletes when syncing. Directories emptied by
+// the deletes, that do not exist in the source, are removed as well.
+//
+// Failures do not stop the copy, they are recorded per file in the plan.
+func applyPlan(opts *CopyOptions, plan *CopyPlan) {
+	copies := make([]CopyAction, 0, len(plan.Actions))
+	deletes := make([]CopyAction, 0)
+	for _, action := range plan.Actions {
+		switch action.Op {
+		case OpCreate, OpOverwrite:
+			copies = append(copies, action)
+		case OpDelete:
+			deletes = append(deletes, action)
+		}
+	}
+
+	for _, dir := range plan.Dirs {
+		if err := mkdirs(opts.Source, opts.Destination, filepath.FromSlash(dir)); err != nil {
+			plan.Failures = append(plan.Failures, CopyFailure{Path: dir, Op: OpCreate, Err: err.Error()})
+		}
+	}
+
+	plan.Failures = append(plan.Failures, runPool(opts.Concurrency, copies, func(action CopyAction) error {
+		rel := filepath.FromSlash(action.Path)
+		if err := mkdirParents(opts.Source, opts.Destination, rel); err != nil {
+			return fmt.Errorf("create parents: %w", err)
+		}
+		return copyEntry(filepath.Join(opts.Source, rel), filepath.Join(opts.Destination, rel))
+	})...)
+
+	if !opts.Sync {
+		return
+	}
+
+	emptied := make(map[string]bool)
+	for _, action := range deletes {
+		rel := filepath.FromSlash(action.Path)
+		if err := os.Remove(filepath.Join(opts.Destination, rel)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			plan.Failures = append(plan.Failures, CopyFailure{Path: action.Path, Op: action.Op, Err: err.Error()})
+			continue
+		}
+		for dir := filepath.Dir(rel); dir != "."; dir = filepath.Dir(dir) {
+			emptied[dir] = true
+		}
+	}
+
+	// Remove the deepest directories first, so their parents can empty out too.
+	dirs := make([]string, 0, len(emptied))
+	for dir := range emptied {
+		dirs = append(dirs, dir)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		return len(dirs[i]) > len(dirs[j])
+	})
+
+	for _, dir := range dirs {
+		if _, err := os.Lstat(filepath.Join(opts.Source, dir)); err == nil {
+			continue
+		}
+		err := os.Remove(filepath.Join(opts.Destination, dir))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, syscall.ENOTEMPTY) && !errors.Is(err, syscall.EEXIST) {
+			plan.Failures = append(plan.Failures, CopyFailure{Path: filepath.ToSlash(dir), Op: OpDelete, Err: err.Error()})
+		}
+	}
+}
+
+// mkdirParents creates the destination directories of the file, with the
+// permissions of their source counterparts. It is safe to call concurrently.
+func mkdirParents(src, dst, rel string) error {
+	return mkdirs(src, dst, filepath.Dir(rel))
+}
+
+// mkdirs creates the destination directory and its parents, with the
+// permissions of their source counterparts.
+func mkdirs(src, dst, dir string) error {
+	if dir == "." {
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		return os.MkdirAll(dst, info.Mode().Perm())
+	}
+
+	if err := mkdirs(src, dst, filepath.Dir(dir)); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(filepath.Join(src, dir))
+	if err != nil {
+		return err
+	}
+
+	err = os.Mkdir(filepath.Join(dst, dir), info.Mode().Perm())
+	if err != nil && !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package fslib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testCounts(plan *CopyPlan) [4]int {
+	var counts [4]int
+	for i, op := range []CopyOp{OpCreate, OpOverwrite, OpDelete, OpUnchanged} {
+		counts[i], _ = plan.Count(op)
+	}
+	return counts
+}
+
+func TestCopyDirToAnother_Sync(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{
+		"a.txt":     "aaa",
+		"b/b.txt":   "bbb",
+		"c/d/c.txt": "ccc",
+	})
+
+	opts := &CopyOptions{Source: src, Destination: dst, Sync: true}
+
+	plan, err := CopyDirToAnother(opts)
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{3, 0, 0, 0}, testCounts(plan), "added, updated, deleted, unchanged")
+
+	plan, err = CopyDirToAnother(opts)
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{0, 0, 0, 3}, testCounts(plan), "mtimes are kept, so nothing changed")
+
+	// Change a file, and remove another along with its directories.
+	testWriteTree(t, src, map[string]string{"a.txt": "changed"})
+	require.NoError(t, os.RemoveAll(filepath.Join(src, "c")))
+	testWriteTree(t, dst, map[string]string{"c/local.txt": "x"})
+
+	plan, err = CopyDirToAnother(opts)
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{0, 1, 2, 1}, testCounts(plan))
+
+	b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "changed", string(b))
+	assert.NoDirExists(t, filepath.Join(dst, "c"), "emptied directories gone from the source are removed")
+	assert.FileExists(t, filepath.Join(dst, "b", "b.txt"))
+}
+
+func TestCopyDirToAnother_Empty_Dirs(t *testing.T) {
+	for name, opts := range map[string]CopyOptions{
+		"copy":   {},
+		"sync":   {Sync: true},
+		"mirror": {WipeDestination: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			src, dst := t.TempDir(), t.TempDir()
+			testWriteTree(t, src, map[string]string{"a.txt": "aaa"})
+			require.NoError(t, os.MkdirAll(filepath.Join(src, "empty", "nested"), 0750))
+			testWriteTree(t, dst, map[string]string{"empty/stale.txt": "x"})
+
+			opts.Source, opts.Destination = src, dst
+			plan, err := CopyDirToAnother(&opts)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"empty", "empty/nested"}, plan.Dirs)
+			assert.Empty(t, plan.Failures)
+
+			info, err := os.Stat(filepath.Join(dst, "empty", "nested"))
+			require.NoError(t, err, "empty directories are copied")
+			assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+		})
+	}
+}
+
+func TestCopyDirToAnother_Sync_Checksum(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "same", "b.txt": "new!"})
+	testWriteTree(t, dst, map[string]string{"a.txt": "same", "b.txt": "old!"})
+
+	// Same sizes, different mtimes: only the content tells them apart.
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dst, "a.txt"), past, past))
+	require.NoError(t, os.Chtimes(filepath.Join(dst, "b.txt"), past, past))
+
+	plan, err := PlanCopy(&CopyOptions{Source: src, Destination: dst, Sync: true})
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{0, 2, 0, 0}, testCounts(plan), "mtimes differ")
+
+	plan, err = CopyDirToAnother(&CopyOptions{Source: src, Destination: dst, Sync: true, Checksum: true})
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{0, 1, 0, 1}, testCounts(plan))
+	assert.Equal(t, OpOverwrite, plan.Actions[1].Op)
+
+	b, err := os.ReadFile(filepath.Join(dst, "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "new!", string(b))
+}
+
+func TestCopyDirToAnother_Sync_Keeps_Excluded(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "a"})
+	testWriteTree(t, dst, map[string]string{".git/HEAD": "ref"})
+
+	plan, err := CopyDirToAnother(&CopyOptions{
+		Source:                    src,
+		Destination:               dst,
+		Sync:                      true,
+		WipeDestinationExclusions: []string{".git"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{1, 0, 0, 0}, testCounts(plan))
+	assert.FileExists(t, filepath.Join(dst, ".git", "HEAD"))
+}
@@ -0,0 +1,161 @@
+package fslib
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// CopyFailure is a planned action that could not be carried out.
+type CopyFailure struct {
+	Path string `json:"path"`
+	Op   CopyOp `json:"op"`
+	Err  string `json:"err"`
+}
+
+// runPool runs "fn" on every action with "concurrency" workers (the number of
+// CPUs when not positive), and returns the failures sorted by path.
+func runPool(concurrency int, actions []CopyAction, fn func(action CopyAction) error) []CopyFailure {
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []CopyFailure
+		jobs     = make(chan CopyAction)
+	)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for action := range jobs {
+				if err := fn(action); err != nil {
+					mu.Lock()
+					failures = append(failures, CopyFailure{Path: action.Path, Op: action.Op, Err: err.Error()})
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, action := range actions {
+		jobs <- action
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Path < failures[j].Path
+	})
+
+	return failures
+}
+
+// copyEntry copies a regular file or recreates a symlink. The destination is
+// written under a temporary name then renamed over, so it is never left truncated.
+func copyEntry(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&fs.ModeSymlink != 0:
+		return copySymlink(src, dst, info)
+	case info.Mode().IsRegular():
+		return copyRegular(src, dst, info)
+	default:
+		return fmt.Errorf("unsupported file type '%s'", info.Mode().Type())
+	}
+}
+
+// copyRegular copies the content, permissions, modification time and, where
+// possible, ownership and extended attributes of the file.
+func copyRegular(src, dst string, info fs.FileInfo) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = io.Copy(tmp, in); err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	if err = tmp.Chmod(info.Mode().Perm()); err != nil {
+		return fmt.Errorf("chmod: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("close: %w", err)
+	}
+
+	preserveOwner(tmp.Name(), info)
+	copyXattrs(src, tmp.Name())
+
+	if err = os.Chtimes(tmp.Name(), info.ModTime(), info.ModTime()); err != nil {
+		return fmt.Errorf("chtimes: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
+
+// copySymlink recreates the symlink with the same target, instead of copying
+// what it points to.
+func copySymlink(src, dst string, info fs.FileInfo) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+
+	// Reserve a unique name next to the destination, then swap it for the link.
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp: %w", err)
+	}
+	tmpName := tmp.Name()
+	_ = tmp.Close()
+	if err := os.Remove(tmpName); err != nil {
+		return fmt.Errorf("remove temp: %w", err)
+	}
+
+	if err := os.Symlink(target, tmpName); err != nil {
+		return fmt.Errorf("symlink: %w", err)
+	}
+
+	preserveOwner(tmpName, info)
+
+	if err := os.Rename(tmpName, dst); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package fslib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCopyDirToAnother_Preserves_Metadata(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"bin/run.sh": "#!/bin/sh", "a.txt": "a"})
+	require.NoError(t, os.Chmod(filepath.Join(src, "bin", "run.sh"), 0750))
+	require.NoError(t, os.Symlink("bin/run.sh", filepath.Join(src, "run")))
+
+	past := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(filepath.Join(src, "a.txt"), past, past))
+
+	plan, err := CopyDirToAnother(&CopyOptions{Source: src, Destination: dst, Concurrency: 2})
+	require.NoError(t, err)
+	assert.Empty(t, plan.Failures)
+
+	target, err := os.Readlink(filepath.Join(dst, "run"))
+	require.NoError(t, err, "symlinks are recreated, not followed")
+	assert.Equal(t, "bin/run.sh", target)
+
+	info, err := os.Stat(filepath.Join(dst, "bin", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(dst, "a.txt"))
+	require.NoError(t, err)
+	assert.True(t, past.Equal(info.ModTime()), "mtime is kept")
+
+	entries, err := os.ReadDir(dst)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "no temp files are left behind")
+}
+
+func TestCopyDirToAnother_Reports_Failures(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"})
+
+	// A directory in the way of a file cannot be renamed over.
+	require.NoError(t, os.MkdirAll(filepath.Join(dst, "b.txt", "x"), 0755))
+
+	plan, err := CopyDirToAnother(&CopyOptions{Source: src, Destination: dst})
+	require.NoError(t, err, "failures do not abort the copy")
+	require.Len(t, plan.Failures, 1)
+	assert.Equal(t, "b.txt", plan.Failures[0].Path)
+	assert.Contains(t, plan.Summary(), "failed 1 file(s)")
+
+	assert.FileExists(t, filepath.Join(dst, "a.txt"))
+	assert.FileExists(t, filepath.Join(dst, "c.txt"))
+}
+
+func TestCopyDirToAnother_Sync_Symlink_Target(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "a", "b.txt": "b"})
+	require.NoError(t, os.Symlink("a.txt", filepath.Join(src, "link")))
+
+	opts := &CopyOptions{Source: src, Destination: dst, Sync: true}
+	_, err := CopyDirToAnother(opts)
+	require.NoError(t, err)
+
+	plan, err := PlanCopy(opts)
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{0, 0, 0, 3}, testCounts(plan))
+
+	require.NoError(t, os.Remove(filepath.Join(src, "link")))
+	require.NoError(t, os.Symlink("b.txt", filepath.Join(src, "link")))
+
+	plan, err = CopyDirToAnother(opts)
+	require.NoError(t, err)
+	assert.Equal(t, [4]int{0, 1, 0, 2}, testCounts(plan), "a new target is an update")
+
+	target, err := os.Readlink(filepath.Join(dst, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "b.txt", target)
+}
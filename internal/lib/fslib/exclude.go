@@ -0,0 +1,62 @@
+package fslib
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/pathmatch"
+	"io/fs"
+	"path/filepath"
+)
+
+// IgnoreFile in the source root holds gitignore-style rules keeping paths out
+// of the copy, e.g. "**/*.log", ".env.*" or "!.env.example".
+const IgnoreFile = ".copyignore"
+
+// exclusions keep paths out of the copy, and out of the destination wipe.
+// Paths are matched relative to their own root.
+type exclusions struct {
+	source      *pathmatch.Matcher
+	destination *pathmatch.Matcher
+}
+
+// loadExclusions compiles the rules of the source's IgnoreFile, then of the
+// ExcludeFrom files, then the option patterns. As with git, the last matching
+// rule wins, so later rules can negate earlier ones. The IgnoreFile itself is
+// excluded first, so "!/.copyignore" copies it.
+func loadExclusions(opts *CopyOptions) (*exclusions, error) {
+	var lines []string
+
+	ignored, err := pathmatch.ReadFile(filepath.Join(opts.Source, IgnoreFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("ignore file: %w", err)
+	}
+	lines = append(lines, ignored...)
+
+	for _, file := range opts.ExcludeFrom {
+		excluded, err := pathmatch.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("exclude from: %w", err)
+		}
+		lines = append(lines, excluded...)
+	}
+
+	source := pathmatch.New(append([]string{"/" + IgnoreFile}, lines...)...)
+	source.AddLines(opts.SourceExclusions, "")
+
+	destination := pathmatch.New(lines...)
+	destination.AddLines(opts.WipeDestinationExclusions, "")
+
+	return &exclusions{source: source, destination: destination}, nil
+}
+
+// excluded reports whether the path below "root" is matched by "m".
+func excluded(m *pathmatch.Matcher, root, path string, isDir bool) (bool, error) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false, err
+	}
+	if rel == "." {
+		return false, nil
+	}
+	return m.Excluded(rel, isDir), nil
+}
@@ -2,13 +2,14 @@ package fslib
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/lib/pathmatch"
 	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
-	"io"
 	"os"
 	"path/filepath"
-	"strings"
+	"syscall"
 )
 
 var (
@@ -16,34 +17,65 @@ var (
 )
 
 type CopyOptions struct {
-	Source                    string   `mapstructure:"source" validate:"required" json:"source"`
-	SourceExclusions          []string `mapstructure:"source_exclusions" json:"source_exclusions"`
-	Destination               string   `mapstructure:"destination" validate:"required" json:"destination"`
+	Source string `mapstructure:"source" validate:"required" json:"source"`
+
+	// SourceExclusions are gitignore-style patterns, relative to the source,
+	// of paths left out of the copy. An IgnoreFile in the source adds to them.
+	SourceExclusions []string `mapstructure:"source_exclusions" json:"source_exclusions"`
+	Destination      string   `mapstructure:"destination" validate:"required" json:"destination"`
+
+	// ExcludeFrom lists ignore files whose patterns extend both exclusion lists.
+	ExcludeFrom []string `mapstructure:"exclude_from" json:"exclude_from"`
+
+	// WipeDestination mirrors the source, by clearing the destination
+	// (except WipeDestinationExclusions) before copying. With Sync, the
+	// destination is mirrored by deleting only what is missing from the source.
 	WipeDestination           bool     `mapstructure:"wipe_destination" json:"wipe_destination"`
 	WipeDestinationExclusions []string `mapstructure:"wipe_destination_exclusions" json:"wipe_destination_exclusions"`
+
+	// DryRun only plans the copy, without changing anything.
+	DryRun bool `mapstructure:"dry_run" json:"dry_run"`
+
+	// Sync only copies files that differ from the destination, and deletes
+	// the destination files that no longer exist in the source.
+	Sync bool `mapstructure:"sync" json:"sync"`
+
+	// Checksum makes Sync compare file contents, instead of sizes and modification times.
+	Checksum bool `mapstructure:"checksum" json:"checksum"`
+
+	// Concurrency is the number of files copied at once, defaults to the number of CPUs.
+	Concurrency int `mapstructure:"concurrency" json:"concurrency"`
 }
 
 func (c *CopyOptions) Validate() error {
 	return validationutils.Validate(c)
 }
 
-func wipeDestinationDir(destination string, exclusions []string) ([]string, error) {
+func wipeDestinationDir(destination string, exclusions *pathmatch.Matcher) ([]string, error) {
 	var pathsToDelete []string
 
 	err := filepath.Walk(destination, func(path string, info os.FileInfo, err error) error {
+		if err != nil && path == destination && errors.Is(err, os.ErrNotExist) {
+			return nil // Nothing to wipe yet
+		}
 		if err != nil {
 			return fmt.Errorf("error accessing path %q: %v", path, err)
 		}
 
-		skip := false
-		for _, exclusion := range exclusions {
-			if strings.HasSuffix(path, exclusion) {
-				skip = true
-				break // Skip the excluded path
+		isExcluded, err := excluded(exclusions, destination, path, info.IsDir())
+		if err != nil {
+			return err
+		}
+
+		if isExcluded {
+			// Keep everything below an excluded directory too.
+			if info.IsDir() {
+				return filepath.SkipDir
 			}
+			return nil
 		}
 
-		if !skip && path != destination {
+		if path != destination {
 			pathsToDelete = append(pathsToDelete, path)
 		}
 		return nil
@@ -56,116 +88,72 @@ func wipeDestinationDir(destination string, exclusions []string) ([]string, erro
 	return pathsToDelete, nil
 }
 
+// deletePaths removes the paths, listed parents first, in reverse so children
+// go before their directories. Directories still holding excluded paths are kept.
 func deletePaths(paths []string) (int, error) {
 	deletedCount := 0
-	for _, path := range paths {
-		if err := os.RemoveAll(path); err != nil {
-			return deletedCount, fmt.Errorf("failed to remove %s: %v", path, err)
+	for i := len(paths) - 1; i >= 0; i-- {
+		err := os.Remove(paths[i])
+		if errors.Is(err, syscall.ENOTEMPTY) || errors.Is(err, syscall.EEXIST) {
+			continue
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return deletedCount, fmt.Errorf("failed to remove %s: %v", paths[i], err)
 		}
 		deletedCount++
 	}
 	return deletedCount, nil
 }
 
-func CopyDirToAnother(opts *CopyOptions) error {
-	totalDeleted := 0
-	totalAdded := 0
-
-	if opts.WipeDestination {
-		pathsToDelete, err := wipeDestinationDir(opts.Destination, opts.WipeDestinationExclusions)
-		if err != nil {
-			return fmt.Errorf("error calculating paths to delete: %w", err)
-		}
-
-		deletedCount, err := deletePaths(pathsToDelete)
-		if err != nil {
-			return fmt.Errorf("error deleting paths: %w", err)
-		}
-		totalDeleted = deletedCount
-	}
-
-	addedCount, err := copyDir(opts.Source, opts.Destination, opts.SourceExclusions)
-	if err != nil {
-		return fmt.Errorf("copy dir: %w", err)
-	}
-	totalAdded = addedCount
-
-	log.Info("totalDeleted:", totalDeleted)
-	log.Info("totalAdded:", totalAdded)
-
-	return nil
-}
-
-func copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
+// CopyDirToAnother copies the source into the destination, and returns the plan
+// of what changed. With DryRun set, the plan is returned without changing anything.
+//
+// Files are copied by a pool of Concurrency workers. Files that fail are listed
+// in the plan's Failures, instead of stopping the copy.
+func CopyDirToAnother(opts *CopyOptions) (*CopyPlan, error) {
+	if opts == nil {
+		return nil, errors.New("opts nil")
 	}
-	defer srcFile.Close()
 
-	dstFile, err := os.Create(dst)
+	ex, err := loadExclusions(opts)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("exclusions: %w", err)
 	}
-	defer dstFile.Close()
-
-	if _, err := io.Copy(dstFile, srcFile); err != nil {
-		return err
-	}
-
-	srcInfo, err := os.Stat(src)
-	if err != nil {
-		return err
-	}
-
-	return os.Chmod(dst, srcInfo.Mode())
-}
 
-func copyDir(src, dst string, exclusions []string) (int, error) {
-	addedCount := 0
-	srcInfo, err := os.Stat(src)
+	plan, err := planCopy(opts, ex)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("plan: %w", err)
 	}
 
-	if !srcInfo.IsDir() {
-		return 0, fmt.Errorf("source is not a directory")
+	if opts.DryRun {
+		return plan, nil
 	}
 
-	for _, exclusion := range exclusions {
-		if strings.HasSuffix(src, exclusion) {
-			return 0, nil // Skip the excluded directory
+	if opts.WipeDestination && !opts.Sync {
+		pathsToDelete, err := wipeDestinationDir(opts.Destination, ex.destination)
+		if err != nil {
+			return nil, fmt.Errorf("error calculating paths to delete: %w", err)
 		}
-	}
 
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
-		return addedCount, err
+		deletedCount, err := deletePaths(pathsToDelete)
+		if err != nil {
+			return nil, fmt.Errorf("error deleting paths: %w", err)
+		}
+		log.Debugf("removed %d paths", deletedCount)
 	}
-	addedCount++
 
-	entries, err := os.ReadDir(src)
-	if err != nil {
-		return addedCount, err
-	}
+	applyPlan(opts, plan)
+	logPlan(plan)
 
-	for _, entry := range entries {
-		srcPath := filepath.Join(src, entry.Name())
-		dstPath := filepath.Join(dst, entry.Name())
+	return plan, nil
+}
 
-		if entry.IsDir() {
-			dirAddedCount, err := copyDir(srcPath, dstPath, exclusions)
-			if err != nil {
-				return addedCount, err
-			}
-			addedCount += dirAddedCount
-		} else {
-			if err := copyFile(srcPath, dstPath); err != nil {
-				return addedCount, err
-			}
-			addedCount++
-		}
-	}
-	return addedCount, nil
+func logPlan(plan *CopyPlan) {
+	added, _ := plan.Count(OpCreate)
+	updated, _ := plan.Count(OpOverwrite)
+	deleted, _ := plan.Count(OpDelete)
+	unchanged, _ := plan.Count(OpUnchanged)
+	log.Infof("added: %d, updated: %d, deleted: %d, unchanged: %d, failed: %d", added, updated, deleted, unchanged, len(plan.Failures))
 }
 
 // CreateFileWithDirs creates the file specified by the given path and writes the provided bytes to it,
@@ -140,7 +140,7 @@ func TestCopyDir_FailInvalidDirectoryA(t *testing.T) {
 		WipeDestinationExclusions: nil,
 	}
 
-	err := CopyDirToAnother(&opts)
+	_, err := CopyDirToAnother(&opts)
 	require.Error(t, err, "error expected")
 }
 
@@ -215,7 +215,7 @@ func TestCopyDir_Success(t *testing.T) {
 		WipeDestinationExclusions: wipeDestinationExclusions,
 	}
 
-	err = CopyDirToAnother(&opts)
+	_, err = CopyDirToAnother(&opts)
 	require.NoError(t, err, "copy files")
 
 	// Do assertions
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package fslib
+
+import "io/fs"
+
+// preserveOwner is a no-op where file ownership is not a uid and gid.
+func preserveOwner(string, fs.FileInfo) {}
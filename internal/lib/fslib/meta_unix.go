@@ -0,0 +1,19 @@
+//go:build unix
+
+package fslib
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// preserveOwner copies the owner and group of the file, which usually needs
+// elevated privileges, so it is best effort.
+func preserveOwner(path string, info fs.FileInfo) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+	_ = os.Lchown(path, int(stat.Uid), int(stat.Gid))
+}
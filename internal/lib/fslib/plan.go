@@ -0,0 +1,306 @@
+package fslib
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type CopyOp string
+
+const (
+	OpCreate    CopyOp = "create"
+	OpOverwrite CopyOp = "overwrite"
+	OpDelete    CopyOp = "delete"
+
+	// OpUnchanged is a file a sync leaves alone, as the destination already matches.
+	OpUnchanged CopyOp = "unchanged"
+)
+
+// copyOpSymbols prefix each action in a printed plan.
+var copyOpSymbols = map[CopyOp]string{
+	OpCreate:    "+",
+	OpOverwrite: "~",
+	OpDelete:    "-",
+}
+
+// CopyAction is a file the copy creates, overwrites or deletes in the destination.
+type CopyAction struct {
+	Op CopyOp `json:"op"`
+
+	// Path is slash separated, and relative to the destination.
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// CopyPlan lists what a copy changes in the destination.
+type CopyPlan struct {
+	Actions []CopyAction `json:"actions"`
+	DryRun  bool         `json:"dry_run"`
+
+	// Dirs are the source directories, parents first, created in the
+	// destination when missing, so empty ones are copied too.
+	Dirs []string `json:"dirs"`
+
+	// Failures are the actions that could not be carried out, filled by the copy.
+	Failures []CopyFailure `json:"failures,omitempty"`
+}
+
+// Count returns how many files the operation touches, and their total size.
+func (p *CopyPlan) Count(op CopyOp) (files int, size int64) {
+	for _, action := range p.Actions {
+		if action.Op == op {
+			files++
+			size += action.Bytes
+		}
+	}
+	return files, size
+}
+
+// Summary renders the per operation totals in a single line.
+func (p *CopyPlan) Summary() string {
+	parts := make([]string, 0, 4)
+	for _, op := range []CopyOp{OpCreate, OpOverwrite, OpDelete} {
+		files, size := p.Count(op)
+		parts = append(parts, fmt.Sprintf("%s %d file(s) (%s)", op, files, strutil.FormatBytes(size)))
+	}
+	if files, _ := p.Count(OpUnchanged); files > 0 {
+		parts = append(parts, fmt.Sprintf("%s %d file(s)", OpUnchanged, files))
+	}
+	if len(p.Failures) > 0 {
+		parts = append(parts, fmt.Sprintf("failed %d file(s)", len(p.Failures)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// String lists every action of the plan that changes something, followed by the summary.
+func (p *CopyPlan) String() string {
+	var sb strings.Builder
+	for _, action := range p.Actions {
+		if action.Op == OpUnchanged {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s %s (%s)\n", copyOpSymbols[action.Op], action.Path, strutil.FormatBytes(action.Bytes)))
+	}
+	sb.WriteString(p.Summary() + "\n")
+	return sb.String()
+}
+
+// PlanCopy works out which destination files a copy creates, overwrites and,
+// when wiping or syncing the destination, deletes for good, without changing anything.
+// Deletes only list files that are not copied back from the source.
+//
+// When syncing, files matching the destination (see sameFile) are planned as unchanged.
+func PlanCopy(opts *CopyOptions) (*CopyPlan, error) {
+	if opts == nil {
+		return nil, errors.New("opts nil")
+	}
+
+	ex, err := loadExclusions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("exclusions: %w", err)
+	}
+
+	return planCopy(opts, ex)
+}
+
+func planCopy(opts *CopyOptions, ex *exclusions) (*CopyPlan, error) {
+	srcInfo, err := os.Stat(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	if !srcInfo.IsDir() {
+		return nil, fmt.Errorf("source is not a directory")
+	}
+
+	plan := &CopyPlan{
+		Actions: make([]CopyAction, 0),
+		DryRun:  opts.DryRun,
+		Dirs:    make([]string, 0),
+	}
+	copied := make(map[string]bool)
+
+	err = filepath.WalkDir(opts.Source, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(opts.Source, path)
+		if err != nil {
+			return err
+		}
+
+		if rel != "." && ex.source.Excluded(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if rel != "." {
+				plan.Dirs = append(plan.Dirs, filepath.ToSlash(rel))
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		action, err := planEntry(opts, path, rel, info, opts.Sync)
+		if err != nil {
+			return err
+		}
+
+		copied[rel] = true
+		plan.Actions = append(plan.Actions, action)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plan source: %w", err)
+	}
+
+	if !opts.WipeDestination && !opts.Sync {
+		return plan, nil
+	}
+
+	err = filepath.WalkDir(opts.Destination, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == opts.Destination && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if path == opts.Destination {
+			return nil
+		}
+
+		rel, err := filepath.Rel(opts.Destination, path)
+		if err != nil {
+			return err
+		}
+
+		if ex.destination.Excluded(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		if copied[rel] {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		plan.Actions = append(plan.Actions, CopyAction{Op: OpDelete, Path: filepath.ToSlash(rel), Bytes: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("plan destination: %w", err)
+	}
+
+	return plan, nil
+}
+
+// planEntry plans the copy of a source file to the same relative path of the
+// destination. When "compare" is set, matching files (see sameFile) are unchanged.
+func planEntry(opts *CopyOptions, path, rel string, info fs.FileInfo, compare bool) (CopyAction, error) {
+	action := CopyAction{Op: OpCreate, Path: filepath.ToSlash(rel), Bytes: info.Size()}
+
+	dst := filepath.Join(opts.Destination, rel)
+	dstInfo, err := os.Lstat(dst)
+	if err != nil || dstInfo.IsDir() {
+		return action, nil
+	}
+
+	action.Op = OpOverwrite
+	if !compare {
+		return action, nil
+	}
+
+	same, err := sameFile(path, info, dst, dstInfo, opts.Checksum)
+	if err != nil {
+		return action, err
+	}
+	if same {
+		action.Op = OpUnchanged
+	}
+
+	return action, nil
+}
+
+// sameFile reports whether the destination already matches the source: by size
+// and modification time (to the second, as file systems differ in precision),
+// or by size and content hash when checksum is set. Symlinks match on their target.
+func sameFile(src string, srcInfo fs.FileInfo, dst string, dstInfo fs.FileInfo, checksum bool) (bool, error) {
+	srcLink, dstLink := srcInfo.Mode()&fs.ModeSymlink != 0, dstInfo.Mode()&fs.ModeSymlink != 0
+	if srcLink || dstLink {
+		if srcLink != dstLink {
+			return false, nil
+		}
+		srcTarget, err := os.Readlink(src)
+		if err != nil {
+			return false, err
+		}
+		dstTarget, err := os.Readlink(dst)
+		if err != nil {
+			return false, err
+		}
+		return srcTarget == dstTarget, nil
+	}
+
+	if srcInfo.Size() != dstInfo.Size() {
+		return false, nil
+	}
+
+	if !checksum {
+		return srcInfo.ModTime().Truncate(time.Second).Equal(dstInfo.ModTime().Truncate(time.Second)), nil
+	}
+
+	srcHash, err := hashFile(src)
+	if err != nil {
+		return false, err
+	}
+
+	dstHash, err := hashFile(dst)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(srcHash, dstHash), nil
+}
+
+func hashFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hash '%s': %w", path, err)
+	}
+
+	return h.Sum(nil), nil
+}
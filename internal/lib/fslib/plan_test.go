@@ -0,0 +1,183 @@
+package fslib
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testWriteTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestPlanCopy(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+
+	testWriteTree(t, src, map[string]string{
+		"new.txt":        "12345",
+		"same.txt":       "abc",
+		".git/HEAD":      "ref",
+		"sub/nested.txt": "1",
+	})
+	testWriteTree(t, dst, map[string]string{
+		"same.txt":       "old",
+		"gone.txt":       "1234567",
+		".git/HEAD":      "kept",
+		"sub/stale.txt":  "12",
+		"sub/nested.txt": "x",
+	})
+
+	opts := &CopyOptions{
+		Source:                    src,
+		SourceExclusions:          []string{".git"},
+		Destination:               dst,
+		WipeDestinationExclusions: []string{".git"},
+	}
+
+	plan, err := PlanCopy(opts)
+	require.NoError(t, err)
+	assert.Equal(t, []CopyAction{
+		{Op: OpCreate, Path: "new.txt", Bytes: 5},
+		{Op: OpOverwrite, Path: "same.txt", Bytes: 3},
+		{Op: OpOverwrite, Path: "sub/nested.txt", Bytes: 1},
+	}, plan.Actions, "nothing is deleted without wiping")
+
+	opts.WipeDestination = true
+	plan, err = PlanCopy(opts)
+	require.NoError(t, err)
+
+	files, bytes := plan.Count(OpDelete)
+	assert.Equal(t, 2, files, "excluded and copied files are not deleted")
+	assert.Equal(t, int64(9), bytes)
+	assert.Contains(t, plan.String(), "- gone.txt (7 B)\n")
+	assert.Contains(t, plan.String(), "- sub/stale.txt (2 B)\n")
+	assert.Contains(t, plan.Summary(), "create 1 file(s) (5 B), overwrite 2 file(s) (4 B), delete 2 file(s) (9 B)")
+}
+
+func TestCopyDirToAnother_Dry_Run(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "new"})
+	testWriteTree(t, dst, map[string]string{"a.txt": "old", "b.txt": "local change"})
+
+	plan, err := CopyDirToAnother(&CopyOptions{
+		Source:          src,
+		Destination:     dst,
+		WipeDestination: true,
+		DryRun:          true,
+	})
+	require.NoError(t, err)
+	assert.True(t, plan.DryRun)
+	assert.Len(t, plan.Actions, 2)
+
+	b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(b), "a dry run changes nothing")
+	assert.FileExists(t, filepath.Join(dst, "b.txt"))
+}
+
+func TestCopyDirToAnother_Keeps_Destination_Without_Wipe(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "new"})
+	testWriteTree(t, dst, map[string]string{"b.txt": "local change"})
+
+	_, err := CopyDirToAnother(&CopyOptions{Source: src, Destination: dst})
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(dst, "a.txt"))
+	assert.FileExists(t, filepath.Join(dst, "b.txt"), "files only in the destination are kept")
+}
+
+func TestCopyDirToAnother_Wipe_Keeps_Excluded_Subtree(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "new"})
+	testWriteTree(t, dst, map[string]string{".git/config": "keep", "b.txt": "wiped"})
+
+	_, err := CopyDirToAnother(&CopyOptions{
+		Source:                    src,
+		Destination:               dst,
+		WipeDestination:           true,
+		WipeDestinationExclusions: []string{".git"},
+	})
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(dst, ".git", "config"))
+	assert.NoFileExists(t, filepath.Join(dst, "b.txt"))
+}
+
+func TestCopyDirToAnother_Wipe_Missing_Destination(t *testing.T) {
+	src := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "new")
+	testWriteTree(t, src, map[string]string{"a.txt": "new"})
+
+	_, err := CopyDirToAnother(&CopyOptions{Source: src, Destination: dst, WipeDestination: true})
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dst, "a.txt"))
+}
+
+func TestPlanCopy_Exclusions(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{
+		".copyignore":       "**/*.log\n!keep.log\n",
+		".git/HEAD":         "ref",
+		"my.git/a.txt":      "a",
+		".env.local":        "secret",
+		".env.example":      "example",
+		"logs/app.log":      "log",
+		"logs/keep.log":     "kept",
+		"vendor/lib/lib.go": "lib",
+	})
+	excludeFrom := filepath.Join(t.TempDir(), "exclude")
+	require.NoError(t, os.WriteFile(excludeFrom, []byte("vendor/\n"), 0644))
+
+	plan, err := PlanCopy(&CopyOptions{
+		Source:           src,
+		Destination:      dst,
+		SourceExclusions: []string{".git", ".env.*", "!.env.example"},
+		ExcludeFrom:      []string{excludeFrom},
+	})
+	require.NoError(t, err)
+
+	var paths []string
+	for _, action := range plan.Actions {
+		paths = append(paths, action.Path)
+	}
+	assert.ElementsMatch(t, []string{".env.example", "logs/keep.log", "my.git/a.txt"}, paths, "the ignore file isn't copied")
+
+	plan, err = PlanCopy(&CopyOptions{
+		Source:           src,
+		Destination:      dst,
+		SourceExclusions: []string{"**/*", "!/" + IgnoreFile},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Actions, 1)
+	assert.Equal(t, IgnoreFile, plan.Actions[0].Path, "a negation copies the ignore file")
+
+	_, err = PlanCopy(&CopyOptions{Source: src, Destination: dst, ExcludeFrom: []string{filepath.Join(src, "missing")}})
+	assert.Error(t, err, "exclude from files must exist")
+}
+
+func TestCopyDirToAnother_Wipe_Keeps_Nested_Exclusion(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "new"})
+	testWriteTree(t, dst, map[string]string{"sub/keep.txt": "keep", "sub/gone.txt": "wiped", "old/gone.txt": "wiped"})
+
+	_, err := CopyDirToAnother(&CopyOptions{
+		Source:                    src,
+		Destination:               dst,
+		WipeDestination:           true,
+		WipeDestinationExclusions: []string{"sub/keep.txt"},
+	})
+	require.NoError(t, err)
+
+	assert.FileExists(t, filepath.Join(dst, "sub", "keep.txt"))
+	assert.NoFileExists(t, filepath.Join(dst, "sub", "gone.txt"))
+	assert.NoDirExists(t, filepath.Join(dst, "old"))
+}
@@ -0,0 +1,284 @@
+package fslib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DefaultDebounce is how long Watch waits for changes to settle before syncing them.
+const DefaultDebounce = 300 * time.Millisecond
+
+// WatchOptions tune Watch.
+type WatchOptions struct {
+	// Debounce batches the changes made within this long, defaults to DefaultDebounce.
+	Debounce time.Duration
+
+	// OnSync receives the plan of the initial copy, and of every batch of changes.
+	// Errors that do not stop the watch, like a failed batch, come with a nil plan.
+	OnSync func(plan *CopyPlan, err error)
+}
+
+// Watch copies the source into the destination, then keeps watching the source
+// and applies every batch of changes to the destination, until the context is
+// done. Changed files are synced, and files removed from the source are removed
+// from the destination. Exclusions apply as for CopyDirToAnother, and are
+// reloaded when the source's IgnoreFile changes.
+func Watch(ctx context.Context, opts *CopyOptions, watchOpts *WatchOptions) error {
+	if opts == nil {
+		return errors.New("opts nil")
+	}
+
+	if watchOpts == nil {
+		watchOpts = &WatchOptions{}
+	}
+
+	debounce := watchOpts.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	onSync := watchOpts.OnSync
+	if onSync == nil {
+		onSync = func(*CopyPlan, error) {}
+	}
+
+	ex, err := loadExclusions(opts)
+	if err != nil {
+		return fmt.Errorf("exclusions: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("new watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch before the initial copy, so changes made during it are not missed.
+	if err := addWatches(watcher, opts.Source, opts.Source, ex); err != nil {
+		return fmt.Errorf("watch source: %w", err)
+	}
+
+	plan, err := CopyDirToAnother(opts)
+	if err != nil {
+		return fmt.Errorf("initial copy: %w", err)
+	}
+	onSync(plan, nil)
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	pending := make(map[string]bool)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			onSync(nil, fmt.Errorf("watch: %w", err))
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			rel, err := filepath.Rel(opts.Source, event.Name)
+			if err != nil || rel == "." {
+				continue
+			}
+
+			if rel == IgnoreFile {
+				if reloaded, err := loadExclusions(opts); err != nil {
+					onSync(nil, fmt.Errorf("reload exclusions: %w", err))
+				} else {
+					ex = reloaded
+				}
+			}
+
+			if ex.source.Excluded(rel, false) || ex.source.Excluded(rel, true) {
+				continue
+			}
+
+			// New directories are not watched yet, and may already hold files.
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Lstat(event.Name); err == nil && info.IsDir() {
+					if err := addWatches(watcher, opts.Source, event.Name, ex); err != nil {
+						onSync(nil, fmt.Errorf("watch '%s': %w", rel, err))
+					}
+				}
+			}
+
+			pending[rel] = true
+			timer.Reset(debounce)
+		case <-timer.C:
+			rels := make([]string, 0, len(pending))
+			for rel := range pending {
+				rels = append(rels, rel)
+			}
+			pending = make(map[string]bool)
+
+			plan, err := syncPaths(opts, ex, rels)
+			if err != nil {
+				onSync(nil, fmt.Errorf("sync changes: %w", err))
+				continue
+			}
+			onSync(plan, nil)
+		}
+	}
+}
+
+// addWatches watches "dir" and its directories, except the excluded ones.
+func addWatches(watcher *fsnotify.Watcher, source, dir string, ex *exclusions) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path != dir && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		isExcluded, err := excluded(ex.source, source, path, true)
+		if err != nil {
+			return err
+		}
+		if isExcluded {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(path)
+	})
+}
+
+// syncPaths syncs the changed source paths, relative to the source, to the
+// destination. Directories are synced along with their contents, and paths
+// gone from the source are deleted from the destination.
+func syncPaths(opts *CopyOptions, ex *exclusions, rels []string) (*CopyPlan, error) {
+	actions := make(map[string]CopyAction)
+
+	for _, rel := range rels {
+		path := filepath.Join(opts.Source, rel)
+
+		info, err := os.Lstat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			if err := planRemoved(opts, ex, rel, actions); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			action, err := planEntry(opts, path, rel, info, true)
+			if err != nil {
+				return nil, err
+			}
+			actions[action.Path] = action
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(opts.Source, path)
+			if err != nil {
+				return err
+			}
+
+			if ex.source.Excluded(rel, d.IsDir()) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			action, err := planEntry(opts, path, rel, info, true)
+			if err != nil {
+				return err
+			}
+			actions[action.Path] = action
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	plan := &CopyPlan{Actions: make([]CopyAction, 0, len(actions))}
+	for _, action := range actions {
+		plan.Actions = append(plan.Actions, action)
+	}
+	sort.Slice(plan.Actions, func(i, j int) bool {
+		return plan.Actions[i].Path < plan.Actions[j].Path
+	})
+
+	syncOpts := *opts
+	syncOpts.Sync = true
+	applyPlan(&syncOpts, plan)
+
+	return plan, nil
+}
+
+// planRemoved plans the delete of a path gone from the source, along with the
+// files below it when it was a directory.
+func planRemoved(opts *CopyOptions, ex *exclusions, rel string, actions map[string]CopyAction) error {
+	dst := filepath.Join(opts.Destination, rel)
+
+	err := filepath.WalkDir(dst, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(opts.Destination, path)
+		if err != nil {
+			return err
+		}
+
+		if ex.destination.Excluded(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		actions[filepath.ToSlash(rel)] = CopyAction{Op: OpDelete, Path: filepath.ToSlash(rel), Bytes: info.Size()}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package fslib
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "a", "old/b.txt": "b"})
+
+	plans := make(chan *CopyPlan, 16)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- Watch(ctx, &CopyOptions{
+			Source:           src,
+			Destination:      dst,
+			SourceExclusions: []string{"*.tmp"},
+		}, &WatchOptions{
+			Debounce: 50 * time.Millisecond,
+			OnSync: func(plan *CopyPlan, err error) {
+				assert.NoError(t, err)
+				if plan != nil {
+					plans <- plan
+				}
+			},
+		})
+	}()
+
+	next := func() *CopyPlan {
+		t.Helper()
+		select {
+		case plan := <-plans:
+			return plan
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a sync")
+			return nil
+		}
+	}
+
+	plan := next()
+	assert.Equal(t, [4]int{2, 0, 0, 0}, testCounts(plan), "the initial copy")
+
+	testWriteTree(t, src, map[string]string{"a.txt": "changed", "new/deep/c.txt": "c", "skip.tmp": "x"})
+	require.NoError(t, os.RemoveAll(filepath.Join(src, "old")))
+
+	// Changes may land over a couple of batches, wait for all of them.
+	require.Eventually(t, func() bool {
+		select {
+		case <-plans:
+		default:
+		}
+		b, err := os.ReadFile(filepath.Join(dst, "a.txt"))
+		_, errNew := os.Stat(filepath.Join(dst, "new", "deep", "c.txt"))
+		_, errOld := os.Stat(filepath.Join(dst, "old", "b.txt"))
+		return err == nil && string(b) == "changed" && errNew == nil && os.IsNotExist(errOld)
+	}, 5*time.Second, 20*time.Millisecond)
+
+	assert.NoFileExists(t, filepath.Join(dst, "skip.tmp"), "exclusions apply")
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch did not stop")
+	}
+}
+
+func TestSyncPaths(t *testing.T) {
+	src, dst := t.TempDir(), t.TempDir()
+	testWriteTree(t, src, map[string]string{"a.txt": "new", "dir/b.txt": "b", "same.txt": "same"})
+	testWriteTree(t, dst, map[string]string{"gone/x.txt": "x", "gone/.keep": "k", "other.txt": "untouched"})
+
+	opts := &CopyOptions{Source: src, Destination: dst, WipeDestinationExclusions: []string{".keep"}}
+	_, err := CopyDirToAnother(&CopyOptions{Source: src, Destination: dst})
+	require.NoError(t, err)
+
+	testWriteTree(t, src, map[string]string{"a.txt": "changed"})
+
+	ex, err := loadExclusions(opts)
+	require.NoError(t, err)
+
+	plan, err := syncPaths(opts, ex, []string{"a.txt", "dir", "gone"})
+	require.NoError(t, err)
+	assert.Equal(t, []CopyAction{
+		{Op: OpOverwrite, Path: "a.txt", Bytes: 7},
+		{Op: OpUnchanged, Path: "dir/b.txt", Bytes: 1},
+		{Op: OpDelete, Path: "gone/x.txt", Bytes: 1},
+	}, plan.Actions)
+
+	assert.FileExists(t, filepath.Join(dst, "gone", ".keep"), "excluded files are kept")
+	assert.FileExists(t, filepath.Join(dst, "other.txt"), "only the changed paths are synced")
+}
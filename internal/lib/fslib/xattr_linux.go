@@ -0,0 +1,42 @@
+//go:build linux
+
+package fslib
+
+import (
+	"bytes"
+	"golang.org/x/sys/unix"
+)
+
+// copyXattrs copies the extended attributes of the file. File systems without
+// xattr support, and attributes needing privileges, are skipped, so it is best effort.
+func copyXattrs(src, dst string) {
+	size, err := unix.Llistxattr(src, nil)
+	if err != nil || size <= 0 {
+		return
+	}
+
+	buf := make([]byte, size)
+	size, err = unix.Llistxattr(src, buf)
+	if err != nil {
+		return
+	}
+
+	for _, name := range bytes.Split(buf[:size], []byte{0}) {
+		if len(name) == 0 {
+			continue
+		}
+
+		valueSize, err := unix.Lgetxattr(src, string(name), nil)
+		if err != nil {
+			continue
+		}
+
+		value := make([]byte, valueSize)
+		valueSize, err = unix.Lgetxattr(src, string(name), value)
+		if err != nil {
+			continue
+		}
+
+		_ = unix.Lsetxattr(dst, string(name), value[:valueSize], 0)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !linux
+
+package fslib
+
+// copyXattrs is a no-op outside linux.
+func copyXattrs(string, string) {}
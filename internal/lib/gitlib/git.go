@@ -0,0 +1,151 @@
+package gitlib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DefaultContextLines matches git's own default for unified diffs.
+const DefaultContextLines = 3
+
+// DiffOptions selects what a diff compares.
+//
+//   - Rev only: the working tree against the revision.
+//   - Staged only: the index against HEAD.
+//   - Both: the index against the revision.
+type DiffOptions struct {
+	Rev    string `mapstructure:"rev" json:"rev"`
+	Staged bool   `mapstructure:"staged" json:"staged"`
+
+	// ContextLines is the amount of unified diff context lines.
+	ContextLines int `mapstructure:"context_lines" json:"context_lines"`
+}
+
+// Enabled reports whether the options ask for a diff at all.
+func (d *DiffOptions) Enabled() bool {
+	return d != nil && (strings.TrimSpace(d.Rev) != "" || d.Staged)
+}
+
+// ChangedFile is a path (slash separated, relative to the directory the
+// diff ran in) that differs, and whether it still exists.
+type ChangedFile struct {
+	Path      string `json:"path"`
+	Deleted   bool   `json:"deleted"`
+	Untracked bool   `json:"untracked"`
+}
+
+// ChangedFiles lists the files under "dir" that changed according to the options.
+// Untracked files that are not ignored count as changed unless comparing the index.
+func ChangedFiles(ctx context.Context, dir string, opts *DiffOptions) ([]ChangedFile, error) {
+	if !opts.Enabled() {
+		return nil, errors.New("diff options need a revision, or staged")
+	}
+
+	args := append([]string{"diff", "--name-status", "-z", "--relative", "--no-renames"}, diffTarget(opts)...)
+	out, err := run(ctx, dir, args...)
+	if err != nil {
+		return nil, fmt.Errorf("diff names: %w", err)
+	}
+
+	var changed []ChangedFile
+	fields := splitNul(out)
+	for i := 0; i+1 < len(fields); i += 2 {
+		changed = append(changed, ChangedFile{
+			Path:    fields[i+1],
+			Deleted: strings.HasPrefix(fields[i], "D"),
+		})
+	}
+
+	if opts.Staged {
+		return changed, nil
+	}
+
+	out, err = run(ctx, dir, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("untracked files: %w", err)
+	}
+
+	for _, path := range splitNul(out) {
+		changed = append(changed, ChangedFile{
+			Path:      path,
+			Untracked: true,
+		})
+	}
+
+	return changed, nil
+}
+
+// FileDiff returns the unified diff of a single changed file.
+func FileDiff(ctx context.Context, dir string, file ChangedFile, opts *DiffOptions) (string, error) {
+	contextLines := opts.ContextLines
+	if contextLines < 0 {
+		contextLines = DefaultContextLines
+	}
+	unified := "-U" + strconv.Itoa(contextLines)
+
+	if file.Untracked {
+		// "--no-index" exits with 1 when the files differ, which they always do here.
+		out, err := run(ctx, dir, "diff", "--no-index", unified, "--", "/dev/null", file.Path)
+		var exitErr *exec.ExitError
+		if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+			return "", fmt.Errorf("diff untracked '%s': %w", file.Path, err)
+		}
+		return out, nil
+	}
+
+	args := append([]string{"diff", unified, "--relative", "--no-renames"}, diffTarget(opts)...)
+	args = append(args, "--", file.Path)
+
+	out, err := run(ctx, dir, args...)
+	if err != nil {
+		return "", fmt.Errorf("diff '%s': %w", file.Path, err)
+	}
+
+	return out, nil
+}
+
+func diffTarget(opts *DiffOptions) []string {
+	var args []string
+	if opts.Staged {
+		args = append(args, "--cached")
+	}
+	if rev := strings.TrimSpace(opts.Rev); rev != "" {
+		args = append(args, rev)
+	}
+	return args
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return "", fmt.Errorf("git not found in PATH: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("%w: %s", err, msg)
+		}
+		return stdout.String(), err
+	}
+
+	return stdout.String(), nil
+}
+
+func splitNul(s string) []string {
+	var fields []string
+	for _, f := range strings.Split(s, "\x00") {
+		if f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
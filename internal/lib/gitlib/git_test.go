@@ -0,0 +1,111 @@
+package gitlib
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// testRepo creates a repository with a committed "a.txt", "b.txt" and "sub/c.txt".
+func testRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+
+	dir := t.TempDir()
+	testGit(t, dir, "init", "-q")
+	testGit(t, dir, "config", "user.email", "test@example.com")
+	testGit(t, dir, "config", "user.name", "test")
+	testGit(t, dir, "config", "commit.gpgsign", "false")
+
+	testWrite(t, dir, "a.txt", "a1\na2\na3\n")
+	testWrite(t, dir, "b.txt", "b\n")
+	testWrite(t, dir, "sub/c.txt", "c\n")
+	testGit(t, dir, "add", "-A")
+	testGit(t, dir, "commit", "-q", "-m", "init")
+
+	return dir
+}
+
+func testGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func testWrite(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := testRepo(t)
+
+	testWrite(t, dir, "a.txt", "a1\nchanged\na3\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "b.txt")))
+	testWrite(t, dir, "sub/new.txt", "new\n")
+	testWrite(t, dir, "sub/c.txt", "staged\n")
+	testGit(t, dir, "add", "sub/c.txt")
+
+	changed, err := ChangedFiles(context.Background(), dir, &DiffOptions{Rev: "HEAD"})
+	require.NoError(t, err)
+	assert.Equal(t, []ChangedFile{
+		{Path: "a.txt"},
+		{Path: "b.txt", Deleted: true},
+		{Path: "sub/c.txt"},
+		{Path: "sub/new.txt", Untracked: true},
+	}, changed)
+
+	changed, err = ChangedFiles(context.Background(), dir, &DiffOptions{Staged: true})
+	require.NoError(t, err)
+	assert.Equal(t, []ChangedFile{{Path: "sub/c.txt"}}, changed, "staged mode only sees the index")
+
+	changed, err = ChangedFiles(context.Background(), filepath.Join(dir, "sub"), &DiffOptions{Rev: "HEAD"})
+	require.NoError(t, err)
+	assert.Equal(t, []ChangedFile{
+		{Path: "c.txt"},
+		{Path: "new.txt", Untracked: true},
+	}, changed, "paths are relative to, and limited to, the directory")
+}
+
+func TestChangedFiles_Errors(t *testing.T) {
+	dir := testRepo(t)
+
+	_, err := ChangedFiles(context.Background(), dir, &DiffOptions{})
+	require.Error(t, err)
+
+	_, err = ChangedFiles(context.Background(), dir, &DiffOptions{Rev: "no-such-rev"})
+	require.Error(t, err)
+
+	_, err = ChangedFiles(context.Background(), t.TempDir(), &DiffOptions{Rev: "HEAD"})
+	require.Error(t, err, "not a repository")
+}
+
+func TestFileDiff(t *testing.T) {
+	dir := testRepo(t)
+
+	testWrite(t, dir, "a.txt", "a1\nchanged\na3\n")
+	testWrite(t, dir, "new.txt", "new\n")
+
+	diff, err := FileDiff(context.Background(), dir, ChangedFile{Path: "a.txt"}, &DiffOptions{Rev: "HEAD", ContextLines: 0})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "@@ -2 +2 @@")
+	assert.Contains(t, diff, "\n-a2\n+changed\n")
+	assert.NotContains(t, diff, "\n a1\n", "no context lines requested")
+
+	diff, err = FileDiff(context.Background(), dir, ChangedFile{Path: "a.txt"}, &DiffOptions{Rev: "HEAD", ContextLines: 1})
+	require.NoError(t, err)
+	assert.Contains(t, diff, " a1\n-a2\n+changed\n a3\n")
+
+	diff, err = FileDiff(context.Background(), dir, ChangedFile{Path: "new.txt", Untracked: true}, &DiffOptions{Rev: "HEAD"})
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+new\n")
+}
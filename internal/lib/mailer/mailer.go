@@ -0,0 +1,62 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Drivers pick where the emails go.
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+	DriverLog  = "log"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Validate rejects a message without a recipient, or with line breaks in its
+// headers, which could inject more headers or recipients.
+func (m *Message) Validate() error {
+	if m == nil {
+		return errors.New("message is nil")
+	}
+	if strings.TrimSpace(m.To) == "" {
+		return errors.New("the recipient is required")
+	}
+	if strings.ContainsAny(m.To+m.Subject, "\r\n") {
+		return errors.New("the recipient and subject can't contain line breaks")
+	}
+	return nil
+}
+
+// Mailer sends emails.
+//
+//counterfeiter:generate . Mailer
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// format renders the message with its headers, with CRLF line endings.
+func format(from string, msg *Message, date time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.String()
+}
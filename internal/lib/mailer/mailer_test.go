@@ -0,0 +1,193 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var mockMessage = &Message{
+	To:      "demby@gmail.com",
+	Subject: "Reset your password",
+	Body:    "Open the link:\nhttp://localhost/reset-password?token=abc",
+}
+
+func TestMessage_Validate(t *testing.T) {
+	assert.NoError(t, mockMessage.Validate())
+	assert.Error(t, (*Message)(nil).Validate())
+	assert.Error(t, (&Message{Subject: "no recipient"}).Validate())
+	assert.Error(t, (&Message{To: "demby@gmail.com\r\nBcc: other@gmail.com"}).Validate(), "headers can't be injected")
+	assert.Error(t, (&Message{To: "demby@gmail.com", Subject: "a\nBcc: other@gmail.com"}).Validate(), "headers can't be injected")
+}
+
+func TestFile_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail", "mail.log")
+	m, err := NewFile(path, "no-reply@local.tools")
+	require.NoError(t, err, "unexpected new error")
+
+	require.NoError(t, m.Send(context.Background(), mockMessage))
+	require.NoError(t, m.Send(context.Background(), &Message{To: "demby@yahoo.com", Subject: "Second"}))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err, "unexpected read error")
+	assert.Contains(t, string(b), "From: no-reply@local.tools\r\nTo: demby@gmail.com\r\nSubject: Reset your password\r\n")
+	assert.Contains(t, string(b), "http://localhost/reset-password?token=abc")
+	assert.Contains(t, string(b), "To: demby@yahoo.com")
+	assert.Equal(t, 2, strings.Count(string(b), separator), "the emails are appended")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	assert.Error(t, m.Send(context.Background(), &Message{}), "invalid messages aren't written")
+
+	_, err = NewFile("", "no-reply@local.tools")
+	assert.Error(t, err)
+}
+
+func TestLog_Send(t *testing.T) {
+	m, err := NewLog(logger.New(context.TODO()))
+	require.NoError(t, err, "unexpected new error")
+	assert.NoError(t, m.Send(context.Background(), mockMessage))
+	assert.Error(t, m.Send(context.Background(), &Message{}))
+
+	_, err = NewLog(nil)
+	assert.Error(t, err)
+}
+
+// serveSMTP accepts one connection, answers like a server without extensions,
+// and sends the received DATA on the channel. With a TLS config, it expects
+// TLS from the start.
+func serveSMTP(t *testing.T, tlsCfg *tls.Config) (port int, received chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err, "unexpected listen error")
+	t.Cleanup(func() { _ = l.Close() })
+	if tlsCfg != nil {
+		l = tls.NewListener(l, tlsCfg)
+	}
+
+	received = make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ESMTP")
+
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 Go ahead")
+				var data strings.Builder
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+				received <- data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Not implemented")
+			}
+		}
+	}()
+
+	return l.Addr().(*net.TCPAddr).Port, received
+}
+
+// requireReceived checks the mail the server received is the mock message.
+func requireReceived(t *testing.T, ctx context.Context, received chan string) {
+	select {
+	case data := <-received:
+		assert.Contains(t, data, "To: demby@gmail.com\r\n")
+		assert.Contains(t, data, "Subject: Reset your password\r\n")
+		assert.Contains(t, data, "Open the link:\r\nhttp://localhost/reset-password?token=abc\r\n")
+	case <-ctx.Done():
+		t.Fatal("the server received nothing")
+	}
+}
+
+func TestSMTP_Send_Insecure(t *testing.T) {
+	port, received := serveSMTP(t, nil)
+
+	m, err := NewSMTP(&SMTPConfig{Host: "127.0.0.1", Port: port, From: "no-reply@local.tools", Insecure: true})
+	require.NoError(t, err, "unexpected new error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Send(ctx, mockMessage), "unexpected send error")
+	requireReceived(t, ctx, received)
+}
+
+func TestSMTP_Send_ImplicitTLS(t *testing.T) {
+	// The test server's certificate is valid for 127.0.0.1
+	srv := httptest.NewTLSServer(nil)
+	defer srv.Close()
+
+	port, received := serveSMTP(t, srv.TLS)
+
+	m, err := NewSMTP(&SMTPConfig{Host: "127.0.0.1", Port: port, From: "no-reply@local.tools", ImplicitTLS: true})
+	require.NoError(t, err, "unexpected new error")
+	m.tls.RootCAs = x509.NewCertPool()
+	m.tls.RootCAs.AddCert(srv.Certificate())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Send(ctx, mockMessage), "unexpected send error")
+	requireReceived(t, ctx, received)
+}
+
+func TestSMTP_Send_Fail_No_STARTTLS(t *testing.T) {
+	port, received := serveSMTP(t, nil)
+
+	m, err := NewSMTP(&SMTPConfig{Host: "127.0.0.1", Port: port, From: "no-reply@local.tools"})
+	require.NoError(t, err, "unexpected new error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.ErrorContains(t, m.Send(ctx, mockMessage), "refusing to send unencrypted")
+	assert.Empty(t, received)
+}
+
+func TestNewSMTP_Fail(t *testing.T) {
+	for _, cfg := range []*SMTPConfig{
+		{Port: 25, From: "no-reply@local.tools"},
+		{Host: "localhost", From: "no-reply@local.tools"},
+		{Host: "localhost", Port: 25},
+		{Host: "smtp.gmail.com", Port: 25, From: "no-reply@local.tools", Insecure: true},
+	} {
+		_, err := NewSMTP(cfg)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,78 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// separator is written between the emails of a File.
+const separator = "--------------------------------------------------------------------------------\n"
+
+// File appends the emails to a file instead of sending them, for development
+// and tests. The file holds live links, so it is only readable by its owner.
+type File struct {
+	path string
+	from string
+	mu   sync.Mutex
+}
+
+func NewFile(path, from string) (*File, error) {
+	if path == "" {
+		return nil, errors.New("the file is required")
+	}
+	return &File{path: path, from: from}, nil
+}
+
+func (f *File) Send(_ context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("create dir: %v", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open: %v", err)
+	}
+	defer file.Close()
+
+	if _, err = file.WriteString(format(f.from, msg, time.Now()) + separator); err != nil {
+		return fmt.Errorf("write: %v", err)
+	}
+	return nil
+}
+
+// Log logs the emails instead of sending them, for development.
+type Log struct {
+	logger *logrus.Entry
+}
+
+func NewLog(logger *logrus.Entry) (*Log, error) {
+	if logger == nil {
+		return nil, errors.New("the logger is required")
+	}
+	return &Log{logger: logger}, nil
+}
+
+func (l *Log) Send(_ context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+
+	l.logger.WithFields(logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Info("email not sent, the log mail driver is set:\n" + msg.Body)
+	return nil
+}
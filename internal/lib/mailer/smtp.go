@@ -0,0 +1,154 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host string
+	Port int
+
+	// Username and Password authenticate with PLAIN auth, when set. Go's
+	// PLAIN auth refuses to send them unencrypted, except to localhost.
+	Username string
+	Password string
+
+	// From is the sender of every email.
+	From string
+
+	// ImplicitTLS connects over TLS from the start, as SMTPS servers expect,
+	// instead of upgrading the connection with STARTTLS. It is implied on
+	// ImplicitTLSPort.
+	ImplicitTLS bool
+
+	// Insecure sends unencrypted when the server doesn't offer STARTTLS. It is
+	// only allowed for localhost, e.g. a local mail catcher.
+	Insecure bool
+}
+
+// ImplicitTLSPort is the SMTPS port, which expects TLS from the start.
+const ImplicitTLSPort = 465
+
+func (c *SMTPConfig) Validate() error {
+	if c.Host == "" {
+		return errors.New("the smtp host is required")
+	}
+	if c.Port <= 0 {
+		return errors.New("the smtp port must be greater than 0")
+	}
+	if c.From == "" {
+		return errors.New("the sender is required")
+	}
+	if c.Insecure && !isLocalhost(c.Host) {
+		return fmt.Errorf("insecure smtp is only allowed for localhost, not '%s'", c.Host)
+	}
+	return nil
+}
+
+func (c *SMTPConfig) implicitTLS() bool {
+	return c.ImplicitTLS || c.Port == ImplicitTLSPort
+}
+
+func isLocalhost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// SMTP sends emails through an SMTP server over TLS, either from the start or
+// by upgrading the connection with STARTTLS. A server without STARTTLS is
+// refused, unless the config allows it.
+type SMTP struct {
+	cfg *SMTPConfig
+	tls *tls.Config
+}
+
+func NewSMTP(cfg *SMTPConfig) (*SMTP, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %v", err)
+	}
+	return &SMTP{cfg: cfg, tls: &tls.Config{ServerName: cfg.Host}}, nil
+}
+
+// Send delivers the message, within the deadline of the context if any.
+func (s *SMTP) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("set deadline: %v", err)
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("greeting: %v", err)
+	}
+	defer client.Close()
+
+	if !s.cfg.implicitTLS() {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err = client.StartTLS(s.tls); err != nil {
+				return fmt.Errorf("starttls: %v", err)
+			}
+		} else if !s.cfg.Insecure {
+			return errors.New("starttls: the server doesn't support it, refusing to send unencrypted")
+		}
+	}
+
+	if s.cfg.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("auth: %v", err)
+		}
+	}
+
+	if err = client.Mail(s.cfg.From); err != nil {
+		return fmt.Errorf("mail from: %v", err)
+	}
+	if err = client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("rcpt to: %v", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data: %v", err)
+	}
+	if _, err = w.Write([]byte(format(s.cfg.From, msg, time.Now()))); err != nil {
+		return fmt.Errorf("write: %v", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("end data: %v", err)
+	}
+
+	return client.Quit()
+}
+
+// dial connects to the server, over TLS with implicit TLS.
+func (s *SMTP) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	if s.cfg.implicitTLS() {
+		dialer := &tls.Dialer{Config: s.tls}
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", addr)
+}
@@ -0,0 +1,204 @@
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+const (
+	Bcrypt   = "bcrypt"
+	Argon2id = "argon2id"
+
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+var (
+	// ErrUnknownFormat is returned for a stored value that is not a hash, as
+	// the plaintext passwords stored before hashing was added.
+	ErrUnknownFormat = errors.New("not a bcrypt or argon2id hash")
+
+	errMalformed = errors.New("malformed argon2id hash")
+)
+
+type Config struct {
+	// Algorithm hashes new passwords, either Bcrypt or Argon2id. Both are
+	// verified whatever the algorithm.
+	Algorithm string
+
+	// BcryptCost is the log2 of the bcrypt rounds.
+	BcryptCost int
+
+	// Argon2Memory is the argon2id memory in KiB.
+	Argon2Memory uint32
+
+	// Argon2Iterations is the argon2id number of passes over the memory.
+	Argon2Iterations uint32
+
+	// Argon2Parallelism is the argon2id number of lanes.
+	Argon2Parallelism uint8
+}
+
+func (c *Config) Validate() error {
+	switch c.Algorithm {
+	case Bcrypt:
+		if c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost {
+			return fmt.Errorf("the bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case Argon2id:
+		if c.Argon2Memory < 8*uint32(c.Argon2Parallelism) || c.Argon2Iterations == 0 || c.Argon2Parallelism == 0 {
+			return errors.New("the argon2id iterations and parallelism must be greater than 0, and the memory at least 8 KiB per lane")
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm '%s', use %s or %s", c.Algorithm, Bcrypt, Argon2id)
+	}
+	return nil
+}
+
+// Hasher hashes passwords with the configured algorithm, and tells when a
+// stored hash was made with other parameters.
+type Hasher struct {
+	cfg *Config
+}
+
+// New creates a hasher for the configured algorithm and cost.
+func New(cfg *Config) (*Hasher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %v", err)
+	}
+	return &Hasher{cfg: cfg}, nil
+}
+
+// Hash returns the encoded hash of the password, salt and parameters included.
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.cfg.Algorithm == Bcrypt {
+		b, err := bcrypt.GenerateFromPassword([]byte(password), h.cfg.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("bcrypt: %v", err)
+		}
+		return string(b), nil
+	}
+
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("read salt: %v", err)
+	}
+
+	p := argon2Params{
+		memory:      h.cfg.Argon2Memory,
+		iterations:  h.cfg.Argon2Iterations,
+		parallelism: h.cfg.Argon2Parallelism,
+		salt:        salt,
+	}
+	p.key = p.derive(password, argon2KeyLen)
+	return p.encode(), nil
+}
+
+// Verify checks the password against an encoded hash. needsRehash is true
+// when the password matched a hash made with another algorithm or parameters
+// than the configured ones.
+func (h *Hasher) Verify(encoded, password string) (ok bool, needsRehash bool, err error) {
+	switch {
+	case isBcrypt(encoded):
+		if err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, false, nil
+			}
+			return false, false, fmt.Errorf("bcrypt: %v", err)
+		}
+
+		cost, err := bcrypt.Cost([]byte(encoded))
+		if err != nil {
+			return false, false, fmt.Errorf("bcrypt cost: %v", err)
+		}
+		return true, h.cfg.Algorithm != Bcrypt || cost != h.cfg.BcryptCost, nil
+
+	case strings.HasPrefix(encoded, "$"+Argon2id+"$"):
+		p, err := decodeArgon2(encoded)
+		if err != nil {
+			return false, false, err
+		}
+
+		key := p.derive(password, uint32(len(p.key)))
+		if subtle.ConstantTimeCompare(key, p.key) != 1 {
+			return false, false, nil
+		}
+		return true, h.cfg.Algorithm != Argon2id ||
+			p.memory != h.cfg.Argon2Memory ||
+			p.iterations != h.cfg.Argon2Iterations ||
+			p.parallelism != h.cfg.Argon2Parallelism, nil
+	}
+
+	return false, false, ErrUnknownFormat
+}
+
+// IsHash reports whether the stored value is a hash Verify understands.
+func IsHash(encoded string) bool {
+	if isBcrypt(encoded) {
+		return true
+	}
+	_, err := decodeArgon2(encoded)
+	return err == nil
+}
+
+// isBcrypt matches the $2a$, $2b$ and $2y$ bcrypt prefixes.
+func isBcrypt(encoded string) bool {
+	return len(encoded) == 60 && strings.HasPrefix(encoded, "$2") && encoded[3] == '$'
+}
+
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func (p *argon2Params) derive(password string, keyLen uint32) []byte {
+	return argon2.IDKey([]byte(password), p.salt, p.iterations, p.memory, p.parallelism, keyLen)
+}
+
+// encode formats the hash as "$argon2id$v=19$m=..,t=..,p=..$salt$key", the
+// format of the reference implementation.
+func (p *argon2Params) encode() string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		Argon2id, argon2.Version, p.memory, p.iterations, p.parallelism,
+		base64.RawStdEncoding.EncodeToString(p.salt),
+		base64.RawStdEncoding.EncodeToString(p.key),
+	)
+}
+
+func decodeArgon2(encoded string) (*argon2Params, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != Argon2id {
+		return nil, errMalformed
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, errMalformed
+	}
+	if version != argon2.Version {
+		return nil, fmt.Errorf("unsupported argon2id version %d", version)
+	}
+
+	p := &argon2Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return nil, errMalformed
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errMalformed
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, errMalformed
+	}
+	return p, nil
+}
@@ -0,0 +1,95 @@
+package passhash
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newBcrypt(t *testing.T, cost int) *Hasher {
+	h, err := New(&Config{Algorithm: Bcrypt, BcryptCost: cost})
+	require.NoError(t, err)
+	return h
+}
+
+func newArgon2id(t *testing.T, memory uint32) *Hasher {
+	h, err := New(&Config{Algorithm: Argon2id, Argon2Memory: memory, Argon2Iterations: 1, Argon2Parallelism: 1})
+	require.NoError(t, err)
+	return h
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&Config{Algorithm: Bcrypt, BcryptCost: 10}).Validate())
+	assert.NoError(t, (&Config{Algorithm: Argon2id, Argon2Memory: 64, Argon2Iterations: 1, Argon2Parallelism: 1}).Validate())
+
+	assert.ErrorContains(t, (&Config{Algorithm: Bcrypt, BcryptCost: 2}).Validate(), "between 4 and 31")
+	assert.ErrorContains(t, (&Config{Algorithm: Argon2id, Argon2Memory: 64, Argon2Parallelism: 1}).Validate(), "greater than 0")
+	assert.ErrorContains(t, (&Config{Algorithm: "md5"}).Validate(), "unsupported algorithm 'md5'")
+}
+
+func TestHasher(t *testing.T) {
+	for _, h := range []*Hasher{newBcrypt(t, 4), newArgon2id(t, 64)} {
+		t.Run(h.cfg.Algorithm, func(t *testing.T) {
+			hash, err := h.Hash("password123")
+			require.NoError(t, err)
+			assert.True(t, IsHash(hash))
+			assert.NotContains(t, hash, "password123")
+
+			other, err := h.Hash("password123")
+			require.NoError(t, err)
+			assert.NotEqual(t, hash, other, "hashes are salted")
+
+			ok, rehash, err := h.Verify(hash, "password123")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.False(t, rehash)
+
+			ok, _, err = h.Verify(hash, "password124")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestHasher_Verify_NeedsRehash(t *testing.T) {
+	bcrypt4, bcrypt5 := newBcrypt(t, 4), newBcrypt(t, 5)
+	argon64, argon128 := newArgon2id(t, 64), newArgon2id(t, 128)
+
+	for _, tc := range []struct {
+		name       string
+		hashedWith *Hasher
+		verifier   *Hasher
+	}{
+		{"bcrypt cost", bcrypt4, bcrypt5},
+		{"argon2id memory", argon64, argon128},
+		{"bcrypt to argon2id", bcrypt4, argon64},
+		{"argon2id to bcrypt", argon64, bcrypt4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := tc.hashedWith.Hash("password123")
+			require.NoError(t, err)
+
+			ok, rehash, err := tc.verifier.Verify(hash, "password123")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.True(t, rehash)
+
+			ok, rehash, err = tc.verifier.Verify(hash, "password124")
+			require.NoError(t, err)
+			assert.False(t, ok)
+			assert.False(t, rehash, "only a matching password is rehashed")
+		})
+	}
+}
+
+func TestHasher_Verify_Plaintext(t *testing.T) {
+	h := newArgon2id(t, 64)
+
+	for _, stored := range []string{"password123", "", "$argon2id$v=19$m=64,t=1,p=1$bad"} {
+		assert.False(t, IsHash(stored))
+
+		ok, _, err := h.Verify(stored, stored)
+		assert.Error(t, err)
+		assert.False(t, ok, "a plaintext password never matches")
+	}
+}
@@ -0,0 +1,111 @@
+package pasteback
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// BackupDirName holds the backups of applied responses in the app dir, one
+// directory per apply.
+const BackupDirName = "apply-backups"
+
+// Result lists what Apply wrote.
+type Result struct {
+	Written []string `json:"written"`
+
+	// BackupDir holds the previous content of the overwritten files, at the
+	// same relative paths. It is empty when nothing was overwritten.
+	BackupDir string `json:"backup_dir"`
+}
+
+// NewBackupDir names a backup directory under "dir", after the current time.
+func NewBackupDir(dir string) string {
+	return filepath.Join(dir, time.Now().Format("20060102-150405.000"))
+}
+
+// Apply writes the changes of the plan under its root. Each file is replaced
+// atomically, and the files it overwrites are first copied to the backup dir.
+// The directories are checked again, in case a symlink replaced one since the
+// plan was made.
+// It stops at the first failure, the result lists what was written until then.
+func Apply(plan *Plan, backupDir string) (*Result, error) {
+	if plan == nil {
+		return nil, errors.New("plan nil")
+	}
+
+	result := &Result{Written: make([]string, 0, len(plan.Changes))}
+	for _, change := range plan.Changes {
+		if change.Op == OpUnchanged {
+			continue
+		}
+
+		if err := checkDirs(plan.Root, change.Path); err != nil {
+			return result, fmt.Errorf("write '%s': %v", change.Path, err)
+		}
+
+		target := filepath.Join(plan.Root, filepath.FromSlash(change.Path))
+
+		if change.Op == OpModify {
+			backup := filepath.Join(backupDir, filepath.FromSlash(change.Path))
+			if err := backupFile(target, backup); err != nil {
+				return result, fmt.Errorf("backup '%s': %v", change.Path, err)
+			}
+			result.BackupDir = backupDir
+		}
+
+		if err := writeAtomic(target, []byte(change.Content), change.Mode); err != nil {
+			return result, fmt.Errorf("write '%s': %v", change.Path, err)
+		}
+		result.Written = append(result.Written, change.Path)
+	}
+
+	return result, nil
+}
+
+func backupFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return fslib.CreateFileWithDirs(dst, data)
+}
+
+// writeAtomic writes through a temp file in the same directory, so the file
+// is either replaced whole, or left as it was.
+func writeAtomic(path string, data []byte, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("create dir: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
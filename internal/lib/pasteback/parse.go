@@ -0,0 +1,198 @@
+package pasteback
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// File is a whole file found in a pasted response.
+type File struct {
+	// Path is slash separated, and relative to the root the response applies to.
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+var (
+	// plainHeader is the "--- path ---" header of the plain clip format.
+	plainHeader = regexp.MustCompile(`^--- (\S.*?) ---\s*$`)
+
+	// plainNotes are plain headers that do not start a file: the tree, and the
+	// skipped files summary.
+	plainNotes = regexp.MustCompile(`^(?:tree|skipped \d+ file\(s\)|left out \d+ file\(s\).*)$`)
+
+	// pathHeader matches the lines naming the file of the fenced block below them:
+	// "### path", "**path**", "`path`", and "File: path".
+	pathHeader = regexp.MustCompile("^(?:#{1,6}\\s+|(?i:file|path):\\s*)?[*`]*([^\\s*`]+?)[*`]*:?\\s*$")
+
+	fenceOpen = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*(.*)$")
+)
+
+// wellKnownFiles are file names without an extension, or a directory, that
+// still count as paths in headers.
+var wellKnownFiles = map[string]bool{
+	"Dockerfile": true,
+	"Makefile":   true,
+	"LICENSE":    true,
+}
+
+// Parse finds the files of a pasted response: the sections of the plain
+// "--- path ---" format or, when there are none, fenced markdown blocks named
+// by a header line (or their info string, e.g. "```go main.go"). The plain
+// format comes first, as its files may hold fenced blocks of their own, like a
+// readme. A file found more than once keeps its last content.
+func Parse(text string) ([]File, error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	files := parsePlain(text)
+	if len(files) == 0 {
+		files = parseMarkdown(text)
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no file blocks found, expected '--- path ---' sections or fenced blocks with path headers")
+	}
+
+	return dedupe(files), nil
+}
+
+// parseMarkdown reads the fenced blocks that come with a path.
+func parseMarkdown(text string) []File {
+	var files []File
+
+	lines := strings.Split(text, "\n")
+	header := ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		m := fenceOpen.FindStringSubmatch(line)
+		if m == nil {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			header = ""
+			if h := pathHeader.FindStringSubmatch(strings.TrimSpace(line)); h != nil && looksLikePath(h[1]) {
+				header = h[1]
+			}
+			continue
+		}
+
+		fence, info := m[1], m[2]
+		path := infoPath(info)
+		if path == "" {
+			path = header
+		}
+		header = ""
+
+		var body []string
+		closed := false
+		for i++; i < len(lines); i++ {
+			if isFenceClose(lines[i], fence) {
+				closed = true
+				break
+			}
+			body = append(body, lines[i])
+		}
+
+		if path == "" || !closed {
+			continue
+		}
+
+		content := strings.Join(body, "\n")
+		if content != "" {
+			content += "\n"
+		}
+		files = append(files, File{Path: path, Content: content})
+	}
+
+	return files
+}
+
+// infoPath finds a path in a fence info string: "go main.go", "go:main.go",
+// "main.go" or `go title="main.go"`.
+func infoPath(info string) string {
+	for _, field := range strings.Fields(info) {
+		if _, after, found := strings.Cut(field, "="); found {
+			field = after
+		} else if _, after, found := strings.Cut(field, ":"); found {
+			field = after
+		}
+		field = strings.Trim(field, `"'`)
+		if looksLikePath(field) {
+			return field
+		}
+	}
+	return ""
+}
+
+// isFenceClose reports whether the line closes a block opened with "fence":
+// the same character, at least as many times, and nothing else.
+func isFenceClose(line, fence string) bool {
+	line = strings.TrimSpace(line)
+	return len(line) >= len(fence) && strings.Trim(line, fence[:1]) == ""
+}
+
+// looksLikePath tells a path from a word: it has a directory or an extension,
+// or is a well known file name.
+func looksLikePath(s string) bool {
+	if s == "" || strings.ContainsAny(s, " \t") {
+		return false
+	}
+	if wellKnownFiles[s] {
+		return true
+	}
+	return strings.Contains(s, "/") || (strings.Contains(s, ".") && !strings.HasSuffix(s, "."))
+}
+
+// parsePlain reads the "--- path ---" sections. Leading and trailing blank
+// lines of each file are dropped, as the format separates sections with them.
+func parsePlain(text string) []File {
+	var files []File
+
+	var current *File
+	var body []string
+	flush := func() {
+		if current == nil {
+			return
+		}
+		content := strings.Trim(strings.Join(body, "\n"), "\n")
+		if content != "" {
+			content += "\n"
+		}
+		current.Content = content
+		files = append(files, *current)
+		current, body = nil, nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if m := plainHeader.FindStringSubmatch(line); m != nil {
+			flush()
+			if !plainNotes.MatchString(m[1]) {
+				current = &File{Path: m[1]}
+			}
+			continue
+		}
+
+		if current != nil {
+			body = append(body, line)
+		}
+	}
+	flush()
+
+	return files
+}
+
+// dedupe keeps the last content of every path, in the order paths first appeared.
+func dedupe(files []File) []File {
+	index := make(map[string]int, len(files))
+	deduped := make([]File, 0, len(files))
+	for _, file := range files {
+		if i, ok := index[file.Path]; ok {
+			deduped[i] = file
+			continue
+		}
+		index[file.Path] = len(deduped)
+		deduped = append(deduped, file)
+	}
+	return deduped
+}
@@ -0,0 +1,137 @@
+package pasteback
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse_Plain(t *testing.T) {
+	text := "\n\n--- tree ---\n\n└── main.go\n\n--- main.go ---\n\npackage main\n\nfunc main() {}\n\n\n--- pkg/util.go ---\n\npackage pkg\n\n--- skipped 1 file(s) ---\n\n- big.bin (binary, 1 MB)\n"
+
+	files, err := Parse(text)
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Path: "main.go", Content: "package main\n\nfunc main() {}\n"},
+		{Path: "pkg/util.go", Content: "package pkg\n"},
+	}, files)
+}
+
+func TestParse_Markdown(t *testing.T) {
+	text := "Here are the updated files.\n\n" +
+		"### main.go\n\n```go\npackage main\n```\n\n" +
+		"**pkg/util.go**\n```go\npackage pkg\n\n// ```go inside a longer fence\n```\n\n" +
+		"````go title=\"pkg/fenced.go\"\n```\nnested\n```\n````\n\n" +
+		"A snippet without a path:\n```sh\ngo test ./...\n```\n\n" +
+		"File: `main.go`\n```go\npackage main // again\n```\n"
+
+	files, err := Parse(text)
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Path: "main.go", Content: "package main // again\n"},
+		{Path: "pkg/util.go", Content: "package pkg\n\n// ```go inside a longer fence\n"},
+		{Path: "pkg/fenced.go", Content: "```\nnested\n```\n"},
+	}, files)
+}
+
+func TestParse_Plain_With_Fenced_Blocks(t *testing.T) {
+	text := "--- readme.md ---\n\n# Usage\n\n### main.go\n\n```go\npackage main\n```\n\n--- pkg/util.go ---\n\npackage pkg\n"
+
+	files, err := Parse(text)
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Path: "readme.md", Content: "# Usage\n\n### main.go\n\n```go\npackage main\n```\n"},
+		{Path: "pkg/util.go", Content: "package pkg\n"},
+	}, files, "the fenced blocks of a plain section are part of its file")
+}
+
+func TestParse_Fail(t *testing.T) {
+	_, err := Parse("Sure! Run `go test ./...` and it should pass.")
+	assert.ErrorContains(t, err, "no file blocks found")
+}
+
+func TestNewPlan_And_Apply(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "same.go"), []byte("package same\n"), 0644))
+
+	plan, err := NewPlan(root, []File{
+		{Path: "main.go", Content: "package main\n\nfunc main() {}\n"},
+		{Path: "same.go", Content: "package same\n"},
+		{Path: filepath.Join(root, "pkg", "new.go"), Content: "package pkg\n"},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 3)
+
+	assert.Equal(t, OpModify, plan.Changes[0].Op)
+	assert.Equal(t, "--- a/main.go\n+++ b/main.go\n@@ -1 +1,3 @@\n package main\n+\n+func main() {}\n", plan.Changes[0].Diff)
+	assert.Equal(t, OpUnchanged, plan.Changes[1].Op)
+	assert.Empty(t, plan.Changes[1].Diff)
+	assert.Equal(t, OpCreate, plan.Changes[2].Op)
+	assert.Equal(t, "pkg/new.go", plan.Changes[2].Path, "absolute paths inside the root are made relative")
+	assert.Contains(t, plan.Changes[2].Diff, "--- /dev/null\n+++ b/pkg/new.go\n")
+
+	backupDir := filepath.Join(t.TempDir(), "backup")
+	result, err := Apply(plan, backupDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.go", "pkg/new.go"}, result.Written)
+	assert.Equal(t, backupDir, result.BackupDir)
+
+	b, err := os.ReadFile(filepath.Join(root, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n\nfunc main() {}\n", string(b))
+
+	info, err := os.Stat(filepath.Join(root, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm(), "the mode is kept")
+
+	b, err = os.ReadFile(filepath.Join(backupDir, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(b))
+
+	assert.FileExists(t, filepath.Join(root, "pkg", "new.go"))
+	assert.NoFileExists(t, filepath.Join(backupDir, "pkg", "new.go"), "created files have nothing to back up")
+}
+
+func TestNewPlan_Fail_Outside_Root(t *testing.T) {
+	root := t.TempDir()
+
+	for _, path := range []string{"../escape.go", "/etc/passwd", "pkg/../../escape.go", "."} {
+		_, err := NewPlan(root, []File{{Path: path}})
+		assert.ErrorContains(t, err, "outside of the root", path)
+	}
+}
+
+func TestNewPlan_Fail_Git_Dir(t *testing.T) {
+	root := t.TempDir()
+
+	for _, path := range []string{".git/config", ".git/hooks/pre-commit", "vendor/mod/.GIT/HEAD"} {
+		_, err := NewPlan(root, []File{{Path: path}})
+		assert.ErrorContains(t, err, "inside a .git directory", path)
+	}
+}
+
+func TestNewPlan_Fail_Symlinked_Dir(t *testing.T) {
+	root, outside := t.TempDir(), t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "vendor")))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "file"), nil, 0644))
+
+	_, err := NewPlan(root, []File{{Path: "vendor/pkg/escape.go", Content: "package pkg\n"}})
+	assert.ErrorContains(t, err, "directory 'vendor' is a symlink")
+
+	_, err = NewPlan(root, []File{{Path: "file/escape.go"}})
+	assert.ErrorContains(t, err, "'file' is not a directory")
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, "pkg"), 0755))
+	plan, err := NewPlan(root, []File{{Path: "pkg/sub/new.go", Content: "package sub\n"}})
+	require.NoError(t, err)
+
+	require.NoError(t, os.Remove(filepath.Join(root, "pkg")))
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "pkg")))
+
+	_, err = Apply(plan, filepath.Join(t.TempDir(), "backup"))
+	assert.ErrorContains(t, err, "directory 'pkg' is a symlink", "the directories are checked again on apply")
+	assert.NoDirExists(t, filepath.Join(outside, "sub"))
+}
@@ -0,0 +1,214 @@
+package pasteback
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pmezard/go-difflib/difflib"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultContextLines matches git's own default for unified diffs.
+const DefaultContextLines = 3
+
+type Op string
+
+const (
+	OpCreate    Op = "create"
+	OpModify    Op = "modify"
+	OpUnchanged Op = "unchanged"
+)
+
+// Options select what a response is applied to.
+type Options struct {
+	// Root is the directory the paths of the response are relative to.
+	Root string `mapstructure:"root" json:"root"`
+
+	// Input is where the response is read from: the clipboard when empty,
+	// stdin when "-", or a file path.
+	Input string `mapstructure:"input" json:"input"`
+}
+
+// Change is a file of the response, compared with the working tree.
+type Change struct {
+	File
+	Op Op `json:"op"`
+
+	// Diff is the unified diff from the working tree to the response, empty when unchanged.
+	Diff string `json:"diff"`
+
+	// Mode is kept when the file is overwritten.
+	Mode fs.FileMode `json:"mode"`
+}
+
+// Plan lists what applying a response changes under the root.
+type Plan struct {
+	Root    string   `json:"root"`
+	Changes []Change `json:"changes"`
+}
+
+// Count returns how many changes carry out the operation.
+func (p *Plan) Count(op Op) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Op == op {
+			count++
+		}
+	}
+	return count
+}
+
+// NewPlan compares the files of a response with the ones under the root.
+// Paths must stay inside the root, absolute ones included, without going
+// through a symlinked directory or into a ".git" directory.
+func NewPlan(root string, files []File) (*Plan, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("abs root: %v", err)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("root: %v", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root '%s' is not a directory", root)
+	}
+
+	plan := &Plan{Root: root, Changes: make([]Change, 0, len(files))}
+	for _, file := range files {
+		rel, err := relPath(root, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		file.Path = rel
+
+		change, err := planChange(root, file)
+		if err != nil {
+			return nil, fmt.Errorf("plan '%s': %v", rel, err)
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	return plan, nil
+}
+
+// relPath returns the slash separated path of the file, relative to the root,
+// and fails when it points outside of it.
+func relPath(root, path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("empty path")
+	}
+
+	abs := filepath.FromSlash(path)
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(root, abs)
+	}
+
+	rel, err := filepath.Rel(root, filepath.Clean(abs))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path '%s' is outside of the root", path)
+	}
+
+	rel = filepath.ToSlash(rel)
+	for _, part := range strings.Split(rel, "/") {
+		if strings.EqualFold(part, ".git") {
+			return "", fmt.Errorf("path '%s' is inside a .git directory", path)
+		}
+	}
+
+	return rel, nil
+}
+
+// checkDirs fails when a directory of the relative path exists as a symlink,
+// or as something else than a directory, as writing through it could land
+// outside of the root. The missing directories are created on apply.
+func checkDirs(root, rel string) error {
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		dir := strings.Join(parts[:i], "/")
+
+		info, err := os.Lstat(filepath.Join(root, filepath.FromSlash(dir)))
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("directory '%s' is a symlink", dir)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("'%s' is not a directory", dir)
+		}
+	}
+	return nil
+}
+
+func planChange(root string, file File) (Change, error) {
+	change := Change{File: file, Op: OpCreate, Mode: 0644}
+
+	if err := checkDirs(root, file.Path); err != nil {
+		return change, err
+	}
+
+	target := filepath.Join(root, filepath.FromSlash(file.Path))
+	info, err := os.Lstat(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		change.Diff, err = unifiedDiff("/dev/null", "b/"+file.Path, "", file.Content)
+		return change, err
+	}
+	if err != nil {
+		return change, err
+	}
+	if !info.Mode().IsRegular() {
+		return change, fmt.Errorf("not a regular file")
+	}
+
+	current, err := os.ReadFile(target)
+	if err != nil {
+		return change, err
+	}
+
+	change.Mode = info.Mode().Perm()
+	if string(current) == file.Content {
+		change.Op = OpUnchanged
+		return change, nil
+	}
+
+	change.Op = OpModify
+	change.Diff, err = unifiedDiff("a/"+file.Path, "b/"+file.Path, string(current), file.Content)
+	return change, err
+}
+
+func unifiedDiff(from, to, a, b string) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(a),
+		B:        splitLines(b),
+		FromFile: from,
+		ToFile:   to,
+		Context:  DefaultContextLines,
+	})
+	if err != nil {
+		return "", fmt.Errorf("diff: %v", err)
+	}
+	return diff, nil
+}
+
+// splitLines splits the text for difflib, every line ending with a newline.
+// Unlike difflib.SplitLines, a trailing newline does not add an empty line.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
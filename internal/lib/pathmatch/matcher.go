@@ -0,0 +1,207 @@
+package pathmatch
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// GitIgnoreFile is the per-directory ignore file read by LoadGitIgnores.
+	GitIgnoreFile = ".gitignore"
+
+	gitDir      = ".git"
+	gitInfoFile = "info/exclude"
+)
+
+// Matcher evaluates an ordered list of gitignore-style patterns.
+// As with git, the last matching pattern decides the outcome.
+type Matcher struct {
+	patterns []*Pattern
+}
+
+// New returns a matcher holding the provided patterns, all declared at the root.
+func New(lines ...string) *Matcher {
+	m := &Matcher{}
+	m.AddLines(lines, "")
+	return m
+}
+
+// AddLines compiles and appends gitignore lines declared in "base".
+func (m *Matcher) AddLines(lines []string, base string) {
+	for _, line := range lines {
+		p, ok := ParsePattern(line, base)
+		if !ok {
+			continue
+		}
+		m.patterns = append(m.patterns, p)
+	}
+}
+
+// AddFile appends the patterns of an ignore file declared in "base".
+// Missing files are not an error.
+func (m *Matcher) AddFile(filePath, base string) error {
+	lines, err := ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	m.AddLines(lines, base)
+	return nil
+}
+
+// ReadFile returns the lines of an ignore file, to be compiled with AddLines.
+func ReadFile(filePath string) ([]string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("open ignore file: %w", err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ignore file '%s': %w", filePath, err)
+	}
+
+	return lines, nil
+}
+
+// Len returns the amount of compiled patterns.
+func (m *Matcher) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.patterns)
+}
+
+// Match reports whether any pattern matched "relPath" itself, and if so,
+// whether the deciding pattern excludes it.
+func (m *Matcher) Match(relPath string, isDir bool) (matched bool, excluded bool) {
+	if m == nil {
+		return false, false
+	}
+	for i := len(m.patterns) - 1; i >= 0; i-- {
+		if m.patterns[i].Match(relPath, isDir) {
+			return true, !m.patterns[i].negate
+		}
+	}
+	return false, false
+}
+
+// Excluded reports whether "relPath" is excluded, either directly or because
+// one of its parent directories is. Like git, a path cannot be re-included
+// when a parent directory is excluded.
+func (m *Matcher) Excluded(relPath string, isDir bool) bool {
+	if m.Len() == 0 {
+		return false
+	}
+
+	relPath = strings.Trim(path.Clean("/"+filepath.ToSlash(relPath)), "/")
+	if relPath == "" {
+		return false
+	}
+
+	segments := strings.Split(relPath, "/")
+	for i := 1; i < len(segments); i++ {
+		if _, excluded := m.Match(strings.Join(segments[:i], "/"), true); excluded {
+			return true
+		}
+	}
+
+	_, excluded := m.Match(relPath, isDir)
+	return excluded
+}
+
+// AnyMatch reports whether any pattern matches "relPath", ignoring negation.
+// It is meant for include lists, where a path is kept if any glob matches it.
+func (m *Matcher) AnyMatch(relPath string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	for _, p := range m.patterns {
+		if p.Match(relPath, isDir) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindGitRoot walks up from "dir" and returns the first directory holding
+// a ".git" entry, or an empty string if "dir" is not inside a repository.
+func FindGitRoot(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, gitDir)); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadGitIgnores returns a matcher rooted at "root", seeded with
+// ".git/info/exclude" and every ".gitignore" between the repository root and
+// "root". Nested ignore files below "root" should be added while walking via
+// AddFile, so they only apply to their own directory.
+//
+// The returned "matchRoot" is the directory paths must be made relative to,
+// which is the repository root when "root" lives inside one.
+func LoadGitIgnores(root string) (m *Matcher, matchRoot string, err error) {
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return nil, "", fmt.Errorf("abs root: %w", err)
+	}
+
+	m = &Matcher{}
+	matchRoot = FindGitRoot(root)
+	if matchRoot == "" {
+		return m, root, nil
+	}
+
+	// Always skip the repository internals.
+	m.AddLines([]string{"/" + gitDir + "/"}, "")
+
+	if err := m.AddFile(filepath.Join(matchRoot, gitDir, filepath.FromSlash(gitInfoFile)), ""); err != nil {
+		return nil, "", fmt.Errorf("git info exclude: %w", err)
+	}
+
+	rel, err := filepath.Rel(matchRoot, root)
+	if err != nil {
+		return nil, "", fmt.Errorf("rel root: %w", err)
+	}
+
+	dirs := []string{""}
+	if rel != "." {
+		base := ""
+		for _, segment := range strings.Split(filepath.ToSlash(rel), "/") {
+			base = path.Join(base, segment)
+			dirs = append(dirs, base)
+		}
+	}
+
+	// The walk root's own ignore file is left to the caller, same as nested ones.
+	for _, dir := range dirs[:len(dirs)-1] {
+		if err := m.AddFile(filepath.Join(matchRoot, filepath.FromSlash(dir), GitIgnoreFile), dir); err != nil {
+			return nil, "", fmt.Errorf("gitignore: %w", err)
+		}
+	}
+
+	return m, matchRoot, nil
+}
@@ -0,0 +1,126 @@
+package pathmatch
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPattern_Match(t *testing.T) {
+	type testCase struct {
+		name    string
+		pattern string
+		base    string
+		path    string
+		isDir   bool
+		matched bool
+	}
+
+	testCases := []testCase{
+		{name: "Basename Any Depth", pattern: "*.log", path: "a/b/c.log", matched: true},
+		{name: "Basename No Match", pattern: "*.log", path: "a/b/c.go", matched: false},
+		{name: "Anchored Leading Slash", pattern: "/build", path: "build", isDir: true, matched: true},
+		{name: "Anchored Leading Slash Nested", pattern: "/build", path: "src/build", isDir: true, matched: false},
+		{name: "Anchored Middle Slash", pattern: "docs/*.md", path: "docs/a.md", matched: true},
+		{name: "Anchored Middle Slash Nested", pattern: "docs/*.md", path: "x/docs/a.md", matched: false},
+		{name: "Star Does Not Cross Slash", pattern: "docs/*.md", path: "docs/sub/a.md", matched: false},
+		{name: "Dir Only Matches Dir", pattern: "node_modules/", path: "web/node_modules", isDir: true, matched: true},
+		{name: "Dir Only Skips File", pattern: "node_modules/", path: "web/node_modules", isDir: false, matched: false},
+		{name: "Leading Double Star", pattern: "**/vendor", path: "vendor", isDir: true, matched: true},
+		{name: "Leading Double Star Nested", pattern: "**/vendor", path: "a/b/vendor", isDir: true, matched: true},
+		{name: "Middle Double Star Zero", pattern: "a/**/b", path: "a/b", matched: true},
+		{name: "Middle Double Star Many", pattern: "a/**/b", path: "a/x/y/b", matched: true},
+		{name: "Trailing Double Star Contents", pattern: "out/**", path: "out/x/y.bin", matched: true},
+		{name: "Trailing Double Star Not Self", pattern: "out/**", path: "out", isDir: true, matched: false},
+		{name: "Question Mark", pattern: "file?.txt", path: "file1.txt", matched: true},
+		{name: "Bracket Negation", pattern: "file[!0-9].txt", path: "filea.txt", matched: true},
+		{name: "Bracket Negation No Match", pattern: "file[!0-9].txt", path: "file1.txt", matched: false},
+		{name: "Escaped Hash", pattern: `\#notes`, path: "#notes", matched: true},
+		{name: "Base Scoped", pattern: "*.tmp", base: "pkg", path: "pkg/a/b.tmp", matched: true},
+		{name: "Base Scoped Outside", pattern: "*.tmp", base: "pkg", path: "other/b.tmp", matched: false},
+		{name: "Base Anchored", pattern: "/gen", base: "pkg", path: "pkg/gen", isDir: true, matched: true},
+		{name: "Base Anchored Deeper", pattern: "/gen", base: "pkg", path: "pkg/a/gen", isDir: true, matched: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, ok := ParsePattern(tc.pattern, tc.base)
+			require.True(t, ok, "pattern should parse")
+			assert.Equal(t, tc.matched, p.Match(tc.path, tc.isDir))
+		})
+	}
+}
+
+func TestParsePattern_Skips_Blank_And_Comments(t *testing.T) {
+	for _, line := range []string{"", "   ", "# comment", "/"} {
+		_, ok := ParsePattern(line, "")
+		assert.False(t, ok, "line %q should be skipped", line)
+	}
+}
+
+func TestMatcher_Excluded_Negation(t *testing.T) {
+	m := New(
+		"*.log",
+		"!keep.log",
+		"build/",
+		"!build/keep.txt",
+	)
+
+	assert.True(t, m.Excluded("a/debug.log", false))
+	assert.False(t, m.Excluded("a/keep.log", false))
+	assert.False(t, m.Excluded("main.go", false))
+
+	// A parent directory that is excluded cannot have its contents re-included.
+	assert.True(t, m.Excluded("build", true))
+	assert.True(t, m.Excluded("build/keep.txt", false))
+}
+
+func TestMatcher_Last_Match_Wins(t *testing.T) {
+	m := New("!secret.txt", "secret.txt")
+	assert.True(t, m.Excluded("secret.txt", false))
+
+	m = New("secret.txt", "!secret.txt")
+	assert.False(t, m.Excluded("secret.txt", false))
+}
+
+func TestMatcher_AnyMatch(t *testing.T) {
+	m := New("*.go", "docs/**")
+	assert.True(t, m.AnyMatch("cmd/main.go", false))
+	assert.True(t, m.AnyMatch("docs/a/b.md", false))
+	assert.False(t, m.AnyMatch("readme.md", false))
+
+	var nilMatcher *Matcher
+	assert.False(t, nilMatcher.AnyMatch("a.go", false))
+	assert.Equal(t, 0, nilMatcher.Len())
+}
+
+func TestLoadGitIgnores(t *testing.T) {
+	repo := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git", "info"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "sub", "inner"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".git", "info", "exclude"), []byte("*.secret\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".gitignore"), []byte("*.log\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "sub", ".gitignore"), []byte("/gen\n"), 0644))
+
+	m, matchRoot, err := LoadGitIgnores(filepath.Join(repo, "sub", "inner"))
+	require.NoError(t, err)
+	assert.Equal(t, repo, matchRoot)
+
+	assert.True(t, m.Excluded(".git", true), ".git should always be excluded")
+	assert.True(t, m.Excluded("sub/inner/a.secret", false), "info/exclude should apply")
+	assert.True(t, m.Excluded("sub/inner/a.log", false), "root .gitignore should apply")
+	assert.True(t, m.Excluded("sub/gen", true), "parent .gitignore should apply")
+	assert.False(t, m.Excluded("sub/inner/gen", true), "anchored pattern should not leak into nested dirs")
+}
+
+func TestLoadGitIgnores_Outside_Repository(t *testing.T) {
+	dir := t.TempDir()
+
+	m, matchRoot, err := LoadGitIgnores(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, dir, matchRoot)
+}
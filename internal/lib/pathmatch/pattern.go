@@ -0,0 +1,167 @@
+package pathmatch
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const doubleStar = "**"
+
+// Pattern is a single compiled gitignore-style rule.
+type Pattern struct {
+	// Raw is the line the pattern was parsed from.
+	Raw string
+
+	// base is the slash separated directory (relative to the matcher root)
+	// the pattern was declared in, e.g. the folder holding a nested .gitignore.
+	base     string
+	segments []string
+	negate   bool
+	dirOnly  bool
+}
+
+// ParsePattern compiles a single gitignore line declared in "base".
+// It returns false for blank lines and comments.
+//
+// Supported syntax follows gitignore(5):
+//   - "#" starts a comment, "\#" and "\!" escape a leading hash or bang.
+//   - "!" negates the pattern, re-including previously excluded paths.
+//   - A trailing "/" only matches directories.
+//   - A leading or middle "/" anchors the pattern to "base", otherwise
+//     it matches at any depth.
+//   - "*", "?" and "[...]" match within a single path segment, while
+//     "**" matches across any number of segments.
+func ParsePattern(line, base string) (*Pattern, bool) {
+	line = strings.TrimRight(line, "\r\n")
+	line = trimTrailingSpaces(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, false
+	}
+
+	p := &Pattern{
+		Raw:  line,
+		base: cleanBase(base),
+	}
+
+	switch {
+	case strings.HasPrefix(line, "!"):
+		p.negate = true
+		line = line[1:]
+	case strings.HasPrefix(line, `\!`), strings.HasPrefix(line, `\#`):
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	if line == "" {
+		return nil, false
+	}
+
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	for _, segment := range strings.Split(line, "/") {
+		if segment == "" {
+			continue
+		}
+		p.segments = append(p.segments, toPathMatchSyntax(segment))
+	}
+
+	if !anchored && (len(p.segments) == 0 || p.segments[0] != doubleStar) {
+		p.segments = append([]string{doubleStar}, p.segments...)
+	}
+
+	return p, true
+}
+
+// Negate reports whether the pattern re-includes paths.
+func (p *Pattern) Negate() bool {
+	return p.negate
+}
+
+// Match reports whether the slash separated "relPath" (relative to the
+// matcher root) is matched by the pattern.
+func (p *Pattern) Match(relPath string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+
+	relPath = strings.Trim(path.Clean("/"+relPath), "/")
+	if p.base != "" {
+		if !strings.HasPrefix(relPath+"/", p.base+"/") {
+			return false
+		}
+		relPath = strings.TrimPrefix(relPath, p.base)
+		relPath = strings.TrimPrefix(relPath, "/")
+	}
+
+	if relPath == "" {
+		return false
+	}
+
+	return matchSegments(p.segments, strings.Split(relPath, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, expanding
+// "**" to zero or more segments. A trailing "**" needs at least one segment
+// so "foo/**" matches everything inside "foo", but not "foo" itself.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == doubleStar {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return len(name) > 0
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// toPathMatchSyntax converts gitignore bracket negation ("[!a-z]")
+// into the form understood by path.Match ("[^a-z]").
+func toPathMatchSyntax(segment string) string {
+	if segment == doubleStar || !strings.Contains(segment, "[!") {
+		return segment
+	}
+	return strings.ReplaceAll(segment, "[!", "[^")
+}
+
+// trimTrailingSpaces removes trailing spaces unless they are escaped with a backslash.
+func trimTrailingSpaces(s string) string {
+	for strings.HasSuffix(s, " ") && !strings.HasSuffix(s, `\ `) {
+		s = s[:len(s)-1]
+	}
+	if strings.HasSuffix(s, `\ `) {
+		s = s[:len(s)-2] + " "
+	}
+	return s
+}
+
+func cleanBase(base string) string {
+	base = filepath.ToSlash(base)
+	base = strings.Trim(path.Clean("/"+base), "/")
+	return base
+}
@@ -0,0 +1,278 @@
+package preface
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"github.com/dembygenesis/local.tools/internal/lib/pathmatch"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	// Ext is the file extension of templates in the library.
+	Ext = ".tmpl"
+
+	// DefaultName is the preface clipped when no name is given.
+	DefaultName = "coding-standards"
+
+	// DirName is the library directory under the app dir.
+	DirName = "prefaces"
+)
+
+//go:embed builtin/*.tmpl
+var builtin embed.FS
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Vars are the values available to templates, e.g. {{.Language}}.
+type Vars struct {
+	Language    string `json:"language"`
+	ProjectName string `json:"project_name"`
+}
+
+// Template is a named preface. Built-in templates ship with the binary,
+// and are overridden by a template of the same name in the library.
+type Template struct {
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+	BuiltIn bool   `json:"built_in"`
+
+	// Path is the file backing the template, empty for built-in ones.
+	Path string `json:"path"`
+}
+
+// Render executes the template with the vars.
+func (t *Template) Render(vars *Vars) (string, error) {
+	if vars == nil {
+		vars = &Vars{}
+	}
+
+	tmpl, err := parse(t.Name, t.Text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("execute '%s': %v", t.Name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// Library is a directory of "<name>.tmpl" files, on top of the built-in templates.
+type Library struct {
+	dir string
+}
+
+func NewLibrary(dir string) *Library {
+	return &Library{dir: dir}
+}
+
+// Dir is where the templates of the library are stored.
+func (l *Library) Dir() string {
+	return l.dir
+}
+
+// List returns all templates sorted by name.
+func (l *Library) List() ([]Template, error) {
+	byName := make(map[string]Template)
+
+	names, err := fs.Glob(builtin, "builtin/*"+Ext)
+	if err != nil {
+		return nil, fmt.Errorf("glob built-in: %v", err)
+	}
+	for _, name := range names {
+		b, err := builtin.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("read built-in '%s': %v", name, err)
+		}
+		t := Template{Name: strings.TrimSuffix(path.Base(name), Ext), Text: string(b), BuiltIn: true}
+		byName[t.Name] = t
+	}
+
+	entries, err := os.ReadDir(l.dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read library: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != Ext {
+			continue
+		}
+		p := filepath.Join(l.dir, entry.Name())
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("read '%s': %v", p, err)
+		}
+		t := Template{Name: strings.TrimSuffix(entry.Name(), Ext), Text: string(b), Path: p}
+		byName[t.Name] = t
+	}
+
+	templates := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates, nil
+}
+
+// Get returns the template with the name.
+func (l *Library) Get(name string) (*Template, error) {
+	templates, err := l.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range templates {
+		if templates[i].Name == name {
+			return &templates[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("preface '%s' not found", name)
+}
+
+// Render renders the template picked by the options (the coding standards by
+// default). The project name defaults to ProjectName.
+func (l *Library) Render(opts *Options) (string, error) {
+	if opts == nil {
+		return "", errors.New("options nil")
+	}
+
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		name = DefaultName
+	}
+
+	vars := opts.Vars
+	if strings.TrimSpace(vars.ProjectName) == "" {
+		vars.ProjectName = ProjectName()
+	}
+
+	t, err := l.Get(name)
+	if err != nil {
+		return "", fmt.Errorf("get preface: %v", err)
+	}
+
+	text, err := t.Render(&vars)
+	if err != nil {
+		return "", fmt.Errorf("render preface: %v", err)
+	}
+
+	return text, nil
+}
+
+// ProjectName is the name of the repository, or directory the command runs in.
+func ProjectName() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	if root := pathmatch.FindGitRoot(wd); root != "" {
+		return filepath.Base(root)
+	}
+	return filepath.Base(wd)
+}
+
+// Add stores a new template. Built-in templates may be overridden,
+// but templates already in the library may not.
+func (l *Library) Add(name, text string) error {
+	if _, err := os.Stat(l.path(name)); err == nil {
+		return fmt.Errorf("preface '%s' already exists", name)
+	}
+	return l.Save(name, text)
+}
+
+// Save creates or replaces a template after checking that it parses.
+func (l *Library) Save(name, text string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	if _, err := parse(name, text); err != nil {
+		return err
+	}
+
+	if err := fslib.CreateFileWithDirs(l.path(name), []byte(text)); err != nil {
+		return fmt.Errorf("write '%s': %v", name, err)
+	}
+
+	return nil
+}
+
+// Remove deletes a template from the library. Built-in templates cannot be
+// removed, only the library templates overriding them.
+func (l *Library) Remove(name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	err := os.Remove(l.path(name))
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, os.ErrNotExist) {
+		if _, builtinErr := fs.Stat(builtin, "builtin/"+name+Ext); builtinErr == nil {
+			return fmt.Errorf("preface '%s' is built-in and cannot be removed", name)
+		}
+		return fmt.Errorf("preface '%s' not found", name)
+	}
+
+	return fmt.Errorf("remove '%s': %v", name, err)
+}
+
+// Materialize makes sure the template has a file in the library, copying
+// built-in templates over, and returns its path so it can be edited.
+func (l *Library) Materialize(name string) (string, error) {
+	t, err := l.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	if t.BuiltIn {
+		if err := l.Save(name, t.Text); err != nil {
+			return "", err
+		}
+	}
+
+	return l.path(name), nil
+}
+
+func (l *Library) path(name string) string {
+	return filepath.Join(l.dir, name+Ext)
+}
+
+// ValidateName only allows lower case names that are safe as file names.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid preface name '%s', use lower case letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+func parse(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse '%s': %v", name, err)
+	}
+	return tmpl, nil
+}
+
+// Options pick the template to render, and the values passed to it.
+type Options struct {
+	Name string `json:"name"`
+	Vars Vars   `json:"vars"`
+}
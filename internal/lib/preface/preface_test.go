@@ -0,0 +1,88 @@
+package preface
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLibrary_List_BuiltIn(t *testing.T) {
+	lib := NewLibrary(filepath.Join(t.TempDir(), "missing"))
+
+	templates, err := lib.List()
+	require.NoError(t, err)
+
+	var names []string
+	for _, tmpl := range templates {
+		assert.True(t, tmpl.BuiltIn)
+		names = append(names, tmpl.Name)
+	}
+	assert.Equal(t, []string{"coding-standards", "go-review", "sql-review", "test-writing"}, names)
+
+	for _, tmpl := range templates {
+		_, err := tmpl.Render(&Vars{Language: "Go", ProjectName: "tools"})
+		assert.NoError(t, err, "built-in '%s' should render", tmpl.Name)
+	}
+}
+
+func TestLibrary_Add_Show_Remove(t *testing.T) {
+	lib := NewLibrary(t.TempDir())
+
+	require.NoError(t, lib.Add("api-review", "Review the {{.Language}} API of {{.ProjectName}}."))
+	require.Error(t, lib.Add("api-review", "again"), "already exists")
+
+	tmpl, err := lib.Get("api-review")
+	require.NoError(t, err)
+	assert.False(t, tmpl.BuiltIn)
+	assert.Equal(t, filepath.Join(lib.Dir(), "api-review"+Ext), tmpl.Path)
+
+	out, err := tmpl.Render(&Vars{Language: "Go", ProjectName: "tools"})
+	require.NoError(t, err)
+	assert.Equal(t, "Review the Go API of tools.", out)
+
+	require.NoError(t, lib.Remove("api-review"))
+	_, err = lib.Get("api-review")
+	require.Error(t, err)
+	require.Error(t, lib.Remove("api-review"), "not found")
+}
+
+func TestLibrary_Override_BuiltIn(t *testing.T) {
+	lib := NewLibrary(t.TempDir())
+
+	require.Error(t, lib.Remove(DefaultName), "built-in templates cannot be removed")
+
+	p, err := lib.Materialize(DefaultName)
+	require.NoError(t, err)
+	require.FileExists(t, p)
+
+	require.NoError(t, lib.Save(DefaultName, "team wording"))
+	tmpl, err := lib.Get(DefaultName)
+	require.NoError(t, err)
+	assert.False(t, tmpl.BuiltIn)
+	assert.Equal(t, "team wording", tmpl.Text)
+
+	require.NoError(t, lib.Remove(DefaultName), "removing the override restores the built-in")
+	tmpl, err = lib.Get(DefaultName)
+	require.NoError(t, err)
+	assert.True(t, tmpl.BuiltIn)
+}
+
+func TestLibrary_Save_Fail(t *testing.T) {
+	lib := NewLibrary(t.TempDir())
+
+	require.Error(t, lib.Save("Bad Name", "text"), "invalid name")
+	require.Error(t, lib.Save("../escape", "text"), "invalid name")
+	require.Error(t, lib.Save("broken", "{{.Language"), "unparsable template")
+
+	_, err := os.Stat(filepath.Join(lib.Dir(), "broken"+Ext))
+	assert.True(t, os.IsNotExist(err), "nothing should be written")
+}
+
+func TestTemplate_Render_Unknown_Var(t *testing.T) {
+	tmpl := &Template{Name: "bad", Text: "{{.Unknown}}"}
+
+	_, err := tmpl.Render(nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package prompt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"strings"
+)
+
+const (
+	SectionPreface  = "preface"
+	SectionFiles    = "files"
+	SectionQuestion = "question"
+)
+
+// DefaultOrder is the order sections are composed in when none is given.
+var DefaultOrder = []string{SectionPreface, SectionFiles, SectionQuestion}
+
+var sectionTitles = map[string]string{
+	SectionPreface:  "Preface",
+	SectionFiles:    "Files",
+	SectionQuestion: "Question",
+}
+
+// Options describe a prompt bundle: a preface, the files under a root, and a question.
+type Options struct {
+	// Preface is rendered unless NoPreface is set.
+	Preface   preface.Options `json:"preface"`
+	NoPreface bool            `json:"no_preface"`
+
+	// Clip selects the files, and holds the format and output of the whole
+	// bundle. Files are left out when the root is empty.
+	Clip cliputil.ClipOptions `json:"clip"`
+
+	Question string `json:"question"`
+
+	// Order lists the sections to compose, defaults to DefaultOrder.
+	Order []string `json:"order"`
+}
+
+// Section is a named part of the prompt. Bodies are already rendered in the
+// format of the prompt, so the files section of a "json" prompt holds JSON.
+type Section struct {
+	Name string
+	Body string
+}
+
+// ParseOrder validates the section order, falling back to DefaultOrder when empty.
+func ParseOrder(order []string) ([]string, error) {
+	if len(order) == 0 {
+		return DefaultOrder, nil
+	}
+
+	seen := make(map[string]bool, len(order))
+	parsed := make([]string, 0, len(order))
+	for _, name := range order {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := sectionTitles[name]; !ok {
+			return nil, fmt.Errorf("unknown section '%s', expected any of: %s", name, strings.Join(DefaultOrder, ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("section '%s' is listed twice", name)
+		}
+		seen[name] = true
+		parsed = append(parsed, name)
+	}
+
+	return parsed, nil
+}
+
+// jsonPrompt is the json format of a prompt. JSON objects are unordered, so
+// its sections always come in the order of the fields.
+type jsonPrompt struct {
+	Preface  string          `json:"preface,omitempty"`
+	Files    json.RawMessage `json:"files,omitempty"`
+	Question string          `json:"question,omitempty"`
+}
+
+// Compose joins the non-empty sections in order, separated in a way that fits
+// the format. The json format keeps the order of jsonPrompt instead.
+func Compose(format string, sections []Section) (string, error) {
+	var kept []Section
+	for _, section := range sections {
+		if strings.TrimSpace(section.Body) != "" {
+			kept = append(kept, section)
+		}
+	}
+	if len(kept) == 0 {
+		return "", errors.New("the prompt is empty")
+	}
+
+	var sb strings.Builder
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", cliputil.FormatPlain:
+		for i, section := range kept {
+			if i > 0 {
+				sb.WriteString("\n\n")
+			}
+			sb.WriteString(fmt.Sprintf("=== %s ===\n\n", strings.ToUpper(sectionTitles[section.Name])))
+			sb.WriteString(strings.Trim(section.Body, "\n"))
+		}
+		sb.WriteString("\n")
+	case cliputil.FormatMarkdown:
+		for i, section := range kept {
+			if i > 0 {
+				sb.WriteString("\n\n---\n\n")
+			}
+			sb.WriteString(fmt.Sprintf("## %s\n\n", sectionTitles[section.Name]))
+			sb.WriteString(strings.Trim(section.Body, "\n"))
+		}
+		sb.WriteString("\n")
+	case cliputil.FormatXML:
+		sb.WriteString("<prompt>\n")
+		for _, section := range kept {
+			// The files section is a <files> element already, the others are text.
+			if section.Name == SectionFiles {
+				sb.WriteString(strings.Trim(section.Body, "\n") + "\n")
+				continue
+			}
+			sb.WriteString("<" + section.Name + "><![CDATA[")
+			sb.WriteString(cliputil.EscapeCDATA(section.Body))
+			sb.WriteString("]]></" + section.Name + ">\n")
+		}
+		sb.WriteString("</prompt>\n")
+	case cliputil.FormatJSON:
+		var prompt jsonPrompt
+		for _, section := range kept {
+			switch section.Name {
+			case SectionPreface:
+				prompt.Preface = section.Body
+			case SectionFiles:
+				if !json.Valid([]byte(section.Body)) {
+					return "", errors.New("the files section is not valid json")
+				}
+				prompt.Files = json.RawMessage(section.Body)
+			case SectionQuestion:
+				prompt.Question = section.Body
+			}
+		}
+
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(prompt); err != nil {
+			return "", fmt.Errorf("marshal: %v", err)
+		}
+		sb.WriteString(strings.TrimSuffix(buf.String(), "\n"))
+	default:
+		return "", fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(cliputil.Formats, ", "))
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,100 @@
+package prompt
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var testSections = []Section{
+	{Name: SectionPreface, Body: "Be <thorough>.\n"},
+	{Name: SectionFiles, Body: ""},
+	{Name: SectionQuestion, Body: "Why ]]> & how?"},
+}
+
+func TestParseOrder(t *testing.T) {
+	order, err := ParseOrder(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultOrder, order)
+
+	order, err = ParseOrder([]string{" Question", "files"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{SectionQuestion, SectionFiles}, order)
+
+	_, err = ParseOrder([]string{"preface", "preface"})
+	require.Error(t, err, "listed twice")
+
+	_, err = ParseOrder([]string{"answer"})
+	require.Error(t, err, "unknown section")
+}
+
+func TestCompose_Plain_Markdown(t *testing.T) {
+	out, err := Compose("", testSections)
+	require.NoError(t, err)
+	assert.Equal(t, "=== PREFACE ===\n\nBe <thorough>.\n\n=== QUESTION ===\n\nWhy ]]> & how?\n", out, "empty sections are left out")
+
+	out, err = Compose(cliputil.FormatMarkdown, testSections)
+	require.NoError(t, err)
+	assert.Equal(t, "## Preface\n\nBe <thorough>.\n\n---\n\n## Question\n\nWhy ]]> & how?\n", out)
+}
+
+func TestCompose_XML(t *testing.T) {
+	files, err := cliputil.Render(cliputil.FormatXML, &cliputil.Payload{
+		Files: []cliputil.FileContent{{Path: "main.go", Content: "package main\n"}},
+	})
+	require.NoError(t, err)
+
+	sections := append([]Section{}, testSections...)
+	sections[1].Body = files
+
+	out, err := Compose(cliputil.FormatXML, sections)
+	require.NoError(t, err)
+
+	var parsed struct {
+		Preface string `xml:"preface"`
+		Files   struct {
+			File []struct {
+				Path string `xml:"path,attr"`
+			} `xml:"file"`
+		} `xml:"files"`
+		Question string `xml:"question"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(out), &parsed), "output should be valid xml")
+	assert.Equal(t, "Be <thorough>.\n", parsed.Preface)
+	assert.Equal(t, "main.go", parsed.Files.File[0].Path)
+	assert.Equal(t, "Why ]]> & how?", parsed.Question)
+}
+
+func TestCompose_JSON(t *testing.T) {
+	sections := []Section{
+		{Name: SectionQuestion, Body: "Why & how?"},
+		{Name: SectionFiles, Body: `[{"path":"main.go","content":"package main\n"}]`},
+	}
+
+	out, err := Compose(cliputil.FormatJSON, sections)
+	require.NoError(t, err)
+	assert.Contains(t, out, `"Why & how?"`, "html characters are kept as is")
+	assert.NotContains(t, out, "preface", "empty sections are left out")
+
+	var parsed struct {
+		Question string                 `json:"question"`
+		Files    []cliputil.FileContent `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &parsed))
+	assert.Equal(t, "Why & how?", parsed.Question)
+	assert.Equal(t, "main.go", parsed.Files[0].Path)
+
+	_, err = Compose(cliputil.FormatJSON, []Section{{Name: SectionFiles, Body: "not json"}})
+	require.Error(t, err)
+}
+
+func TestCompose_Fail(t *testing.T) {
+	_, err := Compose("", []Section{{Name: SectionQuestion, Body: " \n"}})
+	require.Error(t, err, "empty prompt")
+
+	_, err = Compose("yaml", testSections)
+	require.Error(t, err, "unknown format")
+}
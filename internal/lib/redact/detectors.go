@@ -0,0 +1,102 @@
+package redact
+
+import (
+	"math"
+	"regexp"
+	"unicode"
+)
+
+// The built-in detectors, by name.
+const (
+	DetectorAWSAccessKey = "aws-access-key"
+	DetectorAWSSecretKey = "aws-secret-key"
+	DetectorJWT          = "jwt"
+	DetectorPrivateKey   = "private-key"
+	DetectorEnvSecret    = "env-secret"
+	DetectorHighEntropy  = "high-entropy"
+)
+
+const (
+	// minEntropyLen is the shortest string checked for entropy, shorter random
+	// strings do not score high enough to tell them from identifiers.
+	minEntropyLen = 24
+
+	// minEntropy is in bits per character. Hex strings, like hashes and git
+	// revisions, top out at 4, so they are left alone.
+	minEntropy = 4.2
+)
+
+var (
+	awsAccessKey = regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[A-Z0-9]{16}\b`)
+	awsSecretKey = regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?(?P<secret>[A-Za-z0-9/+=]{40})`)
+	jwt          = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	privateKey   = regexp.MustCompile(`(?s)-----BEGIN [A-Z0-9 ]*PRIVATE KEY(?: BLOCK)?-----.*?-----END [A-Z0-9 ]*PRIVATE KEY(?: BLOCK)?-----`)
+
+	// envSecret matches upper case env assignments whose name has a secret-like
+	// part, e.g. "export DB_PASS=secret" or "API_TOKEN: 'secret'".
+	envSecret = regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?(?:[A-Z0-9]+_)*(?:PASS|PASSWORD|PASSWD|PWD|SECRET|TOKEN|API_?KEY|ACCESS_KEY|PRIVATE_KEY|CREDENTIALS?)(?:_[A-Z0-9]+)*[ \t]*[=:][ \t]*(?P<secret>"[^"\n]+"|'[^'\n]+'|[^\s#"']+)`)
+
+	// entropyCandidate also matches the prefix of checksums, like the "h1:" of
+	// go.sum, so isHighEntropy can leave them alone.
+	entropyCandidate = regexp.MustCompile(`(?:\b(?:h1|sha1|sha256|sha384|sha512)[:-])?(?P<secret>[A-Za-z0-9+/_-]{24,}={0,2})`)
+	checksumPrefix   = regexp.MustCompile(`^(?:h1|sha1|sha256|sha384|sha512)[:-]`)
+)
+
+// Builtin returns the built-in detectors.
+func Builtin() []Detector {
+	return []Detector{
+		{Name: DetectorPrivateKey, Pattern: privateKey},
+		{Name: DetectorAWSAccessKey, Pattern: awsAccessKey},
+		{Name: DetectorAWSSecretKey, Pattern: awsSecretKey},
+		{Name: DetectorJWT, Pattern: jwt},
+		{Name: DetectorEnvSecret, Pattern: envSecret},
+		{Name: DetectorHighEntropy, Pattern: entropyCandidate, Confirm: isHighEntropy},
+	}
+}
+
+// isHighEntropy reports whether the matched string looks random: it is not a
+// checksum, mixes letters and digits, and its Shannon entropy is over minEntropy.
+func isHighEntropy(s string) bool {
+	if checksumPrefix.MatchString(s) {
+		return false
+	}
+
+	if len(s) < minEntropyLen {
+		return false
+	}
+
+	var letters, digits bool
+	for _, r := range s {
+		letters = letters || unicode.IsLetter(r)
+		digits = digits || unicode.IsDigit(r)
+	}
+	if !letters || !digits {
+		return false
+	}
+
+	return Entropy(s) >= minEntropy
+}
+
+// Entropy returns the Shannon entropy of the string, in bits per byte.
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	entropy := 0.0
+	n := float64(len(s))
+	for _, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
@@ -0,0 +1,157 @@
+package redact
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/pathmatch"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PatternsFile holds extra patterns in the app dir, one regular expression per
+// line. Blank lines and lines starting with "#" are ignored.
+const PatternsFile = "redact-patterns"
+
+// secretGroup names the capture group holding the secret, when a pattern
+// matches the text around it, e.g. the name of an env assignment.
+const secretGroup = "secret"
+
+// CustomDetector names the secrets matched by the configured patterns.
+const CustomDetector = "custom"
+
+// Config enables redaction, and adds patterns to the built-in detectors.
+type Config struct {
+	// Disabled turns redaction off, it is on by default.
+	Disabled bool `mapstructure:"disabled" json:"disabled"`
+
+	// Patterns are extra regular expressions to mask. A pattern with a
+	// "secret" group only masks what the group matched.
+	Patterns []string `mapstructure:"patterns" json:"patterns"`
+
+	// PatternsFile is read for more patterns, when it exists.
+	PatternsFile string `mapstructure:"patterns_file" json:"patterns_file"`
+}
+
+// Detector finds one kind of secret.
+type Detector struct {
+	Name string
+
+	// Pattern matches the secret, or the text around it with the secret in a "secret" group.
+	Pattern *regexp.Regexp
+
+	// Confirm, when set, gets the whole match, and rejects the ones that are not secrets after all.
+	Confirm func(match string) bool
+}
+
+// Finding is a masked secret. The secret itself is never kept.
+type Finding struct {
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line"`
+	Detector string `json:"detector"`
+}
+
+// Redactor masks the secrets its detectors find.
+type Redactor struct {
+	detectors []Detector
+}
+
+// New builds a Redactor with the built-in detectors, and the patterns of the config.
+// It returns nil when redaction is disabled, which redacts nothing.
+func New(cfg *Config) (*Redactor, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	patterns := append([]string{}, cfg.Patterns...)
+	if strings.TrimSpace(cfg.PatternsFile) != "" {
+		lines, err := pathmatch.ReadFile(cfg.PatternsFile)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("read patterns: %w", err)
+		}
+		patterns = append(patterns, lines...)
+	}
+
+	detectors := Builtin()
+	for _, pattern := range patterns {
+		if strings.TrimSpace(pattern) == "" || strings.HasPrefix(strings.TrimSpace(pattern), "#") {
+			continue
+		}
+
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compile pattern '%s': %w", pattern, err)
+		}
+		detectors = append(detectors, Detector{Name: CustomDetector, Pattern: re})
+	}
+
+	return &Redactor{detectors: detectors}, nil
+}
+
+// span is a secret to mask, in bytes of the text.
+type span struct {
+	start, end int
+	detector   string
+}
+
+// Redact replaces every secret in the text with a "[REDACTED:<detector>]"
+// marker, and reports where they were. Overlapping secrets are masked once,
+// as the one starting first.
+func (r *Redactor) Redact(text string) (string, []Finding) {
+	if r == nil || text == "" {
+		return text, nil
+	}
+
+	var spans []span
+	for _, d := range r.detectors {
+		group := d.Pattern.SubexpIndex(secretGroup)
+		for _, m := range d.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[0], m[1]
+			if group > 0 {
+				start, end = m[2*group], m[2*group+1]
+			}
+			if start < 0 || start == end {
+				continue
+			}
+			if d.Confirm != nil && !d.Confirm(text[m[0]:m[1]]) {
+				continue
+			}
+			spans = append(spans, span{start: start, end: end, detector: d.Name})
+		}
+	}
+
+	if len(spans) == 0 {
+		return text, nil
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		return spans[i].end > spans[j].end
+	})
+
+	var sb strings.Builder
+	findings := make([]Finding, 0, len(spans))
+	last := 0
+	for _, s := range spans {
+		if s.start < last {
+			continue
+		}
+		sb.WriteString(text[last:s.start])
+		sb.WriteString("[REDACTED:" + s.detector + "]")
+		findings = append(findings, Finding{
+			Line:     strings.Count(text[:s.start], "\n") + 1,
+			Detector: s.detector,
+		})
+		last = s.end
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String(), findings
+}
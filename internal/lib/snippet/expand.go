@@ -0,0 +1,114 @@
+package snippet
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// placeholder matches "{{name}}", or "{{name|default}}" with a default value.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*(?:\|([^}]*))?\}\}`)
+
+// Placeholder is a value a snippet needs when it is clipped.
+type Placeholder struct {
+	Name       string `json:"name"`
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"has_default"`
+}
+
+// String renders the placeholder the way it is written in snippets.
+func (p Placeholder) String() string {
+	if p.HasDefault {
+		return p.Name + "|" + p.Default
+	}
+	return p.Name
+}
+
+// Placeholders lists the placeholders of the text once, in order of appearance.
+// A placeholder used more than once keeps its first default.
+func Placeholders(text string) []Placeholder {
+	seen := make(map[string]bool)
+	placeholders := make([]Placeholder, 0)
+	for _, m := range placeholder.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[2]:m[3]]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		p := Placeholder{Name: name}
+		if m[4] >= 0 {
+			p.Default, p.HasDefault = strings.TrimSpace(text[m[4]:m[5]]), true
+		}
+		placeholders = append(placeholders, p)
+	}
+	return placeholders
+}
+
+// Expand fills in the placeholders of the text with the values, or their
+// defaults. It fails on placeholders without a value or default, and on
+// values no placeholder uses, as these are likely typos.
+func Expand(text string, values map[string]string) (string, error) {
+	used := make(map[string]bool)
+	var missing []string
+
+	expanded := placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		m := placeholder.FindStringSubmatch(match)
+		name := m[1]
+		used[name] = true
+
+		if value, ok := values[name]; ok {
+			return value
+		}
+		if strings.Contains(match, "|") {
+			return strings.TrimSpace(m[2])
+		}
+
+		missing = append(missing, name)
+		return match
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing values for: %s", strings.Join(dedupe(missing), ", "))
+	}
+
+	var unknown []string
+	for name := range values {
+		if !used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", fmt.Errorf("unknown placeholders: %s", strings.Join(unknown, ", "))
+	}
+
+	return expanded, nil
+}
+
+// ParseValues reads "key=value" arguments into placeholder values.
+func ParseValues(args []string) (map[string]string, error) {
+	values := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid value '%s', expected key=value", arg)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func dedupe(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	deduped := make([]string, 0, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			deduped = append(deduped, name)
+		}
+	}
+	return deduped
+}
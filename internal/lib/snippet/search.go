@@ -0,0 +1,88 @@
+package snippet
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Match is a snippet found by Search, the higher the score the better.
+type Match struct {
+	Snippet
+	Score int `json:"score"`
+}
+
+// Search ranks the snippets matching the query. Names match fuzzily, the
+// query letters appearing in order, and score best on consecutive letters and
+// word starts. Descriptions and texts match when they contain the query.
+func Search(snippets []Snippet, query string) []Match {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	matches := make([]Match, 0)
+	for _, s := range snippets {
+		score, ok := fuzzyScore(query, s.Name)
+		if ok {
+			score += 100
+		}
+
+		if strings.Contains(strings.ToLower(s.Description), query) {
+			score, ok = score+50, true
+		}
+
+		if strings.Contains(strings.ToLower(s.Text), query) {
+			score, ok = score+10, true
+		}
+
+		if ok {
+			matches = append(matches, Match{Snippet: s, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Name < matches[j].Name
+	})
+
+	return matches
+}
+
+// fuzzyScore reports whether the letters of the query appear in the target in
+// order, scoring consecutive letters and letters starting a word higher.
+func fuzzyScore(query, target string) (int, bool) {
+	target = strings.ToLower(target)
+	if query == "" {
+		return 0, true
+	}
+
+	score, qi, prev := 0, 0, -2
+	runes := []rune(target)
+	queryRunes := []rune(query)
+	for ti, r := range runes {
+		if qi == len(queryRunes) {
+			break
+		}
+		if r != queryRunes[qi] {
+			continue
+		}
+
+		score++
+		if ti == prev+1 {
+			score += 5
+		}
+		if ti == 0 || !unicode.IsLetter(runes[ti-1]) && !unicode.IsDigit(runes[ti-1]) {
+			score += 3
+		}
+
+		prev = ti
+		qi++
+	}
+
+	if qi < len(queryRunes) {
+		return 0, false
+	}
+
+	// Shorter names are closer matches.
+	return score - (len(runes)-len(queryRunes))/4, true
+}
@@ -0,0 +1,198 @@
+package snippet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FileName is the store under the app dir.
+const FileName = "snippets.json"
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// Snippet is a named text, with {{placeholders}} filled in when clipped.
+type Snippet struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Text        string `json:"text"`
+}
+
+// Placeholders lists the placeholders of the text.
+func (s *Snippet) Placeholders() []Placeholder {
+	return Placeholders(s.Text)
+}
+
+// Store keeps the snippets in a single JSON file.
+type Store struct {
+	path string
+}
+
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path is the file backing the store.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// List returns the snippets sorted by name.
+func (s *Store) List() ([]Snippet, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make([]Snippet, 0), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open snippets: %v", err)
+	}
+	defer f.Close()
+
+	snippets, err := Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("read '%s': %v", s.path, err)
+	}
+
+	sort.Slice(snippets, func(i, j int) bool {
+		return snippets[i].Name < snippets[j].Name
+	})
+
+	return snippets, nil
+}
+
+// Get returns the snippet with the name.
+func (s *Store) Get(name string) (*Snippet, error) {
+	snippets, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range snippets {
+		if snippets[i].Name == name {
+			return &snippets[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("snippet '%s' not found", name)
+}
+
+// Add stores the snippets, failing on existing names unless overwriting.
+// Nothing is stored when one of them fails.
+func (s *Store) Add(overwrite bool, add ...Snippet) error {
+	snippets, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]int, len(snippets))
+	for i, snippet := range snippets {
+		index[snippet.Name] = i
+	}
+
+	for _, snippet := range add {
+		if err := ValidateName(snippet.Name); err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(snippet.Text) == "" {
+			return fmt.Errorf("snippet '%s' is empty", snippet.Name)
+		}
+
+		i, exists := index[snippet.Name]
+		if !exists {
+			index[snippet.Name] = len(snippets)
+			snippets = append(snippets, snippet)
+			continue
+		}
+		if !overwrite {
+			return fmt.Errorf("snippet '%s' already exists", snippet.Name)
+		}
+		snippets[i] = snippet
+	}
+
+	return s.write(snippets)
+}
+
+// Remove deletes the snippet with the name.
+func (s *Store) Remove(name string) error {
+	snippets, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	for i := range snippets {
+		if snippets[i].Name == name {
+			return s.write(append(snippets[:i], snippets[i+1:]...))
+		}
+	}
+
+	return fmt.Errorf("snippet '%s' not found", name)
+}
+
+// write replaces the store through a temp file, so a failed write keeps the old one.
+func (s *Store) write(snippets []Snippet) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+		return fmt.Errorf("create dir: %v", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create temp: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := Encode(tmp, snippets); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write snippets: %v", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close snippets: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replace snippets: %v", err)
+	}
+
+	return nil
+}
+
+// Encode writes the snippets as an indented JSON array, the format of the
+// store and of exports.
+func Encode(w io.Writer, snippets []Snippet) error {
+	if snippets == nil {
+		snippets = make([]Snippet, 0)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(snippets)
+}
+
+// Decode reads a JSON array of snippets.
+func Decode(r io.Reader) ([]Snippet, error) {
+	var snippets []Snippet
+	if err := json.NewDecoder(r).Decode(&snippets); err != nil {
+		return nil, fmt.Errorf("decode snippets: %v", err)
+	}
+	if snippets == nil {
+		snippets = make([]Snippet, 0)
+	}
+	return snippets, nil
+}
+
+// ValidateName only allows lower case names, that are easy to type.
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid snippet name '%s', use lower case letters, digits, '.', '-' and '_'", name)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package snippet
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestPlaceholders(t *testing.T) {
+	text := "{{ greeting | Hello there }}, {{name}}! {{name|ignored}} {{.Language}} {{ }}"
+
+	assert.Equal(t, []Placeholder{
+		{Name: "greeting", Default: "Hello there", HasDefault: true},
+		{Name: "name"},
+	}, Placeholders(text))
+}
+
+func TestExpand(t *testing.T) {
+	text := "{{greeting|Hello}}, {{ name }}! Bye {{name}}."
+
+	expanded, err := Expand(text, map[string]string{"name": "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Ada! Bye Ada.", expanded)
+
+	expanded, err = Expand(text, map[string]string{"name": "Ada", "greeting": ""})
+	require.NoError(t, err)
+	assert.Equal(t, ", Ada! Bye Ada.", expanded, "an empty value overrides the default")
+
+	_, err = Expand(text, nil)
+	require.EqualError(t, err, "missing values for: name")
+
+	_, err = Expand(text, map[string]string{"name": "Ada", "nmae": "Ada"})
+	require.EqualError(t, err, "unknown placeholders: nmae")
+}
+
+func TestParseValues(t *testing.T) {
+	values, err := ParseValues([]string{"a=1", "b=x=y", "c="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "x=y", "c": ""}, values)
+
+	_, err = ParseValues([]string{"a"})
+	require.Error(t, err)
+
+	_, err = ParseValues([]string{"=1"})
+	require.Error(t, err)
+}
+
+func TestStore(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested", FileName))
+
+	snippets, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, snippets, "a missing store is empty")
+
+	require.NoError(t, store.Add(false, Snippet{Name: "b", Text: "bee"}, Snippet{Name: "a", Text: "ay"}))
+
+	snippets, err = store.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Snippet{{Name: "a", Text: "ay"}, {Name: "b", Text: "bee"}}, snippets)
+
+	err = store.Add(false, Snippet{Name: "c", Text: "see"}, Snippet{Name: "a", Text: "again"})
+	require.EqualError(t, err, "snippet 'a' already exists")
+	_, err = store.Get("c")
+	require.Error(t, err, "nothing is stored when one fails")
+
+	require.NoError(t, store.Add(true, Snippet{Name: "a", Text: "again"}))
+	snip, err := store.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, "again", snip.Text)
+
+	require.Error(t, store.Add(false, Snippet{Name: "Bad Name", Text: "x"}))
+	require.Error(t, store.Add(false, Snippet{Name: "empty", Text: " \n"}))
+
+	require.NoError(t, store.Remove("a"))
+	require.EqualError(t, store.Remove("a"), "snippet 'a' not found")
+
+	snippets, err = store.List()
+	require.NoError(t, err)
+	assert.Equal(t, []Snippet{{Name: "b", Text: "bee"}}, snippets)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	snippets := []Snippet{{Name: "a", Description: "<html> & co", Text: "{{x}}"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, snippets))
+	assert.Contains(t, buf.String(), "<html> & co", "exports stay readable")
+
+	decoded, err := Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, snippets, decoded)
+
+	_, err = Decode(bytes.NewBufferString(`{"name": "a"}`))
+	require.Error(t, err)
+}
+
+func TestSearch(t *testing.T) {
+	snippets := []Snippet{
+		{Name: "commit-msg", Text: "write a commit message"},
+		{Name: "code-review", Description: "review a diff"},
+		{Name: "explain", Text: "explain this code"},
+		{Name: "unrelated", Text: "nothing"},
+	}
+
+	names := func(matches []Match) []string {
+		var names []string
+		for _, m := range matches {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"code-review"}, names(Search(snippets, "crev")))
+	assert.Equal(t, []string{"commit-msg", "code-review", "explain"}, names(Search(snippets, "co")), "texts add to name matches")
+	assert.Equal(t, []string{"code-review", "explain"}, names(Search(snippets, "code")))
+	assert.Equal(t, []string{"code-review"}, names(Search(snippets, "DIFF")))
+	assert.Empty(t, Search(snippets, "zzz"))
+}
@@ -0,0 +1,115 @@
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pieceRegex splits text before merging, close to the cl100k pre-tokenizer
+// but without the lookahead RE2 does not support.
+var pieceRegex = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`)
+
+// BPE counts tokens with byte-level byte pair encoding over a rank table.
+type BPE struct {
+	ranks map[string]int
+}
+
+// LoadBPE reads a tiktoken style rank file.
+func LoadBPE(filePath string) (*BPE, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer f.Close()
+
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected '<base64 token> <rank>'", line)
+		}
+
+		token, err := base64.StdEncoding.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: decode token: %w", line, err)
+		}
+
+		rank, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: parse rank: %w", line, err)
+		}
+
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	return NewBPE(ranks)
+}
+
+// NewBPE returns a tokenizer for the given token to rank table.
+func NewBPE(ranks map[string]int) (*BPE, error) {
+	if len(ranks) == 0 {
+		return nil, fmt.Errorf("empty rank table")
+	}
+	return &BPE{ranks: ranks}, nil
+}
+
+func (b *BPE) Count(text string) int {
+	count := 0
+	for _, piece := range pieceRegex.FindAllString(text, -1) {
+		count += b.countPiece([]byte(piece))
+	}
+	return count
+}
+
+func (b *BPE) Name() string {
+	return KindBPE
+}
+
+// countPiece repeatedly merges the adjacent pair with the lowest rank,
+// and returns the amount of parts left.
+func (b *BPE) countPiece(piece []byte) int {
+	if _, ok := b.ranks[string(piece)]; ok {
+		return 1
+	}
+
+	// bounds[i] is the start offset of part i, the last entry closes the final part.
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+
+	for len(bounds) > 2 {
+		minRank, minIdx := math.MaxInt, -1
+		for i := 0; i < len(bounds)-2; i++ {
+			rank, ok := b.ranks[string(piece[bounds[i]:bounds[i+2]])]
+			if ok && rank < minRank {
+				minRank, minIdx = rank, i
+			}
+		}
+		if minIdx == -1 {
+			break
+		}
+		bounds = append(bounds[:minIdx+1], bounds[minIdx+2:]...)
+	}
+
+	return len(bounds) - 1
+}
@@ -0,0 +1,31 @@
+package tokenizer
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Chars estimates tokens as a fixed amount of characters per token.
+type Chars struct {
+	charsPerToken float64
+}
+
+// NewChars returns the heuristic tokenizer, falling back to
+// DefaultCharsPerToken for non-positive values.
+func NewChars(charsPerToken float64) *Chars {
+	if charsPerToken <= 0 {
+		charsPerToken = DefaultCharsPerToken
+	}
+	return &Chars{charsPerToken: charsPerToken}
+}
+
+func (c *Chars) Count(text string) int {
+	if text == "" {
+		return 0
+	}
+	return int(math.Ceil(float64(utf8.RuneCountInString(text)) / c.charsPerToken))
+}
+
+func (c *Chars) Name() string {
+	return KindChars
+}
@@ -0,0 +1,56 @@
+package tokenizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	KindChars = "chars"
+	KindBPE   = "bpe"
+
+	// DefaultCharsPerToken is a common rule of thumb for English text and code.
+	DefaultCharsPerToken = 4.0
+)
+
+// Tokenizer estimates how many tokens a model would spend on a text.
+type Tokenizer interface {
+	Count(text string) int
+	Name() string
+}
+
+// Config selects and configures a Tokenizer.
+type Config struct {
+	// Kind is either "chars" or "bpe", and defaults to "chars".
+	Kind string `mapstructure:"kind" json:"kind"`
+
+	// CharsPerToken is used by the "chars" heuristic.
+	CharsPerToken float64 `mapstructure:"chars_per_token" json:"chars_per_token"`
+
+	// BPEFile is the local rank file used by "bpe", in the tiktoken format:
+	// one "<base64 token> <rank>" pair per line.
+	BPEFile string `mapstructure:"bpe_file" json:"bpe_file"`
+}
+
+// New builds the Tokenizer described by the config.
+func New(cfg *Config) (Tokenizer, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Kind)) {
+	case "", KindChars:
+		return NewChars(cfg.CharsPerToken), nil
+	case KindBPE:
+		if strings.TrimSpace(cfg.BPEFile) == "" {
+			return nil, fmt.Errorf("bpe tokenizer requires a rank file")
+		}
+		bpe, err := LoadBPE(cfg.BPEFile)
+		if err != nil {
+			return nil, fmt.Errorf("load bpe: %w", err)
+		}
+		return bpe, nil
+	default:
+		return nil, fmt.Errorf("unknown tokenizer kind: '%s'", cfg.Kind)
+	}
+}
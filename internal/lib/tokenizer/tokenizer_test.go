@@ -0,0 +1,82 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testRankFile writes a tiktoken style rank file with every single byte,
+// followed by the provided merges.
+func testRankFile(t *testing.T, merges ...string) string {
+	t.Helper()
+
+	var sb strings.Builder
+	rank := 0
+	for i := 0; i < 256; i++ {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(i)}), rank))
+		rank++
+	}
+	for _, merge := range merges {
+		sb.WriteString(fmt.Sprintf("%s %d\n", base64.StdEncoding.EncodeToString([]byte(merge)), rank))
+		rank++
+	}
+
+	filePath := filepath.Join(t.TempDir(), "ranks.tiktoken")
+	require.NoError(t, os.WriteFile(filePath, []byte(sb.String()), 0644))
+	return filePath
+}
+
+func TestChars_Count(t *testing.T) {
+	assert.Equal(t, 0, NewChars(4).Count(""))
+	assert.Equal(t, 1, NewChars(4).Count("abc"))
+	assert.Equal(t, 3, NewChars(4).Count("abcdefghi"))
+	assert.Equal(t, 2, NewChars(0).Count("ééééé"), "should count runes, with the default ratio")
+}
+
+func TestBPE_Count(t *testing.T) {
+	bpe, err := LoadBPE(testRankFile(t, "he", "ll", "hell", "hello", " w", "or", " wor", " world"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, bpe.Count("hello world"))
+	assert.Equal(t, 3, bpe.Count("hello worl"), "' wor' + 'l' after 'hello'")
+	assert.Equal(t, 3, bpe.Count("xyz"), "unmerged bytes are a token each")
+	assert.Equal(t, 0, bpe.Count(""))
+}
+
+func TestLoadBPE_Fail(t *testing.T) {
+	_, err := LoadBPE(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+
+	bad := filepath.Join(t.TempDir(), "bad")
+	require.NoError(t, os.WriteFile(bad, []byte("not-base64! 1\n"), 0644))
+	_, err = LoadBPE(bad)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "line 1")
+
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(empty, nil, 0644))
+	_, err = LoadBPE(empty)
+	require.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	tk, err := New(nil)
+	require.NoError(t, err)
+	assert.Equal(t, KindChars, tk.Name())
+
+	tk, err = New(&Config{Kind: "BPE", BPEFile: testRankFile(t)})
+	require.NoError(t, err)
+	assert.Equal(t, KindBPE, tk.Name())
+
+	_, err = New(&Config{Kind: KindBPE})
+	require.Error(t, err)
+
+	_, err = New(&Config{Kind: "unknown"})
+	require.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package apikeylogic
+
+import (
+	"context"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"time"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . persistor
+type persistor interface {
+	CreateAPIKey(ctx context.Context, tx persistence.TransactionHandler, key *model.APIKey) (*model.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, tx persistence.TransactionHandler, hash string) (*model.APIKey, error)
+	GetAPIKeyById(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.APIKey, error)
+	GetUserAPIKeys(ctx context.Context, tx persistence.TransactionHandler, userId int) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, tx persistence.TransactionHandler, id int) (bool, error)
+	TouchAPIKey(ctx context.Context, tx persistence.TransactionHandler, id int, usedAt time.Time) error
+	GetOrganizationById(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.Organization, error)
+	GetUserById(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.User, error)
+	GetPermissions(ctx context.Context, tx persistence.TransactionHandler) ([]model.Permission, error)
+	HasRolePermission(ctx context.Context, tx persistence.TransactionHandler, roleId int, permission string) (bool, error)
+}
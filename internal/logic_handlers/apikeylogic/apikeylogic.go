@@ -0,0 +1,428 @@
+package apikeylogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/authtoken"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	// prefixLength is how much of a key is kept in clear, so users can tell
+	// their keys apart.
+	prefixLength = len(model.APIKeyPrefix) + 8
+
+	// touchInterval throttles the writes of last_used_at, as a busy key would
+	// otherwise write on every request.
+	touchInterval = time.Minute
+)
+
+var (
+	errUnauthenticated = errors.New("authentication required")
+	errKeyManagesKeys  = errors.New("api keys can't manage api keys, log in instead")
+	errInvalidKey      = errors.New("invalid, expired or revoked api key")
+	errInactiveOwner   = errors.New("the user or organization of the api key is inactive")
+	errForeignOrgKey   = errors.New("only the creator of an active organization can manage its api keys")
+	errAPIKeyNotFound  = errors.New("api key not found")
+	errOrgNotFound     = errors.New("organization not found")
+	errAlreadyRevoked  = errors.New("api key already revoked")
+)
+
+type Config struct {
+	TxProvider persistence.TransactionProvider `json:"tx_provider" validate:"required"`
+	Logger     *logrus.Entry                   `json:"logger" validate:"required"`
+	Persistor  persistor                       `json:"persistor" validate:"required"`
+}
+
+func (i *Config) Validate() error {
+	return validationutils.Validate(i)
+}
+
+// Impl issues API keys for machine clients, and authenticates them. Only the
+// hash of a key is stored, so a key is shown once, when it's created.
+type Impl struct {
+	cfg *Config
+}
+
+func New(cfg *Config) (*Impl, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %v", err)
+	}
+	return &Impl{cfg}, nil
+}
+
+// manager returns the logged in user of the request. Requests made with an
+// API key are refused, so a leaked key can't mint more keys.
+func manager(ctx context.Context) (*model.AuthUser, error) {
+	user, ok := model.AuthUserFromContext(ctx)
+	if !ok {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errUnauthenticated,
+		})
+	}
+	if user.APIKeyId != 0 {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusForbidden,
+			Err:        errKeyManagesKeys,
+		})
+	}
+	return user, nil
+}
+
+// CreateAPIKey creates a key for the authenticated user, or for an
+// organization they created. The scopes must be permissions their role has.
+// The returned key can't be read again.
+func (i *Impl) CreateAPIKey(ctx context.Context, params *model.CreateAPIKey) (*model.CreatedAPIKey, error) {
+	user, err := manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = params.Validate(); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	scopes, err := i.checkScopes(ctx, tx, user, params.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	var organizationId null.Int
+	if params.OrganizationId != 0 {
+		if err = i.checkOrganization(ctx, tx, user, params.OrganizationId); err != nil {
+			return nil, err
+		}
+		organizationId = null.IntFrom(params.OrganizationId)
+	}
+
+	token, _, err := authtoken.NewOpaque()
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("api key: %v", err),
+		})
+	}
+	key := model.APIKeyPrefix + token
+
+	created, err := i.cfg.Persistor.CreateAPIKey(ctx, tx, &model.APIKey{
+		UserRefId:         user.Id,
+		OrganizationRefId: organizationId,
+		Name:              params.Name,
+		Prefix:            key[:prefixLength],
+		KeyHash:           authtoken.Hash(key),
+		Scopes:            scopes,
+		ExpiresAt:         params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("create api key: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	i.cfg.Logger.WithFields(logrus.Fields{
+		"user_id":    user.Id,
+		"api_key_id": created.Id,
+	}).Info("api key created")
+
+	return &model.CreatedAPIKey{APIKey: *created, Key: key}, nil
+}
+
+// checkScopes returns the scopes sorted and without duplicates, after
+// checking each is a permission the user's role has.
+func (i *Impl) checkScopes(ctx context.Context, tx persistence.TransactionHandler, user *model.AuthUser, scopes []string) ([]string, error) {
+	permissions, err := i.cfg.Persistor.GetPermissions(ctx, tx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get permissions: %v", err),
+		})
+	}
+	known := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		known[permission.Name] = true
+	}
+
+	seen := make(map[string]bool, len(scopes))
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+
+		if !known[scope] {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusBadRequest,
+				Err:        fmt.Errorf("unknown scope '%s'", scope),
+			})
+		}
+
+		allowed, err := i.cfg.Persistor.HasRolePermission(ctx, tx, user.CategoryTypeRefId, scope)
+		if err != nil {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusInternalServerError,
+				Err:        fmt.Errorf("has role permission: %v", err),
+			})
+		}
+		if !allowed {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusForbidden,
+				Err:        fmt.Errorf("can't grant the scope '%s', your role lacks it", scope),
+			})
+		}
+
+		unique = append(unique, scope)
+	}
+	sort.Strings(unique)
+
+	return unique, nil
+}
+
+// checkOrganization fails unless the organization exists, is active, and
+// was created by the user.
+func (i *Impl) checkOrganization(ctx context.Context, tx persistence.TransactionHandler, user *model.AuthUser, id int) error {
+	organization, err := i.cfg.Persistor.GetOrganizationById(ctx, tx, id)
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
+			return errs.New(&errs.Cfg{
+				StatusCode: http.StatusNotFound,
+				Err:        errOrgNotFound,
+			})
+		}
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get organization: %v", err),
+		})
+	}
+	if !organization.IsActive || !organization.CreatedBy.Valid || organization.CreatedBy.Int != user.Id {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusForbidden,
+			Err:        errForeignOrgKey,
+		})
+	}
+	return nil
+}
+
+// ListAPIKeys returns the personal keys of the authenticated user, and the
+// keys of the organizations they created, newest first.
+func (i *Impl) ListAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	user, err := manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := i.cfg.TxProvider.Db(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+
+	keys, err := i.cfg.Persistor.GetUserAPIKeys(ctx, db, user.Id)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get api keys: %v", err),
+		})
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes one of the keys ListAPIKeys returns, other keys are
+// reported as not found.
+func (i *Impl) RevokeAPIKey(ctx context.Context, id int) error {
+	user, err := manager(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	key, err := i.cfg.Persistor.GetAPIKeyById(ctx, tx, id)
+	if err != nil && !errors.Is(err, persistence.ErrNotFound) {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get api key: %v", err),
+		})
+	}
+
+	manages := false
+	if key != nil {
+		if manages, err = i.manages(ctx, tx, user, key); err != nil {
+			return err
+		}
+	}
+	if !manages {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusNotFound,
+			Err:        errAPIKeyNotFound,
+		})
+	}
+
+	revoked, err := i.cfg.Persistor.RevokeAPIKey(ctx, tx, key.Id)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("revoke api key: %v", err),
+		})
+	}
+	if !revoked {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusConflict,
+			Err:        errAlreadyRevoked,
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	i.cfg.Logger.WithFields(logrus.Fields{
+		"user_id":    user.Id,
+		"api_key_id": key.Id,
+	}).Info("api key revoked")
+
+	return nil
+}
+
+// manages reports whether the key is a personal key of the user, or a key of
+// an organization they created.
+func (i *Impl) manages(ctx context.Context, tx persistence.TransactionHandler, user *model.AuthUser, key *model.APIKey) (bool, error) {
+	if !key.OrganizationRefId.Valid {
+		return key.UserRefId == user.Id, nil
+	}
+
+	organization, err := i.cfg.Persistor.GetOrganizationById(ctx, tx, key.OrganizationRefId.Int)
+	if err != nil {
+		return false, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get organization: %v", err),
+		})
+	}
+	return organization.CreatedBy.Valid && organization.CreatedBy.Int == user.Id, nil
+}
+
+// Authenticate returns the user an API key acts as, limited to the key's
+// scopes. Unknown, revoked and expired keys, and keys of inactive users or
+// organizations, fail with 401.
+func (i *Impl) Authenticate(ctx context.Context, key string) (*model.AuthUser, error) {
+	db, err := i.cfg.TxProvider.Db(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+
+	apiKey, err := i.cfg.Persistor.GetAPIKeyByHash(ctx, db, authtoken.Hash(key))
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusUnauthorized,
+				Err:        errInvalidKey,
+			})
+		}
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get api key: %v", err),
+		})
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt.Valid || (apiKey.ExpiresAt.Valid && !now.Before(apiKey.ExpiresAt.Time)) {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errInvalidKey,
+		})
+	}
+
+	user, err := i.cfg.Persistor.GetUserById(ctx, db, apiKey.UserRefId)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get user: %v", err),
+		})
+	}
+	if !user.IsActive {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errInactiveOwner,
+		})
+	}
+
+	if apiKey.OrganizationRefId.Valid {
+		organization, err := i.cfg.Persistor.GetOrganizationById(ctx, db, apiKey.OrganizationRefId.Int)
+		if err != nil {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusInternalServerError,
+				Err:        fmt.Errorf("get organization: %v", err),
+			})
+		}
+		if !organization.IsActive {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusUnauthorized,
+				Err:        errInactiveOwner,
+			})
+		}
+	}
+
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= touchInterval {
+		if err = i.cfg.Persistor.TouchAPIKey(ctx, db, apiKey.Id, now); err != nil {
+			// Not worth failing the request over
+			i.cfg.Logger.WithField("api_key_id", apiKey.Id).Errorf("touch api key: %v", err)
+		}
+	}
+
+	return &model.AuthUser{
+		Id:                user.Id,
+		Email:             user.Email,
+		Firstname:         user.Firstname,
+		Lastname:          user.Lastname,
+		CategoryTypeRefId: user.CategoryTypeRefId,
+		APIKeyId:          apiKey.Id,
+		OrganizationId:    apiKey.OrganizationRefId.Int,
+		Scopes:            apiKey.Scopes,
+	}, nil
+}
@@ -0,0 +1,386 @@
+package apikeylogic
+
+import (
+	"context"
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/logic_handlers/apikeylogic/apikeylogicfakes"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlconn"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistencefakes"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistors/mysqlstore"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	mockTimeout = 5 * time.Second
+	mockLogger  = logger.New(context.TODO())
+
+	// Seeded users, see the user migration
+	mockSuperAdminEmail  = "demby@gmail.com"
+	mockAdminEmail       = "demby@yahoo.com"
+	mockRegularUserEmail = "demby@hotmail.com"
+)
+
+type dependencies struct {
+	Persistor  *mysqlstore.Repository
+	Logger     *logrus.Entry
+	TxProvider persistence.TransactionProvider
+	Db         *sqlx.DB
+}
+
+func getConcreteDependencies(t *testing.T) (*dependencies, func(ignoreErrors ...bool)) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+
+	store, err := mysqlstore.New(&mysqlstore.Config{
+		Logger: mockLogger,
+		QueryTimeouts: &persistence.QueryTimeouts{
+			Query: mockTimeout,
+			Exec:  mockTimeout,
+		},
+	})
+	require.NoError(t, err, "unexpected new mysqlstore error")
+
+	tx, err := mysqltx.New(&mysqltx.Config{
+		Logger:       mockLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected new mysqltx error")
+
+	prov, err := mysqlconn.New(&mysqlconn.Config{
+		Logger:    mockLogger,
+		TxHandler: tx,
+	})
+	require.NoError(t, err, "unexpected new mysqlconn error")
+
+	return &dependencies{
+		Persistor:  store,
+		TxProvider: prov,
+		Logger:     mockLogger,
+		Db:         db,
+	}, cleanup
+}
+
+func newTestImpl(t *testing.T, deps *dependencies) *Impl {
+	svc, err := New(&Config{
+		TxProvider: deps.TxProvider,
+		Logger:     deps.Logger,
+		Persistor:  deps.Persistor,
+	})
+	require.NoError(t, err, "unexpected new error")
+	return svc
+}
+
+// loggedIn returns a context authenticated as the seeded user of the email.
+func loggedIn(t *testing.T, deps *dependencies, email string) context.Context {
+	db, err := deps.TxProvider.Db(context.Background())
+	require.NoError(t, err, "unexpected get db error")
+
+	user, err := deps.Persistor.GetUserByEmail(context.Background(), db, email)
+	require.NoError(t, err, "unexpected get user error")
+
+	return model.WithAuthUser(context.Background(), &model.AuthUser{
+		Id:                user.Id,
+		Email:             user.Email,
+		CategoryTypeRefId: user.CategoryTypeRefId,
+	})
+}
+
+// createOrganization inserts an organization created by the user of the
+// email, as organizations have no endpoints yet.
+func createOrganization(t *testing.T, deps *dependencies, id int, email string) {
+	_, err := deps.Db.Exec("INSERT INTO organization (id, name, created_by) SELECT ?, 'Acme', id FROM user WHERE email = ?", id, email)
+	require.NoError(t, err, "unexpected create organization error")
+}
+
+func requireStatus(t *testing.T, err error, statusCode int, contains string) {
+	require.Error(t, err)
+
+	var errUtil *errs.Util
+	require.ErrorAs(t, err, &errUtil, "unexpected error type")
+	assert.Equal(t, statusCode, errUtil.StatusCode)
+	assert.Contains(t, err.Error(), contains)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(&Config{})
+	assert.Error(t, err)
+
+	_, err = New(&Config{
+		TxProvider: &persistencefakes.FakeTransactionProvider{},
+		Logger:     mockLogger,
+		Persistor:  &apikeylogicfakes.FakePersistor{},
+	})
+	assert.NoError(t, err)
+}
+
+func TestImpl_CreateAPIKey(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	ctx := loggedIn(t, deps, mockAdminEmail)
+
+	created, err := svc.CreateAPIKey(ctx, &model.CreateAPIKey{
+		Name:      "ci",
+		Scopes:    []string{model.PermissionCategoryWrite, model.PermissionCategoryRead, model.PermissionCategoryWrite},
+		ExpiresAt: null.TimeFrom(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err, "unexpected create error")
+	assert.True(t, strings.HasPrefix(created.Key, model.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+	assert.Len(t, created.Prefix, prefixLength)
+	assert.Equal(t, []string{model.PermissionCategoryRead, model.PermissionCategoryWrite}, created.Scopes, "sorted, without duplicates")
+
+	var hash string
+	require.NoError(t, deps.Db.Get(&hash, "SELECT key_hash FROM api_key WHERE id = ?", created.Id))
+	assert.NotContains(t, hash, created.Key, "only the hash of the key is stored")
+
+	user, err := svc.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err, "unexpected authenticate error")
+	assert.Equal(t, mockAdminEmail, user.Email)
+	assert.Equal(t, created.Id, user.APIKeyId)
+	assert.Zero(t, user.OrganizationId)
+	assert.True(t, user.HasScope(model.PermissionCategoryRead))
+	assert.False(t, user.HasScope(model.PermissionCategoryDelete))
+
+	keys, err := svc.ListAPIKeys(ctx)
+	require.NoError(t, err, "unexpected list error")
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].LastUsedAt.Valid, "authenticating tracks the last use")
+
+	keyCtx := model.WithAuthUser(context.Background(), user)
+	_, err = svc.CreateAPIKey(keyCtx, &model.CreateAPIKey{Name: "more", Scopes: []string{model.PermissionCategoryRead}})
+	requireStatus(t, err, http.StatusForbidden, errKeyManagesKeys.Error())
+	_, err = svc.ListAPIKeys(keyCtx)
+	requireStatus(t, err, http.StatusForbidden, errKeyManagesKeys.Error())
+	err = svc.RevokeAPIKey(keyCtx, created.Id)
+	requireStatus(t, err, http.StatusForbidden, errKeyManagesKeys.Error())
+}
+
+func TestImpl_CreateAPIKey_Fail(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		email      string
+		params     *model.CreateAPIKey
+		statusCode int
+		err        string
+	}{
+		{
+			name:       "fail-unauthenticated",
+			params:     &model.CreateAPIKey{Name: "ci", Scopes: []string{model.PermissionCategoryRead}},
+			statusCode: http.StatusUnauthorized,
+			err:        errUnauthenticated.Error(),
+		},
+		{
+			name:       "fail-no-scopes",
+			email:      mockAdminEmail,
+			params:     &model.CreateAPIKey{Name: "ci"},
+			statusCode: http.StatusBadRequest,
+			err:        "'scopes' must have a value",
+		},
+		{
+			name:       "fail-empty-scopes",
+			email:      mockAdminEmail,
+			params:     &model.CreateAPIKey{Name: "ci", Scopes: []string{}},
+			statusCode: http.StatusBadRequest,
+			err:        "'scopes' must have a value",
+		},
+		{
+			name:       "fail-expired",
+			email:      mockAdminEmail,
+			params:     &model.CreateAPIKey{Name: "ci", Scopes: []string{model.PermissionCategoryRead}, ExpiresAt: null.TimeFrom(time.Now())},
+			statusCode: http.StatusBadRequest,
+			err:        "'expires_at' must be in the future",
+		},
+		{
+			name:       "fail-unknown-scope",
+			email:      mockAdminEmail,
+			params:     &model.CreateAPIKey{Name: "ci", Scopes: []string{"category:fly"}},
+			statusCode: http.StatusBadRequest,
+			err:        "unknown scope 'category:fly'",
+		},
+		{
+			name:       "fail-scope-outside-role",
+			email:      mockRegularUserEmail,
+			params:     &model.CreateAPIKey{Name: "ci", Scopes: []string{model.PermissionCategoryRead, model.PermissionCategoryWrite}},
+			statusCode: http.StatusForbidden,
+			err:        "can't grant the scope 'category:write'",
+		},
+		{
+			name:       "fail-unknown-organization",
+			email:      mockAdminEmail,
+			params:     &model.CreateAPIKey{Name: "ci", OrganizationId: 99, Scopes: []string{model.PermissionCategoryRead}},
+			statusCode: http.StatusNotFound,
+			err:        errOrgNotFound.Error(),
+		},
+		{
+			name:       "fail-foreign-organization",
+			email:      mockAdminEmail,
+			params:     &model.CreateAPIKey{Name: "ci", OrganizationId: 1, Scopes: []string{model.PermissionCategoryRead}},
+			statusCode: http.StatusForbidden,
+			err:        errForeignOrgKey.Error(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, cleanup := getConcreteDependencies(t)
+			defer cleanup()
+			svc := newTestImpl(t, deps)
+			createOrganization(t, deps, 1, mockSuperAdminEmail)
+
+			ctx := context.Background()
+			if tt.email != "" {
+				ctx = loggedIn(t, deps, tt.email)
+			}
+
+			_, err := svc.CreateAPIKey(ctx, tt.params)
+			requireStatus(t, err, tt.statusCode, tt.err)
+
+			var count int
+			require.NoError(t, deps.Db.Get(&count, "SELECT COUNT(*) FROM api_key"))
+			assert.Zero(t, count, "no key is stored")
+		})
+	}
+}
+
+func TestImpl_OrganizationAPIKey(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	createOrganization(t, deps, 1, mockSuperAdminEmail)
+	ctx := loggedIn(t, deps, mockSuperAdminEmail)
+
+	created, err := svc.CreateAPIKey(ctx, &model.CreateAPIKey{Name: "acme", OrganizationId: 1, Scopes: []string{model.PermissionCategoryRead}})
+	require.NoError(t, err, "unexpected create error")
+	assert.Equal(t, null.IntFrom(1), created.OrganizationRefId)
+
+	user, err := svc.Authenticate(context.Background(), created.Key)
+	require.NoError(t, err, "unexpected authenticate error")
+	assert.Equal(t, 1, user.OrganizationId)
+
+	err = svc.RevokeAPIKey(loggedIn(t, deps, mockAdminEmail), created.Id)
+	requireStatus(t, err, http.StatusNotFound, errAPIKeyNotFound.Error())
+
+	_, err = deps.Db.Exec("UPDATE organization SET is_active = 0 WHERE id = 1")
+	require.NoError(t, err, "unexpected deactivate error")
+
+	_, err = svc.Authenticate(context.Background(), created.Key)
+	requireStatus(t, err, http.StatusUnauthorized, errInactiveOwner.Error())
+}
+
+func TestImpl_RevokeAPIKey(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	ctx := loggedIn(t, deps, mockAdminEmail)
+
+	created, err := svc.CreateAPIKey(ctx, &model.CreateAPIKey{Name: "ci", Scopes: []string{model.PermissionCategoryRead}})
+	require.NoError(t, err, "unexpected create error")
+
+	err = svc.RevokeAPIKey(loggedIn(t, deps, mockSuperAdminEmail), created.Id)
+	requireStatus(t, err, http.StatusNotFound, errAPIKeyNotFound.Error())
+
+	err = svc.RevokeAPIKey(ctx, 999)
+	requireStatus(t, err, http.StatusNotFound, errAPIKeyNotFound.Error())
+
+	require.NoError(t, svc.RevokeAPIKey(ctx, created.Id), "unexpected revoke error")
+
+	err = svc.RevokeAPIKey(ctx, created.Id)
+	requireStatus(t, err, http.StatusConflict, errAlreadyRevoked.Error())
+
+	_, err = svc.Authenticate(context.Background(), created.Key)
+	requireStatus(t, err, http.StatusUnauthorized, errInvalidKey.Error())
+}
+
+func TestImpl_Authenticate_Fail(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		mutations  func(t *testing.T, db *sqlx.DB)
+		key        func(key string) string
+		statusCode int
+		err        string
+	}{
+		{
+			name:       "fail-unknown",
+			key:        func(key string) string { return key + "x" },
+			statusCode: http.StatusUnauthorized,
+			err:        errInvalidKey.Error(),
+		},
+		{
+			name: "fail-expired",
+			mutations: func(t *testing.T, db *sqlx.DB) {
+				_, err := db.Exec("UPDATE api_key SET expires_at = NOW() - INTERVAL 1 MINUTE")
+				require.NoError(t, err, "unexpected expire error")
+			},
+			statusCode: http.StatusUnauthorized,
+			err:        errInvalidKey.Error(),
+		},
+		{
+			name: "fail-inactive-user",
+			mutations: func(t *testing.T, db *sqlx.DB) {
+				_, err := db.Exec("UPDATE user SET is_active = 0 WHERE email = ?", mockAdminEmail)
+				require.NoError(t, err, "unexpected deactivate error")
+			},
+			statusCode: http.StatusUnauthorized,
+			err:        errInactiveOwner.Error(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, cleanup := getConcreteDependencies(t)
+			defer cleanup()
+			svc := newTestImpl(t, deps)
+
+			created, err := svc.CreateAPIKey(loggedIn(t, deps, mockAdminEmail), &model.CreateAPIKey{
+				Name:   "ci",
+				Scopes: []string{model.PermissionCategoryRead},
+			})
+			require.NoError(t, err, "unexpected create error")
+
+			if tt.mutations != nil {
+				tt.mutations(t, deps.Db)
+			}
+			key := created.Key
+			if tt.key != nil {
+				key = tt.key(key)
+			}
+
+			_, err = svc.Authenticate(context.Background(), key)
+			requireStatus(t, err, tt.statusCode, tt.err)
+		})
+	}
+}
+
+func TestImpl_Authenticate_Touch(t *testing.T) {
+	persistor := &apikeylogicfakes.FakePersistor{}
+	persistor.GetUserByIdReturns(&model.User{Id: 2, IsActive: true}, nil)
+	persistor.TouchAPIKeyReturns(errors.New("db is read only"))
+
+	svc, err := New(&Config{
+		TxProvider: &persistencefakes.FakeTransactionProvider{},
+		Logger:     mockLogger,
+		Persistor:  persistor,
+	})
+	require.NoError(t, err, "unexpected new error")
+
+	persistor.GetAPIKeyByHashReturns(&model.APIKey{Id: 1, UserRefId: 2, LastUsedAt: null.TimeFrom(time.Now())}, nil)
+	_, err = svc.Authenticate(context.Background(), "ltk_key")
+	require.NoError(t, err, "unexpected authenticate error")
+	assert.Equal(t, 0, persistor.TouchAPIKeyCallCount(), "recent uses aren't written again")
+
+	persistor.GetAPIKeyByHashReturns(&model.APIKey{Id: 1, UserRefId: 2, LastUsedAt: null.TimeFrom(time.Now().Add(-touchInterval))}, nil)
+	_, err = svc.Authenticate(context.Background(), "ltk_key")
+	require.NoError(t, err, "failing to track the last use doesn't fail the request")
+	assert.Equal(t, 1, persistor.TouchAPIKeyCallCount())
+}
@@ -0,0 +1,865 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package apikeylogicfakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+)
+
+type FakePersistor struct {
+	CreateAPIKeyStub        func(context.Context, persistence.TransactionHandler, *model.APIKey) (*model.APIKey, error)
+	createAPIKeyMutex       sync.RWMutex
+	createAPIKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.APIKey
+	}
+	createAPIKeyReturns struct {
+		result1 *model.APIKey
+		result2 error
+	}
+	createAPIKeyReturnsOnCall map[int]struct {
+		result1 *model.APIKey
+		result2 error
+	}
+	GetAPIKeyByHashStub        func(context.Context, persistence.TransactionHandler, string) (*model.APIKey, error)
+	getAPIKeyByHashMutex       sync.RWMutex
+	getAPIKeyByHashArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}
+	getAPIKeyByHashReturns struct {
+		result1 *model.APIKey
+		result2 error
+	}
+	getAPIKeyByHashReturnsOnCall map[int]struct {
+		result1 *model.APIKey
+		result2 error
+	}
+	GetAPIKeyByIdStub        func(context.Context, persistence.TransactionHandler, int) (*model.APIKey, error)
+	getAPIKeyByIdMutex       sync.RWMutex
+	getAPIKeyByIdArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	getAPIKeyByIdReturns struct {
+		result1 *model.APIKey
+		result2 error
+	}
+	getAPIKeyByIdReturnsOnCall map[int]struct {
+		result1 *model.APIKey
+		result2 error
+	}
+	GetOrganizationByIdStub        func(context.Context, persistence.TransactionHandler, int) (*model.Organization, error)
+	getOrganizationByIdMutex       sync.RWMutex
+	getOrganizationByIdArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	getOrganizationByIdReturns struct {
+		result1 *model.Organization
+		result2 error
+	}
+	getOrganizationByIdReturnsOnCall map[int]struct {
+		result1 *model.Organization
+		result2 error
+	}
+	GetPermissionsStub        func(context.Context, persistence.TransactionHandler) ([]model.Permission, error)
+	getPermissionsMutex       sync.RWMutex
+	getPermissionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}
+	getPermissionsReturns struct {
+		result1 []model.Permission
+		result2 error
+	}
+	getPermissionsReturnsOnCall map[int]struct {
+		result1 []model.Permission
+		result2 error
+	}
+	GetUserAPIKeysStub        func(context.Context, persistence.TransactionHandler, int) ([]model.APIKey, error)
+	getUserAPIKeysMutex       sync.RWMutex
+	getUserAPIKeysArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	getUserAPIKeysReturns struct {
+		result1 []model.APIKey
+		result2 error
+	}
+	getUserAPIKeysReturnsOnCall map[int]struct {
+		result1 []model.APIKey
+		result2 error
+	}
+	GetUserByIdStub        func(context.Context, persistence.TransactionHandler, int) (*model.User, error)
+	getUserByIdMutex       sync.RWMutex
+	getUserByIdArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	getUserByIdReturns struct {
+		result1 *model.User
+		result2 error
+	}
+	getUserByIdReturnsOnCall map[int]struct {
+		result1 *model.User
+		result2 error
+	}
+	HasRolePermissionStub        func(context.Context, persistence.TransactionHandler, int, string) (bool, error)
+	hasRolePermissionMutex       sync.RWMutex
+	hasRolePermissionArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}
+	hasRolePermissionReturns struct {
+		result1 bool
+		result2 error
+	}
+	hasRolePermissionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeAPIKeyStub        func(context.Context, persistence.TransactionHandler, int) (bool, error)
+	revokeAPIKeyMutex       sync.RWMutex
+	revokeAPIKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	revokeAPIKeyReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeAPIKeyReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	TouchAPIKeyStub        func(context.Context, persistence.TransactionHandler, int, time.Time) error
+	touchAPIKeyMutex       sync.RWMutex
+	touchAPIKeyArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 time.Time
+	}
+	touchAPIKeyReturns struct {
+		result1 error
+	}
+	touchAPIKeyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePersistor) CreateAPIKey(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 *model.APIKey) (*model.APIKey, error) {
+	fake.createAPIKeyMutex.Lock()
+	ret, specificReturn := fake.createAPIKeyReturnsOnCall[len(fake.createAPIKeyArgsForCall)]
+	fake.createAPIKeyArgsForCall = append(fake.createAPIKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.APIKey
+	}{arg1, arg2, arg3})
+	stub := fake.CreateAPIKeyStub
+	fakeReturns := fake.createAPIKeyReturns
+	fake.recordInvocation("CreateAPIKey", []interface{}{arg1, arg2, arg3})
+	fake.createAPIKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) CreateAPIKeyCallCount() int {
+	fake.createAPIKeyMutex.RLock()
+	defer fake.createAPIKeyMutex.RUnlock()
+	return len(fake.createAPIKeyArgsForCall)
+}
+
+func (fake *FakePersistor) CreateAPIKeyCalls(stub func(context.Context, persistence.TransactionHandler, *model.APIKey) (*model.APIKey, error)) {
+	fake.createAPIKeyMutex.Lock()
+	defer fake.createAPIKeyMutex.Unlock()
+	fake.CreateAPIKeyStub = stub
+}
+
+func (fake *FakePersistor) CreateAPIKeyArgsForCall(i int) (context.Context, persistence.TransactionHandler, *model.APIKey) {
+	fake.createAPIKeyMutex.RLock()
+	defer fake.createAPIKeyMutex.RUnlock()
+	argsForCall := fake.createAPIKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) CreateAPIKeyReturns(result1 *model.APIKey, result2 error) {
+	fake.createAPIKeyMutex.Lock()
+	defer fake.createAPIKeyMutex.Unlock()
+	fake.CreateAPIKeyStub = nil
+	fake.createAPIKeyReturns = struct {
+		result1 *model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) CreateAPIKeyReturnsOnCall(i int, result1 *model.APIKey, result2 error) {
+	fake.createAPIKeyMutex.Lock()
+	defer fake.createAPIKeyMutex.Unlock()
+	fake.CreateAPIKeyStub = nil
+	if fake.createAPIKeyReturnsOnCall == nil {
+		fake.createAPIKeyReturnsOnCall = make(map[int]struct {
+			result1 *model.APIKey
+			result2 error
+		})
+	}
+	fake.createAPIKeyReturnsOnCall[i] = struct {
+		result1 *model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetAPIKeyByHash(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 string) (*model.APIKey, error) {
+	fake.getAPIKeyByHashMutex.Lock()
+	ret, specificReturn := fake.getAPIKeyByHashReturnsOnCall[len(fake.getAPIKeyByHashArgsForCall)]
+	fake.getAPIKeyByHashArgsForCall = append(fake.getAPIKeyByHashArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetAPIKeyByHashStub
+	fakeReturns := fake.getAPIKeyByHashReturns
+	fake.recordInvocation("GetAPIKeyByHash", []interface{}{arg1, arg2, arg3})
+	fake.getAPIKeyByHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetAPIKeyByHashCallCount() int {
+	fake.getAPIKeyByHashMutex.RLock()
+	defer fake.getAPIKeyByHashMutex.RUnlock()
+	return len(fake.getAPIKeyByHashArgsForCall)
+}
+
+func (fake *FakePersistor) GetAPIKeyByHashCalls(stub func(context.Context, persistence.TransactionHandler, string) (*model.APIKey, error)) {
+	fake.getAPIKeyByHashMutex.Lock()
+	defer fake.getAPIKeyByHashMutex.Unlock()
+	fake.GetAPIKeyByHashStub = stub
+}
+
+func (fake *FakePersistor) GetAPIKeyByHashArgsForCall(i int) (context.Context, persistence.TransactionHandler, string) {
+	fake.getAPIKeyByHashMutex.RLock()
+	defer fake.getAPIKeyByHashMutex.RUnlock()
+	argsForCall := fake.getAPIKeyByHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetAPIKeyByHashReturns(result1 *model.APIKey, result2 error) {
+	fake.getAPIKeyByHashMutex.Lock()
+	defer fake.getAPIKeyByHashMutex.Unlock()
+	fake.GetAPIKeyByHashStub = nil
+	fake.getAPIKeyByHashReturns = struct {
+		result1 *model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetAPIKeyByHashReturnsOnCall(i int, result1 *model.APIKey, result2 error) {
+	fake.getAPIKeyByHashMutex.Lock()
+	defer fake.getAPIKeyByHashMutex.Unlock()
+	fake.GetAPIKeyByHashStub = nil
+	if fake.getAPIKeyByHashReturnsOnCall == nil {
+		fake.getAPIKeyByHashReturnsOnCall = make(map[int]struct {
+			result1 *model.APIKey
+			result2 error
+		})
+	}
+	fake.getAPIKeyByHashReturnsOnCall[i] = struct {
+		result1 *model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetAPIKeyById(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) (*model.APIKey, error) {
+	fake.getAPIKeyByIdMutex.Lock()
+	ret, specificReturn := fake.getAPIKeyByIdReturnsOnCall[len(fake.getAPIKeyByIdArgsForCall)]
+	fake.getAPIKeyByIdArgsForCall = append(fake.getAPIKeyByIdArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetAPIKeyByIdStub
+	fakeReturns := fake.getAPIKeyByIdReturns
+	fake.recordInvocation("GetAPIKeyById", []interface{}{arg1, arg2, arg3})
+	fake.getAPIKeyByIdMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetAPIKeyByIdCallCount() int {
+	fake.getAPIKeyByIdMutex.RLock()
+	defer fake.getAPIKeyByIdMutex.RUnlock()
+	return len(fake.getAPIKeyByIdArgsForCall)
+}
+
+func (fake *FakePersistor) GetAPIKeyByIdCalls(stub func(context.Context, persistence.TransactionHandler, int) (*model.APIKey, error)) {
+	fake.getAPIKeyByIdMutex.Lock()
+	defer fake.getAPIKeyByIdMutex.Unlock()
+	fake.GetAPIKeyByIdStub = stub
+}
+
+func (fake *FakePersistor) GetAPIKeyByIdArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.getAPIKeyByIdMutex.RLock()
+	defer fake.getAPIKeyByIdMutex.RUnlock()
+	argsForCall := fake.getAPIKeyByIdArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetAPIKeyByIdReturns(result1 *model.APIKey, result2 error) {
+	fake.getAPIKeyByIdMutex.Lock()
+	defer fake.getAPIKeyByIdMutex.Unlock()
+	fake.GetAPIKeyByIdStub = nil
+	fake.getAPIKeyByIdReturns = struct {
+		result1 *model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetAPIKeyByIdReturnsOnCall(i int, result1 *model.APIKey, result2 error) {
+	fake.getAPIKeyByIdMutex.Lock()
+	defer fake.getAPIKeyByIdMutex.Unlock()
+	fake.GetAPIKeyByIdStub = nil
+	if fake.getAPIKeyByIdReturnsOnCall == nil {
+		fake.getAPIKeyByIdReturnsOnCall = make(map[int]struct {
+			result1 *model.APIKey
+			result2 error
+		})
+	}
+	fake.getAPIKeyByIdReturnsOnCall[i] = struct {
+		result1 *model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetOrganizationById(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) (*model.Organization, error) {
+	fake.getOrganizationByIdMutex.Lock()
+	ret, specificReturn := fake.getOrganizationByIdReturnsOnCall[len(fake.getOrganizationByIdArgsForCall)]
+	fake.getOrganizationByIdArgsForCall = append(fake.getOrganizationByIdArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetOrganizationByIdStub
+	fakeReturns := fake.getOrganizationByIdReturns
+	fake.recordInvocation("GetOrganizationById", []interface{}{arg1, arg2, arg3})
+	fake.getOrganizationByIdMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetOrganizationByIdCallCount() int {
+	fake.getOrganizationByIdMutex.RLock()
+	defer fake.getOrganizationByIdMutex.RUnlock()
+	return len(fake.getOrganizationByIdArgsForCall)
+}
+
+func (fake *FakePersistor) GetOrganizationByIdCalls(stub func(context.Context, persistence.TransactionHandler, int) (*model.Organization, error)) {
+	fake.getOrganizationByIdMutex.Lock()
+	defer fake.getOrganizationByIdMutex.Unlock()
+	fake.GetOrganizationByIdStub = stub
+}
+
+func (fake *FakePersistor) GetOrganizationByIdArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.getOrganizationByIdMutex.RLock()
+	defer fake.getOrganizationByIdMutex.RUnlock()
+	argsForCall := fake.getOrganizationByIdArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetOrganizationByIdReturns(result1 *model.Organization, result2 error) {
+	fake.getOrganizationByIdMutex.Lock()
+	defer fake.getOrganizationByIdMutex.Unlock()
+	fake.GetOrganizationByIdStub = nil
+	fake.getOrganizationByIdReturns = struct {
+		result1 *model.Organization
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetOrganizationByIdReturnsOnCall(i int, result1 *model.Organization, result2 error) {
+	fake.getOrganizationByIdMutex.Lock()
+	defer fake.getOrganizationByIdMutex.Unlock()
+	fake.GetOrganizationByIdStub = nil
+	if fake.getOrganizationByIdReturnsOnCall == nil {
+		fake.getOrganizationByIdReturnsOnCall = make(map[int]struct {
+			result1 *model.Organization
+			result2 error
+		})
+	}
+	fake.getOrganizationByIdReturnsOnCall[i] = struct {
+		result1 *model.Organization
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetPermissions(arg1 context.Context, arg2 persistence.TransactionHandler) ([]model.Permission, error) {
+	fake.getPermissionsMutex.Lock()
+	ret, specificReturn := fake.getPermissionsReturnsOnCall[len(fake.getPermissionsArgsForCall)]
+	fake.getPermissionsArgsForCall = append(fake.getPermissionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}{arg1, arg2})
+	stub := fake.GetPermissionsStub
+	fakeReturns := fake.getPermissionsReturns
+	fake.recordInvocation("GetPermissions", []interface{}{arg1, arg2})
+	fake.getPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetPermissionsCallCount() int {
+	fake.getPermissionsMutex.RLock()
+	defer fake.getPermissionsMutex.RUnlock()
+	return len(fake.getPermissionsArgsForCall)
+}
+
+func (fake *FakePersistor) GetPermissionsCalls(stub func(context.Context, persistence.TransactionHandler) ([]model.Permission, error)) {
+	fake.getPermissionsMutex.Lock()
+	defer fake.getPermissionsMutex.Unlock()
+	fake.GetPermissionsStub = stub
+}
+
+func (fake *FakePersistor) GetPermissionsArgsForCall(i int) (context.Context, persistence.TransactionHandler) {
+	fake.getPermissionsMutex.RLock()
+	defer fake.getPermissionsMutex.RUnlock()
+	argsForCall := fake.getPermissionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePersistor) GetPermissionsReturns(result1 []model.Permission, result2 error) {
+	fake.getPermissionsMutex.Lock()
+	defer fake.getPermissionsMutex.Unlock()
+	fake.GetPermissionsStub = nil
+	fake.getPermissionsReturns = struct {
+		result1 []model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetPermissionsReturnsOnCall(i int, result1 []model.Permission, result2 error) {
+	fake.getPermissionsMutex.Lock()
+	defer fake.getPermissionsMutex.Unlock()
+	fake.GetPermissionsStub = nil
+	if fake.getPermissionsReturnsOnCall == nil {
+		fake.getPermissionsReturnsOnCall = make(map[int]struct {
+			result1 []model.Permission
+			result2 error
+		})
+	}
+	fake.getPermissionsReturnsOnCall[i] = struct {
+		result1 []model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserAPIKeys(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) ([]model.APIKey, error) {
+	fake.getUserAPIKeysMutex.Lock()
+	ret, specificReturn := fake.getUserAPIKeysReturnsOnCall[len(fake.getUserAPIKeysArgsForCall)]
+	fake.getUserAPIKeysArgsForCall = append(fake.getUserAPIKeysArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetUserAPIKeysStub
+	fakeReturns := fake.getUserAPIKeysReturns
+	fake.recordInvocation("GetUserAPIKeys", []interface{}{arg1, arg2, arg3})
+	fake.getUserAPIKeysMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetUserAPIKeysCallCount() int {
+	fake.getUserAPIKeysMutex.RLock()
+	defer fake.getUserAPIKeysMutex.RUnlock()
+	return len(fake.getUserAPIKeysArgsForCall)
+}
+
+func (fake *FakePersistor) GetUserAPIKeysCalls(stub func(context.Context, persistence.TransactionHandler, int) ([]model.APIKey, error)) {
+	fake.getUserAPIKeysMutex.Lock()
+	defer fake.getUserAPIKeysMutex.Unlock()
+	fake.GetUserAPIKeysStub = stub
+}
+
+func (fake *FakePersistor) GetUserAPIKeysArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.getUserAPIKeysMutex.RLock()
+	defer fake.getUserAPIKeysMutex.RUnlock()
+	argsForCall := fake.getUserAPIKeysArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetUserAPIKeysReturns(result1 []model.APIKey, result2 error) {
+	fake.getUserAPIKeysMutex.Lock()
+	defer fake.getUserAPIKeysMutex.Unlock()
+	fake.GetUserAPIKeysStub = nil
+	fake.getUserAPIKeysReturns = struct {
+		result1 []model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserAPIKeysReturnsOnCall(i int, result1 []model.APIKey, result2 error) {
+	fake.getUserAPIKeysMutex.Lock()
+	defer fake.getUserAPIKeysMutex.Unlock()
+	fake.GetUserAPIKeysStub = nil
+	if fake.getUserAPIKeysReturnsOnCall == nil {
+		fake.getUserAPIKeysReturnsOnCall = make(map[int]struct {
+			result1 []model.APIKey
+			result2 error
+		})
+	}
+	fake.getUserAPIKeysReturnsOnCall[i] = struct {
+		result1 []model.APIKey
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserById(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) (*model.User, error) {
+	fake.getUserByIdMutex.Lock()
+	ret, specificReturn := fake.getUserByIdReturnsOnCall[len(fake.getUserByIdArgsForCall)]
+	fake.getUserByIdArgsForCall = append(fake.getUserByIdArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetUserByIdStub
+	fakeReturns := fake.getUserByIdReturns
+	fake.recordInvocation("GetUserById", []interface{}{arg1, arg2, arg3})
+	fake.getUserByIdMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetUserByIdCallCount() int {
+	fake.getUserByIdMutex.RLock()
+	defer fake.getUserByIdMutex.RUnlock()
+	return len(fake.getUserByIdArgsForCall)
+}
+
+func (fake *FakePersistor) GetUserByIdCalls(stub func(context.Context, persistence.TransactionHandler, int) (*model.User, error)) {
+	fake.getUserByIdMutex.Lock()
+	defer fake.getUserByIdMutex.Unlock()
+	fake.GetUserByIdStub = stub
+}
+
+func (fake *FakePersistor) GetUserByIdArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.getUserByIdMutex.RLock()
+	defer fake.getUserByIdMutex.RUnlock()
+	argsForCall := fake.getUserByIdArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetUserByIdReturns(result1 *model.User, result2 error) {
+	fake.getUserByIdMutex.Lock()
+	defer fake.getUserByIdMutex.Unlock()
+	fake.GetUserByIdStub = nil
+	fake.getUserByIdReturns = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserByIdReturnsOnCall(i int, result1 *model.User, result2 error) {
+	fake.getUserByIdMutex.Lock()
+	defer fake.getUserByIdMutex.Unlock()
+	fake.GetUserByIdStub = nil
+	if fake.getUserByIdReturnsOnCall == nil {
+		fake.getUserByIdReturnsOnCall = make(map[int]struct {
+			result1 *model.User
+			result2 error
+		})
+	}
+	fake.getUserByIdReturnsOnCall[i] = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) HasRolePermission(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 string) (bool, error) {
+	fake.hasRolePermissionMutex.Lock()
+	ret, specificReturn := fake.hasRolePermissionReturnsOnCall[len(fake.hasRolePermissionArgsForCall)]
+	fake.hasRolePermissionArgsForCall = append(fake.hasRolePermissionArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.HasRolePermissionStub
+	fakeReturns := fake.hasRolePermissionReturns
+	fake.recordInvocation("HasRolePermission", []interface{}{arg1, arg2, arg3, arg4})
+	fake.hasRolePermissionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) HasRolePermissionCallCount() int {
+	fake.hasRolePermissionMutex.RLock()
+	defer fake.hasRolePermissionMutex.RUnlock()
+	return len(fake.hasRolePermissionArgsForCall)
+}
+
+func (fake *FakePersistor) HasRolePermissionCalls(stub func(context.Context, persistence.TransactionHandler, int, string) (bool, error)) {
+	fake.hasRolePermissionMutex.Lock()
+	defer fake.hasRolePermissionMutex.Unlock()
+	fake.HasRolePermissionStub = stub
+}
+
+func (fake *FakePersistor) HasRolePermissionArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, string) {
+	fake.hasRolePermissionMutex.RLock()
+	defer fake.hasRolePermissionMutex.RUnlock()
+	argsForCall := fake.hasRolePermissionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePersistor) HasRolePermissionReturns(result1 bool, result2 error) {
+	fake.hasRolePermissionMutex.Lock()
+	defer fake.hasRolePermissionMutex.Unlock()
+	fake.HasRolePermissionStub = nil
+	fake.hasRolePermissionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) HasRolePermissionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.hasRolePermissionMutex.Lock()
+	defer fake.hasRolePermissionMutex.Unlock()
+	fake.HasRolePermissionStub = nil
+	if fake.hasRolePermissionReturnsOnCall == nil {
+		fake.hasRolePermissionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.hasRolePermissionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) RevokeAPIKey(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) (bool, error) {
+	fake.revokeAPIKeyMutex.Lock()
+	ret, specificReturn := fake.revokeAPIKeyReturnsOnCall[len(fake.revokeAPIKeyArgsForCall)]
+	fake.revokeAPIKeyArgsForCall = append(fake.revokeAPIKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeAPIKeyStub
+	fakeReturns := fake.revokeAPIKeyReturns
+	fake.recordInvocation("RevokeAPIKey", []interface{}{arg1, arg2, arg3})
+	fake.revokeAPIKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) RevokeAPIKeyCallCount() int {
+	fake.revokeAPIKeyMutex.RLock()
+	defer fake.revokeAPIKeyMutex.RUnlock()
+	return len(fake.revokeAPIKeyArgsForCall)
+}
+
+func (fake *FakePersistor) RevokeAPIKeyCalls(stub func(context.Context, persistence.TransactionHandler, int) (bool, error)) {
+	fake.revokeAPIKeyMutex.Lock()
+	defer fake.revokeAPIKeyMutex.Unlock()
+	fake.RevokeAPIKeyStub = stub
+}
+
+func (fake *FakePersistor) RevokeAPIKeyArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.revokeAPIKeyMutex.RLock()
+	defer fake.revokeAPIKeyMutex.RUnlock()
+	argsForCall := fake.revokeAPIKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) RevokeAPIKeyReturns(result1 bool, result2 error) {
+	fake.revokeAPIKeyMutex.Lock()
+	defer fake.revokeAPIKeyMutex.Unlock()
+	fake.RevokeAPIKeyStub = nil
+	fake.revokeAPIKeyReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) RevokeAPIKeyReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeAPIKeyMutex.Lock()
+	defer fake.revokeAPIKeyMutex.Unlock()
+	fake.RevokeAPIKeyStub = nil
+	if fake.revokeAPIKeyReturnsOnCall == nil {
+		fake.revokeAPIKeyReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeAPIKeyReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) TouchAPIKey(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 time.Time) error {
+	fake.touchAPIKeyMutex.Lock()
+	ret, specificReturn := fake.touchAPIKeyReturnsOnCall[len(fake.touchAPIKeyArgsForCall)]
+	fake.touchAPIKeyArgsForCall = append(fake.touchAPIKeyArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 time.Time
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.TouchAPIKeyStub
+	fakeReturns := fake.touchAPIKeyReturns
+	fake.recordInvocation("TouchAPIKey", []interface{}{arg1, arg2, arg3, arg4})
+	fake.touchAPIKeyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePersistor) TouchAPIKeyCallCount() int {
+	fake.touchAPIKeyMutex.RLock()
+	defer fake.touchAPIKeyMutex.RUnlock()
+	return len(fake.touchAPIKeyArgsForCall)
+}
+
+func (fake *FakePersistor) TouchAPIKeyCalls(stub func(context.Context, persistence.TransactionHandler, int, time.Time) error) {
+	fake.touchAPIKeyMutex.Lock()
+	defer fake.touchAPIKeyMutex.Unlock()
+	fake.TouchAPIKeyStub = stub
+}
+
+func (fake *FakePersistor) TouchAPIKeyArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, time.Time) {
+	fake.touchAPIKeyMutex.RLock()
+	defer fake.touchAPIKeyMutex.RUnlock()
+	argsForCall := fake.touchAPIKeyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePersistor) TouchAPIKeyReturns(result1 error) {
+	fake.touchAPIKeyMutex.Lock()
+	defer fake.touchAPIKeyMutex.Unlock()
+	fake.TouchAPIKeyStub = nil
+	fake.touchAPIKeyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) TouchAPIKeyReturnsOnCall(i int, result1 error) {
+	fake.touchAPIKeyMutex.Lock()
+	defer fake.touchAPIKeyMutex.Unlock()
+	fake.TouchAPIKeyStub = nil
+	if fake.touchAPIKeyReturnsOnCall == nil {
+		fake.touchAPIKeyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.touchAPIKeyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createAPIKeyMutex.RLock()
+	defer fake.createAPIKeyMutex.RUnlock()
+	fake.getAPIKeyByHashMutex.RLock()
+	defer fake.getAPIKeyByHashMutex.RUnlock()
+	fake.getAPIKeyByIdMutex.RLock()
+	defer fake.getAPIKeyByIdMutex.RUnlock()
+	fake.getOrganizationByIdMutex.RLock()
+	defer fake.getOrganizationByIdMutex.RUnlock()
+	fake.getPermissionsMutex.RLock()
+	defer fake.getPermissionsMutex.RUnlock()
+	fake.getUserAPIKeysMutex.RLock()
+	defer fake.getUserAPIKeysMutex.RUnlock()
+	fake.getUserByIdMutex.RLock()
+	defer fake.getUserByIdMutex.RUnlock()
+	fake.hasRolePermissionMutex.RLock()
+	defer fake.hasRolePermissionMutex.RUnlock()
+	fake.revokeAPIKeyMutex.RLock()
+	defer fake.revokeAPIKeyMutex.RUnlock()
+	fake.touchAPIKeyMutex.RLock()
+	defer fake.touchAPIKeyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePersistor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -1 +1,30 @@
 package authlogic
+
+import (
+	"context"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"time"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . persistor
+type persistor interface {
+	GetUserById(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.User, error)
+	GetUserByEmail(ctx context.Context, tx persistence.TransactionHandler, email string) (*model.User, error)
+	CreateRefreshToken(ctx context.Context, tx persistence.TransactionHandler, token *model.RefreshToken) (*model.RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tx persistence.TransactionHandler, hash string) (*model.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, tx persistence.TransactionHandler, id int) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, tx persistence.TransactionHandler, family string) error
+	RevokeUserRefreshTokens(ctx context.Context, tx persistence.TransactionHandler, userId int) error
+	SetUserResetToken(ctx context.Context, tx persistence.TransactionHandler, userId int, hash string, expiresAt time.Time) error
+	GetUserByResetToken(ctx context.Context, tx persistence.TransactionHandler, hash string) (*model.User, error)
+	ClearUserResetToken(ctx context.Context, tx persistence.TransactionHandler, userId int, hash string) (bool, error)
+}
+
+//counterfeiter:generate . passwordManager
+type passwordManager interface {
+	CheckPassword(ctx context.Context, tx persistence.TransactionHandler, user *model.User, password string) (bool, error)
+	SetPassword(ctx context.Context, tx persistence.TransactionHandler, userId int, password string) error
+}
@@ -1,21 +1,66 @@
 package authlogic
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/authtoken"
+	"github.com/dembygenesis/local.tools/internal/lib/mailer"
+	"github.com/dembygenesis/local.tools/internal/model"
 	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
 	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const tokenTypeBearer = "Bearer"
+
+var (
+	errInvalidCredentials = errors.New("invalid email or password")
+	errInvalidRefresh     = errors.New("invalid refresh token")
+	errRefreshReused      = errors.New("refresh token was already used, the session is revoked")
+	errRefreshExpired     = errors.New("refresh token expired")
+	errInvalidAccess      = errors.New("invalid access token")
+	errInactiveUser       = errors.New("user is inactive")
+	errInvalidReset       = errors.New("invalid or expired password reset token")
 )
 
 type Config struct {
 	TxProvider persistence.TransactionProvider `json:"tx_provider" validate:"required"`
 	Logger     *logrus.Entry                   `json:"logger" validate:"required"`
+	Persistor  persistor                       `json:"persistor" validate:"required"`
+
+	// Passwords checks the login passwords against the stored hashes, and
+	// hashes the reset ones.
+	Passwords passwordManager `json:"passwords" validate:"required"`
+
+	// Signer issues the short-lived access tokens.
+	Signer *authtoken.Signer `json:"signer" validate:"required"`
+
+	// RefreshTokenTTL is how long a refresh token can be traded for new tokens.
+	RefreshTokenTTL time.Duration `json:"refresh_token_ttl" validate:"required,is_positive_time_duration"`
+
+	// Mailer sends the password reset links.
+	Mailer mailer.Mailer `json:"mailer" validate:"required"`
+
+	// PasswordResetURL is the page of the reset links, which get the token
+	// as their "token" query parameter.
+	PasswordResetURL string `json:"password_reset_url" validate:"required,url"`
+
+	// PasswordResetTTL is how long a reset link can be used.
+	PasswordResetTTL time.Duration `json:"password_reset_ttl" validate:"required,is_positive_time_duration"`
 }
 
 func (i *Config) Validate() error {
 	return validationutils.Validate(i)
 }
 
+// Impl authenticates users with short-lived access tokens, and rotating
+// refresh tokens stored by hash.
 type Impl struct {
 	cfg *Config
 }
@@ -27,6 +72,317 @@ func New(cfg *Config) (*Impl, error) {
 	return &Impl{cfg}, nil
 }
 
-func (i *Impl) GetCategories() {
+// Login checks the credentials, and starts a session.
+func (i *Impl) Login(ctx context.Context, params *model.Login) (*model.AuthTokens, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	user, err := i.cfg.Persistor.GetUserByEmail(ctx, tx, strings.TrimSpace(params.Email))
+	if err != nil && !errors.Is(err, persistence.ErrNotFound) {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get user: %v", err),
+		})
+	}
+
+	// An unknown email is still checked, against a dummy hash, so it can't be
+	// told apart from a wrong password by the response time
+	ok, err := i.cfg.Passwords.CheckPassword(ctx, tx, user, params.Password)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("check password: %v", err),
+		})
+	}
+
+	if !ok || !user.IsActive {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errInvalidCredentials,
+		})
+	}
+
+	tokens, err := i.issueTokens(ctx, tx, user.Id, uuid.NewString())
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("issue tokens: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	return tokens, nil
+}
+
+// Refresh trades a refresh token for new tokens, revoking it. A refresh token
+// used twice was leaked, so its whole session is revoked.
+func (i *Impl) Refresh(ctx context.Context, params *model.RefreshSession) (*model.AuthTokens, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	stored, err := i.getRefreshToken(ctx, tx, params.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.RevokedAt.Valid {
+		return nil, i.revokeReusedFamily(ctx, tx, stored)
+	}
+
+	if !time.Now().Before(stored.ExpiresAt) {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errRefreshExpired,
+		})
+	}
+
+	revoked, err := i.cfg.Persistor.RevokeRefreshToken(ctx, tx, stored.Id)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("revoke: %v", err),
+		})
+	}
+	if !revoked {
+		// A concurrent refresh rotated it first
+		return nil, i.revokeReusedFamily(ctx, tx, stored)
+	}
+
+	user, err := i.cfg.Persistor.GetUserById(ctx, tx, stored.UserRefId)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get user: %v", err),
+		})
+	}
+	if !user.IsActive {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errInactiveUser,
+		})
+	}
+
+	tokens, err := i.issueTokens(ctx, tx, user.Id, stored.Family)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("issue tokens: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	return tokens, nil
+}
+
+// Logout revokes the session of the refresh token, or all the sessions of its
+// user. Access tokens already issued stay valid until they expire.
+func (i *Impl) Logout(ctx context.Context, params *model.Logout) error {
+	if err := params.Validate(); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	stored, err := i.getRefreshToken(ctx, tx, params.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if params.All {
+		err = i.cfg.Persistor.RevokeUserRefreshTokens(ctx, tx, stored.UserRefId)
+	} else {
+		err = i.cfg.Persistor.RevokeRefreshTokenFamily(ctx, tx, stored.Family)
+	}
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("revoke: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	return nil
+}
+
+// Authenticate verifies an access token, and returns its user if they are
+// still active.
+func (i *Impl) Authenticate(ctx context.Context, accessToken string) (*model.AuthUser, error) {
+	claims, err := i.cfg.Signer.Parse(accessToken)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errInvalidAccess,
+		})
+	}
+
+	// Parse already checked the subject is a user id
+	userId, _ := claims.UserId()
+
+	db, err := i.cfg.TxProvider.Db(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+
+	user, err := i.cfg.Persistor.GetUserById(ctx, db, userId)
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusUnauthorized,
+				Err:        errInvalidAccess,
+			})
+		}
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get user: %v", err),
+		})
+	}
+	if !user.IsActive {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errInactiveUser,
+		})
+	}
+
+	return &model.AuthUser{
+		Id:                user.Id,
+		Email:             user.Email,
+		Firstname:         user.Firstname,
+		Lastname:          user.Lastname,
+		CategoryTypeRefId: user.CategoryTypeRefId,
+	}, nil
+}
+
+// issueTokens signs an access token, and stores a new refresh token in the
+// session's family.
+func (i *Impl) issueTokens(ctx context.Context, tx persistence.TransactionHandler, userId int, family string) (*model.AuthTokens, error) {
+	accessToken, accessExpiresAt, err := i.cfg.Signer.Sign(userId)
+	if err != nil {
+		return nil, fmt.Errorf("access token: %v", err)
+	}
+
+	refreshToken, hash, err := authtoken.NewOpaque()
+	if err != nil {
+		return nil, fmt.Errorf("refresh token: %v", err)
+	}
+
+	stored, err := i.cfg.Persistor.CreateRefreshToken(ctx, tx, &model.RefreshToken{
+		UserRefId: userId,
+		Family:    family,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(i.cfg.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("store refresh token: %v", err)
+	}
+
+	return &model.AuthTokens{
+		TokenType:             tokenTypeBearer,
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// getRefreshToken looks up a refresh token by its hash.
+func (i *Impl) getRefreshToken(ctx context.Context, tx persistence.TransactionHandler, token string) (*model.RefreshToken, error) {
+	stored, err := i.cfg.Persistor.GetRefreshTokenByHash(ctx, tx, authtoken.Hash(token))
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusUnauthorized,
+				Err:        errInvalidRefresh,
+			})
+		}
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get refresh token: %v", err),
+		})
+	}
+	return stored, nil
+}
+
+// revokeReusedFamily revokes the session of a refresh token that was used
+// after being rotated, and commits, as the request itself fails.
+func (i *Impl) revokeReusedFamily(ctx context.Context, tx persistence.TransactionHandler, stored *model.RefreshToken) error {
+	i.cfg.Logger.WithFields(logrus.Fields{
+		"user_id": stored.UserRefId,
+		"family":  stored.Family,
+	}).Warn("refresh token reused, revoking its session")
+
+	if err := i.cfg.Persistor.RevokeRefreshTokenFamily(ctx, tx, stored.Family); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("revoke family: %v", err),
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
 
+	return errs.New(&errs.Cfg{
+		StatusCode: http.StatusUnauthorized,
+		Err:        errRefreshReused,
+	})
 }
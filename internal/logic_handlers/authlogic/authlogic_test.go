@@ -0,0 +1,306 @@
+package authlogic
+
+import (
+	"context"
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/lib/authtoken"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/lib/mailer/mailerfakes"
+	"github.com/dembygenesis/local.tools/internal/lib/passhash"
+	"github.com/dembygenesis/local.tools/internal/logic_handlers/authlogic/authlogicfakes"
+	"github.com/dembygenesis/local.tools/internal/logic_handlers/userlogic"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlconn"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistencefakes"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistors/mysqlstore"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var (
+	mockTimeout  = 5 * time.Second
+	mockLogger   = logger.New(context.TODO())
+	mockEmail    = "demby@gmail.com"
+	mockPassword = "password123"
+	mockResetURL = "http://localhost:3000/reset-password?lang=en"
+)
+
+type dependencies struct {
+	Persistor  persistor
+	Passwords  passwordManager
+	Mailer     *mailerfakes.FakeMailer
+	Logger     *logrus.Entry
+	TxProvider persistence.TransactionProvider
+	Db         *sqlx.DB
+	Cleanup    func(ignoreErrors ...bool)
+}
+
+func getConcreteDependencies(t *testing.T) (*dependencies, func(ignoreErrors ...bool)) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+
+	store, err := mysqlstore.New(&mysqlstore.Config{
+		Logger: mockLogger,
+		QueryTimeouts: &persistence.QueryTimeouts{
+			Query: mockTimeout,
+			Exec:  mockTimeout,
+		},
+	})
+	require.NoError(t, err, "unexpected new mysqlstore error")
+
+	tx, err := mysqltx.New(&mysqltx.Config{
+		Logger:       mockLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected new mysqltx error")
+
+	prov, err := mysqlconn.New(&mysqlconn.Config{
+		Logger:    mockLogger,
+		TxHandler: tx,
+	})
+	require.NoError(t, err, "unexpected new mysqlconn error")
+
+	// Cheaper than the seed's hash parameters, so logins rehash
+	hasher, err := passhash.New(&passhash.Config{
+		Algorithm:         passhash.Argon2id,
+		Argon2Memory:      64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err, "unexpected new hasher error")
+
+	users, err := userlogic.New(&userlogic.Config{
+		TxProvider: prov,
+		Logger:     mockLogger,
+		Persistor:  store,
+		Hasher:     hasher,
+	})
+	require.NoError(t, err, "unexpected new userlogic error")
+
+	return &dependencies{
+		Persistor:  store,
+		Passwords:  users,
+		Mailer:     &mailerfakes.FakeMailer{},
+		TxProvider: prov,
+		Logger:     mockLogger,
+		Cleanup:    cleanup,
+		Db:         db,
+	}, cleanup
+}
+
+func newTestImpl(t *testing.T, deps *dependencies) *Impl {
+	signer, err := authtoken.New(&authtoken.Config{
+		Method:     authtoken.MethodHS256,
+		HMACSecret: []byte("0123456789abcdef0123456789abcdef"),
+		Issuer:     "test",
+		TTL:        time.Minute,
+	})
+	require.NoError(t, err, "unexpected new signer error")
+
+	svc, err := New(&Config{
+		TxProvider:       deps.TxProvider,
+		Logger:           deps.Logger,
+		Persistor:        deps.Persistor,
+		Passwords:        deps.Passwords,
+		Signer:           signer,
+		RefreshTokenTTL:  time.Hour,
+		Mailer:           deps.Mailer,
+		PasswordResetURL: mockResetURL,
+		PasswordResetTTL: time.Hour,
+	})
+	require.NoError(t, err, "unexpected new error")
+	return svc
+}
+
+func requireStatus(t *testing.T, err error, statusCode int, contains string) {
+	require.Error(t, err)
+
+	var errUtil *errs.Util
+	require.ErrorAs(t, err, &errUtil, "unexpected error type")
+	assert.Equal(t, statusCode, errUtil.StatusCode)
+	assert.Contains(t, err.Error(), contains)
+}
+
+func TestImpl_Login(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		login      *model.Login
+		mutations  func(t *testing.T, db *sqlx.DB)
+		statusCode int
+		err        string
+	}{
+		{name: "success", login: &model.Login{Email: mockEmail, Password: mockPassword}},
+		{name: "fail-validate", login: &model.Login{Email: mockEmail}, statusCode: http.StatusBadRequest, err: "'password' must have a value"},
+		{name: "fail-unknown-email", login: &model.Login{Email: "nobody@gmail.com", Password: mockPassword}, statusCode: http.StatusUnauthorized, err: errInvalidCredentials.Error()},
+		{name: "fail-wrong-password", login: &model.Login{Email: mockEmail, Password: "password124"}, statusCode: http.StatusUnauthorized, err: errInvalidCredentials.Error()},
+		{
+			name:  "fail-plaintext-password",
+			login: &model.Login{Email: mockEmail, Password: mockPassword},
+			mutations: func(t *testing.T, db *sqlx.DB) {
+				_, err := db.Exec("UPDATE user SET password = ? WHERE email = ?", mockPassword, mockEmail)
+				require.NoError(t, err, "unexpected unhash error")
+			},
+			statusCode: http.StatusUnauthorized,
+			err:        errInvalidCredentials.Error(),
+		},
+		{
+			name:  "fail-inactive",
+			login: &model.Login{Email: mockEmail, Password: mockPassword},
+			mutations: func(t *testing.T, db *sqlx.DB) {
+				_, err := db.Exec("UPDATE user SET is_active = 0 WHERE email = ?", mockEmail)
+				require.NoError(t, err, "unexpected deactivate error")
+			},
+			statusCode: http.StatusUnauthorized,
+			err:        errInvalidCredentials.Error(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, cleanup := getConcreteDependencies(t)
+			defer cleanup()
+			svc := newTestImpl(t, deps)
+
+			if tt.mutations != nil {
+				tt.mutations(t, deps.Db)
+			}
+
+			tokens, err := svc.Login(context.Background(), tt.login)
+			if tt.statusCode != 0 {
+				requireStatus(t, err, tt.statusCode, tt.err)
+				return
+			}
+
+			require.NoError(t, err, "unexpected login error")
+			assert.Equal(t, tokenTypeBearer, tokens.TokenType)
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.NotEmpty(t, tokens.RefreshToken)
+			assert.True(t, tokens.RefreshTokenExpiresAt.After(tokens.AccessTokenExpiresAt))
+
+			user, err := svc.Authenticate(context.Background(), tokens.AccessToken)
+			require.NoError(t, err, "unexpected authenticate error")
+			assert.Equal(t, mockEmail, user.Email)
+
+			count, err := mysqlmodel.RefreshTokens(mysqlmodel.RefreshTokenWhere.TokenHash.EQ(authtoken.Hash(tokens.RefreshToken))).Count(context.Background(), deps.Db)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), count, "only the hash of the refresh token is stored")
+
+			stored, err := mysqlmodel.Users(mysqlmodel.UserWhere.Email.EQ(mockEmail)).One(context.Background(), deps.Db)
+			require.NoError(t, err)
+			assert.Contains(t, stored.Password, "$m=64,t=1,p=1$", "the password is rehashed with the new parameters")
+
+			_, err = svc.Login(context.Background(), tt.login)
+			require.NoError(t, err, "the rehashed password still logs in")
+		})
+	}
+}
+
+func TestImpl_Refresh(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, &model.Login{Email: mockEmail, Password: mockPassword})
+	require.NoError(t, err, "unexpected login error")
+
+	rotated, err := svc.Refresh(ctx, &model.RefreshSession{RefreshToken: login.RefreshToken})
+	require.NoError(t, err, "unexpected refresh error")
+	assert.NotEqual(t, login.RefreshToken, rotated.RefreshToken)
+
+	_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: login.RefreshToken})
+	requireStatus(t, err, http.StatusUnauthorized, errRefreshReused.Error())
+
+	_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: rotated.RefreshToken})
+	requireStatus(t, err, http.StatusUnauthorized, errRefreshReused.Error())
+
+	_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: "unknown"})
+	requireStatus(t, err, http.StatusUnauthorized, errInvalidRefresh.Error())
+
+	fresh, err := svc.Login(ctx, &model.Login{Email: mockEmail, Password: mockPassword})
+	require.NoError(t, err, "unexpected login error")
+	_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: fresh.RefreshToken})
+	require.NoError(t, err, "reusing a token only revokes its own session")
+}
+
+func TestImpl_Refresh_Expired(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	ctx := context.Background()
+
+	login, err := svc.Login(ctx, &model.Login{Email: mockEmail, Password: mockPassword})
+	require.NoError(t, err, "unexpected login error")
+
+	_, err = deps.Db.Exec("UPDATE refresh_token SET expires_at = ? WHERE token_hash = ?", time.Now().Add(-time.Minute), authtoken.Hash(login.RefreshToken))
+	require.NoError(t, err, "unexpected expire error")
+
+	_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: login.RefreshToken})
+	requireStatus(t, err, http.StatusUnauthorized, errRefreshExpired.Error())
+}
+
+func TestImpl_Logout(t *testing.T) {
+	for _, all := range []bool{false, true} {
+		deps, cleanup := getConcreteDependencies(t)
+		svc := newTestImpl(t, deps)
+		ctx := context.Background()
+
+		first, err := svc.Login(ctx, &model.Login{Email: mockEmail, Password: mockPassword})
+		require.NoError(t, err, "unexpected login error")
+		second, err := svc.Login(ctx, &model.Login{Email: mockEmail, Password: mockPassword})
+		require.NoError(t, err, "unexpected login error")
+
+		require.NoError(t, svc.Logout(ctx, &model.Logout{RefreshToken: first.RefreshToken, All: all}))
+
+		_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: first.RefreshToken})
+		require.Error(t, err, "the session is revoked")
+
+		_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: second.RefreshToken})
+		if all {
+			require.Error(t, err, "every session is revoked")
+		} else {
+			require.NoError(t, err, "the other session is kept")
+		}
+		cleanup()
+	}
+}
+
+func TestImpl_Authenticate_Fail(t *testing.T) {
+	mockPersistor := &authlogicfakes.FakePersistor{}
+	mockPersistor.GetUserByIdReturns(nil, persistence.ErrNotFound)
+
+	mockTxProvider := &persistencefakes.FakeTransactionProvider{}
+	mockTxProvider.DbReturns(&persistencefakes.FakeTransactionHandler{}, nil)
+
+	svc := newTestImpl(t, &dependencies{
+		Persistor:  mockPersistor,
+		Passwords:  &authlogicfakes.FakePasswordManager{},
+		Mailer:     &mailerfakes.FakeMailer{},
+		TxProvider: mockTxProvider,
+		Logger:     mockLogger,
+	})
+
+	_, err := svc.Authenticate(context.Background(), "not-a-token")
+	requireStatus(t, err, http.StatusUnauthorized, errInvalidAccess.Error())
+
+	token, _, err := svc.cfg.Signer.Sign(99)
+	require.NoError(t, err)
+	_, err = svc.Authenticate(context.Background(), token)
+	requireStatus(t, err, http.StatusUnauthorized, errInvalidAccess.Error())
+
+	mockPersistor.GetUserByIdReturns(&model.User{Id: 99}, nil)
+	_, err = svc.Authenticate(context.Background(), token)
+	requireStatus(t, err, http.StatusUnauthorized, errInactiveUser.Error())
+
+	mockTxProvider.DbReturns(nil, errors.New("error getting db"))
+	_, err = svc.Authenticate(context.Background(), token)
+	requireStatus(t, err, http.StatusInternalServerError, "get db:")
+}
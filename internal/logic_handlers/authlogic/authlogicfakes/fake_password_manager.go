@@ -0,0 +1,202 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package authlogicfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+)
+
+type FakePasswordManager struct {
+	CheckPasswordStub        func(context.Context, persistence.TransactionHandler, *model.User, string) (bool, error)
+	checkPasswordMutex       sync.RWMutex
+	checkPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.User
+		arg4 string
+	}
+	checkPasswordReturns struct {
+		result1 bool
+		result2 error
+	}
+	checkPasswordReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SetPasswordStub        func(context.Context, persistence.TransactionHandler, int, string) error
+	setPasswordMutex       sync.RWMutex
+	setPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}
+	setPasswordReturns struct {
+		result1 error
+	}
+	setPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePasswordManager) CheckPassword(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 *model.User, arg4 string) (bool, error) {
+	fake.checkPasswordMutex.Lock()
+	ret, specificReturn := fake.checkPasswordReturnsOnCall[len(fake.checkPasswordArgsForCall)]
+	fake.checkPasswordArgsForCall = append(fake.checkPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.User
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CheckPasswordStub
+	fakeReturns := fake.checkPasswordReturns
+	fake.recordInvocation("CheckPassword", []interface{}{arg1, arg2, arg3, arg4})
+	fake.checkPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePasswordManager) CheckPasswordCallCount() int {
+	fake.checkPasswordMutex.RLock()
+	defer fake.checkPasswordMutex.RUnlock()
+	return len(fake.checkPasswordArgsForCall)
+}
+
+func (fake *FakePasswordManager) CheckPasswordCalls(stub func(context.Context, persistence.TransactionHandler, *model.User, string) (bool, error)) {
+	fake.checkPasswordMutex.Lock()
+	defer fake.checkPasswordMutex.Unlock()
+	fake.CheckPasswordStub = stub
+}
+
+func (fake *FakePasswordManager) CheckPasswordArgsForCall(i int) (context.Context, persistence.TransactionHandler, *model.User, string) {
+	fake.checkPasswordMutex.RLock()
+	defer fake.checkPasswordMutex.RUnlock()
+	argsForCall := fake.checkPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePasswordManager) CheckPasswordReturns(result1 bool, result2 error) {
+	fake.checkPasswordMutex.Lock()
+	defer fake.checkPasswordMutex.Unlock()
+	fake.CheckPasswordStub = nil
+	fake.checkPasswordReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePasswordManager) CheckPasswordReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.checkPasswordMutex.Lock()
+	defer fake.checkPasswordMutex.Unlock()
+	fake.CheckPasswordStub = nil
+	if fake.checkPasswordReturnsOnCall == nil {
+		fake.checkPasswordReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.checkPasswordReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePasswordManager) SetPassword(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 string) error {
+	fake.setPasswordMutex.Lock()
+	ret, specificReturn := fake.setPasswordReturnsOnCall[len(fake.setPasswordArgsForCall)]
+	fake.setPasswordArgsForCall = append(fake.setPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetPasswordStub
+	fakeReturns := fake.setPasswordReturns
+	fake.recordInvocation("SetPassword", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePasswordManager) SetPasswordCallCount() int {
+	fake.setPasswordMutex.RLock()
+	defer fake.setPasswordMutex.RUnlock()
+	return len(fake.setPasswordArgsForCall)
+}
+
+func (fake *FakePasswordManager) SetPasswordCalls(stub func(context.Context, persistence.TransactionHandler, int, string) error) {
+	fake.setPasswordMutex.Lock()
+	defer fake.setPasswordMutex.Unlock()
+	fake.SetPasswordStub = stub
+}
+
+func (fake *FakePasswordManager) SetPasswordArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, string) {
+	fake.setPasswordMutex.RLock()
+	defer fake.setPasswordMutex.RUnlock()
+	argsForCall := fake.setPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePasswordManager) SetPasswordReturns(result1 error) {
+	fake.setPasswordMutex.Lock()
+	defer fake.setPasswordMutex.Unlock()
+	fake.SetPasswordStub = nil
+	fake.setPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePasswordManager) SetPasswordReturnsOnCall(i int, result1 error) {
+	fake.setPasswordMutex.Lock()
+	defer fake.setPasswordMutex.Unlock()
+	fake.SetPasswordStub = nil
+	if fake.setPasswordReturnsOnCall == nil {
+		fake.setPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePasswordManager) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkPasswordMutex.RLock()
+	defer fake.checkPasswordMutex.RUnlock()
+	fake.setPasswordMutex.RLock()
+	defer fake.setPasswordMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePasswordManager) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
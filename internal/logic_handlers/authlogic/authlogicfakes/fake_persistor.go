@@ -0,0 +1,859 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package authlogicfakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+)
+
+type FakePersistor struct {
+	ClearUserResetTokenStub        func(context.Context, persistence.TransactionHandler, int, string) (bool, error)
+	clearUserResetTokenMutex       sync.RWMutex
+	clearUserResetTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}
+	clearUserResetTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	clearUserResetTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	CreateRefreshTokenStub        func(context.Context, persistence.TransactionHandler, *model.RefreshToken) (*model.RefreshToken, error)
+	createRefreshTokenMutex       sync.RWMutex
+	createRefreshTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.RefreshToken
+	}
+	createRefreshTokenReturns struct {
+		result1 *model.RefreshToken
+		result2 error
+	}
+	createRefreshTokenReturnsOnCall map[int]struct {
+		result1 *model.RefreshToken
+		result2 error
+	}
+	GetRefreshTokenByHashStub        func(context.Context, persistence.TransactionHandler, string) (*model.RefreshToken, error)
+	getRefreshTokenByHashMutex       sync.RWMutex
+	getRefreshTokenByHashArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}
+	getRefreshTokenByHashReturns struct {
+		result1 *model.RefreshToken
+		result2 error
+	}
+	getRefreshTokenByHashReturnsOnCall map[int]struct {
+		result1 *model.RefreshToken
+		result2 error
+	}
+	GetUserByEmailStub        func(context.Context, persistence.TransactionHandler, string) (*model.User, error)
+	getUserByEmailMutex       sync.RWMutex
+	getUserByEmailArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}
+	getUserByEmailReturns struct {
+		result1 *model.User
+		result2 error
+	}
+	getUserByEmailReturnsOnCall map[int]struct {
+		result1 *model.User
+		result2 error
+	}
+	GetUserByIdStub        func(context.Context, persistence.TransactionHandler, int) (*model.User, error)
+	getUserByIdMutex       sync.RWMutex
+	getUserByIdArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	getUserByIdReturns struct {
+		result1 *model.User
+		result2 error
+	}
+	getUserByIdReturnsOnCall map[int]struct {
+		result1 *model.User
+		result2 error
+	}
+	GetUserByResetTokenStub        func(context.Context, persistence.TransactionHandler, string) (*model.User, error)
+	getUserByResetTokenMutex       sync.RWMutex
+	getUserByResetTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}
+	getUserByResetTokenReturns struct {
+		result1 *model.User
+		result2 error
+	}
+	getUserByResetTokenReturnsOnCall map[int]struct {
+		result1 *model.User
+		result2 error
+	}
+	RevokeRefreshTokenStub        func(context.Context, persistence.TransactionHandler, int) (bool, error)
+	revokeRefreshTokenMutex       sync.RWMutex
+	revokeRefreshTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	revokeRefreshTokenReturns struct {
+		result1 bool
+		result2 error
+	}
+	revokeRefreshTokenReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RevokeRefreshTokenFamilyStub        func(context.Context, persistence.TransactionHandler, string) error
+	revokeRefreshTokenFamilyMutex       sync.RWMutex
+	revokeRefreshTokenFamilyArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}
+	revokeRefreshTokenFamilyReturns struct {
+		result1 error
+	}
+	revokeRefreshTokenFamilyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeUserRefreshTokensStub        func(context.Context, persistence.TransactionHandler, int) error
+	revokeUserRefreshTokensMutex       sync.RWMutex
+	revokeUserRefreshTokensArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	revokeUserRefreshTokensReturns struct {
+		result1 error
+	}
+	revokeUserRefreshTokensReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetUserResetTokenStub        func(context.Context, persistence.TransactionHandler, int, string, time.Time) error
+	setUserResetTokenMutex       sync.RWMutex
+	setUserResetTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+		arg5 time.Time
+	}
+	setUserResetTokenReturns struct {
+		result1 error
+	}
+	setUserResetTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePersistor) ClearUserResetToken(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 string) (bool, error) {
+	fake.clearUserResetTokenMutex.Lock()
+	ret, specificReturn := fake.clearUserResetTokenReturnsOnCall[len(fake.clearUserResetTokenArgsForCall)]
+	fake.clearUserResetTokenArgsForCall = append(fake.clearUserResetTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ClearUserResetTokenStub
+	fakeReturns := fake.clearUserResetTokenReturns
+	fake.recordInvocation("ClearUserResetToken", []interface{}{arg1, arg2, arg3, arg4})
+	fake.clearUserResetTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) ClearUserResetTokenCallCount() int {
+	fake.clearUserResetTokenMutex.RLock()
+	defer fake.clearUserResetTokenMutex.RUnlock()
+	return len(fake.clearUserResetTokenArgsForCall)
+}
+
+func (fake *FakePersistor) ClearUserResetTokenCalls(stub func(context.Context, persistence.TransactionHandler, int, string) (bool, error)) {
+	fake.clearUserResetTokenMutex.Lock()
+	defer fake.clearUserResetTokenMutex.Unlock()
+	fake.ClearUserResetTokenStub = stub
+}
+
+func (fake *FakePersistor) ClearUserResetTokenArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, string) {
+	fake.clearUserResetTokenMutex.RLock()
+	defer fake.clearUserResetTokenMutex.RUnlock()
+	argsForCall := fake.clearUserResetTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePersistor) ClearUserResetTokenReturns(result1 bool, result2 error) {
+	fake.clearUserResetTokenMutex.Lock()
+	defer fake.clearUserResetTokenMutex.Unlock()
+	fake.ClearUserResetTokenStub = nil
+	fake.clearUserResetTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) ClearUserResetTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.clearUserResetTokenMutex.Lock()
+	defer fake.clearUserResetTokenMutex.Unlock()
+	fake.ClearUserResetTokenStub = nil
+	if fake.clearUserResetTokenReturnsOnCall == nil {
+		fake.clearUserResetTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.clearUserResetTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) CreateRefreshToken(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 *model.RefreshToken) (*model.RefreshToken, error) {
+	fake.createRefreshTokenMutex.Lock()
+	ret, specificReturn := fake.createRefreshTokenReturnsOnCall[len(fake.createRefreshTokenArgsForCall)]
+	fake.createRefreshTokenArgsForCall = append(fake.createRefreshTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.RefreshToken
+	}{arg1, arg2, arg3})
+	stub := fake.CreateRefreshTokenStub
+	fakeReturns := fake.createRefreshTokenReturns
+	fake.recordInvocation("CreateRefreshToken", []interface{}{arg1, arg2, arg3})
+	fake.createRefreshTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) CreateRefreshTokenCallCount() int {
+	fake.createRefreshTokenMutex.RLock()
+	defer fake.createRefreshTokenMutex.RUnlock()
+	return len(fake.createRefreshTokenArgsForCall)
+}
+
+func (fake *FakePersistor) CreateRefreshTokenCalls(stub func(context.Context, persistence.TransactionHandler, *model.RefreshToken) (*model.RefreshToken, error)) {
+	fake.createRefreshTokenMutex.Lock()
+	defer fake.createRefreshTokenMutex.Unlock()
+	fake.CreateRefreshTokenStub = stub
+}
+
+func (fake *FakePersistor) CreateRefreshTokenArgsForCall(i int) (context.Context, persistence.TransactionHandler, *model.RefreshToken) {
+	fake.createRefreshTokenMutex.RLock()
+	defer fake.createRefreshTokenMutex.RUnlock()
+	argsForCall := fake.createRefreshTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) CreateRefreshTokenReturns(result1 *model.RefreshToken, result2 error) {
+	fake.createRefreshTokenMutex.Lock()
+	defer fake.createRefreshTokenMutex.Unlock()
+	fake.CreateRefreshTokenStub = nil
+	fake.createRefreshTokenReturns = struct {
+		result1 *model.RefreshToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) CreateRefreshTokenReturnsOnCall(i int, result1 *model.RefreshToken, result2 error) {
+	fake.createRefreshTokenMutex.Lock()
+	defer fake.createRefreshTokenMutex.Unlock()
+	fake.CreateRefreshTokenStub = nil
+	if fake.createRefreshTokenReturnsOnCall == nil {
+		fake.createRefreshTokenReturnsOnCall = make(map[int]struct {
+			result1 *model.RefreshToken
+			result2 error
+		})
+	}
+	fake.createRefreshTokenReturnsOnCall[i] = struct {
+		result1 *model.RefreshToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetRefreshTokenByHash(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 string) (*model.RefreshToken, error) {
+	fake.getRefreshTokenByHashMutex.Lock()
+	ret, specificReturn := fake.getRefreshTokenByHashReturnsOnCall[len(fake.getRefreshTokenByHashArgsForCall)]
+	fake.getRefreshTokenByHashArgsForCall = append(fake.getRefreshTokenByHashArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetRefreshTokenByHashStub
+	fakeReturns := fake.getRefreshTokenByHashReturns
+	fake.recordInvocation("GetRefreshTokenByHash", []interface{}{arg1, arg2, arg3})
+	fake.getRefreshTokenByHashMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetRefreshTokenByHashCallCount() int {
+	fake.getRefreshTokenByHashMutex.RLock()
+	defer fake.getRefreshTokenByHashMutex.RUnlock()
+	return len(fake.getRefreshTokenByHashArgsForCall)
+}
+
+func (fake *FakePersistor) GetRefreshTokenByHashCalls(stub func(context.Context, persistence.TransactionHandler, string) (*model.RefreshToken, error)) {
+	fake.getRefreshTokenByHashMutex.Lock()
+	defer fake.getRefreshTokenByHashMutex.Unlock()
+	fake.GetRefreshTokenByHashStub = stub
+}
+
+func (fake *FakePersistor) GetRefreshTokenByHashArgsForCall(i int) (context.Context, persistence.TransactionHandler, string) {
+	fake.getRefreshTokenByHashMutex.RLock()
+	defer fake.getRefreshTokenByHashMutex.RUnlock()
+	argsForCall := fake.getRefreshTokenByHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetRefreshTokenByHashReturns(result1 *model.RefreshToken, result2 error) {
+	fake.getRefreshTokenByHashMutex.Lock()
+	defer fake.getRefreshTokenByHashMutex.Unlock()
+	fake.GetRefreshTokenByHashStub = nil
+	fake.getRefreshTokenByHashReturns = struct {
+		result1 *model.RefreshToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetRefreshTokenByHashReturnsOnCall(i int, result1 *model.RefreshToken, result2 error) {
+	fake.getRefreshTokenByHashMutex.Lock()
+	defer fake.getRefreshTokenByHashMutex.Unlock()
+	fake.GetRefreshTokenByHashStub = nil
+	if fake.getRefreshTokenByHashReturnsOnCall == nil {
+		fake.getRefreshTokenByHashReturnsOnCall = make(map[int]struct {
+			result1 *model.RefreshToken
+			result2 error
+		})
+	}
+	fake.getRefreshTokenByHashReturnsOnCall[i] = struct {
+		result1 *model.RefreshToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserByEmail(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 string) (*model.User, error) {
+	fake.getUserByEmailMutex.Lock()
+	ret, specificReturn := fake.getUserByEmailReturnsOnCall[len(fake.getUserByEmailArgsForCall)]
+	fake.getUserByEmailArgsForCall = append(fake.getUserByEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetUserByEmailStub
+	fakeReturns := fake.getUserByEmailReturns
+	fake.recordInvocation("GetUserByEmail", []interface{}{arg1, arg2, arg3})
+	fake.getUserByEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetUserByEmailCallCount() int {
+	fake.getUserByEmailMutex.RLock()
+	defer fake.getUserByEmailMutex.RUnlock()
+	return len(fake.getUserByEmailArgsForCall)
+}
+
+func (fake *FakePersistor) GetUserByEmailCalls(stub func(context.Context, persistence.TransactionHandler, string) (*model.User, error)) {
+	fake.getUserByEmailMutex.Lock()
+	defer fake.getUserByEmailMutex.Unlock()
+	fake.GetUserByEmailStub = stub
+}
+
+func (fake *FakePersistor) GetUserByEmailArgsForCall(i int) (context.Context, persistence.TransactionHandler, string) {
+	fake.getUserByEmailMutex.RLock()
+	defer fake.getUserByEmailMutex.RUnlock()
+	argsForCall := fake.getUserByEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetUserByEmailReturns(result1 *model.User, result2 error) {
+	fake.getUserByEmailMutex.Lock()
+	defer fake.getUserByEmailMutex.Unlock()
+	fake.GetUserByEmailStub = nil
+	fake.getUserByEmailReturns = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserByEmailReturnsOnCall(i int, result1 *model.User, result2 error) {
+	fake.getUserByEmailMutex.Lock()
+	defer fake.getUserByEmailMutex.Unlock()
+	fake.GetUserByEmailStub = nil
+	if fake.getUserByEmailReturnsOnCall == nil {
+		fake.getUserByEmailReturnsOnCall = make(map[int]struct {
+			result1 *model.User
+			result2 error
+		})
+	}
+	fake.getUserByEmailReturnsOnCall[i] = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserById(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) (*model.User, error) {
+	fake.getUserByIdMutex.Lock()
+	ret, specificReturn := fake.getUserByIdReturnsOnCall[len(fake.getUserByIdArgsForCall)]
+	fake.getUserByIdArgsForCall = append(fake.getUserByIdArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetUserByIdStub
+	fakeReturns := fake.getUserByIdReturns
+	fake.recordInvocation("GetUserById", []interface{}{arg1, arg2, arg3})
+	fake.getUserByIdMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetUserByIdCallCount() int {
+	fake.getUserByIdMutex.RLock()
+	defer fake.getUserByIdMutex.RUnlock()
+	return len(fake.getUserByIdArgsForCall)
+}
+
+func (fake *FakePersistor) GetUserByIdCalls(stub func(context.Context, persistence.TransactionHandler, int) (*model.User, error)) {
+	fake.getUserByIdMutex.Lock()
+	defer fake.getUserByIdMutex.Unlock()
+	fake.GetUserByIdStub = stub
+}
+
+func (fake *FakePersistor) GetUserByIdArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.getUserByIdMutex.RLock()
+	defer fake.getUserByIdMutex.RUnlock()
+	argsForCall := fake.getUserByIdArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetUserByIdReturns(result1 *model.User, result2 error) {
+	fake.getUserByIdMutex.Lock()
+	defer fake.getUserByIdMutex.Unlock()
+	fake.GetUserByIdStub = nil
+	fake.getUserByIdReturns = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserByIdReturnsOnCall(i int, result1 *model.User, result2 error) {
+	fake.getUserByIdMutex.Lock()
+	defer fake.getUserByIdMutex.Unlock()
+	fake.GetUserByIdStub = nil
+	if fake.getUserByIdReturnsOnCall == nil {
+		fake.getUserByIdReturnsOnCall = make(map[int]struct {
+			result1 *model.User
+			result2 error
+		})
+	}
+	fake.getUserByIdReturnsOnCall[i] = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserByResetToken(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 string) (*model.User, error) {
+	fake.getUserByResetTokenMutex.Lock()
+	ret, specificReturn := fake.getUserByResetTokenReturnsOnCall[len(fake.getUserByResetTokenArgsForCall)]
+	fake.getUserByResetTokenArgsForCall = append(fake.getUserByResetTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetUserByResetTokenStub
+	fakeReturns := fake.getUserByResetTokenReturns
+	fake.recordInvocation("GetUserByResetToken", []interface{}{arg1, arg2, arg3})
+	fake.getUserByResetTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetUserByResetTokenCallCount() int {
+	fake.getUserByResetTokenMutex.RLock()
+	defer fake.getUserByResetTokenMutex.RUnlock()
+	return len(fake.getUserByResetTokenArgsForCall)
+}
+
+func (fake *FakePersistor) GetUserByResetTokenCalls(stub func(context.Context, persistence.TransactionHandler, string) (*model.User, error)) {
+	fake.getUserByResetTokenMutex.Lock()
+	defer fake.getUserByResetTokenMutex.Unlock()
+	fake.GetUserByResetTokenStub = stub
+}
+
+func (fake *FakePersistor) GetUserByResetTokenArgsForCall(i int) (context.Context, persistence.TransactionHandler, string) {
+	fake.getUserByResetTokenMutex.RLock()
+	defer fake.getUserByResetTokenMutex.RUnlock()
+	argsForCall := fake.getUserByResetTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetUserByResetTokenReturns(result1 *model.User, result2 error) {
+	fake.getUserByResetTokenMutex.Lock()
+	defer fake.getUserByResetTokenMutex.Unlock()
+	fake.GetUserByResetTokenStub = nil
+	fake.getUserByResetTokenReturns = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserByResetTokenReturnsOnCall(i int, result1 *model.User, result2 error) {
+	fake.getUserByResetTokenMutex.Lock()
+	defer fake.getUserByResetTokenMutex.Unlock()
+	fake.GetUserByResetTokenStub = nil
+	if fake.getUserByResetTokenReturnsOnCall == nil {
+		fake.getUserByResetTokenReturnsOnCall = make(map[int]struct {
+			result1 *model.User
+			result2 error
+		})
+	}
+	fake.getUserByResetTokenReturnsOnCall[i] = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) RevokeRefreshToken(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) (bool, error) {
+	fake.revokeRefreshTokenMutex.Lock()
+	ret, specificReturn := fake.revokeRefreshTokenReturnsOnCall[len(fake.revokeRefreshTokenArgsForCall)]
+	fake.revokeRefreshTokenArgsForCall = append(fake.revokeRefreshTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeRefreshTokenStub
+	fakeReturns := fake.revokeRefreshTokenReturns
+	fake.recordInvocation("RevokeRefreshToken", []interface{}{arg1, arg2, arg3})
+	fake.revokeRefreshTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenCallCount() int {
+	fake.revokeRefreshTokenMutex.RLock()
+	defer fake.revokeRefreshTokenMutex.RUnlock()
+	return len(fake.revokeRefreshTokenArgsForCall)
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenCalls(stub func(context.Context, persistence.TransactionHandler, int) (bool, error)) {
+	fake.revokeRefreshTokenMutex.Lock()
+	defer fake.revokeRefreshTokenMutex.Unlock()
+	fake.RevokeRefreshTokenStub = stub
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.revokeRefreshTokenMutex.RLock()
+	defer fake.revokeRefreshTokenMutex.RUnlock()
+	argsForCall := fake.revokeRefreshTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenReturns(result1 bool, result2 error) {
+	fake.revokeRefreshTokenMutex.Lock()
+	defer fake.revokeRefreshTokenMutex.Unlock()
+	fake.RevokeRefreshTokenStub = nil
+	fake.revokeRefreshTokenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.revokeRefreshTokenMutex.Lock()
+	defer fake.revokeRefreshTokenMutex.Unlock()
+	fake.RevokeRefreshTokenStub = nil
+	if fake.revokeRefreshTokenReturnsOnCall == nil {
+		fake.revokeRefreshTokenReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.revokeRefreshTokenReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenFamily(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 string) error {
+	fake.revokeRefreshTokenFamilyMutex.Lock()
+	ret, specificReturn := fake.revokeRefreshTokenFamilyReturnsOnCall[len(fake.revokeRefreshTokenFamilyArgsForCall)]
+	fake.revokeRefreshTokenFamilyArgsForCall = append(fake.revokeRefreshTokenFamilyArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeRefreshTokenFamilyStub
+	fakeReturns := fake.revokeRefreshTokenFamilyReturns
+	fake.recordInvocation("RevokeRefreshTokenFamily", []interface{}{arg1, arg2, arg3})
+	fake.revokeRefreshTokenFamilyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenFamilyCallCount() int {
+	fake.revokeRefreshTokenFamilyMutex.RLock()
+	defer fake.revokeRefreshTokenFamilyMutex.RUnlock()
+	return len(fake.revokeRefreshTokenFamilyArgsForCall)
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenFamilyCalls(stub func(context.Context, persistence.TransactionHandler, string) error) {
+	fake.revokeRefreshTokenFamilyMutex.Lock()
+	defer fake.revokeRefreshTokenFamilyMutex.Unlock()
+	fake.RevokeRefreshTokenFamilyStub = stub
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenFamilyArgsForCall(i int) (context.Context, persistence.TransactionHandler, string) {
+	fake.revokeRefreshTokenFamilyMutex.RLock()
+	defer fake.revokeRefreshTokenFamilyMutex.RUnlock()
+	argsForCall := fake.revokeRefreshTokenFamilyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenFamilyReturns(result1 error) {
+	fake.revokeRefreshTokenFamilyMutex.Lock()
+	defer fake.revokeRefreshTokenFamilyMutex.Unlock()
+	fake.RevokeRefreshTokenFamilyStub = nil
+	fake.revokeRefreshTokenFamilyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) RevokeRefreshTokenFamilyReturnsOnCall(i int, result1 error) {
+	fake.revokeRefreshTokenFamilyMutex.Lock()
+	defer fake.revokeRefreshTokenFamilyMutex.Unlock()
+	fake.RevokeRefreshTokenFamilyStub = nil
+	if fake.revokeRefreshTokenFamilyReturnsOnCall == nil {
+		fake.revokeRefreshTokenFamilyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeRefreshTokenFamilyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) RevokeUserRefreshTokens(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) error {
+	fake.revokeUserRefreshTokensMutex.Lock()
+	ret, specificReturn := fake.revokeUserRefreshTokensReturnsOnCall[len(fake.revokeUserRefreshTokensArgsForCall)]
+	fake.revokeUserRefreshTokensArgsForCall = append(fake.revokeUserRefreshTokensArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeUserRefreshTokensStub
+	fakeReturns := fake.revokeUserRefreshTokensReturns
+	fake.recordInvocation("RevokeUserRefreshTokens", []interface{}{arg1, arg2, arg3})
+	fake.revokeUserRefreshTokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePersistor) RevokeUserRefreshTokensCallCount() int {
+	fake.revokeUserRefreshTokensMutex.RLock()
+	defer fake.revokeUserRefreshTokensMutex.RUnlock()
+	return len(fake.revokeUserRefreshTokensArgsForCall)
+}
+
+func (fake *FakePersistor) RevokeUserRefreshTokensCalls(stub func(context.Context, persistence.TransactionHandler, int) error) {
+	fake.revokeUserRefreshTokensMutex.Lock()
+	defer fake.revokeUserRefreshTokensMutex.Unlock()
+	fake.RevokeUserRefreshTokensStub = stub
+}
+
+func (fake *FakePersistor) RevokeUserRefreshTokensArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.revokeUserRefreshTokensMutex.RLock()
+	defer fake.revokeUserRefreshTokensMutex.RUnlock()
+	argsForCall := fake.revokeUserRefreshTokensArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) RevokeUserRefreshTokensReturns(result1 error) {
+	fake.revokeUserRefreshTokensMutex.Lock()
+	defer fake.revokeUserRefreshTokensMutex.Unlock()
+	fake.RevokeUserRefreshTokensStub = nil
+	fake.revokeUserRefreshTokensReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) RevokeUserRefreshTokensReturnsOnCall(i int, result1 error) {
+	fake.revokeUserRefreshTokensMutex.Lock()
+	defer fake.revokeUserRefreshTokensMutex.Unlock()
+	fake.RevokeUserRefreshTokensStub = nil
+	if fake.revokeUserRefreshTokensReturnsOnCall == nil {
+		fake.revokeUserRefreshTokensReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeUserRefreshTokensReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) SetUserResetToken(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 string, arg5 time.Time) error {
+	fake.setUserResetTokenMutex.Lock()
+	ret, specificReturn := fake.setUserResetTokenReturnsOnCall[len(fake.setUserResetTokenArgsForCall)]
+	fake.setUserResetTokenArgsForCall = append(fake.setUserResetTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+		arg5 time.Time
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.SetUserResetTokenStub
+	fakeReturns := fake.setUserResetTokenReturns
+	fake.recordInvocation("SetUserResetToken", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.setUserResetTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePersistor) SetUserResetTokenCallCount() int {
+	fake.setUserResetTokenMutex.RLock()
+	defer fake.setUserResetTokenMutex.RUnlock()
+	return len(fake.setUserResetTokenArgsForCall)
+}
+
+func (fake *FakePersistor) SetUserResetTokenCalls(stub func(context.Context, persistence.TransactionHandler, int, string, time.Time) error) {
+	fake.setUserResetTokenMutex.Lock()
+	defer fake.setUserResetTokenMutex.Unlock()
+	fake.SetUserResetTokenStub = stub
+}
+
+func (fake *FakePersistor) SetUserResetTokenArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, string, time.Time) {
+	fake.setUserResetTokenMutex.RLock()
+	defer fake.setUserResetTokenMutex.RUnlock()
+	argsForCall := fake.setUserResetTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakePersistor) SetUserResetTokenReturns(result1 error) {
+	fake.setUserResetTokenMutex.Lock()
+	defer fake.setUserResetTokenMutex.Unlock()
+	fake.SetUserResetTokenStub = nil
+	fake.setUserResetTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) SetUserResetTokenReturnsOnCall(i int, result1 error) {
+	fake.setUserResetTokenMutex.Lock()
+	defer fake.setUserResetTokenMutex.Unlock()
+	fake.SetUserResetTokenStub = nil
+	if fake.setUserResetTokenReturnsOnCall == nil {
+		fake.setUserResetTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setUserResetTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.clearUserResetTokenMutex.RLock()
+	defer fake.clearUserResetTokenMutex.RUnlock()
+	fake.createRefreshTokenMutex.RLock()
+	defer fake.createRefreshTokenMutex.RUnlock()
+	fake.getRefreshTokenByHashMutex.RLock()
+	defer fake.getRefreshTokenByHashMutex.RUnlock()
+	fake.getUserByEmailMutex.RLock()
+	defer fake.getUserByEmailMutex.RUnlock()
+	fake.getUserByIdMutex.RLock()
+	defer fake.getUserByIdMutex.RUnlock()
+	fake.getUserByResetTokenMutex.RLock()
+	defer fake.getUserByResetTokenMutex.RUnlock()
+	fake.revokeRefreshTokenMutex.RLock()
+	defer fake.revokeRefreshTokenMutex.RUnlock()
+	fake.revokeRefreshTokenFamilyMutex.RLock()
+	defer fake.revokeRefreshTokenFamilyMutex.RUnlock()
+	fake.revokeUserRefreshTokensMutex.RLock()
+	defer fake.revokeUserRefreshTokensMutex.RUnlock()
+	fake.setUserResetTokenMutex.RLock()
+	defer fake.setUserResetTokenMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePersistor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
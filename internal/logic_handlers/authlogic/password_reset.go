@@ -0,0 +1,208 @@
+package authlogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/authtoken"
+	"github.com/dembygenesis/local.tools/internal/lib/mailer"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const passwordResetSubject = "Reset your password"
+
+// passwordResetSendTimeout bounds sending a reset link, which outlives the request.
+const passwordResetSendTimeout = time.Minute
+
+// ForgotPassword mails a password reset link to the user of the email,
+// replacing any previous link. It succeeds whether the email is registered or
+// not, and when the mail can't be sent, so it can't be used to find out
+// which emails are registered; failures are logged instead. The mail is sent
+// in the background, so the response time doesn't tell either.
+func (i *Impl) ForgotPassword(ctx context.Context, params *model.ForgotPassword) error {
+	if err := params.Validate(); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	user, err := i.cfg.Persistor.GetUserByEmail(ctx, tx, strings.TrimSpace(params.Email))
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
+			return nil
+		}
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get user: %v", err),
+		})
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	token, hash, err := authtoken.NewOpaque()
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("reset token: %v", err),
+		})
+	}
+
+	err = i.cfg.Persistor.SetUserResetToken(ctx, tx, user.Id, hash, time.Now().Add(i.cfg.PasswordResetTTL))
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("store reset token: %v", err),
+		})
+	}
+
+	msg, err := i.passwordResetMessage(user, token)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("reset message: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	go i.sendPasswordReset(user.Id, msg)
+
+	return nil
+}
+
+// sendPasswordReset sends the reset link with its own context, as the
+// request's is cancelled once it is answered.
+func (i *Impl) sendPasswordReset(userId int, msg *mailer.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), passwordResetSendTimeout)
+	defer cancel()
+
+	if err := i.cfg.Mailer.Send(ctx, msg); err != nil {
+		i.cfg.Logger.WithField("user_id", userId).Errorf("send password reset: %v", err)
+	}
+}
+
+// ResetPassword sets a new password with the token of a reset link. The token
+// can only be used once, and every session of the user is revoked.
+func (i *Impl) ResetPassword(ctx context.Context, params *model.ResetPassword) error {
+	if err := params.Validate(); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	hash := authtoken.Hash(params.Token)
+	user, err := i.cfg.Persistor.GetUserByResetToken(ctx, tx, hash)
+	if err != nil {
+		if errors.Is(err, persistence.ErrNotFound) {
+			return errs.New(&errs.Cfg{
+				StatusCode: http.StatusBadRequest,
+				Err:        errInvalidReset,
+			})
+		}
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get user: %v", err),
+		})
+	}
+
+	if !user.IsActive || !user.ResetTokenExpiresAt.Valid || !time.Now().Before(user.ResetTokenExpiresAt.Time) {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        errInvalidReset,
+		})
+	}
+
+	cleared, err := i.cfg.Persistor.ClearUserResetToken(ctx, tx, user.Id, hash)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("clear reset token: %v", err),
+		})
+	}
+	if !cleared {
+		// A concurrent reset used it first
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        errInvalidReset,
+		})
+	}
+
+	if err = i.cfg.Passwords.SetPassword(ctx, tx, user.Id, params.Password); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("set password: %v", err),
+		})
+	}
+
+	if err = i.cfg.Persistor.RevokeUserRefreshTokens(ctx, tx, user.Id); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("revoke sessions: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	i.cfg.Logger.WithField("user_id", user.Id).Info("password reset, sessions revoked")
+	return nil
+}
+
+// passwordResetMessage writes the email with the reset link of the token.
+func (i *Impl) passwordResetMessage(user *model.User, token string) (*mailer.Message, error) {
+	link, err := url.Parse(i.cfg.PasswordResetURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse reset url: %v", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hi %s,\n\n", user.Firstname)
+	fmt.Fprintf(&body, "Open this link to choose a new password, it expires in %s:\n\n", i.cfg.PasswordResetTTL)
+	fmt.Fprintf(&body, "%s\n\n", link.String())
+	body.WriteString("If you didn't ask for it, ignore this email, your password stays the same.\n")
+
+	return &mailer.Message{
+		To:      user.Email,
+		Subject: passwordResetSubject,
+		Body:    body.String(),
+	}, nil
+}
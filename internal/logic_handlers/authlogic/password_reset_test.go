@@ -0,0 +1,203 @@
+package authlogic
+
+import (
+	"context"
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/lib/authtoken"
+	"github.com/dembygenesis/local.tools/internal/lib/mailer"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mailedResetToken returns the token of the reset link of the call-th mail,
+// once it was sent in the background.
+func mailedResetToken(t *testing.T, deps *dependencies, call int) string {
+	require.Eventually(t, func() bool {
+		return deps.Mailer.SendCallCount() > call
+	}, time.Second, 10*time.Millisecond, "the reset link wasn't sent")
+
+	_, msg := deps.Mailer.SendArgsForCall(call)
+	assert.Equal(t, mockEmail, msg.To)
+	assert.Equal(t, passwordResetSubject, msg.Subject)
+
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if !strings.HasPrefix(line, "http") {
+			continue
+		}
+		link, err := url.Parse(line)
+		require.NoError(t, err, "unexpected reset link")
+		assert.Equal(t, "en", link.Query().Get("lang"), "the query of the reset url is kept")
+		return link.Query().Get("token")
+	}
+
+	t.Fatalf("no reset link in the mail: %s", msg.Body)
+	return ""
+}
+
+func TestImpl_ResetPassword(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	ctx := context.Background()
+
+	session, err := svc.Login(ctx, &model.Login{Email: mockEmail, Password: mockPassword})
+	require.NoError(t, err, "unexpected login error")
+
+	require.NoError(t, svc.ForgotPassword(ctx, &model.ForgotPassword{Email: " " + mockEmail + " "}))
+	token := mailedResetToken(t, deps, 0)
+	require.NotEmpty(t, token)
+	require.Equal(t, 1, deps.Mailer.SendCallCount())
+
+	stored, err := mysqlmodel.Users(mysqlmodel.UserWhere.Email.EQ(mockEmail)).One(ctx, deps.Db)
+	require.NoError(t, err)
+	assert.Equal(t, authtoken.Hash(token), stored.ResetToken.String, "only the hash of the token is stored")
+
+	newPassword := "a-new-password"
+	require.NoError(t, svc.ResetPassword(ctx, &model.ResetPassword{Token: token, Password: newPassword}))
+
+	_, err = svc.Login(ctx, &model.Login{Email: mockEmail, Password: mockPassword})
+	requireStatus(t, err, http.StatusUnauthorized, errInvalidCredentials.Error())
+
+	_, err = svc.Login(ctx, &model.Login{Email: mockEmail, Password: newPassword})
+	require.NoError(t, err, "the new password logs in")
+
+	_, err = svc.Refresh(ctx, &model.RefreshSession{RefreshToken: session.RefreshToken})
+	requireStatus(t, err, http.StatusUnauthorized, errRefreshReused.Error())
+
+	err = svc.ResetPassword(ctx, &model.ResetPassword{Token: token, Password: "another-password"})
+	requireStatus(t, err, http.StatusBadRequest, errInvalidReset.Error())
+}
+
+func TestImpl_ResetPassword_Fail(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		mutations  func(t *testing.T, db *sqlx.DB)
+		password   string
+		statusCode int
+		err        string
+	}{
+		{
+			name: "fail-expired",
+			mutations: func(t *testing.T, db *sqlx.DB) {
+				_, err := db.Exec("UPDATE user SET reset_token_expires_at = NOW() - INTERVAL 1 MINUTE WHERE email = ?", mockEmail)
+				require.NoError(t, err, "unexpected expire error")
+			},
+			password:   "a-new-password",
+			statusCode: http.StatusBadRequest,
+			err:        errInvalidReset.Error(),
+		},
+		{
+			name: "fail-inactive",
+			mutations: func(t *testing.T, db *sqlx.DB) {
+				_, err := db.Exec("UPDATE user SET is_active = 0 WHERE email = ?", mockEmail)
+				require.NoError(t, err, "unexpected deactivate error")
+			},
+			password:   "a-new-password",
+			statusCode: http.StatusBadRequest,
+			err:        errInvalidReset.Error(),
+		},
+		{
+			name:       "fail-short-password",
+			password:   "short",
+			statusCode: http.StatusBadRequest,
+			err:        "'password' must have at least 8 characters",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			deps, cleanup := getConcreteDependencies(t)
+			defer cleanup()
+			svc := newTestImpl(t, deps)
+			ctx := context.Background()
+
+			require.NoError(t, svc.ForgotPassword(ctx, &model.ForgotPassword{Email: mockEmail}))
+			token := mailedResetToken(t, deps, 0)
+
+			if tt.mutations != nil {
+				tt.mutations(t, deps.Db)
+			}
+
+			err := svc.ResetPassword(ctx, &model.ResetPassword{Token: token, Password: tt.password})
+			requireStatus(t, err, tt.statusCode, tt.err)
+
+			_, err = svc.Login(ctx, &model.Login{Email: mockEmail, Password: tt.password})
+			assert.Error(t, err, "the password is unchanged")
+		})
+	}
+
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+
+	err := svc.ResetPassword(context.Background(), &model.ResetPassword{Token: "unknown", Password: "a-new-password"})
+	requireStatus(t, err, http.StatusBadRequest, errInvalidReset.Error())
+}
+
+func TestImpl_ForgotPassword_Silent(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	ctx := context.Background()
+
+	require.NoError(t, svc.ForgotPassword(ctx, &model.ForgotPassword{Email: "nobody@gmail.com"}), "unknown emails aren't told apart")
+	assert.Equal(t, 0, deps.Mailer.SendCallCount())
+
+	_, err := deps.Db.Exec("UPDATE user SET is_active = 0 WHERE email = 'demby@yahoo.com'")
+	require.NoError(t, err, "unexpected deactivate error")
+	require.NoError(t, svc.ForgotPassword(ctx, &model.ForgotPassword{Email: "demby@yahoo.com"}), "inactive users aren't told apart")
+	assert.Equal(t, 0, deps.Mailer.SendCallCount())
+
+	deps.Mailer.SendReturns(errors.New("smtp is down"))
+	require.NoError(t, svc.ForgotPassword(ctx, &model.ForgotPassword{Email: mockEmail}), "mail failures are only logged")
+
+	token := mailedResetToken(t, deps, 0)
+	assert.Equal(t, 1, deps.Mailer.SendCallCount())
+	require.NoError(t, svc.ResetPassword(ctx, &model.ResetPassword{Token: token, Password: "a-new-password"}), "the link stays valid")
+
+	err = svc.ForgotPassword(ctx, &model.ForgotPassword{})
+	requireStatus(t, err, http.StatusBadRequest, "'email' must have a value")
+}
+
+func TestImpl_ForgotPassword_Sends_In_Background(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+
+	sent := make(chan error, 1)
+	release := make(chan struct{})
+	deps.Mailer.SendStub = func(ctx context.Context, _ *mailer.Message) error {
+		<-release
+		sent <- ctx.Err()
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.ForgotPassword(ctx, &model.ForgotPassword{Email: mockEmail})
+	}()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("ForgotPassword waited on the mail")
+	}
+	cancel()
+	close(release)
+
+	select {
+	case err := <-sent:
+		assert.NoError(t, err, "the mail outlives the request's context")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the mail wasn't sent")
+	}
+}
@@ -18,3 +18,8 @@ type persistor interface {
 	DeleteCategory(ctx context.Context, tx persistence.TransactionHandler, id int) error
 	RestoreCategory(ctx context.Context, tx persistence.TransactionHandler, id int) error
 }
+
+//counterfeiter:generate . guard
+type guard interface {
+	Authorize(ctx context.Context, permission string) error
+}
@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package categorylogicfakes
+
+import (
+	"context"
+	"sync"
+)
+
+type FakeGuard struct {
+	AuthorizeStub        func(context.Context, string) error
+	authorizeMutex       sync.RWMutex
+	authorizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	authorizeReturns struct {
+		result1 error
+	}
+	authorizeReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeGuard) Authorize(arg1 context.Context, arg2 string) error {
+	fake.authorizeMutex.Lock()
+	ret, specificReturn := fake.authorizeReturnsOnCall[len(fake.authorizeArgsForCall)]
+	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.AuthorizeStub
+	fakeReturns := fake.authorizeReturns
+	fake.recordInvocation("Authorize", []interface{}{arg1, arg2})
+	fake.authorizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGuard) AuthorizeCallCount() int {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	return len(fake.authorizeArgsForCall)
+}
+
+func (fake *FakeGuard) AuthorizeCalls(stub func(context.Context, string) error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = stub
+}
+
+func (fake *FakeGuard) AuthorizeArgsForCall(i int) (context.Context, string) {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	argsForCall := fake.authorizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGuard) AuthorizeReturns(result1 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	fake.authorizeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGuard) AuthorizeReturnsOnCall(i int, result1 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	if fake.authorizeReturnsOnCall == nil {
+		fake.authorizeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.authorizeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGuard) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeGuard) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -18,6 +18,10 @@ type Config struct {
 	TxProvider persistence.TransactionProvider `json:"tx_provider" validate:"required"`
 	Logger     *logrus.Entry                   `json:"Logger" validate:"required"`
 	Persistor  persistor                       `json:"Persistor" validate:"required"`
+
+	// Guard checks the caller's permissions when set. Callers without an
+	// authenticated user, such as the CLI, leave it unset.
+	Guard guard `json:"guard"`
 }
 
 func (i *Config) Validate() error {
@@ -35,6 +39,14 @@ func New(cfg *Config) (*Service, error) {
 	return &Service{cfg}, nil
 }
 
+// authorize checks the caller has the permission, when a guard is set.
+func (i *Service) authorize(ctx context.Context, permission string) error {
+	if i.cfg.Guard == nil {
+		return nil
+	}
+	return i.cfg.Guard.Authorize(ctx, permission)
+}
+
 func (i *Service) validateCategoryTypeId(ctx context.Context, handler persistence.TransactionHandler, id int) error {
 	_, err := i.cfg.Persistor.GetCategoryTypeById(ctx, handler, id)
 	if err != nil {
@@ -45,6 +57,10 @@ func (i *Service) validateCategoryTypeId(ctx context.Context, handler persistenc
 
 // CreateCategory creates a new category.
 func (i *Service) CreateCategory(ctx context.Context, params *model.CreateCategory) (*model.Category, error) {
+	if err := i.authorize(ctx, model.PermissionCategoryWrite); err != nil {
+		return nil, err
+	}
+
 	if err := params.Validate(); err != nil {
 		return nil, errs.New(&errs.Cfg{
 			StatusCode: http.StatusBadRequest,
@@ -110,6 +126,10 @@ func (i *Service) ListCategories(
 	ctx context.Context,
 	filter *model.CategoryFilters,
 ) (*model.PaginatedCategories, error) {
+	if err := i.authorize(ctx, model.PermissionCategoryRead); err != nil {
+		return nil, err
+	}
+
 	db, err := i.cfg.TxProvider.Db(ctx)
 	if err != nil {
 		return nil, errs.New(&errs.Cfg{
@@ -132,6 +152,10 @@ func (i *Service) ListCategories(
 
 // UpdateCategory updates an existing category.
 func (i *Service) UpdateCategory(ctx context.Context, params *model.UpdateCategory) (*model.Category, error) {
+	if err := i.authorize(ctx, model.PermissionCategoryWrite); err != nil {
+		return nil, err
+	}
+
 	tx, err := i.cfg.TxProvider.Tx(ctx)
 	if err != nil {
 		return nil, errs.New(&errs.Cfg{
@@ -161,6 +185,10 @@ func (i *Service) UpdateCategory(ctx context.Context, params *model.UpdateCatego
 
 // DeleteCategory deletes a category by ID.
 func (s *Service) DeleteCategory(ctx context.Context, params *model.DeleteCategory) error {
+	if err := s.authorize(ctx, model.PermissionCategoryDelete); err != nil {
+		return err
+	}
+
 	tx, err := s.cfg.TxProvider.Tx(ctx)
 	if err != nil {
 		return errs.New(&errs.Cfg{
@@ -191,6 +219,10 @@ func (s *Service) DeleteCategory(ctx context.Context, params *model.DeleteCatego
 
 // RestoreCategory restores a deleted category by ID.
 func (s *Service) RestoreCategory(ctx context.Context, params *model.RestoreCategory) error {
+	if err := s.authorize(ctx, model.PermissionCategoryWrite); err != nil {
+		return err
+	}
+
 	tx, err := s.cfg.TxProvider.Tx(ctx)
 	if err != nil {
 		return errs.New(&errs.Cfg{
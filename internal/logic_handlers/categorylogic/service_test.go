@@ -510,3 +510,46 @@ func TestService_UpdateCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Guard(t *testing.T) {
+	mockTxProvider := &persistencefakes.FakeTransactionProvider{}
+	mockTxProvider.TxReturns(&persistencefakes.FakeTransactionHandler{}, nil)
+	mockTxProvider.DbReturns(&persistencefakes.FakeTransactionHandler{}, nil)
+
+	mockPersistor := &categorylogicfakes.FakePersistor{}
+	mockGuard := &categorylogicfakes.FakeGuard{}
+	mockGuard.AuthorizeReturns(errors.New("missing permission"))
+
+	svc, err := New(&Config{
+		TxProvider: mockTxProvider,
+		Logger:     mockLogger,
+		Persistor:  mockPersistor,
+		Guard:      mockGuard,
+	})
+	require.NoError(t, err, "unexpected new error")
+
+	ctx := context.Background()
+	for permission, call := range map[string]func() error{
+		model.PermissionCategoryRead: func() error {
+			_, err := svc.ListCategories(ctx, &model.CategoryFilters{})
+			return err
+		},
+		model.PermissionCategoryWrite: func() error {
+			_, err := svc.CreateCategory(ctx, &model.CreateCategory{})
+			return err
+		},
+		model.PermissionCategoryDelete: func() error {
+			return svc.DeleteCategory(ctx, &model.DeleteCategory{ID: 1})
+		},
+	} {
+		calls := mockGuard.AuthorizeCallCount()
+		assert.Error(t, call(), "the guard's error is returned")
+		require.Equal(t, calls+1, mockGuard.AuthorizeCallCount())
+		_, got := mockGuard.AuthorizeArgsForCall(calls)
+		assert.Equal(t, permission, got)
+	}
+
+	assert.Equal(t, 0, mockTxProvider.TxCallCount(), "nothing runs without the permission")
+	assert.Equal(t, 0, mockTxProvider.DbCallCount(), "nothing runs without the permission")
+	assert.Equal(t, 0, len(mockPersistor.Invocations()), "nothing runs without the permission")
+}
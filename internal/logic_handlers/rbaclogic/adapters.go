@@ -0,0 +1,20 @@
+package rbaclogic
+
+import (
+	"context"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+//counterfeiter:generate . persistor
+type persistor interface {
+	GetRoles(ctx context.Context, tx persistence.TransactionHandler) ([]model.Role, error)
+	GetRole(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.Role, error)
+	GetPermissions(ctx context.Context, tx persistence.TransactionHandler) ([]model.Permission, error)
+	GetPermissionByName(ctx context.Context, tx persistence.TransactionHandler, name string) (*model.Permission, error)
+	HasRolePermission(ctx context.Context, tx persistence.TransactionHandler, roleId int, permission string) (bool, error)
+	AddRolePermission(ctx context.Context, tx persistence.TransactionHandler, roleId, permissionId int) (bool, error)
+	DeleteRolePermission(ctx context.Context, tx persistence.TransactionHandler, roleId, permissionId int) (bool, error)
+}
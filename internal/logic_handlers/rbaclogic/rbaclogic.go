@@ -0,0 +1,243 @@
+package rbaclogic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+var (
+	errUnauthenticated = errors.New("authentication required")
+	errSelfLockout     = fmt.Errorf("can't revoke '%s' from your own role", model.PermissionRoleWrite)
+)
+
+type Config struct {
+	TxProvider persistence.TransactionProvider `json:"tx_provider" validate:"required"`
+	Logger     *logrus.Entry                   `json:"logger" validate:"required"`
+	Persistor  persistor                       `json:"persistor" validate:"required"`
+}
+
+func (i *Config) Validate() error {
+	return validationutils.Validate(i)
+}
+
+// Impl checks the permissions of the authenticated user's role, and manages
+// which permissions each role has. The mapping is read on every check, so
+// changes apply to the next request.
+type Impl struct {
+	cfg *Config
+}
+
+func New(cfg *Config) (*Impl, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %v", err)
+	}
+	return &Impl{cfg}, nil
+}
+
+// Authorize fails with 401 when the context has no authenticated user, and
+// with 403 when their role lacks the permission, or the API key they used
+// wasn't granted it.
+func (i *Impl) Authorize(ctx context.Context, permission string) error {
+	user, ok := model.AuthUserFromContext(ctx)
+	if !ok {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusUnauthorized,
+			Err:        errUnauthenticated,
+		})
+	}
+	if !user.HasScope(permission) {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusForbidden,
+			Err:        fmt.Errorf("the api key lacks the '%s' scope", permission),
+		})
+	}
+
+	db, err := i.cfg.TxProvider.Db(ctx)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+
+	allowed, err := i.cfg.Persistor.HasRolePermission(ctx, db, user.CategoryTypeRefId, permission)
+	if err != nil {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("has role permission: %v", err),
+		})
+	}
+	if !allowed {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusForbidden,
+			Err:        fmt.Errorf("missing permission '%s'", permission),
+		})
+	}
+
+	return nil
+}
+
+// ListPermissions returns every permission.
+func (i *Impl) ListPermissions(ctx context.Context) ([]model.Permission, error) {
+	if err := i.Authorize(ctx, model.PermissionRoleRead); err != nil {
+		return nil, err
+	}
+
+	db, err := i.cfg.TxProvider.Db(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+
+	permissions, err := i.cfg.Persistor.GetPermissions(ctx, db)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get permissions: %v", err),
+		})
+	}
+
+	return permissions, nil
+}
+
+// ListRoles returns the roles with their permissions.
+func (i *Impl) ListRoles(ctx context.Context) ([]model.Role, error) {
+	if err := i.Authorize(ctx, model.PermissionRoleRead); err != nil {
+		return nil, err
+	}
+
+	db, err := i.cfg.TxProvider.Db(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+
+	roles, err := i.cfg.Persistor.GetRoles(ctx, db)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get roles: %v", err),
+		})
+	}
+
+	return roles, nil
+}
+
+// GrantPermission grants a permission to a role, and returns the role.
+// Granting it again changes nothing.
+func (i *Impl) GrantPermission(ctx context.Context, params *model.RolePermission) (*model.Role, error) {
+	return i.updateRolePermission(ctx, params, func(tx persistence.TransactionHandler, roleId, permissionId int) error {
+		_, err := i.cfg.Persistor.AddRolePermission(ctx, tx, roleId, permissionId)
+		return err
+	})
+}
+
+// RevokePermission revokes a permission from a role, and returns the role.
+// Users can't revoke role:write from their own role, which would leave
+// them unable to undo it.
+func (i *Impl) RevokePermission(ctx context.Context, params *model.RolePermission) (*model.Role, error) {
+	if user, ok := model.AuthUserFromContext(ctx); ok &&
+		user.CategoryTypeRefId == params.RoleId && params.Permission == model.PermissionRoleWrite {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusConflict,
+			Err:        errSelfLockout,
+		})
+	}
+
+	return i.updateRolePermission(ctx, params, func(tx persistence.TransactionHandler, roleId, permissionId int) error {
+		_, err := i.cfg.Persistor.DeleteRolePermission(ctx, tx, roleId, permissionId)
+		return err
+	})
+}
+
+// updateRolePermission checks the caller may manage roles, and that the role
+// and permission exist, then applies update in a transaction.
+func (i *Impl) updateRolePermission(
+	ctx context.Context,
+	params *model.RolePermission,
+	update func(tx persistence.TransactionHandler, roleId, permissionId int) error,
+) (*model.Role, error) {
+	if err := i.Authorize(ctx, model.PermissionRoleWrite); err != nil {
+		return nil, err
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = i.cfg.Persistor.GetRole(ctx, tx, params.RoleId); err != nil {
+		return nil, notFoundOrInternal(err, "get role")
+	}
+
+	permission, err := i.cfg.Persistor.GetPermissionByName(ctx, tx, params.Permission)
+	if err != nil {
+		return nil, notFoundOrInternal(err, "get permission")
+	}
+
+	if err = update(tx, params.RoleId, permission.Id); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("update role permission: %v", err),
+		})
+	}
+
+	role, err := i.cfg.Persistor.GetRole(ctx, tx, params.RoleId)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get updated role: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
+
+	i.cfg.Logger.WithFields(logrus.Fields{
+		"role_id":    params.RoleId,
+		"permission": params.Permission,
+	}).Info("role permissions updated")
+
+	return role, nil
+}
+
+// notFoundOrInternal maps persistence.ErrNotFound to 404, and anything else
+// to 500.
+func notFoundOrInternal(err error, action string) error {
+	if errors.Is(err, persistence.ErrNotFound) {
+		return errs.New(&errs.Cfg{
+			StatusCode: http.StatusNotFound,
+			Err:        err,
+		})
+	}
+	return errs.New(&errs.Cfg{
+		StatusCode: http.StatusInternalServerError,
+		Err:        fmt.Errorf("%s: %v", action, err),
+	})
+}
@@ -0,0 +1,229 @@
+package rbaclogic
+
+import (
+	"context"
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/logic_handlers/rbaclogic/rbaclogicfakes"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlconn"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistencefakes"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistors/mysqlstore"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+	"time"
+)
+
+var (
+	mockTimeout = 5 * time.Second
+	mockLogger  = logger.New(context.TODO())
+
+	// Seeded roles, see the category migrations
+	mockSuperAdminId  = 1
+	mockAdminId       = 2
+	mockRegularUserId = 3
+)
+
+type dependencies struct {
+	Persistor  persistor
+	Logger     *logrus.Entry
+	TxProvider persistence.TransactionProvider
+}
+
+func getConcreteDependencies(t *testing.T) (*dependencies, func(ignoreErrors ...bool)) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+
+	store, err := mysqlstore.New(&mysqlstore.Config{
+		Logger: mockLogger,
+		QueryTimeouts: &persistence.QueryTimeouts{
+			Query: mockTimeout,
+			Exec:  mockTimeout,
+		},
+	})
+	require.NoError(t, err, "unexpected new mysqlstore error")
+
+	tx, err := mysqltx.New(&mysqltx.Config{
+		Logger:       mockLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected new mysqltx error")
+
+	prov, err := mysqlconn.New(&mysqlconn.Config{
+		Logger:    mockLogger,
+		TxHandler: tx,
+	})
+	require.NoError(t, err, "unexpected new mysqlconn error")
+
+	return &dependencies{
+		Persistor:  store,
+		TxProvider: prov,
+		Logger:     mockLogger,
+	}, cleanup
+}
+
+func newTestImpl(t *testing.T, deps *dependencies) *Impl {
+	svc, err := New(&Config{
+		TxProvider: deps.TxProvider,
+		Logger:     deps.Logger,
+		Persistor:  deps.Persistor,
+	})
+	require.NoError(t, err, "unexpected new error")
+	return svc
+}
+
+func asRole(roleId int) context.Context {
+	return model.WithAuthUser(context.Background(), &model.AuthUser{Id: 1, CategoryTypeRefId: roleId})
+}
+
+func requireStatus(t *testing.T, err error, statusCode int, contains string) {
+	require.Error(t, err)
+
+	var errUtil *errs.Util
+	require.ErrorAs(t, err, &errUtil, "unexpected error type")
+	assert.Equal(t, statusCode, errUtil.StatusCode)
+	assert.Contains(t, err.Error(), contains)
+}
+
+func TestImpl_Authorize(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+
+	// An API key of a super admin, limited to reading categories
+	apiKey := model.WithAuthUser(context.Background(), &model.AuthUser{
+		CategoryTypeRefId: mockSuperAdminId,
+		APIKeyId:          1,
+		Scopes:            []string{model.PermissionCategoryRead},
+	})
+
+	for _, tt := range []struct {
+		name       string
+		ctx        context.Context
+		permission string
+		statusCode int
+		err        string
+	}{
+		{name: "success-super-admin", ctx: asRole(mockSuperAdminId), permission: model.PermissionRoleWrite},
+		{name: "success-regular-user", ctx: asRole(mockRegularUserId), permission: model.PermissionCategoryRead},
+		{name: "fail-admin-role-write", ctx: asRole(mockAdminId), permission: model.PermissionRoleWrite, statusCode: http.StatusForbidden, err: "missing permission 'role:write'"},
+		{name: "fail-regular-user-delete", ctx: asRole(mockRegularUserId), permission: model.PermissionCategoryDelete, statusCode: http.StatusForbidden, err: "missing permission 'category:delete'"},
+		{name: "fail-unknown-permission", ctx: asRole(mockSuperAdminId), permission: "nothing:ever", statusCode: http.StatusForbidden, err: "missing permission"},
+		{name: "fail-unauthenticated", ctx: context.Background(), permission: model.PermissionCategoryRead, statusCode: http.StatusUnauthorized, err: errUnauthenticated.Error()},
+		{name: "success-api-key-scope", ctx: apiKey, permission: model.PermissionCategoryRead},
+		{name: "fail-api-key-scope", ctx: apiKey, permission: model.PermissionCategoryWrite, statusCode: http.StatusForbidden, err: "the api key lacks the 'category:write' scope"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.Authorize(tt.ctx, tt.permission)
+			if tt.statusCode != 0 {
+				requireStatus(t, err, tt.statusCode, tt.err)
+				return
+			}
+			require.NoError(t, err, "unexpected authorize error")
+		})
+	}
+}
+
+func TestImpl_ListRoles(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+
+	roles, err := svc.ListRoles(asRole(mockAdminId))
+	require.NoError(t, err, "unexpected list roles error")
+	require.Len(t, roles, 3)
+	assert.Equal(t, "Super Admin", roles[0].Name)
+	assert.Contains(t, roles[0].Permissions, model.PermissionRoleWrite)
+	assert.NotContains(t, roles[1].Permissions, model.PermissionRoleWrite)
+	assert.Equal(t, []string{model.PermissionCategoryRead, model.PermissionUserRead}, roles[2].Permissions)
+
+	permissions, err := svc.ListPermissions(asRole(mockAdminId))
+	require.NoError(t, err, "unexpected list permissions error")
+	assert.Len(t, permissions, 8)
+
+	_, err = svc.ListRoles(asRole(mockRegularUserId))
+	requireStatus(t, err, http.StatusForbidden, "missing permission 'role:read'")
+}
+
+func TestImpl_GrantPermission(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+	ctx := asRole(mockSuperAdminId)
+
+	params := &model.RolePermission{RoleId: mockRegularUserId, Permission: model.PermissionCategoryWrite}
+	require.Error(t, svc.Authorize(asRole(mockRegularUserId), model.PermissionCategoryWrite))
+
+	role, err := svc.GrantPermission(ctx, params)
+	require.NoError(t, err, "unexpected grant error")
+	assert.Contains(t, role.Permissions, model.PermissionCategoryWrite)
+	assert.NoError(t, svc.Authorize(asRole(mockRegularUserId), model.PermissionCategoryWrite), "applies to the next check")
+
+	role, err = svc.GrantPermission(ctx, params)
+	require.NoError(t, err, "granting again changes nothing")
+	assert.Len(t, role.Permissions, 3)
+
+	role, err = svc.RevokePermission(ctx, params)
+	require.NoError(t, err, "unexpected revoke error")
+	assert.NotContains(t, role.Permissions, model.PermissionCategoryWrite)
+	assert.Error(t, svc.Authorize(asRole(mockRegularUserId), model.PermissionCategoryWrite))
+}
+
+func TestImpl_UpdateRolePermission_Fail(t *testing.T) {
+	deps, cleanup := getConcreteDependencies(t)
+	defer cleanup()
+	svc := newTestImpl(t, deps)
+
+	for _, tt := range []struct {
+		name       string
+		ctx        context.Context
+		params     *model.RolePermission
+		revoke     bool
+		statusCode int
+		err        string
+	}{
+		{name: "fail-forbidden", ctx: asRole(mockAdminId), params: &model.RolePermission{RoleId: mockAdminId, Permission: model.PermissionRoleWrite}, statusCode: http.StatusForbidden, err: "missing permission 'role:write'"},
+		{name: "fail-validate", ctx: asRole(mockSuperAdminId), params: &model.RolePermission{RoleId: mockAdminId}, statusCode: http.StatusBadRequest, err: "'permission' must have a value"},
+		{name: "fail-unknown-role", ctx: asRole(mockSuperAdminId), params: &model.RolePermission{RoleId: 999, Permission: model.PermissionUserRead}, statusCode: http.StatusNotFound, err: "role 999"},
+		{name: "fail-unknown-permission", ctx: asRole(mockSuperAdminId), params: &model.RolePermission{RoleId: mockAdminId, Permission: "nothing:ever"}, statusCode: http.StatusNotFound, err: "permission 'nothing:ever'"},
+		{name: "fail-self-lockout", ctx: asRole(mockSuperAdminId), params: &model.RolePermission{RoleId: mockSuperAdminId, Permission: model.PermissionRoleWrite}, revoke: true, statusCode: http.StatusConflict, err: errSelfLockout.Error()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.revoke {
+				_, err = svc.RevokePermission(tt.ctx, tt.params)
+			} else {
+				_, err = svc.GrantPermission(tt.ctx, tt.params)
+			}
+			requireStatus(t, err, tt.statusCode, tt.err)
+		})
+	}
+}
+
+func TestImpl_Authorize_Fail(t *testing.T) {
+	mockPersistor := &rbaclogicfakes.FakePersistor{}
+	mockPersistor.HasRolePermissionReturns(false, errors.New("error checking"))
+
+	mockTxProvider := &persistencefakes.FakeTransactionProvider{}
+	mockTxProvider.DbReturns(&persistencefakes.FakeTransactionHandler{}, nil)
+
+	svc := newTestImpl(t, &dependencies{
+		Persistor:  mockPersistor,
+		TxProvider: mockTxProvider,
+		Logger:     mockLogger,
+	})
+
+	err := svc.Authorize(asRole(mockSuperAdminId), model.PermissionCategoryRead)
+	requireStatus(t, err, http.StatusInternalServerError, "has role permission:")
+
+	mockTxProvider.DbReturns(nil, errors.New("error getting db"))
+	err = svc.Authorize(asRole(mockSuperAdminId), model.PermissionCategoryRead)
+	requireStatus(t, err, http.StatusInternalServerError, "get db:")
+}
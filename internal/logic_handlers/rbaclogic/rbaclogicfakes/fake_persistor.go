@@ -0,0 +1,620 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package rbaclogicfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+)
+
+type FakePersistor struct {
+	AddRolePermissionStub        func(context.Context, persistence.TransactionHandler, int, int) (bool, error)
+	addRolePermissionMutex       sync.RWMutex
+	addRolePermissionArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 int
+	}
+	addRolePermissionReturns struct {
+		result1 bool
+		result2 error
+	}
+	addRolePermissionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	DeleteRolePermissionStub        func(context.Context, persistence.TransactionHandler, int, int) (bool, error)
+	deleteRolePermissionMutex       sync.RWMutex
+	deleteRolePermissionArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 int
+	}
+	deleteRolePermissionReturns struct {
+		result1 bool
+		result2 error
+	}
+	deleteRolePermissionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	GetPermissionByNameStub        func(context.Context, persistence.TransactionHandler, string) (*model.Permission, error)
+	getPermissionByNameMutex       sync.RWMutex
+	getPermissionByNameArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}
+	getPermissionByNameReturns struct {
+		result1 *model.Permission
+		result2 error
+	}
+	getPermissionByNameReturnsOnCall map[int]struct {
+		result1 *model.Permission
+		result2 error
+	}
+	GetPermissionsStub        func(context.Context, persistence.TransactionHandler) ([]model.Permission, error)
+	getPermissionsMutex       sync.RWMutex
+	getPermissionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}
+	getPermissionsReturns struct {
+		result1 []model.Permission
+		result2 error
+	}
+	getPermissionsReturnsOnCall map[int]struct {
+		result1 []model.Permission
+		result2 error
+	}
+	GetRoleStub        func(context.Context, persistence.TransactionHandler, int) (*model.Role, error)
+	getRoleMutex       sync.RWMutex
+	getRoleArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}
+	getRoleReturns struct {
+		result1 *model.Role
+		result2 error
+	}
+	getRoleReturnsOnCall map[int]struct {
+		result1 *model.Role
+		result2 error
+	}
+	GetRolesStub        func(context.Context, persistence.TransactionHandler) ([]model.Role, error)
+	getRolesMutex       sync.RWMutex
+	getRolesArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}
+	getRolesReturns struct {
+		result1 []model.Role
+		result2 error
+	}
+	getRolesReturnsOnCall map[int]struct {
+		result1 []model.Role
+		result2 error
+	}
+	HasRolePermissionStub        func(context.Context, persistence.TransactionHandler, int, string) (bool, error)
+	hasRolePermissionMutex       sync.RWMutex
+	hasRolePermissionArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}
+	hasRolePermissionReturns struct {
+		result1 bool
+		result2 error
+	}
+	hasRolePermissionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePersistor) AddRolePermission(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 int) (bool, error) {
+	fake.addRolePermissionMutex.Lock()
+	ret, specificReturn := fake.addRolePermissionReturnsOnCall[len(fake.addRolePermissionArgsForCall)]
+	fake.addRolePermissionArgsForCall = append(fake.addRolePermissionArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.AddRolePermissionStub
+	fakeReturns := fake.addRolePermissionReturns
+	fake.recordInvocation("AddRolePermission", []interface{}{arg1, arg2, arg3, arg4})
+	fake.addRolePermissionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) AddRolePermissionCallCount() int {
+	fake.addRolePermissionMutex.RLock()
+	defer fake.addRolePermissionMutex.RUnlock()
+	return len(fake.addRolePermissionArgsForCall)
+}
+
+func (fake *FakePersistor) AddRolePermissionCalls(stub func(context.Context, persistence.TransactionHandler, int, int) (bool, error)) {
+	fake.addRolePermissionMutex.Lock()
+	defer fake.addRolePermissionMutex.Unlock()
+	fake.AddRolePermissionStub = stub
+}
+
+func (fake *FakePersistor) AddRolePermissionArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, int) {
+	fake.addRolePermissionMutex.RLock()
+	defer fake.addRolePermissionMutex.RUnlock()
+	argsForCall := fake.addRolePermissionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePersistor) AddRolePermissionReturns(result1 bool, result2 error) {
+	fake.addRolePermissionMutex.Lock()
+	defer fake.addRolePermissionMutex.Unlock()
+	fake.AddRolePermissionStub = nil
+	fake.addRolePermissionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) AddRolePermissionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.addRolePermissionMutex.Lock()
+	defer fake.addRolePermissionMutex.Unlock()
+	fake.AddRolePermissionStub = nil
+	if fake.addRolePermissionReturnsOnCall == nil {
+		fake.addRolePermissionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.addRolePermissionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) DeleteRolePermission(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 int) (bool, error) {
+	fake.deleteRolePermissionMutex.Lock()
+	ret, specificReturn := fake.deleteRolePermissionReturnsOnCall[len(fake.deleteRolePermissionArgsForCall)]
+	fake.deleteRolePermissionArgsForCall = append(fake.deleteRolePermissionArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DeleteRolePermissionStub
+	fakeReturns := fake.deleteRolePermissionReturns
+	fake.recordInvocation("DeleteRolePermission", []interface{}{arg1, arg2, arg3, arg4})
+	fake.deleteRolePermissionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) DeleteRolePermissionCallCount() int {
+	fake.deleteRolePermissionMutex.RLock()
+	defer fake.deleteRolePermissionMutex.RUnlock()
+	return len(fake.deleteRolePermissionArgsForCall)
+}
+
+func (fake *FakePersistor) DeleteRolePermissionCalls(stub func(context.Context, persistence.TransactionHandler, int, int) (bool, error)) {
+	fake.deleteRolePermissionMutex.Lock()
+	defer fake.deleteRolePermissionMutex.Unlock()
+	fake.DeleteRolePermissionStub = stub
+}
+
+func (fake *FakePersistor) DeleteRolePermissionArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, int) {
+	fake.deleteRolePermissionMutex.RLock()
+	defer fake.deleteRolePermissionMutex.RUnlock()
+	argsForCall := fake.deleteRolePermissionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePersistor) DeleteRolePermissionReturns(result1 bool, result2 error) {
+	fake.deleteRolePermissionMutex.Lock()
+	defer fake.deleteRolePermissionMutex.Unlock()
+	fake.DeleteRolePermissionStub = nil
+	fake.deleteRolePermissionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) DeleteRolePermissionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.deleteRolePermissionMutex.Lock()
+	defer fake.deleteRolePermissionMutex.Unlock()
+	fake.DeleteRolePermissionStub = nil
+	if fake.deleteRolePermissionReturnsOnCall == nil {
+		fake.deleteRolePermissionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.deleteRolePermissionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetPermissionByName(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 string) (*model.Permission, error) {
+	fake.getPermissionByNameMutex.Lock()
+	ret, specificReturn := fake.getPermissionByNameReturnsOnCall[len(fake.getPermissionByNameArgsForCall)]
+	fake.getPermissionByNameArgsForCall = append(fake.getPermissionByNameArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetPermissionByNameStub
+	fakeReturns := fake.getPermissionByNameReturns
+	fake.recordInvocation("GetPermissionByName", []interface{}{arg1, arg2, arg3})
+	fake.getPermissionByNameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetPermissionByNameCallCount() int {
+	fake.getPermissionByNameMutex.RLock()
+	defer fake.getPermissionByNameMutex.RUnlock()
+	return len(fake.getPermissionByNameArgsForCall)
+}
+
+func (fake *FakePersistor) GetPermissionByNameCalls(stub func(context.Context, persistence.TransactionHandler, string) (*model.Permission, error)) {
+	fake.getPermissionByNameMutex.Lock()
+	defer fake.getPermissionByNameMutex.Unlock()
+	fake.GetPermissionByNameStub = stub
+}
+
+func (fake *FakePersistor) GetPermissionByNameArgsForCall(i int) (context.Context, persistence.TransactionHandler, string) {
+	fake.getPermissionByNameMutex.RLock()
+	defer fake.getPermissionByNameMutex.RUnlock()
+	argsForCall := fake.getPermissionByNameArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetPermissionByNameReturns(result1 *model.Permission, result2 error) {
+	fake.getPermissionByNameMutex.Lock()
+	defer fake.getPermissionByNameMutex.Unlock()
+	fake.GetPermissionByNameStub = nil
+	fake.getPermissionByNameReturns = struct {
+		result1 *model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetPermissionByNameReturnsOnCall(i int, result1 *model.Permission, result2 error) {
+	fake.getPermissionByNameMutex.Lock()
+	defer fake.getPermissionByNameMutex.Unlock()
+	fake.GetPermissionByNameStub = nil
+	if fake.getPermissionByNameReturnsOnCall == nil {
+		fake.getPermissionByNameReturnsOnCall = make(map[int]struct {
+			result1 *model.Permission
+			result2 error
+		})
+	}
+	fake.getPermissionByNameReturnsOnCall[i] = struct {
+		result1 *model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetPermissions(arg1 context.Context, arg2 persistence.TransactionHandler) ([]model.Permission, error) {
+	fake.getPermissionsMutex.Lock()
+	ret, specificReturn := fake.getPermissionsReturnsOnCall[len(fake.getPermissionsArgsForCall)]
+	fake.getPermissionsArgsForCall = append(fake.getPermissionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}{arg1, arg2})
+	stub := fake.GetPermissionsStub
+	fakeReturns := fake.getPermissionsReturns
+	fake.recordInvocation("GetPermissions", []interface{}{arg1, arg2})
+	fake.getPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetPermissionsCallCount() int {
+	fake.getPermissionsMutex.RLock()
+	defer fake.getPermissionsMutex.RUnlock()
+	return len(fake.getPermissionsArgsForCall)
+}
+
+func (fake *FakePersistor) GetPermissionsCalls(stub func(context.Context, persistence.TransactionHandler) ([]model.Permission, error)) {
+	fake.getPermissionsMutex.Lock()
+	defer fake.getPermissionsMutex.Unlock()
+	fake.GetPermissionsStub = stub
+}
+
+func (fake *FakePersistor) GetPermissionsArgsForCall(i int) (context.Context, persistence.TransactionHandler) {
+	fake.getPermissionsMutex.RLock()
+	defer fake.getPermissionsMutex.RUnlock()
+	argsForCall := fake.getPermissionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePersistor) GetPermissionsReturns(result1 []model.Permission, result2 error) {
+	fake.getPermissionsMutex.Lock()
+	defer fake.getPermissionsMutex.Unlock()
+	fake.GetPermissionsStub = nil
+	fake.getPermissionsReturns = struct {
+		result1 []model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetPermissionsReturnsOnCall(i int, result1 []model.Permission, result2 error) {
+	fake.getPermissionsMutex.Lock()
+	defer fake.getPermissionsMutex.Unlock()
+	fake.GetPermissionsStub = nil
+	if fake.getPermissionsReturnsOnCall == nil {
+		fake.getPermissionsReturnsOnCall = make(map[int]struct {
+			result1 []model.Permission
+			result2 error
+		})
+	}
+	fake.getPermissionsReturnsOnCall[i] = struct {
+		result1 []model.Permission
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetRole(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int) (*model.Role, error) {
+	fake.getRoleMutex.Lock()
+	ret, specificReturn := fake.getRoleReturnsOnCall[len(fake.getRoleArgsForCall)]
+	fake.getRoleArgsForCall = append(fake.getRoleArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetRoleStub
+	fakeReturns := fake.getRoleReturns
+	fake.recordInvocation("GetRole", []interface{}{arg1, arg2, arg3})
+	fake.getRoleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetRoleCallCount() int {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	return len(fake.getRoleArgsForCall)
+}
+
+func (fake *FakePersistor) GetRoleCalls(stub func(context.Context, persistence.TransactionHandler, int) (*model.Role, error)) {
+	fake.getRoleMutex.Lock()
+	defer fake.getRoleMutex.Unlock()
+	fake.GetRoleStub = stub
+}
+
+func (fake *FakePersistor) GetRoleArgsForCall(i int) (context.Context, persistence.TransactionHandler, int) {
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	argsForCall := fake.getRoleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) GetRoleReturns(result1 *model.Role, result2 error) {
+	fake.getRoleMutex.Lock()
+	defer fake.getRoleMutex.Unlock()
+	fake.GetRoleStub = nil
+	fake.getRoleReturns = struct {
+		result1 *model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetRoleReturnsOnCall(i int, result1 *model.Role, result2 error) {
+	fake.getRoleMutex.Lock()
+	defer fake.getRoleMutex.Unlock()
+	fake.GetRoleStub = nil
+	if fake.getRoleReturnsOnCall == nil {
+		fake.getRoleReturnsOnCall = make(map[int]struct {
+			result1 *model.Role
+			result2 error
+		})
+	}
+	fake.getRoleReturnsOnCall[i] = struct {
+		result1 *model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetRoles(arg1 context.Context, arg2 persistence.TransactionHandler) ([]model.Role, error) {
+	fake.getRolesMutex.Lock()
+	ret, specificReturn := fake.getRolesReturnsOnCall[len(fake.getRolesArgsForCall)]
+	fake.getRolesArgsForCall = append(fake.getRolesArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}{arg1, arg2})
+	stub := fake.GetRolesStub
+	fakeReturns := fake.getRolesReturns
+	fake.recordInvocation("GetRoles", []interface{}{arg1, arg2})
+	fake.getRolesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetRolesCallCount() int {
+	fake.getRolesMutex.RLock()
+	defer fake.getRolesMutex.RUnlock()
+	return len(fake.getRolesArgsForCall)
+}
+
+func (fake *FakePersistor) GetRolesCalls(stub func(context.Context, persistence.TransactionHandler) ([]model.Role, error)) {
+	fake.getRolesMutex.Lock()
+	defer fake.getRolesMutex.Unlock()
+	fake.GetRolesStub = stub
+}
+
+func (fake *FakePersistor) GetRolesArgsForCall(i int) (context.Context, persistence.TransactionHandler) {
+	fake.getRolesMutex.RLock()
+	defer fake.getRolesMutex.RUnlock()
+	argsForCall := fake.getRolesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePersistor) GetRolesReturns(result1 []model.Role, result2 error) {
+	fake.getRolesMutex.Lock()
+	defer fake.getRolesMutex.Unlock()
+	fake.GetRolesStub = nil
+	fake.getRolesReturns = struct {
+		result1 []model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetRolesReturnsOnCall(i int, result1 []model.Role, result2 error) {
+	fake.getRolesMutex.Lock()
+	defer fake.getRolesMutex.Unlock()
+	fake.GetRolesStub = nil
+	if fake.getRolesReturnsOnCall == nil {
+		fake.getRolesReturnsOnCall = make(map[int]struct {
+			result1 []model.Role
+			result2 error
+		})
+	}
+	fake.getRolesReturnsOnCall[i] = struct {
+		result1 []model.Role
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) HasRolePermission(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 string) (bool, error) {
+	fake.hasRolePermissionMutex.Lock()
+	ret, specificReturn := fake.hasRolePermissionReturnsOnCall[len(fake.hasRolePermissionArgsForCall)]
+	fake.hasRolePermissionArgsForCall = append(fake.hasRolePermissionArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.HasRolePermissionStub
+	fakeReturns := fake.hasRolePermissionReturns
+	fake.recordInvocation("HasRolePermission", []interface{}{arg1, arg2, arg3, arg4})
+	fake.hasRolePermissionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) HasRolePermissionCallCount() int {
+	fake.hasRolePermissionMutex.RLock()
+	defer fake.hasRolePermissionMutex.RUnlock()
+	return len(fake.hasRolePermissionArgsForCall)
+}
+
+func (fake *FakePersistor) HasRolePermissionCalls(stub func(context.Context, persistence.TransactionHandler, int, string) (bool, error)) {
+	fake.hasRolePermissionMutex.Lock()
+	defer fake.hasRolePermissionMutex.Unlock()
+	fake.HasRolePermissionStub = stub
+}
+
+func (fake *FakePersistor) HasRolePermissionArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, string) {
+	fake.hasRolePermissionMutex.RLock()
+	defer fake.hasRolePermissionMutex.RUnlock()
+	argsForCall := fake.hasRolePermissionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePersistor) HasRolePermissionReturns(result1 bool, result2 error) {
+	fake.hasRolePermissionMutex.Lock()
+	defer fake.hasRolePermissionMutex.Unlock()
+	fake.HasRolePermissionStub = nil
+	fake.hasRolePermissionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) HasRolePermissionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.hasRolePermissionMutex.Lock()
+	defer fake.hasRolePermissionMutex.Unlock()
+	fake.HasRolePermissionStub = nil
+	if fake.hasRolePermissionReturnsOnCall == nil {
+		fake.hasRolePermissionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.hasRolePermissionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addRolePermissionMutex.RLock()
+	defer fake.addRolePermissionMutex.RUnlock()
+	fake.deleteRolePermissionMutex.RLock()
+	defer fake.deleteRolePermissionMutex.RUnlock()
+	fake.getPermissionByNameMutex.RLock()
+	defer fake.getPermissionByNameMutex.RUnlock()
+	fake.getPermissionsMutex.RLock()
+	defer fake.getPermissionsMutex.RUnlock()
+	fake.getRoleMutex.RLock()
+	defer fake.getRoleMutex.RUnlock()
+	fake.getRolesMutex.RLock()
+	defer fake.getRolesMutex.RUnlock()
+	fake.hasRolePermissionMutex.RLock()
+	defer fake.hasRolePermissionMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePersistor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
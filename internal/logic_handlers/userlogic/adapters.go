@@ -1,8 +1,18 @@
 package userlogic
 
+import (
+	"context"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+)
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 //counterfeiter:generate . persistor
 type persistor interface {
 	//GetUsers(ctx context.Context, tx persistence.TransactionHandler, filters *model.UserFilters) (*model.PaginatedUsers, error)
+	CreateUser(ctx context.Context, tx persistence.TransactionHandler, user *model.User) (*model.User, error)
+	UpdateUser(ctx context.Context, tx persistence.TransactionHandler, user *model.User) (*model.User, error)
+	GetUserPasswords(ctx context.Context, tx persistence.TransactionHandler) (map[int]string, error)
+	UpdateUserPassword(ctx context.Context, tx persistence.TransactionHandler, id int, hash string) error
 }
@@ -1,32 +1,207 @@
 package userlogic
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/passhash"
+	"github.com/dembygenesis/local.tools/internal/model"
 	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/sysconsts"
+	"github.com/dembygenesis/local.tools/internal/utilities/errs"
 	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
 	"github.com/sirupsen/logrus"
+	"net/http"
 )
 
 type Config struct {
 	TxProvider persistence.TransactionProvider `json:"tx_provider" validate:"required"`
 	Logger     *logrus.Entry                   `json:"logger" validate:"required"`
+	Persistor  persistor                       `json:"persistor" validate:"required"`
+
+	// Hasher hashes the passwords before they are stored.
+	Hasher *passhash.Hasher `json:"hasher" validate:"required"`
 }
 
 func (i *Config) Validate() error {
 	return validationutils.Validate(i)
 }
 
+// Impl owns the users' passwords, which are only ever stored hashed.
 type Impl struct {
 	cfg *Config
+
+	// dummyHash is verified against for unknown users, so a login takes
+	// as long whether the email exists or not.
+	dummyHash string
 }
 
 func New(cfg *Config) (*Impl, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("validate: %v", err)
 	}
-	return &Impl{cfg}, nil
+
+	dummyHash, err := cfg.Hasher.Hash("dummy password")
+	if err != nil {
+		return nil, fmt.Errorf("dummy hash: %v", err)
+	}
+	return &Impl{cfg: cfg, dummyHash: dummyHash}, nil
+}
+
+// CreateUser stores a new user, hashing their password.
+func (i *Impl) CreateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if err := user.ValidateCreate(); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        err,
+		})
+	}
+	if user.Password == "" {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        errors.New("password is required"),
+		})
+	}
+
+	return i.writeUser(ctx, user, i.cfg.Persistor.CreateUser)
+}
+
+// UpdateUser updates a user, hashing their password if a new one is set.
+func (i *Impl) UpdateUser(ctx context.Context, user *model.User) (*model.User, error) {
+	if user == nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusBadRequest,
+			Err:        errors.New(sysconsts.ErrStructNil),
+		})
+	}
+
+	return i.writeUser(ctx, user, i.cfg.Persistor.UpdateUser)
+}
+
+// CheckPassword verifies a login's password against the user's hash, and
+// rehashes it within tx when the hashing parameters changed since. A nil
+// user is checked against a dummy hash, and never matches.
+func (i *Impl) CheckPassword(ctx context.Context, tx persistence.TransactionHandler, user *model.User, password string) (bool, error) {
+	if user == nil {
+		_, _, _ = i.cfg.Hasher.Verify(i.dummyHash, password)
+		return false, nil
+	}
+
+	ok, needsRehash, err := i.cfg.Hasher.Verify(user.Password, password)
+	if err != nil {
+		if errors.Is(err, passhash.ErrUnknownFormat) {
+			i.cfg.Logger.WithField("user_id", user.Id).Warn("the stored password is not hashed, run the hash-passwords command")
+			return false, nil
+		}
+		return false, fmt.Errorf("verify: %v", err)
+	}
+	if !ok || !needsRehash {
+		return ok, nil
+	}
+
+	hash, err := i.cfg.Hasher.Hash(password)
+	if err != nil {
+		return false, fmt.Errorf("rehash: %v", err)
+	}
+	if err = i.cfg.Persistor.UpdateUserPassword(ctx, tx, user.Id, hash); err != nil {
+		return false, fmt.Errorf("update rehashed password: %v", err)
+	}
+	return true, nil
+}
+
+// SetPassword hashes a new password for the user, and stores it within tx.
+func (i *Impl) SetPassword(ctx context.Context, tx persistence.TransactionHandler, userId int, password string) error {
+	if password == "" {
+		return errors.New("password is required")
+	}
+
+	hash, err := i.cfg.Hasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("hash: %v", err)
+	}
+	if err = i.cfg.Persistor.UpdateUserPassword(ctx, tx, userId, hash); err != nil {
+		return fmt.Errorf("update password: %v", err)
+	}
+	return nil
 }
 
-func (i *Impl) GetCategories() {
+// HashPlaintextPasswords hashes every stored password that is not a hash yet,
+// returning how many were. Running it again hashes nothing.
+func (i *Impl) HashPlaintextPasswords(ctx context.Context) (int, error) {
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get db: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	passwords, err := i.cfg.Persistor.GetUserPasswords(ctx, tx)
+	if err != nil {
+		return 0, fmt.Errorf("get passwords: %v", err)
+	}
+
+	hashed := 0
+	for id, password := range passwords {
+		if passhash.IsHash(password) {
+			continue
+		}
+
+		hash, err := i.cfg.Hasher.Hash(password)
+		if err != nil {
+			return 0, fmt.Errorf("hash user %d: %v", id, err)
+		}
+		if err = i.cfg.Persistor.UpdateUserPassword(ctx, tx, id, hash); err != nil {
+			return 0, fmt.Errorf("update user %d: %v", id, err)
+		}
+		hashed++
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit: %v", err)
+	}
+
+	return hashed, nil
+}
+
+// writeUser hashes the user's password if set, and stores the user with write.
+func (i *Impl) writeUser(
+	ctx context.Context,
+	user *model.User,
+	write func(context.Context, persistence.TransactionHandler, *model.User) (*model.User, error),
+) (*model.User, error) {
+	if user.Password != "" {
+		hash, err := i.cfg.Hasher.Hash(user.Password)
+		if err != nil {
+			return nil, errs.New(&errs.Cfg{
+				StatusCode: http.StatusInternalServerError,
+				Err:        fmt.Errorf("hash password: %v", err),
+			})
+		}
+		user.Password = hash
+	}
+
+	tx, err := i.cfg.TxProvider.Tx(ctx)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("get db: %v", err),
+		})
+	}
+	defer tx.Rollback(ctx)
+
+	written, err := write(ctx, tx, user)
+	if err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("write user: %v", err),
+		})
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errs.New(&errs.Cfg{
+			StatusCode: http.StatusInternalServerError,
+			Err:        fmt.Errorf("commit: %v", err),
+		})
+	}
 
+	return written, nil
 }
@@ -0,0 +1,213 @@
+package userlogic
+
+import (
+	"context"
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/lib/passhash"
+	"github.com/dembygenesis/local.tools/internal/logic_handlers/userlogic/userlogicfakes"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlconn"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistencefakes"
+	"github.com/dembygenesis/local.tools/internal/persistence/persistors/mysqlstore"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var (
+	mockTimeout = 5 * time.Second
+	mockLogger  = logger.New(context.TODO())
+)
+
+func newTestHasher(t *testing.T, iterations uint32) *passhash.Hasher {
+	hasher, err := passhash.New(&passhash.Config{
+		Algorithm:         passhash.Argon2id,
+		Argon2Memory:      64,
+		Argon2Iterations:  iterations,
+		Argon2Parallelism: 1,
+	})
+	require.NoError(t, err, "unexpected new hasher error")
+	return hasher
+}
+
+func getConcreteImpl(t *testing.T) (*Impl, *sqlx.DB, func(ignoreErrors ...bool)) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+
+	store, err := mysqlstore.New(&mysqlstore.Config{
+		Logger: mockLogger,
+		QueryTimeouts: &persistence.QueryTimeouts{
+			Query: mockTimeout,
+			Exec:  mockTimeout,
+		},
+	})
+	require.NoError(t, err, "unexpected new mysqlstore error")
+
+	tx, err := mysqltx.New(&mysqltx.Config{
+		Logger:       mockLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected new mysqltx error")
+
+	prov, err := mysqlconn.New(&mysqlconn.Config{
+		Logger:    mockLogger,
+		TxHandler: tx,
+	})
+	require.NoError(t, err, "unexpected new mysqlconn error")
+
+	svc, err := New(&Config{
+		TxProvider: prov,
+		Logger:     mockLogger,
+		Persistor:  store,
+		Hasher:     newTestHasher(t, 1),
+	})
+	require.NoError(t, err, "unexpected new error")
+	return svc, db, cleanup
+}
+
+func TestImpl_HashPlaintextPasswords(t *testing.T) {
+	svc, db, cleanup := getConcreteImpl(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_, err := db.Exec("UPDATE user SET password = CONCAT('plain-', id) WHERE id <= 2")
+	require.NoError(t, err, "unexpected unhash error")
+
+	hashed, err := svc.HashPlaintextPasswords(ctx)
+	require.NoError(t, err, "unexpected hash error")
+	assert.Equal(t, 2, hashed)
+
+	users, err := mysqlmodel.Users().All(ctx, db)
+	require.NoError(t, err)
+	for _, user := range users {
+		assert.True(t, passhash.IsHash(user.Password), "user %d is hashed", user.ID)
+	}
+
+	ok, err := svc.CheckPassword(ctx, nil, &model.User{Id: 1, Password: users[0].Password}, "plain-1")
+	require.NoError(t, err)
+	assert.True(t, ok, "the hash is of the former plaintext password")
+
+	hashed, err = svc.HashPlaintextPasswords(ctx)
+	require.NoError(t, err, "unexpected hash error")
+	assert.Equal(t, 0, hashed, "hashes are not hashed again")
+}
+
+func TestImpl_CreateUser(t *testing.T) {
+	svc, db, cleanup := getConcreteImpl(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	category, err := mysqlmodel.Categories().One(ctx, db)
+	require.NoError(t, err)
+
+	_, err = svc.CreateUser(ctx, &model.User{Firstname: "Demby", Lastname: "Abella", Email: "new@gmail.com", CategoryTypeRefId: category.ID})
+	assert.ErrorContains(t, err, "password is required")
+
+	user, err := svc.CreateUser(ctx, &model.User{
+		Firstname:         "Demby",
+		Lastname:          "Abella",
+		Email:             "new@gmail.com",
+		Password:          "secret-password",
+		CategoryTypeRefId: category.ID,
+		IsActive:          true,
+	})
+	require.NoError(t, err, "unexpected create error")
+
+	stored, err := mysqlmodel.FindUser(ctx, db, user.Id)
+	require.NoError(t, err)
+	assert.True(t, passhash.IsHash(stored.Password), "only the hash is stored")
+
+	ok, err := svc.CheckPassword(ctx, nil, &model.User{Password: stored.Password}, "secret-password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	updated, err := svc.UpdateUser(ctx, &model.User{Id: user.Id, Password: "other-password"})
+	require.NoError(t, err, "unexpected update error")
+	assert.NotEqual(t, stored.Password, updated.Password)
+
+	ok, err = svc.CheckPassword(ctx, nil, updated, "other-password")
+	require.NoError(t, err)
+	assert.True(t, ok, "the new password is hashed too")
+}
+
+func TestImpl_CheckPassword(t *testing.T) {
+	mockPersistor := &userlogicfakes.FakePersistor{}
+	svc, err := New(&Config{
+		TxProvider: &persistencefakes.FakeTransactionProvider{},
+		Logger:     mockLogger,
+		Persistor:  mockPersistor,
+		Hasher:     newTestHasher(t, 2),
+	})
+	require.NoError(t, err, "unexpected new error")
+	ctx := context.Background()
+
+	ok, err := svc.CheckPassword(ctx, nil, nil, "password123")
+	require.NoError(t, err)
+	assert.False(t, ok, "an unknown user never matches")
+
+	ok, err = svc.CheckPassword(ctx, nil, &model.User{Id: 1, Password: "password123"}, "password123")
+	require.NoError(t, err)
+	assert.False(t, ok, "a plaintext password never matches")
+
+	current, err := svc.cfg.Hasher.Hash("password123")
+	require.NoError(t, err)
+	ok, err = svc.CheckPassword(ctx, nil, &model.User{Id: 1, Password: current}, "password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 0, mockPersistor.UpdateUserPasswordCallCount(), "current hashes are kept")
+
+	outdated, err := newTestHasher(t, 1).Hash("password123")
+	require.NoError(t, err)
+
+	ok, err = svc.CheckPassword(ctx, nil, &model.User{Id: 1, Password: outdated}, "password124")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, mockPersistor.UpdateUserPasswordCallCount(), "a wrong password is not rehashed")
+
+	ok, err = svc.CheckPassword(ctx, nil, &model.User{Id: 1, Password: outdated}, "password123")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	require.Equal(t, 1, mockPersistor.UpdateUserPasswordCallCount(), "an outdated hash is rehashed")
+
+	_, _, id, rehashed := mockPersistor.UpdateUserPasswordArgsForCall(0)
+	assert.Equal(t, 1, id)
+	assert.Contains(t, rehashed, "$m=64,t=2,p=1$")
+
+	mockPersistor.UpdateUserPasswordReturns(errors.New("update failed"))
+	_, err = svc.CheckPassword(ctx, nil, &model.User{Id: 1, Password: outdated}, "password123")
+	assert.ErrorContains(t, err, "update rehashed password: update failed")
+}
+
+func TestImpl_SetPassword(t *testing.T) {
+	mockPersistor := &userlogicfakes.FakePersistor{}
+	svc, err := New(&Config{
+		TxProvider: &persistencefakes.FakeTransactionProvider{},
+		Logger:     mockLogger,
+		Persistor:  mockPersistor,
+		Hasher:     newTestHasher(t, 2),
+	})
+	require.NoError(t, err, "unexpected new error")
+	ctx := context.Background()
+
+	require.NoError(t, svc.SetPassword(ctx, nil, 1, "new-password"))
+	require.Equal(t, 1, mockPersistor.UpdateUserPasswordCallCount())
+
+	_, _, id, hash := mockPersistor.UpdateUserPasswordArgsForCall(0)
+	assert.Equal(t, 1, id)
+	ok, _, err := svc.cfg.Hasher.Verify(hash, "new-password")
+	require.NoError(t, err)
+	assert.True(t, ok, "the password is stored hashed")
+
+	assert.Error(t, svc.SetPassword(ctx, nil, 1, ""), "an empty password is refused")
+	assert.Equal(t, 1, mockPersistor.UpdateUserPasswordCallCount())
+
+	mockPersistor.UpdateUserPasswordReturns(errors.New("update failed"))
+	assert.ErrorContains(t, svc.SetPassword(ctx, nil, 1, "new-password"), "update password: update failed")
+}
@@ -2,17 +2,348 @@
 package userlogicfakes
 
 import (
+	"context"
 	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
 )
 
 type FakePersistor struct {
+	CreateUserStub        func(context.Context, persistence.TransactionHandler, *model.User) (*model.User, error)
+	createUserMutex       sync.RWMutex
+	createUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.User
+	}
+	createUserReturns struct {
+		result1 *model.User
+		result2 error
+	}
+	createUserReturnsOnCall map[int]struct {
+		result1 *model.User
+		result2 error
+	}
+	GetUserPasswordsStub        func(context.Context, persistence.TransactionHandler) (map[int]string, error)
+	getUserPasswordsMutex       sync.RWMutex
+	getUserPasswordsArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}
+	getUserPasswordsReturns struct {
+		result1 map[int]string
+		result2 error
+	}
+	getUserPasswordsReturnsOnCall map[int]struct {
+		result1 map[int]string
+		result2 error
+	}
+	UpdateUserStub        func(context.Context, persistence.TransactionHandler, *model.User) (*model.User, error)
+	updateUserMutex       sync.RWMutex
+	updateUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.User
+	}
+	updateUserReturns struct {
+		result1 *model.User
+		result2 error
+	}
+	updateUserReturnsOnCall map[int]struct {
+		result1 *model.User
+		result2 error
+	}
+	UpdateUserPasswordStub        func(context.Context, persistence.TransactionHandler, int, string) error
+	updateUserPasswordMutex       sync.RWMutex
+	updateUserPasswordArgsForCall []struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}
+	updateUserPasswordReturns struct {
+		result1 error
+	}
+	updateUserPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakePersistor) CreateUser(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 *model.User) (*model.User, error) {
+	fake.createUserMutex.Lock()
+	ret, specificReturn := fake.createUserReturnsOnCall[len(fake.createUserArgsForCall)]
+	fake.createUserArgsForCall = append(fake.createUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.User
+	}{arg1, arg2, arg3})
+	stub := fake.CreateUserStub
+	fakeReturns := fake.createUserReturns
+	fake.recordInvocation("CreateUser", []interface{}{arg1, arg2, arg3})
+	fake.createUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) CreateUserCallCount() int {
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	return len(fake.createUserArgsForCall)
+}
+
+func (fake *FakePersistor) CreateUserCalls(stub func(context.Context, persistence.TransactionHandler, *model.User) (*model.User, error)) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = stub
+}
+
+func (fake *FakePersistor) CreateUserArgsForCall(i int) (context.Context, persistence.TransactionHandler, *model.User) {
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	argsForCall := fake.createUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) CreateUserReturns(result1 *model.User, result2 error) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = nil
+	fake.createUserReturns = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) CreateUserReturnsOnCall(i int, result1 *model.User, result2 error) {
+	fake.createUserMutex.Lock()
+	defer fake.createUserMutex.Unlock()
+	fake.CreateUserStub = nil
+	if fake.createUserReturnsOnCall == nil {
+		fake.createUserReturnsOnCall = make(map[int]struct {
+			result1 *model.User
+			result2 error
+		})
+	}
+	fake.createUserReturnsOnCall[i] = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserPasswords(arg1 context.Context, arg2 persistence.TransactionHandler) (map[int]string, error) {
+	fake.getUserPasswordsMutex.Lock()
+	ret, specificReturn := fake.getUserPasswordsReturnsOnCall[len(fake.getUserPasswordsArgsForCall)]
+	fake.getUserPasswordsArgsForCall = append(fake.getUserPasswordsArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+	}{arg1, arg2})
+	stub := fake.GetUserPasswordsStub
+	fakeReturns := fake.getUserPasswordsReturns
+	fake.recordInvocation("GetUserPasswords", []interface{}{arg1, arg2})
+	fake.getUserPasswordsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) GetUserPasswordsCallCount() int {
+	fake.getUserPasswordsMutex.RLock()
+	defer fake.getUserPasswordsMutex.RUnlock()
+	return len(fake.getUserPasswordsArgsForCall)
+}
+
+func (fake *FakePersistor) GetUserPasswordsCalls(stub func(context.Context, persistence.TransactionHandler) (map[int]string, error)) {
+	fake.getUserPasswordsMutex.Lock()
+	defer fake.getUserPasswordsMutex.Unlock()
+	fake.GetUserPasswordsStub = stub
+}
+
+func (fake *FakePersistor) GetUserPasswordsArgsForCall(i int) (context.Context, persistence.TransactionHandler) {
+	fake.getUserPasswordsMutex.RLock()
+	defer fake.getUserPasswordsMutex.RUnlock()
+	argsForCall := fake.getUserPasswordsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePersistor) GetUserPasswordsReturns(result1 map[int]string, result2 error) {
+	fake.getUserPasswordsMutex.Lock()
+	defer fake.getUserPasswordsMutex.Unlock()
+	fake.GetUserPasswordsStub = nil
+	fake.getUserPasswordsReturns = struct {
+		result1 map[int]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) GetUserPasswordsReturnsOnCall(i int, result1 map[int]string, result2 error) {
+	fake.getUserPasswordsMutex.Lock()
+	defer fake.getUserPasswordsMutex.Unlock()
+	fake.GetUserPasswordsStub = nil
+	if fake.getUserPasswordsReturnsOnCall == nil {
+		fake.getUserPasswordsReturnsOnCall = make(map[int]struct {
+			result1 map[int]string
+			result2 error
+		})
+	}
+	fake.getUserPasswordsReturnsOnCall[i] = struct {
+		result1 map[int]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) UpdateUser(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 *model.User) (*model.User, error) {
+	fake.updateUserMutex.Lock()
+	ret, specificReturn := fake.updateUserReturnsOnCall[len(fake.updateUserArgsForCall)]
+	fake.updateUserArgsForCall = append(fake.updateUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 *model.User
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateUserStub
+	fakeReturns := fake.updateUserReturns
+	fake.recordInvocation("UpdateUser", []interface{}{arg1, arg2, arg3})
+	fake.updateUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePersistor) UpdateUserCallCount() int {
+	fake.updateUserMutex.RLock()
+	defer fake.updateUserMutex.RUnlock()
+	return len(fake.updateUserArgsForCall)
+}
+
+func (fake *FakePersistor) UpdateUserCalls(stub func(context.Context, persistence.TransactionHandler, *model.User) (*model.User, error)) {
+	fake.updateUserMutex.Lock()
+	defer fake.updateUserMutex.Unlock()
+	fake.UpdateUserStub = stub
+}
+
+func (fake *FakePersistor) UpdateUserArgsForCall(i int) (context.Context, persistence.TransactionHandler, *model.User) {
+	fake.updateUserMutex.RLock()
+	defer fake.updateUserMutex.RUnlock()
+	argsForCall := fake.updateUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePersistor) UpdateUserReturns(result1 *model.User, result2 error) {
+	fake.updateUserMutex.Lock()
+	defer fake.updateUserMutex.Unlock()
+	fake.UpdateUserStub = nil
+	fake.updateUserReturns = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) UpdateUserReturnsOnCall(i int, result1 *model.User, result2 error) {
+	fake.updateUserMutex.Lock()
+	defer fake.updateUserMutex.Unlock()
+	fake.UpdateUserStub = nil
+	if fake.updateUserReturnsOnCall == nil {
+		fake.updateUserReturnsOnCall = make(map[int]struct {
+			result1 *model.User
+			result2 error
+		})
+	}
+	fake.updateUserReturnsOnCall[i] = struct {
+		result1 *model.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePersistor) UpdateUserPassword(arg1 context.Context, arg2 persistence.TransactionHandler, arg3 int, arg4 string) error {
+	fake.updateUserPasswordMutex.Lock()
+	ret, specificReturn := fake.updateUserPasswordReturnsOnCall[len(fake.updateUserPasswordArgsForCall)]
+	fake.updateUserPasswordArgsForCall = append(fake.updateUserPasswordArgsForCall, struct {
+		arg1 context.Context
+		arg2 persistence.TransactionHandler
+		arg3 int
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateUserPasswordStub
+	fakeReturns := fake.updateUserPasswordReturns
+	fake.recordInvocation("UpdateUserPassword", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateUserPasswordMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakePersistor) UpdateUserPasswordCallCount() int {
+	fake.updateUserPasswordMutex.RLock()
+	defer fake.updateUserPasswordMutex.RUnlock()
+	return len(fake.updateUserPasswordArgsForCall)
+}
+
+func (fake *FakePersistor) UpdateUserPasswordCalls(stub func(context.Context, persistence.TransactionHandler, int, string) error) {
+	fake.updateUserPasswordMutex.Lock()
+	defer fake.updateUserPasswordMutex.Unlock()
+	fake.UpdateUserPasswordStub = stub
+}
+
+func (fake *FakePersistor) UpdateUserPasswordArgsForCall(i int) (context.Context, persistence.TransactionHandler, int, string) {
+	fake.updateUserPasswordMutex.RLock()
+	defer fake.updateUserPasswordMutex.RUnlock()
+	argsForCall := fake.updateUserPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePersistor) UpdateUserPasswordReturns(result1 error) {
+	fake.updateUserPasswordMutex.Lock()
+	defer fake.updateUserPasswordMutex.Unlock()
+	fake.UpdateUserPasswordStub = nil
+	fake.updateUserPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePersistor) UpdateUserPasswordReturnsOnCall(i int, result1 error) {
+	fake.updateUserPasswordMutex.Lock()
+	defer fake.updateUserPasswordMutex.Unlock()
+	fake.UpdateUserPasswordStub = nil
+	if fake.updateUserPasswordReturnsOnCall == nil {
+		fake.updateUserPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateUserPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePersistor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createUserMutex.RLock()
+	defer fake.createUserMutex.RUnlock()
+	fake.getUserPasswordsMutex.RLock()
+	defer fake.getUserPasswordsMutex.RUnlock()
+	fake.updateUserMutex.RLock()
+	defer fake.updateUserMutex.RUnlock()
+	fake.updateUserPasswordMutex.RLock()
+	defer fake.updateUserPasswordMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
+	"github.com/volatiletech/null/v8"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, which tells them apart from access
+// tokens in an "Authorization: Bearer" header.
+const APIKeyPrefix = "ltk_"
+
+// APIKey is a stored API key, only its hash is kept. A key acts as its user,
+// limited to its scopes.
+type APIKey struct {
+	Id                int       `json:"id"`
+	UserRefId         int       `json:"user_id"`
+	OrganizationRefId null.Int  `json:"organization_id"`
+	Name              string    `json:"name"`
+	Prefix            string    `json:"prefix"`
+	KeyHash           string    `json:"-"`
+	Scopes            []string  `json:"scopes"`
+	ExpiresAt         null.Time `json:"expires_at"`
+	LastUsedAt        null.Time `json:"last_used_at"`
+	CreatedAt         time.Time `json:"created_at"`
+	RevokedAt         null.Time `json:"revoked_at"`
+}
+
+// CreateAPIKey creates a key for the authenticated user, or for an
+// organization they created when OrganizationId is set.
+type CreateAPIKey struct {
+	Name           string    `json:"name" validate:"required"`
+	OrganizationId int       `json:"organization_id"`
+	Scopes         []string  `json:"scopes" validate:"required"`
+	ExpiresAt      null.Time `json:"expires_at"`
+}
+
+func (c *CreateAPIKey) Validate() error {
+	if err := validationutils.Validate(c); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+	if len(c.Scopes) == 0 {
+		return errors.New("validate: 'scopes' must have a value")
+	}
+	if c.OrganizationId < 0 {
+		return errors.New("validate: 'organization_id' can't be negative")
+	}
+	if c.ExpiresAt.Valid && !c.ExpiresAt.Time.After(time.Now()) {
+		return errors.New("validate: 'expires_at' must be in the future")
+	}
+	return nil
+}
+
+// CreatedAPIKey is returned once, when the key is created, as only its hash
+// is stored.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
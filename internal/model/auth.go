@@ -0,0 +1,152 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
+	"github.com/volatiletech/null/v8"
+	"time"
+	"unicode/utf8"
+)
+
+type authUserKey struct{}
+
+// AuthUser is the authenticated user of a request.
+type AuthUser struct {
+	Id                int    `json:"id"`
+	Email             string `json:"email"`
+	Firstname         string `json:"firstname"`
+	Lastname          string `json:"lastname"`
+	CategoryTypeRefId int    `json:"category_type_ref_id"`
+
+	// APIKeyId is set when the request used an API key, which limits the
+	// user to the key's Scopes.
+	APIKeyId       int      `json:"api_key_id,omitempty"`
+	OrganizationId int      `json:"organization_id,omitempty"`
+	Scopes         []string `json:"scopes,omitempty"`
+}
+
+// HasScope reports whether the API key of the request, if any, was granted
+// the permission. Logins are not limited by scopes.
+func (u *AuthUser) HasScope(permission string) bool {
+	if u.APIKeyId == 0 {
+		return true
+	}
+	for _, scope := range u.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthUserKey is the key the authenticated user is stored under. A user set
+// with fiber's Locals under it can be read back with AuthUserFromContext, from
+// the request's context.
+func AuthUserKey() interface{} {
+	return authUserKey{}
+}
+
+// WithAuthUser returns a copy of the context carrying the user.
+func WithAuthUser(ctx context.Context, user *AuthUser) context.Context {
+	return context.WithValue(ctx, authUserKey{}, user)
+}
+
+// AuthUserFromContext returns the authenticated user of the request, if any.
+func AuthUserFromContext(ctx context.Context) (*AuthUser, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	user, ok := ctx.Value(authUserKey{}).(*AuthUser)
+	return user, ok && user != nil
+}
+
+// Login holds the credentials of a login.
+type Login struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (l *Login) Validate() error {
+	if err := validationutils.Validate(l); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+	return nil
+}
+
+// RefreshSession trades a refresh token for new tokens.
+type RefreshSession struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+func (r *RefreshSession) Validate() error {
+	if err := validationutils.Validate(r); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+	return nil
+}
+
+// Logout revokes the session of the refresh token, or every session of its
+// user when All is set.
+type Logout struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+	All          bool   `json:"all"`
+}
+
+func (l *Logout) Validate() error {
+	if err := validationutils.Validate(l); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+	return nil
+}
+
+// ForgotPassword requests a password reset link for the email.
+type ForgotPassword struct {
+	Email string `json:"email" validate:"required"`
+}
+
+func (f *ForgotPassword) Validate() error {
+	if err := validationutils.Validate(f); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+	return nil
+}
+
+// MinPasswordLength is the length a new password must have at least.
+const MinPasswordLength = 8
+
+// ResetPassword sets a new password with the token of a reset link.
+type ResetPassword struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (r *ResetPassword) Validate() error {
+	if err := validationutils.Validate(r); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+	if utf8.RuneCountInString(r.Password) < MinPasswordLength {
+		return fmt.Errorf("validate: 'password' must have at least %d characters", MinPasswordLength)
+	}
+	return nil
+}
+
+// AuthTokens are issued on login and refresh.
+type AuthTokens struct {
+	TokenType             string    `json:"token_type"`
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// RefreshToken is a stored refresh token, only its hash is kept.
+type RefreshToken struct {
+	Id        int       `json:"id" boil:"id"`
+	UserRefId int       `json:"user_ref_id" boil:"user_ref_id"`
+	Family    string    `json:"family" boil:"family"`
+	TokenHash string    `json:"-" boil:"token_hash"`
+	CreatedAt time.Time `json:"created_at" boil:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" boil:"expires_at"`
+	RevokedAt null.Time `json:"revoked_at" boil:"revoked_at"`
+}
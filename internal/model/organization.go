@@ -0,0 +1,11 @@
+package model
+
+import "github.com/volatiletech/null/v8"
+
+// Organization owns API keys, which only the user who created it manages.
+type Organization struct {
+	Id        int      `json:"id"`
+	Name      string   `json:"name"`
+	CreatedBy null.Int `json:"created_by"`
+	IsActive  bool     `json:"is_active"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
+)
+
+// Permissions checked by the routes and services. The roles they are granted
+// to are stored, and can be changed at runtime.
+const (
+	PermissionCategoryRead   = "category:read"
+	PermissionCategoryWrite  = "category:write"
+	PermissionCategoryDelete = "category:delete"
+	PermissionUserRead       = "user:read"
+	PermissionUserWrite      = "user:write"
+	PermissionUserDelete     = "user:delete"
+	PermissionRoleRead       = "role:read"
+	PermissionRoleWrite      = "role:write"
+)
+
+// Permission is an action on a resource, named "<resource>:<action>".
+type Permission struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Role is a "User Types" category, with the names of its permissions.
+type Role struct {
+	Id          int      `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// RolePermission grants a permission to, or revokes it from, a role.
+type RolePermission struct {
+	RoleId     int    `json:"role_id" validate:"required,greater_than_zero"`
+	Permission string `json:"permission" validate:"required"`
+}
+
+func (r *RolePermission) Validate() error {
+	if err := validationutils.Validate(r); err != nil {
+		return fmt.Errorf("validate: %v", err)
+	}
+	return nil
+}
@@ -16,24 +16,25 @@ type PaginatedUser struct {
 
 // User contains all relevant struct fields
 type User struct {
-	Id                int         `json:"id" boil:"id"`
-	Firstname         string      `json:"firstname" boil:"firstname"`
-	Lastname          string      `json:"lastname" boil:"lastname"`
-	Email             string      `json:"email" boil:"email"`
-	Password          string      `json:"password" boil:"password"`
-	CategoryType      string      `json:"category_type" boil:"category_type"`
-	CategoryTypeRefId int         `json:"category_type_ref_id" boil:"category_type_ref_id"`
-	CreatedBy         null.Int    `json:"created_by" boil:"created_by"`
-	LastUpdatedById   null.Int    `json:"last_updated_by" boil:"last_updated_by"`
-	LastUpdatedBy     string      `json:"last_updated" boil:"last_updated"`
-	CreatedAt         time.Time   `json:"created_at" boil:"created_at"`
-	LastUpdatedAt     null.Time   `json:"last_updated_at" boil:"last_updated_at"`
-	IsActive          bool        `json:"is_active" boil:"is_active"`
-	ResetToken        null.String `json:"reset_token" boil:"reset_token"`
-	Address           null.String `json:"address" boil:"address"`
-	Birthday          null.Time   `json:"birthday" boil:"birthday"`
-	Gender            null.String `json:"gender" boil:"gender"`
-	IsSelfRegistered  null.Bool   `json:"is_self_registered" boil:"is_self_registered"`
+	Id                  int         `json:"id" boil:"id"`
+	Firstname           string      `json:"firstname" boil:"firstname"`
+	Lastname            string      `json:"lastname" boil:"lastname"`
+	Email               string      `json:"email" boil:"email"`
+	Password            string      `json:"-" boil:"password"`
+	CategoryType        string      `json:"category_type" boil:"category_type"`
+	CategoryTypeRefId   int         `json:"category_type_ref_id" boil:"category_type_ref_id"`
+	CreatedBy           null.Int    `json:"created_by" boil:"created_by"`
+	LastUpdatedById     null.Int    `json:"last_updated_by" boil:"last_updated_by"`
+	LastUpdatedBy       string      `json:"last_updated" boil:"last_updated"`
+	CreatedAt           time.Time   `json:"created_at" boil:"created_at"`
+	LastUpdatedAt       null.Time   `json:"last_updated_at" boil:"last_updated_at"`
+	IsActive            bool        `json:"is_active" boil:"is_active"`
+	ResetToken          null.String `json:"-" boil:"reset_token"`
+	ResetTokenExpiresAt null.Time   `json:"-" boil:"reset_token_expires_at"`
+	Address             null.String `json:"address" boil:"address"`
+	Birthday            null.Time   `json:"birthday" boil:"birthday"`
+	Gender              null.String `json:"gender" boil:"gender"`
+	IsSelfRegistered    null.Bool   `json:"is_self_registered" boil:"is_self_registered"`
 }
 
 // UserFilters contains the user filters.
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"testing"
+)
+
+func TestUser_Marshal_Omits_Secrets(t *testing.T) {
+	b, err := json.Marshal(User{
+		Email:      "demby@gmail.com",
+		Password:   "$argon2id$v=19$m=64,t=1,p=1$c2FsdA$a2V5",
+		ResetToken: null.StringFrom("5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"),
+	})
+	require.NoError(t, err, "unexpected marshal error")
+	require.NotContains(t, string(b), "password", "unexpected password in the json")
+	require.NotContains(t, string(b), "argon2id", "unexpected hash in the json")
+	require.NotContains(t, string(b), "reset_token", "unexpected reset token in the json")
+	require.NotContains(t, string(b), "5e884898", "unexpected reset token hash in the json")
+}
@@ -2,9 +2,14 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrNotFound is wrapped by the persistors when a looked up entry does not
+// exist, so callers can tell it apart from a failing query with errors.Is.
+var ErrNotFound = errors.New("not found")
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 // TransactionProvider
@@ -0,0 +1,1338 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package mysqlmodel
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// APIKey is an object representing the database table.
+type APIKey struct {
+	ID                int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserRefID         int       `boil:"user_ref_id" json:"user_ref_id" toml:"user_ref_id" yaml:"user_ref_id"`
+	OrganizationRefID null.Int  `boil:"organization_ref_id" json:"organization_ref_id,omitempty" toml:"organization_ref_id" yaml:"organization_ref_id,omitempty"`
+	Name              string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Prefix            string    `boil:"prefix" json:"prefix" toml:"prefix" yaml:"prefix"`
+	KeyHash           string    `boil:"key_hash" json:"key_hash" toml:"key_hash" yaml:"key_hash"`
+	Scopes            string    `boil:"scopes" json:"scopes" toml:"scopes" yaml:"scopes"`
+	ExpiresAt         null.Time `boil:"expires_at" json:"expires_at,omitempty" toml:"expires_at" yaml:"expires_at,omitempty"`
+	LastUsedAt        null.Time `boil:"last_used_at" json:"last_used_at,omitempty" toml:"last_used_at" yaml:"last_used_at,omitempty"`
+	CreatedAt         time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	RevokedAt         null.Time `boil:"revoked_at" json:"revoked_at,omitempty" toml:"revoked_at" yaml:"revoked_at,omitempty"`
+
+	R *apiKeyR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L apiKeyL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var APIKeyColumns = struct {
+	ID                string
+	UserRefID         string
+	OrganizationRefID string
+	Name              string
+	Prefix            string
+	KeyHash           string
+	Scopes            string
+	ExpiresAt         string
+	LastUsedAt        string
+	CreatedAt         string
+	RevokedAt         string
+}{
+	ID:                "id",
+	UserRefID:         "user_ref_id",
+	OrganizationRefID: "organization_ref_id",
+	Name:              "name",
+	Prefix:            "prefix",
+	KeyHash:           "key_hash",
+	Scopes:            "scopes",
+	ExpiresAt:         "expires_at",
+	LastUsedAt:        "last_used_at",
+	CreatedAt:         "created_at",
+	RevokedAt:         "revoked_at",
+}
+
+var APIKeyTableColumns = struct {
+	ID                string
+	UserRefID         string
+	OrganizationRefID string
+	Name              string
+	Prefix            string
+	KeyHash           string
+	Scopes            string
+	ExpiresAt         string
+	LastUsedAt        string
+	CreatedAt         string
+	RevokedAt         string
+}{
+	ID:                "api_key.id",
+	UserRefID:         "api_key.user_ref_id",
+	OrganizationRefID: "api_key.organization_ref_id",
+	Name:              "api_key.name",
+	Prefix:            "api_key.prefix",
+	KeyHash:           "api_key.key_hash",
+	Scopes:            "api_key.scopes",
+	ExpiresAt:         "api_key.expires_at",
+	LastUsedAt:        "api_key.last_used_at",
+	CreatedAt:         "api_key.created_at",
+	RevokedAt:         "api_key.revoked_at",
+}
+
+// Generated where
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod   { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod   { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod   { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) LIKE(x string) qm.QueryMod  { return qm.Where(w.field+" LIKE ?", x) }
+func (w whereHelperstring) NLIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var APIKeyWhere = struct {
+	ID                whereHelperint
+	UserRefID         whereHelperint
+	OrganizationRefID whereHelpernull_Int
+	Name              whereHelperstring
+	Prefix            whereHelperstring
+	KeyHash           whereHelperstring
+	Scopes            whereHelperstring
+	ExpiresAt         whereHelpernull_Time
+	LastUsedAt        whereHelpernull_Time
+	CreatedAt         whereHelpertime_Time
+	RevokedAt         whereHelpernull_Time
+}{
+	ID:                whereHelperint{field: "`api_key`.`id`"},
+	UserRefID:         whereHelperint{field: "`api_key`.`user_ref_id`"},
+	OrganizationRefID: whereHelpernull_Int{field: "`api_key`.`organization_ref_id`"},
+	Name:              whereHelperstring{field: "`api_key`.`name`"},
+	Prefix:            whereHelperstring{field: "`api_key`.`prefix`"},
+	KeyHash:           whereHelperstring{field: "`api_key`.`key_hash`"},
+	Scopes:            whereHelperstring{field: "`api_key`.`scopes`"},
+	ExpiresAt:         whereHelpernull_Time{field: "`api_key`.`expires_at`"},
+	LastUsedAt:        whereHelpernull_Time{field: "`api_key`.`last_used_at`"},
+	CreatedAt:         whereHelpertime_Time{field: "`api_key`.`created_at`"},
+	RevokedAt:         whereHelpernull_Time{field: "`api_key`.`revoked_at`"},
+}
+
+// APIKeyRels is where relationship names are stored.
+var APIKeyRels = struct {
+	OrganizationRef string
+	UserRef         string
+}{
+	OrganizationRef: "OrganizationRef",
+	UserRef:         "UserRef",
+}
+
+// apiKeyR is where relationships are stored.
+type apiKeyR struct {
+	OrganizationRef *Organization `boil:"OrganizationRef" json:"OrganizationRef" toml:"OrganizationRef" yaml:"OrganizationRef"`
+	UserRef         *User         `boil:"UserRef" json:"UserRef" toml:"UserRef" yaml:"UserRef"`
+}
+
+// NewStruct creates a new relationship struct
+func (*apiKeyR) NewStruct() *apiKeyR {
+	return &apiKeyR{}
+}
+
+func (r *apiKeyR) GetOrganizationRef() *Organization {
+	if r == nil {
+		return nil
+	}
+	return r.OrganizationRef
+}
+
+func (r *apiKeyR) GetUserRef() *User {
+	if r == nil {
+		return nil
+	}
+	return r.UserRef
+}
+
+// apiKeyL is where Load methods for each relationship are stored.
+type apiKeyL struct{}
+
+var (
+	apiKeyAllColumns            = []string{"id", "user_ref_id", "organization_ref_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "created_at", "revoked_at"}
+	apiKeyColumnsWithoutDefault = []string{"user_ref_id", "organization_ref_id", "name", "prefix", "key_hash", "scopes", "expires_at", "last_used_at", "revoked_at"}
+	apiKeyColumnsWithDefault    = []string{"id", "created_at"}
+	apiKeyPrimaryKeyColumns     = []string{"id"}
+	apiKeyGeneratedColumns      = []string{}
+)
+
+type (
+	// APIKeySlice is an alias for a slice of pointers to APIKey.
+	// This should almost always be used instead of []APIKey.
+	APIKeySlice []*APIKey
+
+	apiKeyQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	apiKeyType                 = reflect.TypeOf(&APIKey{})
+	apiKeyMapping              = queries.MakeStructMapping(apiKeyType)
+	apiKeyPrimaryKeyMapping, _ = queries.BindMapping(apiKeyType, apiKeyMapping, apiKeyPrimaryKeyColumns)
+	apiKeyInsertCacheMut       sync.RWMutex
+	apiKeyInsertCache          = make(map[string]insertCache)
+	apiKeyUpdateCacheMut       sync.RWMutex
+	apiKeyUpdateCache          = make(map[string]updateCache)
+	apiKeyUpsertCacheMut       sync.RWMutex
+	apiKeyUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single apiKey record from the query.
+func (q apiKeyQuery) One(ctx context.Context, exec boil.ContextExecutor) (*APIKey, error) {
+	o := &APIKey{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "mysqlmodel: failed to execute a one query for api_key")
+	}
+
+	return o, nil
+}
+
+// All returns all APIKey records from the query.
+func (q apiKeyQuery) All(ctx context.Context, exec boil.ContextExecutor) (APIKeySlice, error) {
+	var o []*APIKey
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "mysqlmodel: failed to assign all query results to APIKey slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all APIKey records in the query.
+func (q apiKeyQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to count api_key rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q apiKeyQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "mysqlmodel: failed to check if api_key exists")
+	}
+
+	return count > 0, nil
+}
+
+// OrganizationRef pointed to by the foreign key.
+func (o *APIKey) OrganizationRef(mods ...qm.QueryMod) organizationQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.OrganizationRefID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Organizations(queryMods...)
+}
+
+// UserRef pointed to by the foreign key.
+func (o *APIKey) UserRef(mods ...qm.QueryMod) userQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.UserRefID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Users(queryMods...)
+}
+
+// LoadOrganizationRef allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (apiKeyL) LoadOrganizationRef(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAPIKey interface{}, mods queries.Applicator) error {
+	var slice []*APIKey
+	var object *APIKey
+
+	if singular {
+		var ok bool
+		object, ok = maybeAPIKey.(*APIKey)
+		if !ok {
+			object = new(APIKey)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAPIKey)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAPIKey))
+			}
+		}
+	} else {
+		s, ok := maybeAPIKey.(*[]*APIKey)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAPIKey)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAPIKey))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &apiKeyR{}
+		}
+		if !queries.IsNil(object.OrganizationRefID) {
+			args[object.OrganizationRefID] = struct{}{}
+		}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &apiKeyR{}
+			}
+
+			if !queries.IsNil(obj.OrganizationRefID) {
+				args[obj.OrganizationRefID] = struct{}{}
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`organization`),
+		qm.WhereIn(`organization.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Organization")
+	}
+
+	var resultSlice []*Organization
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Organization")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for organization")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for organization")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.OrganizationRef = foreign
+		if foreign.R == nil {
+			foreign.R = &organizationR{}
+		}
+		foreign.R.OrganizationRefAPIKeys = append(foreign.R.OrganizationRefAPIKeys, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.OrganizationRefID, foreign.ID) {
+				local.R.OrganizationRef = foreign
+				if foreign.R == nil {
+					foreign.R = &organizationR{}
+				}
+				foreign.R.OrganizationRefAPIKeys = append(foreign.R.OrganizationRefAPIKeys, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserRef allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (apiKeyL) LoadUserRef(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAPIKey interface{}, mods queries.Applicator) error {
+	var slice []*APIKey
+	var object *APIKey
+
+	if singular {
+		var ok bool
+		object, ok = maybeAPIKey.(*APIKey)
+		if !ok {
+			object = new(APIKey)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAPIKey)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAPIKey))
+			}
+		}
+	} else {
+		s, ok := maybeAPIKey.(*[]*APIKey)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAPIKey)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAPIKey))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &apiKeyR{}
+		}
+		args[object.UserRefID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &apiKeyR{}
+			}
+
+			args[obj.UserRefID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`user`),
+		qm.WhereIn(`user.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load User")
+	}
+
+	var resultSlice []*User
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice User")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for user")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.UserRef = foreign
+		if foreign.R == nil {
+			foreign.R = &userR{}
+		}
+		foreign.R.UserRefAPIKeys = append(foreign.R.UserRefAPIKeys, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.UserRefID == foreign.ID {
+				local.R.UserRef = foreign
+				if foreign.R == nil {
+					foreign.R = &userR{}
+				}
+				foreign.R.UserRefAPIKeys = append(foreign.R.UserRefAPIKeys, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetOrganizationRef of the apiKey to the related item.
+// Sets o.R.OrganizationRef to related.
+// Adds o to related.R.OrganizationRefAPIKeys.
+func (o *APIKey) SetOrganizationRef(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Organization) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `api_key` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"organization_ref_id"}),
+		strmangle.WhereClause("`", "`", 0, apiKeyPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.OrganizationRefID, related.ID)
+	if o.R == nil {
+		o.R = &apiKeyR{
+			OrganizationRef: related,
+		}
+	} else {
+		o.R.OrganizationRef = related
+	}
+
+	if related.R == nil {
+		related.R = &organizationR{
+			OrganizationRefAPIKeys: APIKeySlice{o},
+		}
+	} else {
+		related.R.OrganizationRefAPIKeys = append(related.R.OrganizationRefAPIKeys, o)
+	}
+
+	return nil
+}
+
+// RemoveOrganizationRef relationship.
+// Sets o.R.OrganizationRef to nil.
+// Removes o from all passed in related items' relationships struct.
+func (o *APIKey) RemoveOrganizationRef(ctx context.Context, exec boil.ContextExecutor, related *Organization) error {
+	var err error
+
+	queries.SetScanner(&o.OrganizationRefID, nil)
+	if _, err = o.Update(ctx, exec, boil.Whitelist("organization_ref_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.OrganizationRef = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.OrganizationRefAPIKeys {
+		if queries.Equal(o.OrganizationRefID, ri.OrganizationRefID) {
+			continue
+		}
+
+		ln := len(related.R.OrganizationRefAPIKeys)
+		if ln > 1 && i < ln-1 {
+			related.R.OrganizationRefAPIKeys[i] = related.R.OrganizationRefAPIKeys[ln-1]
+		}
+		related.R.OrganizationRefAPIKeys = related.R.OrganizationRefAPIKeys[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetUserRef of the apiKey to the related item.
+// Sets o.R.UserRef to related.
+// Adds o to related.R.UserRefAPIKeys.
+func (o *APIKey) SetUserRef(ctx context.Context, exec boil.ContextExecutor, insert bool, related *User) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `api_key` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"user_ref_id"}),
+		strmangle.WhereClause("`", "`", 0, apiKeyPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.UserRefID = related.ID
+	if o.R == nil {
+		o.R = &apiKeyR{
+			UserRef: related,
+		}
+	} else {
+		o.R.UserRef = related
+	}
+
+	if related.R == nil {
+		related.R = &userR{
+			UserRefAPIKeys: APIKeySlice{o},
+		}
+	} else {
+		related.R.UserRefAPIKeys = append(related.R.UserRefAPIKeys, o)
+	}
+
+	return nil
+}
+
+// APIKeys retrieves all the records using an executor.
+func APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	mods = append(mods, qm.From("`api_key`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`api_key`.*"})
+	}
+
+	return apiKeyQuery{q}
+}
+
+// FindAPIKey retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAPIKey(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*APIKey, error) {
+	apiKeyObj := &APIKey{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `api_key` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, apiKeyObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "mysqlmodel: unable to select from api_key")
+	}
+
+	return apiKeyObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *APIKey) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("mysqlmodel: no api_key provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(apiKeyColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	apiKeyInsertCacheMut.RLock()
+	cache, cached := apiKeyInsertCache[key]
+	apiKeyInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			apiKeyAllColumns,
+			apiKeyColumnsWithDefault,
+			apiKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(apiKeyType, apiKeyMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(apiKeyType, apiKeyMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `api_key` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `api_key` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `api_key` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, apiKeyPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to insert into api_key")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == apiKeyMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to populate default values for api_key")
+	}
+
+CacheNoHooks:
+	if !cached {
+		apiKeyInsertCacheMut.Lock()
+		apiKeyInsertCache[key] = cache
+		apiKeyInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the APIKey.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *APIKey) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	apiKeyUpdateCacheMut.RLock()
+	cache, cached := apiKeyUpdateCache[key]
+	apiKeyUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			apiKeyAllColumns,
+			apiKeyPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("mysqlmodel: unable to update api_key, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `api_key` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, apiKeyPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(apiKeyType, apiKeyMapping, append(wl, apiKeyPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update api_key row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by update for api_key")
+	}
+
+	if !cached {
+		apiKeyUpdateCacheMut.Lock()
+		apiKeyUpdateCache[key] = cache
+		apiKeyUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q apiKeyQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update all for api_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to retrieve rows affected for api_key")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o APIKeySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("mysqlmodel: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), apiKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `api_key` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, apiKeyPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update all in apiKey slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to retrieve rows affected all in update all apiKey")
+	}
+	return rowsAff, nil
+}
+
+var mySQLAPIKeyUniqueColumns = []string{
+	"id",
+	"key_hash",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *APIKey) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("mysqlmodel: no api_key provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(apiKeyColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLAPIKeyUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	apiKeyUpsertCacheMut.RLock()
+	cache, cached := apiKeyUpsertCache[key]
+	apiKeyUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			apiKeyAllColumns,
+			apiKeyColumnsWithDefault,
+			apiKeyColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			apiKeyAllColumns,
+			apiKeyPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("mysqlmodel: unable to upsert api_key, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(apiKeyAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`api_key`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `api_key` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(apiKeyType, apiKeyMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(apiKeyType, apiKeyMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to upsert for api_key")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == apiKeyMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(apiKeyType, apiKeyMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to retrieve unique values for api_key")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to populate default values for api_key")
+	}
+
+CacheNoHooks:
+	if !cached {
+		apiKeyUpsertCacheMut.Lock()
+		apiKeyUpsertCache[key] = cache
+		apiKeyUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single APIKey record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *APIKey) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("mysqlmodel: no APIKey provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), apiKeyPrimaryKeyMapping)
+	sql := "DELETE FROM `api_key` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete from api_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by delete for api_key")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q apiKeyQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("mysqlmodel: no apiKeyQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete all from api_key")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by deleteall for api_key")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o APIKeySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), apiKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `api_key` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, apiKeyPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete all from apiKey slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by deleteall for api_key")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *APIKey) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAPIKey(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *APIKeySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := APIKeySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), apiKeyPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `api_key`.* FROM `api_key` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, apiKeyPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to reload all in APIKeySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// APIKeyExists checks if the APIKey row exists.
+func APIKeyExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `api_key` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "mysqlmodel: unable to check if api_key exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the APIKey row exists.
+func (o *APIKey) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return APIKeyExists(ctx, exec, o.ID)
+}
@@ -4,6 +4,7 @@
 package mysqlmodel
 
 var TableNames = struct {
+	APIKey           string
 	CapturePage      string
 	CapturePageSet   string
 	Category         string
@@ -12,9 +13,13 @@ var TableNames = struct {
 	ClickTrackerLog  string
 	ClickTrackerSet  string
 	Organization     string
+	Permission       string
+	RefreshToken     string
+	RolePermission   string
 	SchemaMigrations string
 	User             string
 }{
+	APIKey:           "api_key",
 	CapturePage:      "capture_page",
 	CapturePageSet:   "capture_page_set",
 	Category:         "category",
@@ -23,6 +28,9 @@ var TableNames = struct {
 	ClickTrackerLog:  "click_tracker_log",
 	ClickTrackerSet:  "click_tracker_set",
 	Organization:     "organization",
+	Permission:       "permission",
+	RefreshToken:     "refresh_token",
+	RolePermission:   "role_permission",
 	SchemaMigrations: "schema_migrations",
 	User:             "user",
 }
@@ -101,17 +101,20 @@ var CategoryWhere = struct {
 
 // CategoryRels is where relationship names are stored.
 var CategoryRels = struct {
-	CategoryTypeRef      string
-	CategoryTypeRefUsers string
+	CategoryTypeRef        string
+	RoleRefRolePermissions string
+	CategoryTypeRefUsers   string
 }{
-	CategoryTypeRef:      "CategoryTypeRef",
-	CategoryTypeRefUsers: "CategoryTypeRefUsers",
+	CategoryTypeRef:        "CategoryTypeRef",
+	RoleRefRolePermissions: "RoleRefRolePermissions",
+	CategoryTypeRefUsers:   "CategoryTypeRefUsers",
 }
 
 // categoryR is where relationships are stored.
 type categoryR struct {
-	CategoryTypeRef      *CategoryType `boil:"CategoryTypeRef" json:"CategoryTypeRef" toml:"CategoryTypeRef" yaml:"CategoryTypeRef"`
-	CategoryTypeRefUsers UserSlice     `boil:"CategoryTypeRefUsers" json:"CategoryTypeRefUsers" toml:"CategoryTypeRefUsers" yaml:"CategoryTypeRefUsers"`
+	CategoryTypeRef        *CategoryType       `boil:"CategoryTypeRef" json:"CategoryTypeRef" toml:"CategoryTypeRef" yaml:"CategoryTypeRef"`
+	RoleRefRolePermissions RolePermissionSlice `boil:"RoleRefRolePermissions" json:"RoleRefRolePermissions" toml:"RoleRefRolePermissions" yaml:"RoleRefRolePermissions"`
+	CategoryTypeRefUsers   UserSlice           `boil:"CategoryTypeRefUsers" json:"CategoryTypeRefUsers" toml:"CategoryTypeRefUsers" yaml:"CategoryTypeRefUsers"`
 }
 
 // NewStruct creates a new relationship struct
@@ -126,6 +129,13 @@ func (r *categoryR) GetCategoryTypeRef() *CategoryType {
 	return r.CategoryTypeRef
 }
 
+func (r *categoryR) GetRoleRefRolePermissions() RolePermissionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.RoleRefRolePermissions
+}
+
 func (r *categoryR) GetCategoryTypeRefUsers() UserSlice {
 	if r == nil {
 		return nil
@@ -246,6 +256,20 @@ func (o *Category) CategoryTypeRef(mods ...qm.QueryMod) categoryTypeQuery {
 	return CategoryTypes(queryMods...)
 }
 
+// RoleRefRolePermissions retrieves all the role_permission's RolePermissions with an executor via role_ref_id column.
+func (o *Category) RoleRefRolePermissions(mods ...qm.QueryMod) rolePermissionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`role_permission`.`role_ref_id`=?", o.ID),
+	)
+
+	return RolePermissions(queryMods...)
+}
+
 // CategoryTypeRefUsers retrieves all the user's Users with an executor via category_type_ref_id column.
 func (o *Category) CategoryTypeRefUsers(mods ...qm.QueryMod) userQuery {
 	var queryMods []qm.QueryMod
@@ -372,6 +396,112 @@ func (categoryL) LoadCategoryTypeRef(ctx context.Context, e boil.ContextExecutor
 	return nil
 }
 
+// LoadRoleRefRolePermissions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (categoryL) LoadRoleRefRolePermissions(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCategory interface{}, mods queries.Applicator) error {
+	var slice []*Category
+	var object *Category
+
+	if singular {
+		var ok bool
+		object, ok = maybeCategory.(*Category)
+		if !ok {
+			object = new(Category)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeCategory)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeCategory))
+			}
+		}
+	} else {
+		s, ok := maybeCategory.(*[]*Category)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeCategory)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeCategory))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &categoryR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &categoryR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`role_permission`),
+		qm.WhereIn(`role_permission.role_ref_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load role_permission")
+	}
+
+	var resultSlice []*RolePermission
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice role_permission")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on role_permission")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for role_permission")
+	}
+
+	if singular {
+		object.R.RoleRefRolePermissions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &rolePermissionR{}
+			}
+			foreign.R.RoleRef = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.RoleRefID {
+				local.R.RoleRefRolePermissions = append(local.R.RoleRefRolePermissions, foreign)
+				if foreign.R == nil {
+					foreign.R = &rolePermissionR{}
+				}
+				foreign.R.RoleRef = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadCategoryTypeRefUsers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (categoryL) LoadCategoryTypeRefUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeCategory interface{}, mods queries.Applicator) error {
@@ -525,6 +655,59 @@ func (o *Category) SetCategoryTypeRef(ctx context.Context, exec boil.ContextExec
 	return nil
 }
 
+// AddRoleRefRolePermissions adds the given related objects to the existing relationships
+// of the category, optionally inserting them as new records.
+// Appends related to o.R.RoleRefRolePermissions.
+// Sets related.R.RoleRef appropriately.
+func (o *Category) AddRoleRefRolePermissions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RolePermission) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.RoleRefID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `role_permission` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"role_ref_id"}),
+				strmangle.WhereClause("`", "`", 0, rolePermissionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.RoleRefID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &categoryR{
+			RoleRefRolePermissions: related,
+		}
+	} else {
+		o.R.RoleRefRolePermissions = append(o.R.RoleRefRolePermissions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &rolePermissionR{
+				RoleRef: o,
+			}
+		} else {
+			rel.R.RoleRef = o
+		}
+	}
+	return nil
+}
+
 // AddCategoryTypeRefUsers adds the given related objects to the existing relationships
 // of the category, optionally inserting them as new records.
 // Appends related to o.R.CategoryTypeRefUsers.
@@ -95,10 +95,12 @@ var OrganizationWhere = struct {
 // OrganizationRels is where relationship names are stored.
 var OrganizationRels = struct {
 	CreatedByUser                  string
+	OrganizationRefAPIKeys         string
 	OrganizationRefCapturePageSets string
 	OrganizationRefUsers           string
 }{
 	CreatedByUser:                  "CreatedByUser",
+	OrganizationRefAPIKeys:         "OrganizationRefAPIKeys",
 	OrganizationRefCapturePageSets: "OrganizationRefCapturePageSets",
 	OrganizationRefUsers:           "OrganizationRefUsers",
 }
@@ -106,6 +108,7 @@ var OrganizationRels = struct {
 // organizationR is where relationships are stored.
 type organizationR struct {
 	CreatedByUser                  *User               `boil:"CreatedByUser" json:"CreatedByUser" toml:"CreatedByUser" yaml:"CreatedByUser"`
+	OrganizationRefAPIKeys         APIKeySlice         `boil:"OrganizationRefAPIKeys" json:"OrganizationRefAPIKeys" toml:"OrganizationRefAPIKeys" yaml:"OrganizationRefAPIKeys"`
 	OrganizationRefCapturePageSets CapturePageSetSlice `boil:"OrganizationRefCapturePageSets" json:"OrganizationRefCapturePageSets" toml:"OrganizationRefCapturePageSets" yaml:"OrganizationRefCapturePageSets"`
 	OrganizationRefUsers           UserSlice           `boil:"OrganizationRefUsers" json:"OrganizationRefUsers" toml:"OrganizationRefUsers" yaml:"OrganizationRefUsers"`
 }
@@ -122,6 +125,13 @@ func (r *organizationR) GetCreatedByUser() *User {
 	return r.CreatedByUser
 }
 
+func (r *organizationR) GetOrganizationRefAPIKeys() APIKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.OrganizationRefAPIKeys
+}
+
 func (r *organizationR) GetOrganizationRefCapturePageSets() CapturePageSetSlice {
 	if r == nil {
 		return nil
@@ -249,6 +259,20 @@ func (o *Organization) CreatedByUser(mods ...qm.QueryMod) userQuery {
 	return Users(queryMods...)
 }
 
+// OrganizationRefAPIKeys retrieves all the api_key's APIKeys with an executor via organization_ref_id column.
+func (o *Organization) OrganizationRefAPIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`api_key`.`organization_ref_id`=?", o.ID),
+	)
+
+	return APIKeys(queryMods...)
+}
+
 // OrganizationRefCapturePageSets retrieves all the capture_page_set's CapturePageSets with an executor via organization_ref_id column.
 func (o *Organization) OrganizationRefCapturePageSets(mods ...qm.QueryMod) capturePageSetQuery {
 	var queryMods []qm.QueryMod
@@ -393,6 +417,112 @@ func (organizationL) LoadCreatedByUser(ctx context.Context, e boil.ContextExecut
 	return nil
 }
 
+// LoadOrganizationRefAPIKeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (organizationL) LoadOrganizationRefAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOrganization interface{}, mods queries.Applicator) error {
+	var slice []*Organization
+	var object *Organization
+
+	if singular {
+		var ok bool
+		object, ok = maybeOrganization.(*Organization)
+		if !ok {
+			object = new(Organization)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeOrganization)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeOrganization))
+			}
+		}
+	} else {
+		s, ok := maybeOrganization.(*[]*Organization)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeOrganization)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeOrganization))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &organizationR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &organizationR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`api_key`),
+		qm.WhereIn(`api_key.organization_ref_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load api_key")
+	}
+
+	var resultSlice []*APIKey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice api_key")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on api_key")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_key")
+	}
+
+	if singular {
+		object.R.OrganizationRefAPIKeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &apiKeyR{}
+			}
+			foreign.R.OrganizationRef = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.OrganizationRefID) {
+				local.R.OrganizationRefAPIKeys = append(local.R.OrganizationRefAPIKeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &apiKeyR{}
+				}
+				foreign.R.OrganizationRef = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadOrganizationRefCapturePageSets allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (organizationL) LoadOrganizationRefCapturePageSets(ctx context.Context, e boil.ContextExecutor, singular bool, maybeOrganization interface{}, mods queries.Applicator) error {
@@ -685,6 +815,133 @@ func (o *Organization) RemoveCreatedByUser(ctx context.Context, exec boil.Contex
 	return nil
 }
 
+// AddOrganizationRefAPIKeys adds the given related objects to the existing relationships
+// of the organization, optionally inserting them as new records.
+// Appends related to o.R.OrganizationRefAPIKeys.
+// Sets related.R.OrganizationRef appropriately.
+func (o *Organization) AddOrganizationRefAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.OrganizationRefID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `api_key` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"organization_ref_id"}),
+				strmangle.WhereClause("`", "`", 0, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.OrganizationRefID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &organizationR{
+			OrganizationRefAPIKeys: related,
+		}
+	} else {
+		o.R.OrganizationRefAPIKeys = append(o.R.OrganizationRefAPIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				OrganizationRef: o,
+			}
+		} else {
+			rel.R.OrganizationRef = o
+		}
+	}
+	return nil
+}
+
+// SetOrganizationRefAPIKeys removes all previously related items of the
+// organization replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.OrganizationRef's OrganizationRefAPIKeys accordingly.
+// Replaces o.R.OrganizationRefAPIKeys with related.
+// Sets related.R.OrganizationRef's OrganizationRefAPIKeys accordingly.
+func (o *Organization) SetOrganizationRefAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	query := "update `api_key` set `organization_ref_id` = null where `organization_ref_id` = ?"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.OrganizationRefAPIKeys {
+			queries.SetScanner(&rel.OrganizationRefID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.OrganizationRef = nil
+		}
+		o.R.OrganizationRefAPIKeys = nil
+	}
+
+	return o.AddOrganizationRefAPIKeys(ctx, exec, insert, related...)
+}
+
+// RemoveOrganizationRefAPIKeys relationships from objects passed in.
+// Removes related items from R.OrganizationRefAPIKeys (uses pointer comparison, removal does not keep order)
+// Sets related.R.OrganizationRef.
+func (o *Organization) RemoveOrganizationRefAPIKeys(ctx context.Context, exec boil.ContextExecutor, related ...*APIKey) error {
+	if len(related) == 0 {
+		return nil
+	}
+
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.OrganizationRefID, nil)
+		if rel.R != nil {
+			rel.R.OrganizationRef = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("organization_ref_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.OrganizationRefAPIKeys {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.OrganizationRefAPIKeys)
+			if ln > 1 && i < ln-1 {
+				o.R.OrganizationRefAPIKeys[i] = o.R.OrganizationRefAPIKeys[ln-1]
+			}
+			o.R.OrganizationRefAPIKeys = o.R.OrganizationRefAPIKeys[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // AddOrganizationRefCapturePageSets adds the given related objects to the existing relationships
 // of the organization, optionally inserting them as new records.
 // Appends related to o.R.OrganizationRefCapturePageSets.
@@ -0,0 +1,943 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package mysqlmodel
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Permission is an object representing the database table.
+type Permission struct {
+	ID          int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name        string    `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Description string    `boil:"description" json:"description" toml:"description" yaml:"description"`
+	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *permissionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L permissionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PermissionColumns = struct {
+	ID          string
+	Name        string
+	Description string
+	CreatedAt   string
+}{
+	ID:          "id",
+	Name:        "name",
+	Description: "description",
+	CreatedAt:   "created_at",
+}
+
+var PermissionTableColumns = struct {
+	ID          string
+	Name        string
+	Description string
+	CreatedAt   string
+}{
+	ID:          "permission.id",
+	Name:        "permission.name",
+	Description: "permission.description",
+	CreatedAt:   "permission.created_at",
+}
+
+// Generated where
+
+var PermissionWhere = struct {
+	ID          whereHelperint
+	Name        whereHelperstring
+	Description whereHelperstring
+	CreatedAt   whereHelpertime_Time
+}{
+	ID:          whereHelperint{field: "`permission`.`id`"},
+	Name:        whereHelperstring{field: "`permission`.`name`"},
+	Description: whereHelperstring{field: "`permission`.`description`"},
+	CreatedAt:   whereHelpertime_Time{field: "`permission`.`created_at`"},
+}
+
+// PermissionRels is where relationship names are stored.
+var PermissionRels = struct {
+	PermissionRefRolePermissions string
+}{
+	PermissionRefRolePermissions: "PermissionRefRolePermissions",
+}
+
+// permissionR is where relationships are stored.
+type permissionR struct {
+	PermissionRefRolePermissions RolePermissionSlice `boil:"PermissionRefRolePermissions" json:"PermissionRefRolePermissions" toml:"PermissionRefRolePermissions" yaml:"PermissionRefRolePermissions"`
+}
+
+// NewStruct creates a new relationship struct
+func (*permissionR) NewStruct() *permissionR {
+	return &permissionR{}
+}
+
+func (r *permissionR) GetPermissionRefRolePermissions() RolePermissionSlice {
+	if r == nil {
+		return nil
+	}
+	return r.PermissionRefRolePermissions
+}
+
+// permissionL is where Load methods for each relationship are stored.
+type permissionL struct{}
+
+var (
+	permissionAllColumns            = []string{"id", "name", "description", "created_at"}
+	permissionColumnsWithoutDefault = []string{"name", "description"}
+	permissionColumnsWithDefault    = []string{"id", "created_at"}
+	permissionPrimaryKeyColumns     = []string{"id"}
+	permissionGeneratedColumns      = []string{}
+)
+
+type (
+	// PermissionSlice is an alias for a slice of pointers to Permission.
+	// This should almost always be used instead of []Permission.
+	PermissionSlice []*Permission
+
+	permissionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	permissionType                 = reflect.TypeOf(&Permission{})
+	permissionMapping              = queries.MakeStructMapping(permissionType)
+	permissionPrimaryKeyMapping, _ = queries.BindMapping(permissionType, permissionMapping, permissionPrimaryKeyColumns)
+	permissionInsertCacheMut       sync.RWMutex
+	permissionInsertCache          = make(map[string]insertCache)
+	permissionUpdateCacheMut       sync.RWMutex
+	permissionUpdateCache          = make(map[string]updateCache)
+	permissionUpsertCacheMut       sync.RWMutex
+	permissionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single permission record from the query.
+func (q permissionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Permission, error) {
+	o := &Permission{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "mysqlmodel: failed to execute a one query for permission")
+	}
+
+	return o, nil
+}
+
+// All returns all Permission records from the query.
+func (q permissionQuery) All(ctx context.Context, exec boil.ContextExecutor) (PermissionSlice, error) {
+	var o []*Permission
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "mysqlmodel: failed to assign all query results to Permission slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Permission records in the query.
+func (q permissionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to count permission rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q permissionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "mysqlmodel: failed to check if permission exists")
+	}
+
+	return count > 0, nil
+}
+
+// PermissionRefRolePermissions retrieves all the role_permission's RolePermissions with an executor via permission_ref_id column.
+func (o *Permission) PermissionRefRolePermissions(mods ...qm.QueryMod) rolePermissionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`role_permission`.`permission_ref_id`=?", o.ID),
+	)
+
+	return RolePermissions(queryMods...)
+}
+
+// LoadPermissionRefRolePermissions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (permissionL) LoadPermissionRefRolePermissions(ctx context.Context, e boil.ContextExecutor, singular bool, maybePermission interface{}, mods queries.Applicator) error {
+	var slice []*Permission
+	var object *Permission
+
+	if singular {
+		var ok bool
+		object, ok = maybePermission.(*Permission)
+		if !ok {
+			object = new(Permission)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybePermission))
+			}
+		}
+	} else {
+		s, ok := maybePermission.(*[]*Permission)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybePermission))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &permissionR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &permissionR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`role_permission`),
+		qm.WhereIn(`role_permission.permission_ref_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load role_permission")
+	}
+
+	var resultSlice []*RolePermission
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice role_permission")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on role_permission")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for role_permission")
+	}
+
+	if singular {
+		object.R.PermissionRefRolePermissions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &rolePermissionR{}
+			}
+			foreign.R.PermissionRef = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.PermissionRefID {
+				local.R.PermissionRefRolePermissions = append(local.R.PermissionRefRolePermissions, foreign)
+				if foreign.R == nil {
+					foreign.R = &rolePermissionR{}
+				}
+				foreign.R.PermissionRef = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddPermissionRefRolePermissions adds the given related objects to the existing relationships
+// of the permission, optionally inserting them as new records.
+// Appends related to o.R.PermissionRefRolePermissions.
+// Sets related.R.PermissionRef appropriately.
+func (o *Permission) AddPermissionRefRolePermissions(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RolePermission) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.PermissionRefID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `role_permission` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"permission_ref_id"}),
+				strmangle.WhereClause("`", "`", 0, rolePermissionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.PermissionRefID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &permissionR{
+			PermissionRefRolePermissions: related,
+		}
+	} else {
+		o.R.PermissionRefRolePermissions = append(o.R.PermissionRefRolePermissions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &rolePermissionR{
+				PermissionRef: o,
+			}
+		} else {
+			rel.R.PermissionRef = o
+		}
+	}
+	return nil
+}
+
+// Permissions retrieves all the records using an executor.
+func Permissions(mods ...qm.QueryMod) permissionQuery {
+	mods = append(mods, qm.From("`permission`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`permission`.*"})
+	}
+
+	return permissionQuery{q}
+}
+
+// FindPermission retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPermission(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*Permission, error) {
+	permissionObj := &Permission{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `permission` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, permissionObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "mysqlmodel: unable to select from permission")
+	}
+
+	return permissionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Permission) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("mysqlmodel: no permission provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(permissionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	permissionInsertCacheMut.RLock()
+	cache, cached := permissionInsertCache[key]
+	permissionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			permissionAllColumns,
+			permissionColumnsWithDefault,
+			permissionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(permissionType, permissionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(permissionType, permissionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `permission` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `permission` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `permission` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, permissionPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to insert into permission")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == permissionMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to populate default values for permission")
+	}
+
+CacheNoHooks:
+	if !cached {
+		permissionInsertCacheMut.Lock()
+		permissionInsertCache[key] = cache
+		permissionInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the Permission.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Permission) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	permissionUpdateCacheMut.RLock()
+	cache, cached := permissionUpdateCache[key]
+	permissionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			permissionAllColumns,
+			permissionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("mysqlmodel: unable to update permission, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `permission` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, permissionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(permissionType, permissionMapping, append(wl, permissionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update permission row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by update for permission")
+	}
+
+	if !cached {
+		permissionUpdateCacheMut.Lock()
+		permissionUpdateCache[key] = cache
+		permissionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q permissionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update all for permission")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to retrieve rows affected for permission")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PermissionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("mysqlmodel: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), permissionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `permission` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, permissionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update all in permission slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to retrieve rows affected all in update all permission")
+	}
+	return rowsAff, nil
+}
+
+var mySQLPermissionUniqueColumns = []string{
+	"id",
+	"name",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Permission) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("mysqlmodel: no permission provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(permissionColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLPermissionUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	permissionUpsertCacheMut.RLock()
+	cache, cached := permissionUpsertCache[key]
+	permissionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			permissionAllColumns,
+			permissionColumnsWithDefault,
+			permissionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			permissionAllColumns,
+			permissionPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("mysqlmodel: unable to upsert permission, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(permissionAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`permission`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `permission` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(permissionType, permissionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(permissionType, permissionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to upsert for permission")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == permissionMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(permissionType, permissionMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to retrieve unique values for permission")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to populate default values for permission")
+	}
+
+CacheNoHooks:
+	if !cached {
+		permissionUpsertCacheMut.Lock()
+		permissionUpsertCache[key] = cache
+		permissionUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single Permission record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Permission) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("mysqlmodel: no Permission provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), permissionPrimaryKeyMapping)
+	sql := "DELETE FROM `permission` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete from permission")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by delete for permission")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q permissionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("mysqlmodel: no permissionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete all from permission")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by deleteall for permission")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PermissionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), permissionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `permission` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, permissionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete all from permission slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by deleteall for permission")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Permission) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPermission(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PermissionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PermissionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), permissionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `permission`.* FROM `permission` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, permissionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to reload all in PermissionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PermissionExists checks if the Permission row exists.
+func PermissionExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `permission` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "mysqlmodel: unable to check if permission exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Permission row exists.
+func (o *Permission) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return PermissionExists(ctx, exec, o.ID)
+}
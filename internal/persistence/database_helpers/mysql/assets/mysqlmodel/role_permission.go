@@ -0,0 +1,1119 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package mysqlmodel
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// RolePermission is an object representing the database table.
+type RolePermission struct {
+	ID              int       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	RoleRefID       int       `boil:"role_ref_id" json:"role_ref_id" toml:"role_ref_id" yaml:"role_ref_id"`
+	PermissionRefID int       `boil:"permission_ref_id" json:"permission_ref_id" toml:"permission_ref_id" yaml:"permission_ref_id"`
+	CreatedAt       time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *rolePermissionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L rolePermissionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var RolePermissionColumns = struct {
+	ID              string
+	RoleRefID       string
+	PermissionRefID string
+	CreatedAt       string
+}{
+	ID:              "id",
+	RoleRefID:       "role_ref_id",
+	PermissionRefID: "permission_ref_id",
+	CreatedAt:       "created_at",
+}
+
+var RolePermissionTableColumns = struct {
+	ID              string
+	RoleRefID       string
+	PermissionRefID string
+	CreatedAt       string
+}{
+	ID:              "role_permission.id",
+	RoleRefID:       "role_permission.role_ref_id",
+	PermissionRefID: "role_permission.permission_ref_id",
+	CreatedAt:       "role_permission.created_at",
+}
+
+// Generated where
+
+var RolePermissionWhere = struct {
+	ID              whereHelperint
+	RoleRefID       whereHelperint
+	PermissionRefID whereHelperint
+	CreatedAt       whereHelpertime_Time
+}{
+	ID:              whereHelperint{field: "`role_permission`.`id`"},
+	RoleRefID:       whereHelperint{field: "`role_permission`.`role_ref_id`"},
+	PermissionRefID: whereHelperint{field: "`role_permission`.`permission_ref_id`"},
+	CreatedAt:       whereHelpertime_Time{field: "`role_permission`.`created_at`"},
+}
+
+// RolePermissionRels is where relationship names are stored.
+var RolePermissionRels = struct {
+	PermissionRef string
+	RoleRef       string
+}{
+	PermissionRef: "PermissionRef",
+	RoleRef:       "RoleRef",
+}
+
+// rolePermissionR is where relationships are stored.
+type rolePermissionR struct {
+	PermissionRef *Permission `boil:"PermissionRef" json:"PermissionRef" toml:"PermissionRef" yaml:"PermissionRef"`
+	RoleRef       *Category   `boil:"RoleRef" json:"RoleRef" toml:"RoleRef" yaml:"RoleRef"`
+}
+
+// NewStruct creates a new relationship struct
+func (*rolePermissionR) NewStruct() *rolePermissionR {
+	return &rolePermissionR{}
+}
+
+func (r *rolePermissionR) GetPermissionRef() *Permission {
+	if r == nil {
+		return nil
+	}
+	return r.PermissionRef
+}
+
+func (r *rolePermissionR) GetRoleRef() *Category {
+	if r == nil {
+		return nil
+	}
+	return r.RoleRef
+}
+
+// rolePermissionL is where Load methods for each relationship are stored.
+type rolePermissionL struct{}
+
+var (
+	rolePermissionAllColumns            = []string{"id", "role_ref_id", "permission_ref_id", "created_at"}
+	rolePermissionColumnsWithoutDefault = []string{"role_ref_id", "permission_ref_id"}
+	rolePermissionColumnsWithDefault    = []string{"id", "created_at"}
+	rolePermissionPrimaryKeyColumns     = []string{"id"}
+	rolePermissionGeneratedColumns      = []string{}
+)
+
+type (
+	// RolePermissionSlice is an alias for a slice of pointers to RolePermission.
+	// This should almost always be used instead of []RolePermission.
+	RolePermissionSlice []*RolePermission
+
+	rolePermissionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	rolePermissionType                 = reflect.TypeOf(&RolePermission{})
+	rolePermissionMapping              = queries.MakeStructMapping(rolePermissionType)
+	rolePermissionPrimaryKeyMapping, _ = queries.BindMapping(rolePermissionType, rolePermissionMapping, rolePermissionPrimaryKeyColumns)
+	rolePermissionInsertCacheMut       sync.RWMutex
+	rolePermissionInsertCache          = make(map[string]insertCache)
+	rolePermissionUpdateCacheMut       sync.RWMutex
+	rolePermissionUpdateCache          = make(map[string]updateCache)
+	rolePermissionUpsertCacheMut       sync.RWMutex
+	rolePermissionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+// One returns a single rolePermission record from the query.
+func (q rolePermissionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*RolePermission, error) {
+	o := &RolePermission{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "mysqlmodel: failed to execute a one query for role_permission")
+	}
+
+	return o, nil
+}
+
+// All returns all RolePermission records from the query.
+func (q rolePermissionQuery) All(ctx context.Context, exec boil.ContextExecutor) (RolePermissionSlice, error) {
+	var o []*RolePermission
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "mysqlmodel: failed to assign all query results to RolePermission slice")
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all RolePermission records in the query.
+func (q rolePermissionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to count role_permission rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q rolePermissionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "mysqlmodel: failed to check if role_permission exists")
+	}
+
+	return count > 0, nil
+}
+
+// PermissionRef pointed to by the foreign key.
+func (o *RolePermission) PermissionRef(mods ...qm.QueryMod) permissionQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.PermissionRefID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Permissions(queryMods...)
+}
+
+// RoleRef pointed to by the foreign key.
+func (o *RolePermission) RoleRef(mods ...qm.QueryMod) categoryQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("`id` = ?", o.RoleRefID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Categories(queryMods...)
+}
+
+// LoadPermissionRef allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (rolePermissionL) LoadPermissionRef(ctx context.Context, e boil.ContextExecutor, singular bool, maybeRolePermission interface{}, mods queries.Applicator) error {
+	var slice []*RolePermission
+	var object *RolePermission
+
+	if singular {
+		var ok bool
+		object, ok = maybeRolePermission.(*RolePermission)
+		if !ok {
+			object = new(RolePermission)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeRolePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeRolePermission))
+			}
+		}
+	} else {
+		s, ok := maybeRolePermission.(*[]*RolePermission)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeRolePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeRolePermission))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &rolePermissionR{}
+		}
+		args[object.PermissionRefID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &rolePermissionR{}
+			}
+
+			args[obj.PermissionRefID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`permission`),
+		qm.WhereIn(`permission.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Permission")
+	}
+
+	var resultSlice []*Permission
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Permission")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for permission")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for permission")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.PermissionRef = foreign
+		if foreign.R == nil {
+			foreign.R = &permissionR{}
+		}
+		foreign.R.PermissionRefRolePermissions = append(foreign.R.PermissionRefRolePermissions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.PermissionRefID == foreign.ID {
+				local.R.PermissionRef = foreign
+				if foreign.R == nil {
+					foreign.R = &permissionR{}
+				}
+				foreign.R.PermissionRefRolePermissions = append(foreign.R.PermissionRefRolePermissions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRoleRef allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (rolePermissionL) LoadRoleRef(ctx context.Context, e boil.ContextExecutor, singular bool, maybeRolePermission interface{}, mods queries.Applicator) error {
+	var slice []*RolePermission
+	var object *RolePermission
+
+	if singular {
+		var ok bool
+		object, ok = maybeRolePermission.(*RolePermission)
+		if !ok {
+			object = new(RolePermission)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeRolePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeRolePermission))
+			}
+		}
+	} else {
+		s, ok := maybeRolePermission.(*[]*RolePermission)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeRolePermission)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeRolePermission))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &rolePermissionR{}
+		}
+		args[object.RoleRefID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &rolePermissionR{}
+			}
+
+			args[obj.RoleRefID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`category`),
+		qm.WhereIn(`category.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Category")
+	}
+
+	var resultSlice []*Category
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Category")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for category")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for category")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.RoleRef = foreign
+		if foreign.R == nil {
+			foreign.R = &categoryR{}
+		}
+		foreign.R.RoleRefRolePermissions = append(foreign.R.RoleRefRolePermissions, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.RoleRefID == foreign.ID {
+				local.R.RoleRef = foreign
+				if foreign.R == nil {
+					foreign.R = &categoryR{}
+				}
+				foreign.R.RoleRefRolePermissions = append(foreign.R.RoleRefRolePermissions, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetPermissionRef of the rolePermission to the related item.
+// Sets o.R.PermissionRef to related.
+// Adds o to related.R.PermissionRefRolePermissions.
+func (o *RolePermission) SetPermissionRef(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Permission) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `role_permission` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"permission_ref_id"}),
+		strmangle.WhereClause("`", "`", 0, rolePermissionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.PermissionRefID = related.ID
+	if o.R == nil {
+		o.R = &rolePermissionR{
+			PermissionRef: related,
+		}
+	} else {
+		o.R.PermissionRef = related
+	}
+
+	if related.R == nil {
+		related.R = &permissionR{
+			PermissionRefRolePermissions: RolePermissionSlice{o},
+		}
+	} else {
+		related.R.PermissionRefRolePermissions = append(related.R.PermissionRefRolePermissions, o)
+	}
+
+	return nil
+}
+
+// SetRoleRef of the rolePermission to the related item.
+// Sets o.R.RoleRef to related.
+// Adds o to related.R.RoleRefRolePermissions.
+func (o *RolePermission) SetRoleRef(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Category) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE `role_permission` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, []string{"role_ref_id"}),
+		strmangle.WhereClause("`", "`", 0, rolePermissionPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.RoleRefID = related.ID
+	if o.R == nil {
+		o.R = &rolePermissionR{
+			RoleRef: related,
+		}
+	} else {
+		o.R.RoleRef = related
+	}
+
+	if related.R == nil {
+		related.R = &categoryR{
+			RoleRefRolePermissions: RolePermissionSlice{o},
+		}
+	} else {
+		related.R.RoleRefRolePermissions = append(related.R.RoleRefRolePermissions, o)
+	}
+
+	return nil
+}
+
+// RolePermissions retrieves all the records using an executor.
+func RolePermissions(mods ...qm.QueryMod) rolePermissionQuery {
+	mods = append(mods, qm.From("`role_permission`"))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"`role_permission`.*"})
+	}
+
+	return rolePermissionQuery{q}
+}
+
+// FindRolePermission retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindRolePermission(ctx context.Context, exec boil.ContextExecutor, iD int, selectCols ...string) (*RolePermission, error) {
+	rolePermissionObj := &RolePermission{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from `role_permission` where `id`=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, rolePermissionObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "mysqlmodel: unable to select from role_permission")
+	}
+
+	return rolePermissionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *RolePermission) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("mysqlmodel: no role_permission provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(rolePermissionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	rolePermissionInsertCacheMut.RLock()
+	cache, cached := rolePermissionInsertCache[key]
+	rolePermissionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			rolePermissionAllColumns,
+			rolePermissionColumnsWithDefault,
+			rolePermissionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(rolePermissionType, rolePermissionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(rolePermissionType, rolePermissionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO `role_permission` (`%s`) %%sVALUES (%s)%%s", strings.Join(wl, "`,`"), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO `role_permission` () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT `%s` FROM `role_permission` WHERE %s", strings.Join(returnColumns, "`,`"), strmangle.WhereClause("`", "`", 0, rolePermissionPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to insert into role_permission")
+	}
+
+	var lastID int64
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == rolePermissionMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, identifierCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to populate default values for role_permission")
+	}
+
+CacheNoHooks:
+	if !cached {
+		rolePermissionInsertCacheMut.Lock()
+		rolePermissionInsertCache[key] = cache
+		rolePermissionInsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Update uses an executor to update the RolePermission.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *RolePermission) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	key := makeCacheKey(columns, nil)
+	rolePermissionUpdateCacheMut.RLock()
+	cache, cached := rolePermissionUpdateCache[key]
+	rolePermissionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			rolePermissionAllColumns,
+			rolePermissionPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("mysqlmodel: unable to update role_permission, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE `role_permission` SET %s WHERE %s",
+			strmangle.SetParamNames("`", "`", 0, wl),
+			strmangle.WhereClause("`", "`", 0, rolePermissionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(rolePermissionType, rolePermissionMapping, append(wl, rolePermissionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update role_permission row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by update for role_permission")
+	}
+
+	if !cached {
+		rolePermissionUpdateCacheMut.Lock()
+		rolePermissionUpdateCache[key] = cache
+		rolePermissionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q rolePermissionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update all for role_permission")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to retrieve rows affected for role_permission")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o RolePermissionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("mysqlmodel: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), rolePermissionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE `role_permission` SET %s WHERE %s",
+		strmangle.SetParamNames("`", "`", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, rolePermissionPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to update all in rolePermission slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to retrieve rows affected all in update all rolePermission")
+	}
+	return rowsAff, nil
+}
+
+var mySQLRolePermissionUniqueColumns = []string{
+	"id",
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *RolePermission) Upsert(ctx context.Context, exec boil.ContextExecutor, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("mysqlmodel: no role_permission provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(rolePermissionColumnsWithDefault, o)
+	nzUniques := queries.NonZeroDefaultSet(mySQLRolePermissionUniqueColumns, o)
+
+	if len(nzUniques) == 0 {
+		return errors.New("cannot upsert with a table that cannot conflict on a unique column")
+	}
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzUniques {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	rolePermissionUpsertCacheMut.RLock()
+	cache, cached := rolePermissionUpsertCache[key]
+	rolePermissionUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			rolePermissionAllColumns,
+			rolePermissionColumnsWithDefault,
+			rolePermissionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			rolePermissionAllColumns,
+			rolePermissionPrimaryKeyColumns,
+		)
+
+		if !updateColumns.IsNone() && len(update) == 0 {
+			return errors.New("mysqlmodel: unable to upsert role_permission, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(rolePermissionAllColumns, strmangle.SetIntersect(insert, update))
+
+		cache.query = buildUpsertQueryMySQL(dialect, "`role_permission`", update, insert)
+		cache.retQuery = fmt.Sprintf(
+			"SELECT %s FROM `role_permission` WHERE %s",
+			strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, ret), ","),
+			strmangle.WhereClause("`", "`", 0, nzUniques),
+		)
+
+		cache.valueMapping, err = queries.BindMapping(rolePermissionType, rolePermissionMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(rolePermissionType, rolePermissionMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	result, err := exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to upsert for role_permission")
+	}
+
+	var lastID int64
+	var uniqueMap []uint64
+	var nzUniqueCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	lastID, err = result.LastInsertId()
+	if err != nil {
+		return ErrSyncFail
+	}
+
+	o.ID = int(lastID)
+	if lastID != 0 && len(cache.retMapping) == 1 && cache.retMapping[0] == rolePermissionMapping["id"] {
+		goto CacheNoHooks
+	}
+
+	uniqueMap, err = queries.BindMapping(rolePermissionType, rolePermissionMapping, nzUniques)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to retrieve unique values for role_permission")
+	}
+	nzUniqueCols = queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), uniqueMap)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.retQuery)
+		fmt.Fprintln(writer, nzUniqueCols...)
+	}
+	err = exec.QueryRowContext(ctx, cache.retQuery, nzUniqueCols...).Scan(returns...)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to populate default values for role_permission")
+	}
+
+CacheNoHooks:
+	if !cached {
+		rolePermissionUpsertCacheMut.Lock()
+		rolePermissionUpsertCache[key] = cache
+		rolePermissionUpsertCacheMut.Unlock()
+	}
+
+	return nil
+}
+
+// Delete deletes a single RolePermission record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *RolePermission) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("mysqlmodel: no RolePermission provided for delete")
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), rolePermissionPrimaryKeyMapping)
+	sql := "DELETE FROM `role_permission` WHERE `id`=?"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete from role_permission")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by delete for role_permission")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q rolePermissionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("mysqlmodel: no rolePermissionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete all from role_permission")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by deleteall for role_permission")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o RolePermissionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), rolePermissionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM `role_permission` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, rolePermissionPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: unable to delete all from rolePermission slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "mysqlmodel: failed to get rows affected by deleteall for role_permission")
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *RolePermission) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindRolePermission(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *RolePermissionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := RolePermissionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), rolePermissionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT `role_permission`.* FROM `role_permission` WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, rolePermissionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "mysqlmodel: unable to reload all in RolePermissionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// RolePermissionExists checks if the RolePermission row exists.
+func RolePermissionExists(ctx context.Context, exec boil.ContextExecutor, iD int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from `role_permission` where `id`=? limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "mysqlmodel: unable to check if role_permission exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the RolePermission row exists.
+func (o *RolePermission) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return RolePermissionExists(ctx, exec, o.ID)
+}
@@ -24,135 +24,142 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	ID                int         `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Firstname         string      `boil:"firstname" json:"firstname" toml:"firstname" yaml:"firstname"`
-	Lastname          string      `boil:"lastname" json:"lastname" toml:"lastname" yaml:"lastname"`
-	Email             string      `boil:"email" json:"email" toml:"email" yaml:"email"`
-	Password          string      `boil:"password" json:"password" toml:"password" yaml:"password"`
-	OrganizationRefID null.Int    `boil:"organization_ref_id" json:"organization_ref_id,omitempty" toml:"organization_ref_id" yaml:"organization_ref_id,omitempty"`
-	CategoryTypeRefID int         `boil:"category_type_ref_id" json:"category_type_ref_id" toml:"category_type_ref_id" yaml:"category_type_ref_id"`
-	CreatedBy         null.Int    `boil:"created_by" json:"created_by,omitempty" toml:"created_by" yaml:"created_by,omitempty"`
-	LastUpdatedBy     null.Int    `boil:"last_updated_by" json:"last_updated_by,omitempty" toml:"last_updated_by" yaml:"last_updated_by,omitempty"`
-	CreatedAt         time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	LastUpdatedAt     null.Time   `boil:"last_updated_at" json:"last_updated_at,omitempty" toml:"last_updated_at" yaml:"last_updated_at,omitempty"`
-	IsActive          bool        `boil:"is_active" json:"is_active" toml:"is_active" yaml:"is_active"`
-	ResetToken        null.String `boil:"reset_token" json:"reset_token,omitempty" toml:"reset_token" yaml:"reset_token,omitempty"`
-	Address           null.String `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
-	Birthday          null.Time   `boil:"birthday" json:"birthday,omitempty" toml:"birthday" yaml:"birthday,omitempty"`
-	Gender            null.String `boil:"gender" json:"gender,omitempty" toml:"gender" yaml:"gender,omitempty"`
+	ID                  int         `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Firstname           string      `boil:"firstname" json:"firstname" toml:"firstname" yaml:"firstname"`
+	Lastname            string      `boil:"lastname" json:"lastname" toml:"lastname" yaml:"lastname"`
+	Email               string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	Password            string      `boil:"password" json:"password" toml:"password" yaml:"password"`
+	OrganizationRefID   null.Int    `boil:"organization_ref_id" json:"organization_ref_id,omitempty" toml:"organization_ref_id" yaml:"organization_ref_id,omitempty"`
+	CategoryTypeRefID   int         `boil:"category_type_ref_id" json:"category_type_ref_id" toml:"category_type_ref_id" yaml:"category_type_ref_id"`
+	CreatedBy           null.Int    `boil:"created_by" json:"created_by,omitempty" toml:"created_by" yaml:"created_by,omitempty"`
+	LastUpdatedBy       null.Int    `boil:"last_updated_by" json:"last_updated_by,omitempty" toml:"last_updated_by" yaml:"last_updated_by,omitempty"`
+	CreatedAt           time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	LastUpdatedAt       null.Time   `boil:"last_updated_at" json:"last_updated_at,omitempty" toml:"last_updated_at" yaml:"last_updated_at,omitempty"`
+	IsActive            bool        `boil:"is_active" json:"is_active" toml:"is_active" yaml:"is_active"`
+	ResetToken          null.String `boil:"reset_token" json:"reset_token,omitempty" toml:"reset_token" yaml:"reset_token,omitempty"`
+	ResetTokenExpiresAt null.Time   `boil:"reset_token_expires_at" json:"reset_token_expires_at,omitempty" toml:"reset_token_expires_at" yaml:"reset_token_expires_at,omitempty"`
+	Address             null.String `boil:"address" json:"address,omitempty" toml:"address" yaml:"address,omitempty"`
+	Birthday            null.Time   `boil:"birthday" json:"birthday,omitempty" toml:"birthday" yaml:"birthday,omitempty"`
+	Gender              null.String `boil:"gender" json:"gender,omitempty" toml:"gender" yaml:"gender,omitempty"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var UserColumns = struct {
-	ID                string
-	Firstname         string
-	Lastname          string
-	Email             string
-	Password          string
-	OrganizationRefID string
-	CategoryTypeRefID string
-	CreatedBy         string
-	LastUpdatedBy     string
-	CreatedAt         string
-	LastUpdatedAt     string
-	IsActive          string
-	ResetToken        string
-	Address           string
-	Birthday          string
-	Gender            string
+	ID                  string
+	Firstname           string
+	Lastname            string
+	Email               string
+	Password            string
+	OrganizationRefID   string
+	CategoryTypeRefID   string
+	CreatedBy           string
+	LastUpdatedBy       string
+	CreatedAt           string
+	LastUpdatedAt       string
+	IsActive            string
+	ResetToken          string
+	ResetTokenExpiresAt string
+	Address             string
+	Birthday            string
+	Gender              string
 }{
-	ID:                "id",
-	Firstname:         "firstname",
-	Lastname:          "lastname",
-	Email:             "email",
-	Password:          "password",
-	OrganizationRefID: "organization_ref_id",
-	CategoryTypeRefID: "category_type_ref_id",
-	CreatedBy:         "created_by",
-	LastUpdatedBy:     "last_updated_by",
-	CreatedAt:         "created_at",
-	LastUpdatedAt:     "last_updated_at",
-	IsActive:          "is_active",
-	ResetToken:        "reset_token",
-	Address:           "address",
-	Birthday:          "birthday",
-	Gender:            "gender",
+	ID:                  "id",
+	Firstname:           "firstname",
+	Lastname:            "lastname",
+	Email:               "email",
+	Password:            "password",
+	OrganizationRefID:   "organization_ref_id",
+	CategoryTypeRefID:   "category_type_ref_id",
+	CreatedBy:           "created_by",
+	LastUpdatedBy:       "last_updated_by",
+	CreatedAt:           "created_at",
+	LastUpdatedAt:       "last_updated_at",
+	IsActive:            "is_active",
+	ResetToken:          "reset_token",
+	ResetTokenExpiresAt: "reset_token_expires_at",
+	Address:             "address",
+	Birthday:            "birthday",
+	Gender:              "gender",
 }
 
 var UserTableColumns = struct {
-	ID                string
-	Firstname         string
-	Lastname          string
-	Email             string
-	Password          string
-	OrganizationRefID string
-	CategoryTypeRefID string
-	CreatedBy         string
-	LastUpdatedBy     string
-	CreatedAt         string
-	LastUpdatedAt     string
-	IsActive          string
-	ResetToken        string
-	Address           string
-	Birthday          string
-	Gender            string
+	ID                  string
+	Firstname           string
+	Lastname            string
+	Email               string
+	Password            string
+	OrganizationRefID   string
+	CategoryTypeRefID   string
+	CreatedBy           string
+	LastUpdatedBy       string
+	CreatedAt           string
+	LastUpdatedAt       string
+	IsActive            string
+	ResetToken          string
+	ResetTokenExpiresAt string
+	Address             string
+	Birthday            string
+	Gender              string
 }{
-	ID:                "user.id",
-	Firstname:         "user.firstname",
-	Lastname:          "user.lastname",
-	Email:             "user.email",
-	Password:          "user.password",
-	OrganizationRefID: "user.organization_ref_id",
-	CategoryTypeRefID: "user.category_type_ref_id",
-	CreatedBy:         "user.created_by",
-	LastUpdatedBy:     "user.last_updated_by",
-	CreatedAt:         "user.created_at",
-	LastUpdatedAt:     "user.last_updated_at",
-	IsActive:          "user.is_active",
-	ResetToken:        "user.reset_token",
-	Address:           "user.address",
-	Birthday:          "user.birthday",
-	Gender:            "user.gender",
+	ID:                  "user.id",
+	Firstname:           "user.firstname",
+	Lastname:            "user.lastname",
+	Email:               "user.email",
+	Password:            "user.password",
+	OrganizationRefID:   "user.organization_ref_id",
+	CategoryTypeRefID:   "user.category_type_ref_id",
+	CreatedBy:           "user.created_by",
+	LastUpdatedBy:       "user.last_updated_by",
+	CreatedAt:           "user.created_at",
+	LastUpdatedAt:       "user.last_updated_at",
+	IsActive:            "user.is_active",
+	ResetToken:          "user.reset_token",
+	ResetTokenExpiresAt: "user.reset_token_expires_at",
+	Address:             "user.address",
+	Birthday:            "user.birthday",
+	Gender:              "user.gender",
 }
 
 // Generated where
 
 var UserWhere = struct {
-	ID                whereHelperint
-	Firstname         whereHelperstring
-	Lastname          whereHelperstring
-	Email             whereHelperstring
-	Password          whereHelperstring
-	OrganizationRefID whereHelpernull_Int
-	CategoryTypeRefID whereHelperint
-	CreatedBy         whereHelpernull_Int
-	LastUpdatedBy     whereHelpernull_Int
-	CreatedAt         whereHelpertime_Time
-	LastUpdatedAt     whereHelpernull_Time
-	IsActive          whereHelperbool
-	ResetToken        whereHelpernull_String
-	Address           whereHelpernull_String
-	Birthday          whereHelpernull_Time
-	Gender            whereHelpernull_String
+	ID                  whereHelperint
+	Firstname           whereHelperstring
+	Lastname            whereHelperstring
+	Email               whereHelperstring
+	Password            whereHelperstring
+	OrganizationRefID   whereHelpernull_Int
+	CategoryTypeRefID   whereHelperint
+	CreatedBy           whereHelpernull_Int
+	LastUpdatedBy       whereHelpernull_Int
+	CreatedAt           whereHelpertime_Time
+	LastUpdatedAt       whereHelpernull_Time
+	IsActive            whereHelperbool
+	ResetToken          whereHelpernull_String
+	ResetTokenExpiresAt whereHelpernull_Time
+	Address             whereHelpernull_String
+	Birthday            whereHelpernull_Time
+	Gender              whereHelpernull_String
 }{
-	ID:                whereHelperint{field: "`user`.`id`"},
-	Firstname:         whereHelperstring{field: "`user`.`firstname`"},
-	Lastname:          whereHelperstring{field: "`user`.`lastname`"},
-	Email:             whereHelperstring{field: "`user`.`email`"},
-	Password:          whereHelperstring{field: "`user`.`password`"},
-	OrganizationRefID: whereHelpernull_Int{field: "`user`.`organization_ref_id`"},
-	CategoryTypeRefID: whereHelperint{field: "`user`.`category_type_ref_id`"},
-	CreatedBy:         whereHelpernull_Int{field: "`user`.`created_by`"},
-	LastUpdatedBy:     whereHelpernull_Int{field: "`user`.`last_updated_by`"},
-	CreatedAt:         whereHelpertime_Time{field: "`user`.`created_at`"},
-	LastUpdatedAt:     whereHelpernull_Time{field: "`user`.`last_updated_at`"},
-	IsActive:          whereHelperbool{field: "`user`.`is_active`"},
-	ResetToken:        whereHelpernull_String{field: "`user`.`reset_token`"},
-	Address:           whereHelpernull_String{field: "`user`.`address`"},
-	Birthday:          whereHelpernull_Time{field: "`user`.`birthday`"},
-	Gender:            whereHelpernull_String{field: "`user`.`gender`"},
+	ID:                  whereHelperint{field: "`user`.`id`"},
+	Firstname:           whereHelperstring{field: "`user`.`firstname`"},
+	Lastname:            whereHelperstring{field: "`user`.`lastname`"},
+	Email:               whereHelperstring{field: "`user`.`email`"},
+	Password:            whereHelperstring{field: "`user`.`password`"},
+	OrganizationRefID:   whereHelpernull_Int{field: "`user`.`organization_ref_id`"},
+	CategoryTypeRefID:   whereHelperint{field: "`user`.`category_type_ref_id`"},
+	CreatedBy:           whereHelpernull_Int{field: "`user`.`created_by`"},
+	LastUpdatedBy:       whereHelpernull_Int{field: "`user`.`last_updated_by`"},
+	CreatedAt:           whereHelpertime_Time{field: "`user`.`created_at`"},
+	LastUpdatedAt:       whereHelpernull_Time{field: "`user`.`last_updated_at`"},
+	IsActive:            whereHelperbool{field: "`user`.`is_active`"},
+	ResetToken:          whereHelpernull_String{field: "`user`.`reset_token`"},
+	ResetTokenExpiresAt: whereHelpernull_Time{field: "`user`.`reset_token_expires_at`"},
+	Address:             whereHelpernull_String{field: "`user`.`address`"},
+	Birthday:            whereHelpernull_Time{field: "`user`.`birthday`"},
+	Gender:              whereHelpernull_String{field: "`user`.`gender`"},
 }
 
 // UserRels is where relationship names are stored.
@@ -161,6 +168,7 @@ var UserRels = struct {
 	CreatedByUser                 string
 	LastUpdatedByUser             string
 	OrganizationRef               string
+	UserRefAPIKeys                string
 	CreatedByCapturePages         string
 	LastUpdatedByCapturePages     string
 	CreatedByCapturePageSets      string
@@ -172,6 +180,7 @@ var UserRels = struct {
 	CreatedByClickTrackerSets     string
 	LastUpdatedByClickTrackerSets string
 	CreatedByOrganizations        string
+	UserRefRefreshTokens          string
 	CreatedByUsers                string
 	LastUpdatedByUsers            string
 }{
@@ -179,6 +188,7 @@ var UserRels = struct {
 	CreatedByUser:                 "CreatedByUser",
 	LastUpdatedByUser:             "LastUpdatedByUser",
 	OrganizationRef:               "OrganizationRef",
+	UserRefAPIKeys:                "UserRefAPIKeys",
 	CreatedByCapturePages:         "CreatedByCapturePages",
 	LastUpdatedByCapturePages:     "LastUpdatedByCapturePages",
 	CreatedByCapturePageSets:      "CreatedByCapturePageSets",
@@ -190,6 +200,7 @@ var UserRels = struct {
 	CreatedByClickTrackerSets:     "CreatedByClickTrackerSets",
 	LastUpdatedByClickTrackerSets: "LastUpdatedByClickTrackerSets",
 	CreatedByOrganizations:        "CreatedByOrganizations",
+	UserRefRefreshTokens:          "UserRefRefreshTokens",
 	CreatedByUsers:                "CreatedByUsers",
 	LastUpdatedByUsers:            "LastUpdatedByUsers",
 }
@@ -200,6 +211,7 @@ type userR struct {
 	CreatedByUser                 *User                `boil:"CreatedByUser" json:"CreatedByUser" toml:"CreatedByUser" yaml:"CreatedByUser"`
 	LastUpdatedByUser             *User                `boil:"LastUpdatedByUser" json:"LastUpdatedByUser" toml:"LastUpdatedByUser" yaml:"LastUpdatedByUser"`
 	OrganizationRef               *Organization        `boil:"OrganizationRef" json:"OrganizationRef" toml:"OrganizationRef" yaml:"OrganizationRef"`
+	UserRefAPIKeys                APIKeySlice          `boil:"UserRefAPIKeys" json:"UserRefAPIKeys" toml:"UserRefAPIKeys" yaml:"UserRefAPIKeys"`
 	CreatedByCapturePages         CapturePageSlice     `boil:"CreatedByCapturePages" json:"CreatedByCapturePages" toml:"CreatedByCapturePages" yaml:"CreatedByCapturePages"`
 	LastUpdatedByCapturePages     CapturePageSlice     `boil:"LastUpdatedByCapturePages" json:"LastUpdatedByCapturePages" toml:"LastUpdatedByCapturePages" yaml:"LastUpdatedByCapturePages"`
 	CreatedByCapturePageSets      CapturePageSetSlice  `boil:"CreatedByCapturePageSets" json:"CreatedByCapturePageSets" toml:"CreatedByCapturePageSets" yaml:"CreatedByCapturePageSets"`
@@ -211,6 +223,7 @@ type userR struct {
 	CreatedByClickTrackerSets     ClickTrackerSetSlice `boil:"CreatedByClickTrackerSets" json:"CreatedByClickTrackerSets" toml:"CreatedByClickTrackerSets" yaml:"CreatedByClickTrackerSets"`
 	LastUpdatedByClickTrackerSets ClickTrackerSetSlice `boil:"LastUpdatedByClickTrackerSets" json:"LastUpdatedByClickTrackerSets" toml:"LastUpdatedByClickTrackerSets" yaml:"LastUpdatedByClickTrackerSets"`
 	CreatedByOrganizations        OrganizationSlice    `boil:"CreatedByOrganizations" json:"CreatedByOrganizations" toml:"CreatedByOrganizations" yaml:"CreatedByOrganizations"`
+	UserRefRefreshTokens          RefreshTokenSlice    `boil:"UserRefRefreshTokens" json:"UserRefRefreshTokens" toml:"UserRefRefreshTokens" yaml:"UserRefRefreshTokens"`
 	CreatedByUsers                UserSlice            `boil:"CreatedByUsers" json:"CreatedByUsers" toml:"CreatedByUsers" yaml:"CreatedByUsers"`
 	LastUpdatedByUsers            UserSlice            `boil:"LastUpdatedByUsers" json:"LastUpdatedByUsers" toml:"LastUpdatedByUsers" yaml:"LastUpdatedByUsers"`
 }
@@ -248,6 +261,13 @@ func (r *userR) GetOrganizationRef() *Organization {
 	return r.OrganizationRef
 }
 
+func (r *userR) GetUserRefAPIKeys() APIKeySlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserRefAPIKeys
+}
+
 func (r *userR) GetCreatedByCapturePages() CapturePageSlice {
 	if r == nil {
 		return nil
@@ -325,6 +345,13 @@ func (r *userR) GetCreatedByOrganizations() OrganizationSlice {
 	return r.CreatedByOrganizations
 }
 
+func (r *userR) GetUserRefRefreshTokens() RefreshTokenSlice {
+	if r == nil {
+		return nil
+	}
+	return r.UserRefRefreshTokens
+}
+
 func (r *userR) GetCreatedByUsers() UserSlice {
 	if r == nil {
 		return nil
@@ -343,8 +370,8 @@ func (r *userR) GetLastUpdatedByUsers() UserSlice {
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "firstname", "lastname", "email", "password", "organization_ref_id", "category_type_ref_id", "created_by", "last_updated_by", "created_at", "last_updated_at", "is_active", "reset_token", "address", "birthday", "gender"}
-	userColumnsWithoutDefault = []string{"firstname", "lastname", "email", "password", "organization_ref_id", "category_type_ref_id", "created_by", "last_updated_by", "last_updated_at", "reset_token", "address", "birthday", "gender"}
+	userAllColumns            = []string{"id", "firstname", "lastname", "email", "password", "organization_ref_id", "category_type_ref_id", "created_by", "last_updated_by", "created_at", "last_updated_at", "is_active", "reset_token", "reset_token_expires_at", "address", "birthday", "gender"}
+	userColumnsWithoutDefault = []string{"firstname", "lastname", "email", "password", "organization_ref_id", "category_type_ref_id", "created_by", "last_updated_by", "last_updated_at", "reset_token", "reset_token_expires_at", "address", "birthday", "gender"}
 	userColumnsWithDefault    = []string{"id", "created_at", "is_active"}
 	userPrimaryKeyColumns     = []string{"id"}
 	userGeneratedColumns      = []string{}
@@ -485,6 +512,20 @@ func (o *User) OrganizationRef(mods ...qm.QueryMod) organizationQuery {
 	return Organizations(queryMods...)
 }
 
+// UserRefAPIKeys retrieves all the api_key's APIKeys with an executor via user_ref_id column.
+func (o *User) UserRefAPIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`api_key`.`user_ref_id`=?", o.ID),
+	)
+
+	return APIKeys(queryMods...)
+}
+
 // CreatedByCapturePages retrieves all the capture_page's CapturePages with an executor via created_by column.
 func (o *User) CreatedByCapturePages(mods ...qm.QueryMod) capturePageQuery {
 	var queryMods []qm.QueryMod
@@ -639,6 +680,20 @@ func (o *User) CreatedByOrganizations(mods ...qm.QueryMod) organizationQuery {
 	return Organizations(queryMods...)
 }
 
+// UserRefRefreshTokens retrieves all the refresh_token's RefreshTokens with an executor via user_ref_id column.
+func (o *User) UserRefRefreshTokens(mods ...qm.QueryMod) refreshTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("`refresh_token`.`user_ref_id`=?", o.ID),
+	)
+
+	return RefreshTokens(queryMods...)
+}
+
 // CreatedByUsers retrieves all the user's Users with an executor via created_by column.
 func (o *User) CreatedByUsers(mods ...qm.QueryMod) userQuery {
 	var queryMods []qm.QueryMod
@@ -1127,6 +1182,112 @@ func (userL) LoadOrganizationRef(ctx context.Context, e boil.ContextExecutor, si
 	return nil
 }
 
+// LoadUserRefAPIKeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadUserRefAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`api_key`),
+		qm.WhereIn(`api_key.user_ref_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load api_key")
+	}
+
+	var resultSlice []*APIKey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice api_key")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on api_key")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_key")
+	}
+
+	if singular {
+		object.R.UserRefAPIKeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &apiKeyR{}
+			}
+			foreign.R.UserRef = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserRefID {
+				local.R.UserRefAPIKeys = append(local.R.UserRefAPIKeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &apiKeyR{}
+				}
+				foreign.R.UserRef = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadCreatedByCapturePages allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (userL) LoadCreatedByCapturePages(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
@@ -2293,6 +2454,112 @@ func (userL) LoadCreatedByOrganizations(ctx context.Context, e boil.ContextExecu
 	return nil
 }
 
+// LoadUserRefRefreshTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadUserRefRefreshTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		var ok bool
+		object, ok = maybeUser.(*User)
+		if !ok {
+			object = new(User)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeUser))
+			}
+		}
+	} else {
+		s, ok := maybeUser.(*[]*User)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeUser)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeUser))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`refresh_token`),
+		qm.WhereIn(`refresh_token.user_ref_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load refresh_token")
+	}
+
+	var resultSlice []*RefreshToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice refresh_token")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on refresh_token")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for refresh_token")
+	}
+
+	if singular {
+		object.R.UserRefRefreshTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &refreshTokenR{}
+			}
+			foreign.R.UserRef = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserRefID {
+				local.R.UserRefRefreshTokens = append(local.R.UserRefRefreshTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &refreshTokenR{}
+				}
+				foreign.R.UserRef = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadCreatedByUsers allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (userL) LoadCreatedByUsers(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
@@ -2792,6 +3059,59 @@ func (o *User) RemoveOrganizationRef(ctx context.Context, exec boil.ContextExecu
 	return nil
 }
 
+// AddUserRefAPIKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.UserRefAPIKeys.
+// Sets related.R.UserRef appropriately.
+func (o *User) AddUserRefAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserRefID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `api_key` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"user_ref_id"}),
+				strmangle.WhereClause("`", "`", 0, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserRefID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			UserRefAPIKeys: related,
+		}
+	} else {
+		o.R.UserRefAPIKeys = append(o.R.UserRefAPIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				UserRef: o,
+			}
+		} else {
+			rel.R.UserRef = o
+		}
+	}
+	return nil
+}
+
 // AddCreatedByCapturePages adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
 // Appends related to o.R.CreatedByCapturePages.
@@ -4189,6 +4509,59 @@ func (o *User) RemoveCreatedByOrganizations(ctx context.Context, exec boil.Conte
 	return nil
 }
 
+// AddUserRefRefreshTokens adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.UserRefRefreshTokens.
+// Sets related.R.UserRef appropriately.
+func (o *User) AddUserRefRefreshTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*RefreshToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserRefID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE `refresh_token` SET %s WHERE %s",
+				strmangle.SetParamNames("`", "`", 0, []string{"user_ref_id"}),
+				strmangle.WhereClause("`", "`", 0, refreshTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserRefID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			UserRefRefreshTokens: related,
+		}
+	} else {
+		o.R.UserRefRefreshTokens = append(o.R.UserRefRefreshTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &refreshTokenR{
+				UserRef: o,
+			}
+		} else {
+			rel.R.UserRef = o
+		}
+	}
+	return nil
+}
+
 // AddCreatedByUsers adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
 // Appends related to o.R.CreatedByUsers.
@@ -4717,6 +5090,7 @@ func (o UserSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, col
 var mySQLUserUniqueColumns = []string{
 	"id",
 	"email",
+	"reset_token",
 }
 
 // Upsert attempts an insert using an executor, and does an update or ignore on conflict.
@@ -68,3 +68,17 @@ func NewDbClient(ctx context.Context, o *ClientOptions) (*sqlx.DB, error) {
 	}
 	return db, nil
 }
+
+// Ping connects once, without the retries of NewDbClient, and closes the connection.
+func Ping(ctx context.Context, connString string) error {
+	db, err := sqlx.Open("mysql", connString)
+	if err != nil {
+		return fmt.Errorf("open: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping: %v", err)
+	}
+	return nil
+}
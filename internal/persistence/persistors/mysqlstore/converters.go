@@ -3,6 +3,7 @@ package mysqlstore
 import (
 	"github.com/dembygenesis/local.tools/internal/model"
 	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"strings"
 )
 
 // ConvertMysqlModelToCategory converts a mysql model category to a model category.
@@ -16,3 +17,89 @@ func ConvertMysqlModelToCategory(category *mysqlmodel.Category) *model.Category
 		Name:              category.Name,
 	}
 }
+
+// ConvertMysqlModelToUser converts a mysql model user to a model user.
+func ConvertMysqlModelToUser(user *mysqlmodel.User) *model.User {
+	if user == nil {
+		return nil
+	}
+	return &model.User{
+		Id:                  user.ID,
+		Firstname:           user.Firstname,
+		Lastname:            user.Lastname,
+		Email:               user.Email,
+		Password:            user.Password,
+		CategoryTypeRefId:   user.CategoryTypeRefID,
+		CreatedBy:           user.CreatedBy,
+		LastUpdatedById:     user.LastUpdatedBy,
+		CreatedAt:           user.CreatedAt,
+		LastUpdatedAt:       user.LastUpdatedAt,
+		IsActive:            user.IsActive,
+		ResetToken:          user.ResetToken,
+		ResetTokenExpiresAt: user.ResetTokenExpiresAt,
+		Address:             user.Address,
+		Birthday:            user.Birthday,
+		Gender:              user.Gender,
+	}
+}
+
+// ConvertMysqlModelToRefreshToken converts a mysql model refresh token to a model refresh token.
+func ConvertMysqlModelToRefreshToken(token *mysqlmodel.RefreshToken) *model.RefreshToken {
+	if token == nil {
+		return nil
+	}
+	return &model.RefreshToken{
+		Id:        token.ID,
+		UserRefId: token.UserRefID,
+		Family:    token.Family,
+		TokenHash: token.TokenHash,
+		CreatedAt: token.CreatedAt,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+	}
+}
+
+// ConvertMysqlModelToPermission converts a mysql model permission to a model permission.
+func ConvertMysqlModelToPermission(permission *mysqlmodel.Permission) *model.Permission {
+	if permission == nil {
+		return nil
+	}
+	return &model.Permission{
+		Id:          permission.ID,
+		Name:        permission.Name,
+		Description: permission.Description,
+	}
+}
+
+// ConvertMysqlModelToAPIKey converts a mysql model API key to a model API key.
+func ConvertMysqlModelToAPIKey(key *mysqlmodel.APIKey) *model.APIKey {
+	if key == nil {
+		return nil
+	}
+	return &model.APIKey{
+		Id:                key.ID,
+		UserRefId:         key.UserRefID,
+		OrganizationRefId: key.OrganizationRefID,
+		Name:              key.Name,
+		Prefix:            key.Prefix,
+		KeyHash:           key.KeyHash,
+		Scopes:            strings.Fields(key.Scopes),
+		ExpiresAt:         key.ExpiresAt,
+		LastUsedAt:        key.LastUsedAt,
+		CreatedAt:         key.CreatedAt,
+		RevokedAt:         key.RevokedAt,
+	}
+}
+
+// ConvertMysqlModelToOrganization converts a mysql model organization to a model organization.
+func ConvertMysqlModelToOrganization(organization *mysqlmodel.Organization) *model.Organization {
+	if organization == nil {
+		return nil
+	}
+	return &model.Organization{
+		Id:        organization.ID,
+		Name:      organization.Name,
+		CreatedBy: organization.CreatedBy,
+		IsActive:  organization.IsActive,
+	}
+}
@@ -0,0 +1,151 @@
+package mysqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"strings"
+	"time"
+)
+
+// CreateAPIKey stores a new API key.
+func (m *Repository) CreateAPIKey(ctx context.Context, tx persistence.TransactionHandler, key *model.APIKey) (*model.APIKey, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	entry := &mysqlmodel.APIKey{
+		UserRefID:         key.UserRefId,
+		OrganizationRefID: key.OrganizationRefId,
+		Name:              key.Name,
+		Prefix:            key.Prefix,
+		KeyHash:           key.KeyHash,
+		Scopes:            strings.Join(key.Scopes, " "),
+		ExpiresAt:         key.ExpiresAt,
+	}
+	if err = entry.Insert(ctx, ctxExec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("insert api key: %v", err)
+	}
+
+	return ConvertMysqlModelToAPIKey(entry), nil
+}
+
+// GetAPIKeyByHash fetches an API key, revoked or not, by its hash.
+func (m *Repository) GetAPIKeyByHash(ctx context.Context, tx persistence.TransactionHandler, hash string) (*model.APIKey, error) {
+	return m.getAPIKey(ctx, tx, mysqlmodel.APIKeyWhere.KeyHash.EQ(hash))
+}
+
+// GetAPIKeyById fetches an API key, revoked or not, by id.
+func (m *Repository) GetAPIKeyById(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.APIKey, error) {
+	return m.getAPIKey(ctx, tx, mysqlmodel.APIKeyWhere.ID.EQ(id))
+}
+
+func (m *Repository) getAPIKey(ctx context.Context, tx persistence.TransactionHandler, where qm.QueryMod) (*model.APIKey, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entry, err := mysqlmodel.APIKeys(where).One(ctx, ctxExec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("api key: %w", persistence.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get api key: %v", err)
+	}
+
+	return ConvertMysqlModelToAPIKey(entry), nil
+}
+
+// GetUserAPIKeys fetches the personal API keys of the user, and the keys of
+// the organizations they created, revoked or not, newest first.
+func (m *Repository) GetUserAPIKeys(ctx context.Context, tx persistence.TransactionHandler, userId int) ([]model.APIKey, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entries, err := mysqlmodel.APIKeys(
+		qm.Select(mysqlmodel.TableNames.APIKey+".*"),
+		qm.LeftOuterJoin(fmt.Sprintf(
+			"%s ON %s = %s",
+			mysqlmodel.TableNames.Organization,
+			mysqlmodel.OrganizationTableColumns.ID,
+			mysqlmodel.APIKeyTableColumns.OrganizationRefID,
+		)),
+		qm.Expr(
+			mysqlmodel.APIKeyWhere.UserRefID.EQ(userId),
+			mysqlmodel.APIKeyWhere.OrganizationRefID.IsNull(),
+		),
+		qm.Or2(mysqlmodel.OrganizationWhere.CreatedBy.EQ(null.IntFrom(userId))),
+		qm.OrderBy(mysqlmodel.APIKeyTableColumns.ID+" DESC"),
+	).All(ctx, ctxExec)
+	if err != nil {
+		return nil, fmt.Errorf("get api keys: %v", err)
+	}
+
+	keys := make([]model.APIKey, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, *ConvertMysqlModelToAPIKey(entry))
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key, reporting false when it already was.
+func (m *Repository) RevokeAPIKey(ctx context.Context, tx persistence.TransactionHandler, id int) (bool, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return false, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	affected, err := mysqlmodel.APIKeys(
+		mysqlmodel.APIKeyWhere.ID.EQ(id),
+		mysqlmodel.APIKeyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, ctxExec, mysqlmodel.M{
+		mysqlmodel.APIKeyColumns.RevokedAt: null.TimeFrom(time.Now()),
+	})
+	if err != nil {
+		return false, fmt.Errorf("revoke api key: %v", err)
+	}
+	return affected > 0, nil
+}
+
+// TouchAPIKey records when an API key was last used.
+func (m *Repository) TouchAPIKey(ctx context.Context, tx persistence.TransactionHandler, id int, usedAt time.Time) error {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	_, err = mysqlmodel.APIKeys(mysqlmodel.APIKeyWhere.ID.EQ(id)).UpdateAll(ctx, ctxExec, mysqlmodel.M{
+		mysqlmodel.APIKeyColumns.LastUsedAt: null.TimeFrom(usedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("touch api key: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package mysqlstore
+
+import (
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"testing"
+	"time"
+)
+
+func TestAPIKey_CreateListRevoke(t *testing.T) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+	defer cleanup()
+
+	txHandlerController, err := mysqltx.New(&mysqltx.Config{
+		Logger:       testLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	txHandler, err := txHandlerController.Db(testCtx)
+	require.NoError(t, err, "unexpected non nil error")
+
+	store, err := New(&Config{
+		Logger:        testLogger,
+		QueryTimeouts: testQueryTimeouts,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	owner, err := store.GetUserByEmail(testCtx, txHandler, "demby@gmail.com")
+	require.NoError(t, err, "unexpected error getting the seeded user")
+	other, err := store.GetUserByEmail(testCtx, txHandler, "demby@yahoo.com")
+	require.NoError(t, err, "unexpected error getting the seeded user")
+
+	_, err = db.Exec("INSERT INTO organization (id, name, created_by) VALUES (1, 'Acme', ?)", owner.Id)
+	require.NoError(t, err, "unexpected error creating an organization")
+
+	organization, err := store.GetOrganizationById(testCtx, txHandler, 1)
+	require.NoError(t, err, "unexpected error getting the organization")
+	assert.Equal(t, &model.Organization{Id: 1, Name: "Acme", CreatedBy: null.IntFrom(owner.Id), IsActive: true}, organization)
+
+	_, err = store.GetOrganizationById(testCtx, txHandler, 999)
+	assert.ErrorIs(t, err, persistence.ErrNotFound)
+
+	create := func(userId int, organizationId null.Int, hash string) *model.APIKey {
+		key, err := store.CreateAPIKey(testCtx, txHandler, &model.APIKey{
+			UserRefId:         userId,
+			OrganizationRefId: organizationId,
+			Name:              "ci",
+			Prefix:            "ltk_abcdefgh",
+			KeyHash:           hash,
+			Scopes:            []string{model.PermissionCategoryRead, model.PermissionCategoryWrite},
+			ExpiresAt:         null.TimeFrom(time.Now().Add(time.Hour)),
+		})
+		require.NoError(t, err, "unexpected error creating an api key")
+		return key
+	}
+	personal := create(owner.Id, null.Int{}, "hash-1")
+	organizationKey := create(other.Id, null.IntFrom(1), "hash-2")
+	otherKey := create(other.Id, null.Int{}, "hash-3")
+
+	got, err := store.GetAPIKeyByHash(testCtx, txHandler, "hash-1")
+	require.NoError(t, err, "unexpected error getting an api key")
+	assert.Equal(t, personal.Id, got.Id)
+	assert.Equal(t, []string{model.PermissionCategoryRead, model.PermissionCategoryWrite}, got.Scopes)
+	assert.False(t, got.CreatedAt.IsZero())
+
+	_, err = store.GetAPIKeyByHash(testCtx, txHandler, "unknown")
+	assert.ErrorIs(t, err, persistence.ErrNotFound)
+
+	keys, err := store.GetUserAPIKeys(testCtx, txHandler, owner.Id)
+	require.NoError(t, err, "unexpected error listing api keys")
+	require.Len(t, keys, 2, "the personal keys, and the keys of the organizations created")
+	assert.Equal(t, organizationKey.Id, keys[0].Id, "newest first")
+	assert.Equal(t, personal.Id, keys[1].Id)
+
+	keys, err = store.GetUserAPIKeys(testCtx, txHandler, other.Id)
+	require.NoError(t, err, "unexpected error listing api keys")
+	require.Len(t, keys, 1, "creating an organization key doesn't make it personal")
+	assert.Equal(t, otherKey.Id, keys[0].Id)
+
+	usedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, store.TouchAPIKey(testCtx, txHandler, personal.Id, usedAt))
+	got, err = store.GetAPIKeyById(testCtx, txHandler, personal.Id)
+	require.NoError(t, err, "unexpected error getting an api key")
+	assert.WithinDuration(t, usedAt, got.LastUsedAt.Time, time.Second)
+
+	revoked, err := store.RevokeAPIKey(testCtx, txHandler, personal.Id)
+	require.NoError(t, err, "unexpected error revoking")
+	assert.True(t, revoked)
+
+	revoked, err = store.RevokeAPIKey(testCtx, txHandler, personal.Id)
+	require.NoError(t, err, "unexpected error revoking")
+	assert.False(t, revoked, "a revoked key can't be revoked again")
+
+	got, err = store.GetAPIKeyById(testCtx, txHandler, personal.Id)
+	require.NoError(t, err, "unexpected error getting an api key")
+	assert.True(t, got.RevokedAt.Valid)
+}
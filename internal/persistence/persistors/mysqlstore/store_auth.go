@@ -0,0 +1,109 @@
+package mysqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"time"
+)
+
+// CreateRefreshToken stores a refresh token by its hash.
+func (m *Repository) CreateRefreshToken(ctx context.Context, tx persistence.TransactionHandler, token *model.RefreshToken) (*model.RefreshToken, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	entry := &mysqlmodel.RefreshToken{
+		UserRefID: token.UserRefId,
+		Family:    token.Family,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+	}
+	if err = entry.Insert(ctx, ctxExec, boil.Infer()); err != nil {
+		return nil, fmt.Errorf("insert refresh token: %v", err)
+	}
+
+	return ConvertMysqlModelToRefreshToken(entry), nil
+}
+
+// GetRefreshTokenByHash fetches a refresh token, revoked or not, by its hash.
+func (m *Repository) GetRefreshTokenByHash(ctx context.Context, tx persistence.TransactionHandler, hash string) (*model.RefreshToken, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entry, err := mysqlmodel.RefreshTokens(mysqlmodel.RefreshTokenWhere.TokenHash.EQ(hash)).One(ctx, ctxExec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("refresh token: %w", persistence.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get refresh token: %v", err)
+	}
+
+	return ConvertMysqlModelToRefreshToken(entry), nil
+}
+
+// RevokeRefreshToken revokes a refresh token, reporting false when it was
+// already revoked, by this or a concurrent request.
+func (m *Repository) RevokeRefreshToken(ctx context.Context, tx persistence.TransactionHandler, id int) (bool, error) {
+	affected, err := m.revokeRefreshTokens(ctx, tx, mysqlmodel.RefreshTokenWhere.ID.EQ(id))
+	if err != nil {
+		return false, fmt.Errorf("revoke refresh token: %v", err)
+	}
+	return affected == 1, nil
+}
+
+// RevokeRefreshTokenFamily revokes every refresh token rotated out of the
+// same login.
+func (m *Repository) RevokeRefreshTokenFamily(ctx context.Context, tx persistence.TransactionHandler, family string) error {
+	if _, err := m.revokeRefreshTokens(ctx, tx, mysqlmodel.RefreshTokenWhere.Family.EQ(family)); err != nil {
+		return fmt.Errorf("revoke refresh token family: %v", err)
+	}
+	return nil
+}
+
+// RevokeUserRefreshTokens revokes every refresh token of the user, ending all
+// of their sessions.
+func (m *Repository) RevokeUserRefreshTokens(ctx context.Context, tx persistence.TransactionHandler, userId int) error {
+	if _, err := m.revokeRefreshTokens(ctx, tx, mysqlmodel.RefreshTokenWhere.UserRefID.EQ(userId)); err != nil {
+		return fmt.Errorf("revoke user refresh tokens: %v", err)
+	}
+	return nil
+}
+
+// revokeRefreshTokens revokes the matching refresh tokens that are not revoked
+// yet, returning how many were.
+func (m *Repository) revokeRefreshTokens(ctx context.Context, tx persistence.TransactionHandler, where ...qm.QueryMod) (int64, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return 0, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	mods := append(where, mysqlmodel.RefreshTokenWhere.RevokedAt.IsNull())
+	affected, err := mysqlmodel.RefreshTokens(mods...).UpdateAll(ctx, ctxExec, mysqlmodel.M{
+		mysqlmodel.RefreshTokenColumns.RevokedAt: null.TimeFrom(time.Now()),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("update: %v", err)
+	}
+	return affected, nil
+}
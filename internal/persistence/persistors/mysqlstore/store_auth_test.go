@@ -0,0 +1,77 @@
+package mysqlstore
+
+import (
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRefreshToken_Revoke(t *testing.T) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+	defer cleanup()
+
+	txHandlerController, err := mysqltx.New(&mysqltx.Config{
+		Logger:       testLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	txHandler, err := txHandlerController.Db(testCtx)
+	require.NoError(t, err, "unexpected non nil error")
+
+	store, err := New(&Config{
+		Logger:        testLogger,
+		QueryTimeouts: testQueryTimeouts,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	user, err := store.GetUserByEmail(testCtx, txHandler, "demby@gmail.com")
+	require.NoError(t, err, "unexpected error getting the seeded user")
+
+	create := func(family, hash string) *model.RefreshToken {
+		token, err := store.CreateRefreshToken(testCtx, txHandler, &model.RefreshToken{
+			UserRefId: user.Id,
+			Family:    family,
+			TokenHash: hash,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err, "unexpected error creating a refresh token")
+		return token
+	}
+	first := create("family-a", "hash-1")
+	second := create("family-a", "hash-2")
+	other := create("family-b", "hash-3")
+
+	revoked, err := store.RevokeRefreshToken(testCtx, txHandler, first.Id)
+	require.NoError(t, err, "unexpected error revoking")
+	assert.True(t, revoked)
+
+	revoked, err = store.RevokeRefreshToken(testCtx, txHandler, first.Id)
+	require.NoError(t, err, "unexpected error revoking")
+	assert.False(t, revoked, "a revoked token can't be revoked again")
+
+	require.NoError(t, store.RevokeRefreshTokenFamily(testCtx, txHandler, "family-a"))
+
+	got, err := store.GetRefreshTokenByHash(testCtx, txHandler, second.TokenHash)
+	require.NoError(t, err, "unexpected error getting a refresh token")
+	assert.True(t, got.RevokedAt.Valid, "the family is revoked")
+
+	got, err = store.GetRefreshTokenByHash(testCtx, txHandler, other.TokenHash)
+	require.NoError(t, err, "unexpected error getting a refresh token")
+	assert.False(t, got.RevokedAt.Valid, "other families are kept")
+
+	require.NoError(t, store.RevokeUserRefreshTokens(testCtx, txHandler, user.Id))
+
+	got, err = store.GetRefreshTokenByHash(testCtx, txHandler, other.TokenHash)
+	require.NoError(t, err, "unexpected error getting a refresh token")
+	assert.True(t, got.RevokedAt.Valid, "every token of the user is revoked")
+
+	_, err = store.GetRefreshTokenByHash(testCtx, txHandler, "unknown")
+	assert.ErrorIs(t, err, persistence.ErrNotFound)
+}
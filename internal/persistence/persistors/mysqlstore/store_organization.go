@@ -0,0 +1,33 @@
+package mysqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+)
+
+// GetOrganizationById fetches an organization, active or not, by id.
+func (m *Repository) GetOrganizationById(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.Organization, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entry, err := mysqlmodel.FindOrganization(ctx, ctxExec, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("organization %d: %w", id, persistence.ErrNotFound)
+		}
+		return nil, fmt.Errorf("find organization: %v", err)
+	}
+
+	return ConvertMysqlModelToOrganization(entry), nil
+}
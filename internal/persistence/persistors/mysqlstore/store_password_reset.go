@@ -0,0 +1,84 @@
+package mysqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/volatiletech/null/v8"
+	"time"
+)
+
+// SetUserResetToken stores the hash of a user's password reset token,
+// replacing any previous one.
+func (m *Repository) SetUserResetToken(ctx context.Context, tx persistence.TransactionHandler, userId int, hash string, expiresAt time.Time) error {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	affected, err := mysqlmodel.Users(mysqlmodel.UserWhere.ID.EQ(userId)).UpdateAll(ctx, ctxExec, mysqlmodel.M{
+		mysqlmodel.UserColumns.ResetToken:          null.StringFrom(hash),
+		mysqlmodel.UserColumns.ResetTokenExpiresAt: null.TimeFrom(expiresAt),
+	})
+	if err != nil {
+		return fmt.Errorf("update reset token: %v", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("user %d: %w", userId, persistence.ErrNotFound)
+	}
+	return nil
+}
+
+// GetUserByResetToken fetches the user holding the reset token hash, expired
+// or not.
+func (m *Repository) GetUserByResetToken(ctx context.Context, tx persistence.TransactionHandler, hash string) (*model.User, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entry, err := mysqlmodel.Users(mysqlmodel.UserWhere.ResetToken.EQ(null.StringFrom(hash))).One(ctx, ctxExec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("reset token: %w", persistence.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get user: %v", err)
+	}
+
+	return ConvertMysqlModelToUser(entry), nil
+}
+
+// ClearUserResetToken removes the user's reset token if it is still the hash,
+// reporting false when it was already used or replaced.
+func (m *Repository) ClearUserResetToken(ctx context.Context, tx persistence.TransactionHandler, userId int, hash string) (bool, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return false, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	affected, err := mysqlmodel.Users(
+		mysqlmodel.UserWhere.ID.EQ(userId),
+		mysqlmodel.UserWhere.ResetToken.EQ(null.StringFrom(hash)),
+	).UpdateAll(ctx, ctxExec, mysqlmodel.M{
+		mysqlmodel.UserColumns.ResetToken:          null.String{},
+		mysqlmodel.UserColumns.ResetTokenExpiresAt: null.Time{},
+	})
+	if err != nil {
+		return false, fmt.Errorf("clear reset token: %v", err)
+	}
+	return affected > 0, nil
+}
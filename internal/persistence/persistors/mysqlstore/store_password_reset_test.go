@@ -0,0 +1,62 @@
+package mysqlstore
+
+import (
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUserResetToken_SetGetClear(t *testing.T) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+	defer cleanup()
+
+	txHandlerController, err := mysqltx.New(&mysqltx.Config{
+		Logger:       testLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	txHandler, err := txHandlerController.Db(testCtx)
+	require.NoError(t, err, "unexpected non nil error")
+
+	store, err := New(&Config{
+		Logger:        testLogger,
+		QueryTimeouts: testQueryTimeouts,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	user, err := store.GetUserByEmail(testCtx, txHandler, "demby@gmail.com")
+	require.NoError(t, err, "unexpected error getting the seeded user")
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, store.SetUserResetToken(testCtx, txHandler, user.Id, "hash-1", expiresAt))
+
+	got, err := store.GetUserByResetToken(testCtx, txHandler, "hash-1")
+	require.NoError(t, err, "unexpected error getting the user by reset token")
+	assert.Equal(t, user.Id, got.Id)
+	assert.True(t, got.ResetTokenExpiresAt.Valid)
+	assert.WithinDuration(t, expiresAt, got.ResetTokenExpiresAt.Time, time.Second)
+
+	require.NoError(t, store.SetUserResetToken(testCtx, txHandler, user.Id, "hash-2", expiresAt))
+	_, err = store.GetUserByResetToken(testCtx, txHandler, "hash-1")
+	assert.ErrorIs(t, err, persistence.ErrNotFound, "a new token replaces the previous one")
+
+	cleared, err := store.ClearUserResetToken(testCtx, txHandler, user.Id, "hash-1")
+	require.NoError(t, err, "unexpected error clearing")
+	assert.False(t, cleared, "a replaced token can't be cleared")
+
+	cleared, err = store.ClearUserResetToken(testCtx, txHandler, user.Id, "hash-2")
+	require.NoError(t, err, "unexpected error clearing")
+	assert.True(t, cleared)
+
+	_, err = store.GetUserByResetToken(testCtx, txHandler, "hash-2")
+	assert.ErrorIs(t, err, persistence.ErrNotFound, "a cleared token is gone")
+
+	err = store.SetUserResetToken(testCtx, txHandler, 999, "hash-3", expiresAt)
+	assert.ErrorIs(t, err, persistence.ErrNotFound)
+}
@@ -0,0 +1,238 @@
+package mysqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/dembygenesis/local.tools/internal/sysconsts"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// GetRoles fetches the roles, the "User Types" categories, with their
+// permissions.
+func (m *Repository) GetRoles(ctx context.Context, tx persistence.TransactionHandler) ([]model.Role, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	roles, err := m.getRoles(ctx, ctxExec)
+	if err != nil {
+		return nil, fmt.Errorf("get roles: %v", err)
+	}
+	return roles, nil
+}
+
+// GetRole fetches a role with its permissions, failing with
+// persistence.ErrNotFound for a category that is not a role.
+func (m *Repository) GetRole(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.Role, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	roles, err := m.getRoles(ctx, ctxExec, mysqlmodel.CategoryWhere.ID.EQ(id))
+	if err != nil {
+		return nil, fmt.Errorf("get role: %v", err)
+	}
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("role %d: %w", id, persistence.ErrNotFound)
+	}
+	return &roles[0], nil
+}
+
+// getRoles fetches the matching roles, and the names of their permissions.
+func (m *Repository) getRoles(ctx context.Context, ctxExec boil.ContextExecutor, where ...qm.QueryMod) ([]model.Role, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	queryMods := append([]qm.QueryMod{
+		qm.Select(mysqlmodel.TableNames.Category + ".*"),
+		qm.InnerJoin(fmt.Sprintf(
+			"%s ON %s.%s = %s.%s",
+			mysqlmodel.TableNames.CategoryType,
+			mysqlmodel.TableNames.CategoryType,
+			mysqlmodel.CategoryTypeColumns.ID,
+			mysqlmodel.TableNames.Category,
+			mysqlmodel.CategoryColumns.CategoryTypeRefID,
+		)),
+		mysqlmodel.CategoryTypeWhere.Name.EQ(sysconsts.CategoryTypeUserTypes),
+		qm.OrderBy(mysqlmodel.CategoryTableColumns.ID),
+	}, where...)
+
+	categories, err := mysqlmodel.Categories(queryMods...).All(ctx, ctxExec)
+	if err != nil {
+		return nil, fmt.Errorf("categories: %v", err)
+	}
+
+	roles := make([]model.Role, 0, len(categories))
+	if len(categories) == 0 {
+		return roles, nil
+	}
+
+	ids := make([]int, 0, len(categories))
+	for _, category := range categories {
+		ids = append(ids, category.ID)
+	}
+
+	var granted []struct {
+		RoleRefId int    `boil:"role_ref_id"`
+		Name      string `boil:"name"`
+	}
+	err = mysqlmodel.RolePermissions(
+		qm.Select(
+			mysqlmodel.RolePermissionTableColumns.RoleRefID+" AS "+mysqlmodel.RolePermissionColumns.RoleRefID,
+			mysqlmodel.PermissionTableColumns.Name+" AS "+mysqlmodel.PermissionColumns.Name,
+		),
+		qm.InnerJoin(fmt.Sprintf(
+			"%s ON %s = %s",
+			mysqlmodel.TableNames.Permission,
+			mysqlmodel.PermissionTableColumns.ID,
+			mysqlmodel.RolePermissionTableColumns.PermissionRefID,
+		)),
+		mysqlmodel.RolePermissionWhere.RoleRefID.IN(ids),
+		qm.OrderBy(mysqlmodel.PermissionTableColumns.Name),
+	).Bind(ctx, ctxExec, &granted)
+	if err != nil {
+		return nil, fmt.Errorf("role permissions: %v", err)
+	}
+
+	permissions := make(map[int][]string, len(categories))
+	for _, g := range granted {
+		permissions[g.RoleRefId] = append(permissions[g.RoleRefId], g.Name)
+	}
+
+	for _, category := range categories {
+		role := model.Role{Id: category.ID, Name: category.Name, Permissions: permissions[category.ID]}
+		if role.Permissions == nil {
+			role.Permissions = make([]string, 0)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// GetPermissions fetches every permission.
+func (m *Repository) GetPermissions(ctx context.Context, tx persistence.TransactionHandler) ([]model.Permission, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entries, err := mysqlmodel.Permissions(qm.OrderBy(mysqlmodel.PermissionColumns.Name)).All(ctx, ctxExec)
+	if err != nil {
+		return nil, fmt.Errorf("get permissions: %v", err)
+	}
+
+	permissions := make([]model.Permission, 0, len(entries))
+	for _, entry := range entries {
+		permissions = append(permissions, *ConvertMysqlModelToPermission(entry))
+	}
+	return permissions, nil
+}
+
+// GetPermissionByName fetches a permission by name.
+func (m *Repository) GetPermissionByName(ctx context.Context, tx persistence.TransactionHandler, name string) (*model.Permission, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entry, err := mysqlmodel.Permissions(mysqlmodel.PermissionWhere.Name.EQ(name)).One(ctx, ctxExec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("permission '%s': %w", name, persistence.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get permission: %v", err)
+	}
+
+	return ConvertMysqlModelToPermission(entry), nil
+}
+
+// HasRolePermission reports whether the role was granted the permission.
+func (m *Repository) HasRolePermission(ctx context.Context, tx persistence.TransactionHandler, roleId int, permission string) (bool, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return false, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	exists, err := mysqlmodel.RolePermissions(
+		qm.InnerJoin(fmt.Sprintf(
+			"%s ON %s = %s",
+			mysqlmodel.TableNames.Permission,
+			mysqlmodel.PermissionTableColumns.ID,
+			mysqlmodel.RolePermissionTableColumns.PermissionRefID,
+		)),
+		mysqlmodel.RolePermissionWhere.RoleRefID.EQ(roleId),
+		mysqlmodel.PermissionWhere.Name.EQ(permission),
+	).Exists(ctx, ctxExec)
+	if err != nil {
+		return false, fmt.Errorf("role permission exists: %v", err)
+	}
+	return exists, nil
+}
+
+// AddRolePermission grants the permission to the role, reporting false when
+// it already was.
+func (m *Repository) AddRolePermission(ctx context.Context, tx persistence.TransactionHandler, roleId, permissionId int) (bool, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return false, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	exists, err := mysqlmodel.RolePermissions(
+		mysqlmodel.RolePermissionWhere.RoleRefID.EQ(roleId),
+		mysqlmodel.RolePermissionWhere.PermissionRefID.EQ(permissionId),
+	).Exists(ctx, ctxExec)
+	if err != nil {
+		return false, fmt.Errorf("role permission exists: %v", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	entry := &mysqlmodel.RolePermission{RoleRefID: roleId, PermissionRefID: permissionId}
+	if err = entry.Insert(ctx, ctxExec, boil.Infer()); err != nil {
+		return false, fmt.Errorf("insert role permission: %v", err)
+	}
+	return true, nil
+}
+
+// DeleteRolePermission revokes the permission from the role, reporting false
+// when it was not granted.
+func (m *Repository) DeleteRolePermission(ctx context.Context, tx persistence.TransactionHandler, roleId, permissionId int) (bool, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return false, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	affected, err := mysqlmodel.RolePermissions(
+		mysqlmodel.RolePermissionWhere.RoleRefID.EQ(roleId),
+		mysqlmodel.RolePermissionWhere.PermissionRefID.EQ(permissionId),
+	).DeleteAll(ctx, ctxExec)
+	if err != nil {
+		return false, fmt.Errorf("delete role permission: %v", err)
+	}
+	return affected > 0, nil
+}
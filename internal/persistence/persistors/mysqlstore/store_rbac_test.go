@@ -0,0 +1,78 @@
+package mysqlstore
+
+import (
+	"github.com/dembygenesis/local.tools/internal/model"
+	"github.com/dembygenesis/local.tools/internal/persistence"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqlhelper"
+	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRolePermission_AddDelete(t *testing.T) {
+	db, cp, cleanup := mysqlhelper.TestGetMockMariaDB(t)
+	defer cleanup()
+
+	txHandlerController, err := mysqltx.New(&mysqltx.Config{
+		Logger:       testLogger,
+		Db:           db,
+		DatabaseName: cp.Database,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	txHandler, err := txHandlerController.Db(testCtx)
+	require.NoError(t, err, "unexpected non nil error")
+
+	store, err := New(&Config{
+		Logger:        testLogger,
+		QueryTimeouts: testQueryTimeouts,
+	})
+	require.NoError(t, err, "unexpected non nil error")
+
+	roles, err := store.GetRoles(testCtx, txHandler)
+	require.NoError(t, err, "unexpected error getting the roles")
+	require.Len(t, roles, 3, "the seeded user types are the roles")
+	assert.Equal(t, model.Role{
+		Id:          3,
+		Name:        "Regular User",
+		Permissions: []string{model.PermissionCategoryRead, model.PermissionUserRead},
+	}, roles[2])
+
+	_, err = store.GetRole(testCtx, txHandler, 999)
+	assert.ErrorIs(t, err, persistence.ErrNotFound)
+
+	permission, err := store.GetPermissionByName(testCtx, txHandler, model.PermissionCategoryDelete)
+	require.NoError(t, err, "unexpected error getting a permission")
+
+	_, err = store.GetPermissionByName(testCtx, txHandler, "nothing:ever")
+	assert.ErrorIs(t, err, persistence.ErrNotFound)
+
+	has, err := store.HasRolePermission(testCtx, txHandler, 3, model.PermissionCategoryDelete)
+	require.NoError(t, err, "unexpected error checking a permission")
+	assert.False(t, has)
+
+	added, err := store.AddRolePermission(testCtx, txHandler, 3, permission.Id)
+	require.NoError(t, err, "unexpected error adding a permission")
+	assert.True(t, added)
+
+	added, err = store.AddRolePermission(testCtx, txHandler, 3, permission.Id)
+	require.NoError(t, err, "unexpected error adding a permission")
+	assert.False(t, added, "a granted permission isn't added twice")
+
+	has, err = store.HasRolePermission(testCtx, txHandler, 3, model.PermissionCategoryDelete)
+	require.NoError(t, err, "unexpected error checking a permission")
+	assert.True(t, has)
+
+	deleted, err := store.DeleteRolePermission(testCtx, txHandler, 3, permission.Id)
+	require.NoError(t, err, "unexpected error deleting a permission")
+	assert.True(t, deleted)
+
+	deleted, err = store.DeleteRolePermission(testCtx, txHandler, 3, permission.Id)
+	require.NoError(t, err, "unexpected error deleting a permission")
+	assert.False(t, deleted, "a revoked permission can't be deleted again")
+
+	permissions, err := store.GetPermissions(testCtx, txHandler)
+	require.NoError(t, err, "unexpected error getting the permissions")
+	assert.Len(t, permissions, 8)
+}
@@ -2,12 +2,15 @@ package mysqlstore
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/dembygenesis/local.tools/internal/model"
 	"github.com/dembygenesis/local.tools/internal/persistence"
 	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/assets/mysqlmodel"
 	"github.com/dembygenesis/local.tools/internal/persistence/database_helpers/mysql/mysqltx"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 //// GetUsers attempts to fetch the users
@@ -138,6 +141,92 @@ import (
 //	return &paginated, nil
 //}
 
+// GetUserById fetches a user, active or not, by id.
+func (m *Repository) GetUserById(ctx context.Context, tx persistence.TransactionHandler, id int) (*model.User, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entry, err := mysqlmodel.FindUser(ctx, ctxExec, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user %d: %w", id, persistence.ErrNotFound)
+		}
+		return nil, fmt.Errorf("find user: %v", err)
+	}
+
+	return ConvertMysqlModelToUser(entry), nil
+}
+
+// GetUserByEmail fetches a user, active or not, by email.
+func (m *Repository) GetUserByEmail(ctx context.Context, tx persistence.TransactionHandler, email string) (*model.User, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entry, err := mysqlmodel.Users(mysqlmodel.UserWhere.Email.EQ(email)).One(ctx, ctxExec)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("user '%s': %w", email, persistence.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get user: %v", err)
+	}
+
+	return ConvertMysqlModelToUser(entry), nil
+}
+
+// GetUserPasswords fetches the stored password of every user, keyed by id.
+func (m *Repository) GetUserPasswords(ctx context.Context, tx persistence.TransactionHandler) (map[int]string, error) {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return nil, fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Query)
+	defer cancel()
+
+	entries, err := mysqlmodel.Users(qm.Select(mysqlmodel.UserColumns.ID, mysqlmodel.UserColumns.Password)).All(ctx, ctxExec)
+	if err != nil {
+		return nil, fmt.Errorf("get users: %v", err)
+	}
+
+	passwords := make(map[int]string, len(entries))
+	for _, entry := range entries {
+		passwords[entry.ID] = entry.Password
+	}
+	return passwords, nil
+}
+
+// UpdateUserPassword replaces the stored password hash of a user.
+func (m *Repository) UpdateUserPassword(ctx context.Context, tx persistence.TransactionHandler, id int, hash string) error {
+	ctxExec, err := mysqltx.GetCtxExecutor(tx)
+	if err != nil {
+		return fmt.Errorf("extract context executor: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.QueryTimeouts.Exec)
+	defer cancel()
+
+	affected, err := mysqlmodel.Users(mysqlmodel.UserWhere.ID.EQ(id)).UpdateAll(ctx, ctxExec, mysqlmodel.M{
+		mysqlmodel.UserColumns.Password: hash,
+	})
+	if err != nil {
+		return fmt.Errorf("update password: %v", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("user %d: %w", id, persistence.ErrNotFound)
+	}
+	return nil
+}
+
 func (m *Repository) UpdateUser(
 	ctx context.Context,
 	tx persistence.TransactionHandler,
@@ -187,13 +276,11 @@ func (m *Repository) UpdateUser(
 		entry.Birthday = user.Birthday
 	}
 
-	fmt.Println("===== here here")
-
 	if _, err = entry.Update(ctx, ctxExec, boil.Infer()); err != nil {
 		return nil, fmt.Errorf("update: %v", err)
 	}
 
-	return nil, nil
+	return ConvertMysqlModelToUser(entry), nil
 }
 
 func (m *Repository) CreateUser(
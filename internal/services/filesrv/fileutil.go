@@ -1,53 +1,151 @@
 package filesrv
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/copyprofile"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
 	"github.com/dembygenesis/local.tools/internal/sysconsts"
+	"io"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 type FileUtils interface {
-	CopyDirToAnother(opts *fslib.CopyOptions) error
+	CopyDirToAnother(opts *fslib.CopyOptions) (*fslib.CopyPlan, error)
+	WatchDirToAnother(ctx context.Context, opts *fslib.CopyOptions, watchOpts *fslib.WatchOptions) error
+	ListCopyProfiles() ([]copyprofile.Profile, error)
+	GetCopyProfile(name string) (*copyprofile.Profile, error)
+	AddCopyProfile(profile *copyprofile.Profile, overwrite bool) error
+	CopyProfilesPath() string
+	RunCopyProfile(ctx context.Context, name string, opts *copyprofile.RunOptions) (*fslib.CopyPlan, error)
 }
 
 //counterfeiter:generate . osLayer
 type osLayer interface {
-	CopyDirToAnother(opts *fslib.CopyOptions) error
+	CopyDirToAnother(opts *fslib.CopyOptions) (*fslib.CopyPlan, error)
+	Watch(ctx context.Context, opts *fslib.CopyOptions, watchOpts *fslib.WatchOptions) error
 }
 
+// New stores the copy profiles in the app dir.
 func New(conf *config.App, osLayer osLayer) (FileUtils, error) {
 	if conf == nil {
 		return nil, errors.New(sysconsts.ErrConfigNil)
 	}
 
 	return &fileUtils{
-		conf,
-		osLayer,
+		conf:     conf,
+		osLayer:  osLayer,
+		profiles: copyprofile.NewStore(conf.Settings.AppDir),
 	}, nil
 }
 
 type fileUtils struct {
-	conf    *config.App
-	osLayer osLayer
+	conf     *config.App
+	osLayer  osLayer
+	profiles *copyprofile.Store
 }
 
-func (g *fileUtils) CopyDirToAnother(opts *fslib.CopyOptions) error {
+// CopyDirToAnother copies with the configured exclusions, extended by the
+// caller's. The destination is only wiped when the caller opts in to mirroring.
+func (g *fileUtils) CopyDirToAnother(opts *fslib.CopyOptions) (*fslib.CopyPlan, error) {
 	if opts == nil {
-		return fmt.Errorf("opts nil")
+		return nil, fmt.Errorf("opts nil")
 	}
 
-	opts.WipeDestination = true
-	opts.SourceExclusions = g.conf.FolderAToFolderB.GenericExclusions
-	opts.WipeDestinationExclusions = g.conf.FolderAToFolderB.GenericExclusions
+	g.addExclusions(opts)
 
-	err := g.osLayer.CopyDirToAnother(opts)
+	plan, err := g.osLayer.CopyDirToAnother(opts)
 	if err != nil {
+		return nil, fmt.Errorf("os: %v", err)
+	}
+
+	return plan, nil
+}
+
+// WatchDirToAnother copies like CopyDirToAnother, then keeps syncing changes
+// until the context is done.
+func (g *fileUtils) WatchDirToAnother(ctx context.Context, opts *fslib.CopyOptions, watchOpts *fslib.WatchOptions) error {
+	if opts == nil {
+		return fmt.Errorf("opts nil")
+	}
+
+	g.addExclusions(opts)
+
+	if err := g.osLayer.Watch(ctx, opts, watchOpts); err != nil {
 		return fmt.Errorf("os: %v", err)
 	}
 
 	return nil
 }
+
+// addExclusions puts the configured exclusions ahead of the caller's.
+func (g *fileUtils) addExclusions(opts *fslib.CopyOptions) {
+	generic := g.conf.FolderAToFolderB.GenericExclusions
+	opts.SourceExclusions = append(append([]string{}, generic...), opts.SourceExclusions...)
+	opts.WipeDestinationExclusions = append(append([]string{}, generic...), opts.WipeDestinationExclusions...)
+}
+
+func (g *fileUtils) ListCopyProfiles() ([]copyprofile.Profile, error) {
+	return g.profiles.List()
+}
+
+func (g *fileUtils) GetCopyProfile(name string) (*copyprofile.Profile, error) {
+	return g.profiles.Get(name)
+}
+
+func (g *fileUtils) AddCopyProfile(profile *copyprofile.Profile, overwrite bool) error {
+	return g.profiles.Add(profile, overwrite)
+}
+
+func (g *fileUtils) CopyProfilesPath() string {
+	return g.profiles.Path()
+}
+
+// RunCopyProfile copies the named profile like CopyDirToAnother, then runs its
+// hook. The hook is skipped on dry runs, and when any file failed to copy.
+func (g *fileUtils) RunCopyProfile(ctx context.Context, name string, opts *copyprofile.RunOptions) (*fslib.CopyPlan, error) {
+	if opts == nil {
+		opts = &copyprofile.RunOptions{}
+	}
+
+	profile, err := g.profiles.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	copyOpts, err := profile.Options()
+	if err != nil {
+		return nil, fmt.Errorf("options: %v", err)
+	}
+	copyOpts.DryRun = opts.DryRun
+
+	plan, err := g.CopyDirToAnother(&copyOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun || profile.Hook == "" {
+		return plan, nil
+	}
+
+	if len(plan.Failures) > 0 {
+		return plan, fmt.Errorf("skipped hook, %d file(s) failed to copy", len(plan.Failures))
+	}
+
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
+	if err := profile.RunHook(ctx, stdout, stderr); err != nil {
+		return plan, err
+	}
+
+	return plan, nil
+}
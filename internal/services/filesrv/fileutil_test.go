@@ -1,8 +1,11 @@
 package filesrv
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/copyprofile"
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
 	"github.com/dembygenesis/local.tools/internal/services/filesrv/fileutilfakes"
 	"github.com/stretchr/testify/require"
@@ -13,7 +16,7 @@ func Test_fileUtils_CopyDir_Fail_Nil_Opts(t *testing.T) {
 	conf := config.App{}
 	fakeFileUtils, _ := New(&conf, &fileutilfakes.FakeOsLayer{})
 
-	err := fakeFileUtils.CopyDirToAnother(nil)
+	_, err := fakeFileUtils.CopyDirToAnother(nil)
 	require.Error(t, err, "expected opts nil error")
 }
 
@@ -23,11 +26,11 @@ func Test_fileUtils_CopyDir_Fail_Os_Layer(t *testing.T) {
 
 	fakeFileUtils, _ := New(&conf, &fakeOsLayer)
 
-	fakeOsLayer.CopyDirToAnotherReturns(errors.New("mock error"))
+	fakeOsLayer.CopyDirToAnotherReturns(nil, errors.New("mock error"))
 
 	opts := fslib.CopyOptions{}
 
-	err := fakeFileUtils.CopyDirToAnother(&opts)
+	_, err := fakeFileUtils.CopyDirToAnother(&opts)
 	require.Error(t, err, "expected opts nil error")
 	require.Contains(t, err.Error(), "os:")
 	require.Contains(t, err.Error(), "mock error")
@@ -41,6 +44,106 @@ func Test_fileUtils_CopyDir_Success(t *testing.T) {
 
 	opts := fslib.CopyOptions{}
 
-	err := fakeFileUtils.CopyDirToAnother(&opts)
+	_, err := fakeFileUtils.CopyDirToAnother(&opts)
 	require.NoError(t, err, "expected opts has error")
 }
+
+func Test_fileUtils_CopyDir_Does_Not_Force_Wipe(t *testing.T) {
+	conf := config.App{}
+	conf.FolderAToFolderB.GenericExclusions = []string{".git"}
+	fakeOsLayer := fileutilfakes.FakeOsLayer{}
+	fakeOsLayer.CopyDirToAnotherReturns(&fslib.CopyPlan{DryRun: true}, nil)
+
+	fakeFileUtils, _ := New(&conf, &fakeOsLayer)
+
+	plan, err := fakeFileUtils.CopyDirToAnother(&fslib.CopyOptions{DryRun: true})
+	require.NoError(t, err)
+	require.True(t, plan.DryRun)
+
+	opts := fakeOsLayer.CopyDirToAnotherArgsForCall(0)
+	require.False(t, opts.WipeDestination, "wiping is opt-in")
+	require.Equal(t, []string{".git"}, opts.SourceExclusions)
+	require.Equal(t, []string{".git"}, opts.WipeDestinationExclusions)
+}
+
+func Test_fileUtils_CopyDir_Extends_Exclusions(t *testing.T) {
+	conf := config.App{}
+	conf.FolderAToFolderB.GenericExclusions = []string{".git"}
+	fakeOsLayer := fileutilfakes.FakeOsLayer{}
+
+	fakeFileUtils, _ := New(&conf, &fakeOsLayer)
+
+	_, err := fakeFileUtils.CopyDirToAnother(&fslib.CopyOptions{
+		SourceExclusions:          []string{".env.*"},
+		WipeDestinationExclusions: []string{".env.*"},
+	})
+	require.NoError(t, err)
+
+	opts := fakeOsLayer.CopyDirToAnotherArgsForCall(0)
+	require.Equal(t, []string{".git", ".env.*"}, opts.SourceExclusions)
+	require.Equal(t, []string{".git", ".env.*"}, opts.WipeDestinationExclusions)
+	require.Equal(t, []string{".git"}, conf.FolderAToFolderB.GenericExclusions, "the config is left untouched")
+}
+
+func Test_fileUtils_RunCopyProfile(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.FolderAToFolderB.GenericExclusions = []string{".git"}
+	fakeOsLayer := fileutilfakes.FakeOsLayer{}
+	fakeOsLayer.CopyDirToAnotherReturns(&fslib.CopyPlan{}, nil)
+
+	fakeFileUtils, _ := New(&conf, &fakeOsLayer)
+
+	dst := t.TempDir()
+	err := fakeFileUtils.AddCopyProfile(&copyprofile.Profile{
+		Name:        "api",
+		CopyOptions: fslib.CopyOptions{Source: "/src", Destination: dst, SourceExclusions: []string{"tmp/"}},
+		Hook:        "echo done",
+	}, false)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	_, err = fakeFileUtils.RunCopyProfile(context.Background(), "api", &copyprofile.RunOptions{Stdout: &out})
+	require.NoError(t, err)
+	require.Equal(t, "done\n", out.String())
+
+	opts := fakeOsLayer.CopyDirToAnotherArgsForCall(0)
+	require.Equal(t, []string{".git", "tmp/"}, opts.SourceExclusions)
+	require.Equal(t, []string{".git", "tmp/"}, opts.WipeDestinationExclusions)
+
+	out.Reset()
+	_, err = fakeFileUtils.RunCopyProfile(context.Background(), "api", &copyprofile.RunOptions{DryRun: true, Stdout: &out})
+	require.NoError(t, err)
+	require.Empty(t, out.String(), "dry runs skip the hook")
+	require.True(t, fakeOsLayer.CopyDirToAnotherArgsForCall(1).DryRun)
+
+	fakeOsLayer.CopyDirToAnotherReturns(&fslib.CopyPlan{Failures: []fslib.CopyFailure{{Path: "a.txt"}}}, nil)
+	_, err = fakeFileUtils.RunCopyProfile(context.Background(), "api", &copyprofile.RunOptions{Stdout: &out})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "skipped hook")
+	require.Empty(t, out.String())
+
+	_, err = fakeFileUtils.RunCopyProfile(context.Background(), "missing", nil)
+	require.Error(t, err)
+}
+
+func Test_fileUtils_WatchDirToAnother(t *testing.T) {
+	conf := config.App{}
+	conf.FolderAToFolderB.GenericExclusions = []string{".git"}
+	fakeOsLayer := fileutilfakes.FakeOsLayer{}
+
+	fakeFileUtils, _ := New(&conf, &fakeOsLayer)
+
+	err := fakeFileUtils.WatchDirToAnother(context.Background(), nil, nil)
+	require.Error(t, err, "expected opts nil error")
+
+	err = fakeFileUtils.WatchDirToAnother(context.Background(), &fslib.CopyOptions{SourceExclusions: []string{"*.tmp"}}, nil)
+	require.NoError(t, err)
+
+	_, opts, _ := fakeOsLayer.WatchArgsForCall(0)
+	require.Equal(t, []string{".git", "*.tmp"}, opts.SourceExclusions)
+
+	fakeOsLayer.WatchReturns(errors.New("mock error"))
+	err = fakeFileUtils.WatchDirToAnother(context.Background(), &fslib.CopyOptions{}, nil)
+	require.ErrorContains(t, err, "os: mock error")
+}
@@ -2,28 +2,44 @@
 package fileutilfakes
 
 import (
+	"context"
 	"sync"
 
 	"github.com/dembygenesis/local.tools/internal/lib/fslib"
 )
 
 type FakeOsLayer struct {
-	CopyDirToAnotherStub        func(*fslib.CopyOptions) error
+	CopyDirToAnotherStub        func(*fslib.CopyOptions) (*fslib.CopyPlan, error)
 	copyDirToAnotherMutex       sync.RWMutex
 	copyDirToAnotherArgsForCall []struct {
 		arg1 *fslib.CopyOptions
 	}
 	copyDirToAnotherReturns struct {
-		result1 error
+		result1 *fslib.CopyPlan
+		result2 error
 	}
 	copyDirToAnotherReturnsOnCall map[int]struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}
+	WatchStub        func(context.Context, *fslib.CopyOptions, *fslib.WatchOptions) error
+	watchMutex       sync.RWMutex
+	watchArgsForCall []struct {
+		arg1 context.Context
+		arg2 *fslib.CopyOptions
+		arg3 *fslib.WatchOptions
+	}
+	watchReturns struct {
+		result1 error
+	}
+	watchReturnsOnCall map[int]struct {
 		result1 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeOsLayer) CopyDirToAnother(arg1 *fslib.CopyOptions) error {
+func (fake *FakeOsLayer) CopyDirToAnother(arg1 *fslib.CopyOptions) (*fslib.CopyPlan, error) {
 	fake.copyDirToAnotherMutex.Lock()
 	ret, specificReturn := fake.copyDirToAnotherReturnsOnCall[len(fake.copyDirToAnotherArgsForCall)]
 	fake.copyDirToAnotherArgsForCall = append(fake.copyDirToAnotherArgsForCall, struct {
@@ -37,9 +53,9 @@ func (fake *FakeOsLayer) CopyDirToAnother(arg1 *fslib.CopyOptions) error {
 		return stub(arg1)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeOsLayer) CopyDirToAnotherCallCount() int {
@@ -48,7 +64,7 @@ func (fake *FakeOsLayer) CopyDirToAnotherCallCount() int {
 	return len(fake.copyDirToAnotherArgsForCall)
 }
 
-func (fake *FakeOsLayer) CopyDirToAnotherCalls(stub func(*fslib.CopyOptions) error) {
+func (fake *FakeOsLayer) CopyDirToAnotherCalls(stub func(*fslib.CopyOptions) (*fslib.CopyPlan, error)) {
 	fake.copyDirToAnotherMutex.Lock()
 	defer fake.copyDirToAnotherMutex.Unlock()
 	fake.CopyDirToAnotherStub = stub
@@ -61,25 +77,91 @@ func (fake *FakeOsLayer) CopyDirToAnotherArgsForCall(i int) *fslib.CopyOptions {
 	return argsForCall.arg1
 }
 
-func (fake *FakeOsLayer) CopyDirToAnotherReturns(result1 error) {
+func (fake *FakeOsLayer) CopyDirToAnotherReturns(result1 *fslib.CopyPlan, result2 error) {
 	fake.copyDirToAnotherMutex.Lock()
 	defer fake.copyDirToAnotherMutex.Unlock()
 	fake.CopyDirToAnotherStub = nil
 	fake.copyDirToAnotherReturns = struct {
-		result1 error
-	}{result1}
+		result1 *fslib.CopyPlan
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeOsLayer) CopyDirToAnotherReturnsOnCall(i int, result1 error) {
+func (fake *FakeOsLayer) CopyDirToAnotherReturnsOnCall(i int, result1 *fslib.CopyPlan, result2 error) {
 	fake.copyDirToAnotherMutex.Lock()
 	defer fake.copyDirToAnotherMutex.Unlock()
 	fake.CopyDirToAnotherStub = nil
 	if fake.copyDirToAnotherReturnsOnCall == nil {
 		fake.copyDirToAnotherReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 *fslib.CopyPlan
+			result2 error
 		})
 	}
 	fake.copyDirToAnotherReturnsOnCall[i] = struct {
+		result1 *fslib.CopyPlan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOsLayer) Watch(arg1 context.Context, arg2 *fslib.CopyOptions, arg3 *fslib.WatchOptions) error {
+	fake.watchMutex.Lock()
+	ret, specificReturn := fake.watchReturnsOnCall[len(fake.watchArgsForCall)]
+	fake.watchArgsForCall = append(fake.watchArgsForCall, struct {
+		arg1 context.Context
+		arg2 *fslib.CopyOptions
+		arg3 *fslib.WatchOptions
+	}{arg1, arg2, arg3})
+	stub := fake.WatchStub
+	fakeReturns := fake.watchReturns
+	fake.recordInvocation("Watch", []interface{}{arg1, arg2, arg3})
+	fake.watchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOsLayer) WatchCallCount() int {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	return len(fake.watchArgsForCall)
+}
+
+func (fake *FakeOsLayer) WatchCalls(stub func(context.Context, *fslib.CopyOptions, *fslib.WatchOptions) error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = stub
+}
+
+func (fake *FakeOsLayer) WatchArgsForCall(i int) (context.Context, *fslib.CopyOptions, *fslib.WatchOptions) {
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
+	argsForCall := fake.watchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeOsLayer) WatchReturns(result1 error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	fake.watchReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOsLayer) WatchReturnsOnCall(i int, result1 error) {
+	fake.watchMutex.Lock()
+	defer fake.watchMutex.Unlock()
+	fake.WatchStub = nil
+	if fake.watchReturnsOnCall == nil {
+		fake.watchReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.watchReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -89,6 +171,8 @@ func (fake *FakeOsLayer) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.copyDirToAnotherMutex.RLock()
 	defer fake.copyDirToAnotherMutex.RUnlock()
+	fake.watchMutex.RLock()
+	defer fake.watchMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -1,30 +1,68 @@
 package gptsrv
 
-const (
-	// Project Preface:
-	//
-	// We aspire to FAANG-level standards, emphasizing testability, robustness, and strict adherence to
-	// software engineering best practices. Contributors are expected to:
-	// - Thoroughly validate their work, ensuring top-notch quality.
-	// - Confirm the rigor of their checks after each submission.
-	// - Provide revisions that are immediately usable (copy paste-able).
-	// - Use defensive programming, and catch all errors immediately.
-	// - Return the full code, and not just partial edits (very important).
-	//
-	// This commitment is crucial for the project's integrity and excellence.
-	preface = `
-        /**
-         * Preface:
-         *
-         * This project strives for excellence, mirroring FAANG standards. It demands rigorous testing,
-         * impeccable code quality, and a strict adherence to the best software engineering practices.
-         *
-         * Contributors are expected to:
-         *   - Ensure their work is of the highest quality and rigorously validated.
-         *   - Confirm the thoroughness of their review post-submission.
-         *   - Provide revisions that are ready for immediate integration (copy paste-able).
-         *
-         * Our dedication to these principles is essential for maintaining the project's superior standard.
-         */
-    `
+import (
+	"errors"
+	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/sysconsts"
+	"path/filepath"
 )
+
+type GptUtils interface {
+	RenderPreface(opts *preface.Options) (string, error)
+	ListPrefaces() ([]preface.Template, error)
+	GetPreface(name string) (*preface.Template, error)
+	AddPreface(name, text string) error
+	SavePreface(name, text string) error
+	RemovePreface(name string) error
+	PrefacePath(name string) (string, error)
+}
+
+// New stores the preface library in the app dir. Prefaces are clipped by the
+// string service, like every other payload.
+func New(conf *config.App) (GptUtils, error) {
+	if conf == nil {
+		return nil, errors.New(sysconsts.ErrConfigNil)
+	}
+	return &gptUtils{
+		library: preface.NewLibrary(filepath.Join(conf.Settings.AppDir, preface.DirName)),
+	}, nil
+}
+
+type gptUtils struct {
+	library *preface.Library
+}
+
+// RenderPreface renders the named preface (the coding standards by default).
+// The project name defaults to the name of the repository, or directory the
+// command runs in.
+func (g *gptUtils) RenderPreface(opts *preface.Options) (string, error) {
+	if opts == nil {
+		return "", errors.New(sysconsts.ErrOptsNil)
+	}
+	return g.library.Render(opts)
+}
+
+func (g *gptUtils) ListPrefaces() ([]preface.Template, error) {
+	return g.library.List()
+}
+
+func (g *gptUtils) GetPreface(name string) (*preface.Template, error) {
+	return g.library.Get(name)
+}
+
+func (g *gptUtils) AddPreface(name, text string) error {
+	return g.library.Add(name, text)
+}
+
+func (g *gptUtils) SavePreface(name, text string) error {
+	return g.library.Save(name, text)
+}
+
+func (g *gptUtils) RemovePreface(name string) error {
+	return g.library.Remove(name)
+}
+
+func (g *gptUtils) PrefacePath(name string) (string, error) {
+	return g.library.Materialize(name)
+}
@@ -0,0 +1,76 @@
+package gptsrv
+
+import (
+	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/sysconsts"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestGptUtils returns utils storing their library in a temp app dir.
+func newTestGptUtils(t *testing.T) (GptUtils, *config.App) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+
+	g, err := New(&conf)
+	require.NoError(t, err, "unexpected new error")
+	return g, &conf
+}
+
+func Test_New_Conf_Fail(t *testing.T) {
+	_, err := New(nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), sysconsts.ErrConfigNil)
+}
+
+func Test_RenderPreface(t *testing.T) {
+	g, _ := newTestGptUtils(t)
+
+	text, err := g.RenderPreface(&preface.Options{Vars: preface.Vars{ProjectName: "acme"}})
+	require.NoError(t, err, "unexpected render error")
+	require.Contains(t, text, "This project (acme)", "the coding standards are the default")
+
+	text, err = g.RenderPreface(&preface.Options{Name: " go-review ", Vars: preface.Vars{ProjectName: "acme"}})
+	require.NoError(t, err, "unexpected render error")
+	require.Contains(t, text, `You are reviewing Go code from the "acme" project.`)
+
+	text, err = g.RenderPreface(&preface.Options{Name: "go-review"})
+	require.NoError(t, err, "unexpected render error")
+	require.Contains(t, text, "from the \"", "the project name defaults to the working directory")
+
+	require.NoError(t, g.AddPreface("team", "Hello {{.ProjectName}}"))
+	text, err = g.RenderPreface(&preface.Options{Name: "team", Vars: preface.Vars{ProjectName: "acme"}})
+	require.NoError(t, err, "unexpected render error")
+	require.Equal(t, "Hello acme", text, "library prefaces are rendered too")
+}
+
+func Test_RenderPreface_Fail(t *testing.T) {
+	g, _ := newTestGptUtils(t)
+
+	_, err := g.RenderPreface(nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), sysconsts.ErrOptsNil)
+
+	_, err = g.RenderPreface(&preface.Options{Name: "nothing-like-it"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "get preface")
+}
+
+func Test_PrefacePath_Materializes_Builtin(t *testing.T) {
+	g, conf := newTestGptUtils(t)
+
+	path, err := g.PrefacePath("go-review")
+	require.NoError(t, err, "unexpected path error")
+	require.Equal(t, filepath.Join(conf.Settings.AppDir, preface.DirName, "go-review"+preface.Ext), path)
+
+	require.NoError(t, os.WriteFile(path, []byte("Edited {{.ProjectName}}"), 0o600))
+	text, err := g.RenderPreface(&preface.Options{Name: "go-review", Vars: preface.Vars{ProjectName: "acme"}})
+	require.NoError(t, err, "unexpected render error")
+	require.Equal(t, "Edited acme", text, "the edited copy overrides the built-in")
+
+	_, err = g.PrefacePath("nothing-like-it")
+	require.Error(t, err)
+}
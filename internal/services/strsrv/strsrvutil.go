@@ -1,52 +1,432 @@
 package strsrv
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/cliphistory"
+	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/lib/pasteback"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/lib/prompt"
+	"github.com/dembygenesis/local.tools/internal/lib/snippet"
 	"github.com/dembygenesis/local.tools/internal/sysconsts"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
+	"path/filepath"
 	"strings"
 )
 
+var (
+	log = logger.New(context.TODO())
+)
+
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
 
 type StringUtils interface {
-	CopyRootPathToClipboard(root string, exclude []string) ([]string, error)
+	CopyRootPathToClipboard(opts *cliputil.ClipOptions) (*cliputil.ClipReport, error)
+	CopyContextToClipboard(opts *cliputil.ContextOptions) (*cliputil.ContextReport, error)
+	CopyPromptToClipboard(opts *prompt.Options) (*cliputil.ClipReport, error)
+	ClipPreface(opts *preface.Options) error
+	RenderRootPath(opts *cliputil.ClipOptions) (string, *cliputil.ClipReport, error)
+	WriteOutput(output, payload string, origin cliphistory.Origin) error
+	ClipHistory() ([]cliphistory.Entry, error)
+	GetClip(n int) (*cliphistory.Entry, error)
+	RestoreClip(n int) (*cliphistory.Entry, error)
+	PlanApplyClipboard(opts *pasteback.Options) (*pasteback.Plan, error)
+	ApplyClipboard(plan *pasteback.Plan) (*pasteback.Result, error)
+	ListSnippets() ([]snippet.Snippet, error)
+	GetSnippet(name string) (*snippet.Snippet, error)
+	AddSnippets(overwrite bool, snippets ...snippet.Snippet) error
+	RemoveSnippet(name string) error
+	SearchSnippets(query string) ([]snippet.Match, error)
+	ClipSnippet(name string, values map[string]string, output string) error
 }
 
 //counterfeiter:generate . osLayer
 type osLayer interface {
-	CopyRootPathToClipboard(root string, exclude []string) ([]string, error)
+	RenderRootPath(opts *cliputil.ClipOptions) (string, *cliputil.ClipReport, error)
+	RenderContext(opts *cliputil.ContextOptions) (string, *cliputil.ContextReport, error)
+	RenderPreface(libraryDir string, opts *preface.Options) (string, error)
+	WriteOutput(output, payload string) error
+	WriteClipboard(opts *clipboard.Options, payload string) (string, error)
+	ReadInput(input string) (string, error)
+	ReadClipboard(opts *clipboard.Options) (string, string, error)
 }
 
+// New keeps the clipboard history, the snippets and the prefaces in the app dir.
 func New(conf *config.App, osLayer osLayer) (StringUtils, error) {
 	if conf == nil {
 		return nil, errors.New(sysconsts.ErrConfigNil)
 	}
-	return &stringUtils{conf, osLayer}, nil
+	return &stringUtils{
+		conf:       conf,
+		osLayer:    osLayer,
+		history:    cliphistory.NewStore(filepath.Join(conf.Settings.AppDir, cliphistory.FileName), conf.CopyToClipboard.HistoryLimit),
+		snippets:   snippet.NewStore(filepath.Join(conf.Settings.AppDir, snippet.FileName)),
+		prefaceDir: filepath.Join(conf.Settings.AppDir, preface.DirName),
+	}, nil
 }
 
 type stringUtils struct {
-	conf    *config.App
-	osLayer osLayer
+	conf       *config.App
+	osLayer    osLayer
+	history    *cliphistory.Store
+	snippets   *snippet.Store
+	prefaceDir string
 }
 
-func (s *stringUtils) CopyRootPathToClipboard(root string, exclude []string) ([]string, error) {
-	root = strings.TrimSpace(root)
-	if root == "" {
-		return nil, errors.New(sysconsts.ErrRootMissing)
+func (s *stringUtils) CopyRootPathToClipboard(opts *cliputil.ClipOptions) (*cliputil.ClipReport, error) {
+	rendered, report, err := s.RenderRootPath(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.WriteOutput(opts.Output, rendered, cliphistory.Origin{Command: cliphistory.CommandFiles, Source: absPath(opts.Root)}); err != nil {
+		return report, err
 	}
 
-	if exclude == nil {
-		exclude = make([]string, 0)
+	return report, nil
+}
+
+func (s *stringUtils) CopyContextToClipboard(opts *cliputil.ContextOptions) (*cliputil.ContextReport, error) {
+	if opts == nil {
+		return nil, errors.New(sysconsts.ErrOptsNil)
 	}
 
-	exclude = append(exclude, s.conf.CopyToClipboard.Exclusions...)
+	if err := s.applyClipDefaults(&opts.ClipOptions); err != nil {
+		return nil, err
+	}
 
-	files, err := s.osLayer.CopyRootPathToClipboard(root, exclude)
+	if opts.MaxTokens == 0 {
+		opts.MaxTokens = s.conf.CopyToClipboard.MaxTokens
+	}
+
+	if opts.Tokenizer.Kind == "" {
+		opts.Tokenizer.Kind = s.conf.CopyToClipboard.Tokenizer
+	}
+
+	if opts.Tokenizer.BPEFile == "" {
+		opts.Tokenizer.BPEFile = s.conf.CopyToClipboard.BPEFile
+	}
+
+	rendered, report, err := s.osLayer.RenderContext(opts)
 	if err != nil {
 		return nil, fmt.Errorf("os: %v", err)
 	}
 
-	return files, nil
+	if err := s.WriteOutput(opts.Output, rendered, cliphistory.Origin{Command: cliphistory.CommandContext, Source: absPath(opts.Root)}); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// CopyPromptToClipboard composes the preface, the selected files and the question
+// into a single payload, in the requested order, and writes it to the requested output.
+// The returned report is empty when no files were requested.
+func (s *stringUtils) CopyPromptToClipboard(opts *prompt.Options) (*cliputil.ClipReport, error) {
+	if opts == nil {
+		return nil, errors.New(sysconsts.ErrOptsNil)
+	}
+
+	if strings.TrimSpace(opts.Question) == "" {
+		return nil, errors.New("question is empty")
+	}
+
+	order, err := prompt.ParseOrder(opts.Order)
+	if err != nil {
+		return nil, fmt.Errorf("order: %v", err)
+	}
+
+	report := &cliputil.ClipReport{}
+	sections := make([]prompt.Section, 0, len(order))
+	for _, name := range order {
+		section := prompt.Section{Name: name}
+
+		switch name {
+		case prompt.SectionPreface:
+			if opts.NoPreface {
+				continue
+			}
+			section.Body, err = s.osLayer.RenderPreface(s.prefaceDir, &opts.Preface)
+			if err != nil {
+				return nil, fmt.Errorf("render preface: %v", err)
+			}
+		case prompt.SectionFiles:
+			if strings.TrimSpace(opts.Clip.Root) == "" {
+				continue
+			}
+			section.Body, report, err = s.RenderRootPath(&opts.Clip)
+			if err != nil {
+				return nil, fmt.Errorf("render files: %v", err)
+			}
+		case prompt.SectionQuestion:
+			section.Body = opts.Question
+		}
+
+		sections = append(sections, section)
+	}
+
+	payload, err := prompt.Compose(opts.Clip.Format, sections)
+	if err != nil {
+		return nil, fmt.Errorf("compose: %v", err)
+	}
+
+	origin := cliphistory.Origin{Command: cliphistory.CommandPrompt}
+	if strings.TrimSpace(opts.Clip.Root) != "" {
+		origin.Source = absPath(opts.Clip.Root)
+	}
+
+	if err := s.WriteOutput(opts.Clip.Output, payload, origin); err != nil {
+		return nil, fmt.Errorf("write prompt: %v", err)
+	}
+
+	return report, nil
+}
+
+// ClipPreface renders the preface, and copies it to the clipboard like every
+// other payload, recording it in the history under its name.
+func (s *stringUtils) ClipPreface(opts *preface.Options) error {
+	if opts == nil {
+		return errors.New(sysconsts.ErrOptsNil)
+	}
+
+	text, err := s.osLayer.RenderPreface(s.prefaceDir, opts)
+	if err != nil {
+		return fmt.Errorf("render preface: %v", err)
+	}
+
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		name = preface.DefaultName
+	}
+
+	if err := s.WriteOutput("", text, cliphistory.Origin{Command: cliphistory.CommandPreface, Source: name}); err != nil {
+		return fmt.Errorf("clip preface: %v", err)
+	}
+
+	return nil
+}
+
+// RenderRootPath renders the selected files without writing them anywhere,
+// so they can be composed into a bigger payload.
+func (s *stringUtils) RenderRootPath(opts *cliputil.ClipOptions) (string, *cliputil.ClipReport, error) {
+	if opts == nil {
+		return "", nil, errors.New(sysconsts.ErrOptsNil)
+	}
+
+	if err := s.applyClipDefaults(opts); err != nil {
+		return "", nil, err
+	}
+
+	rendered, report, err := s.osLayer.RenderRootPath(opts)
+	if err != nil {
+		return "", nil, fmt.Errorf("os: %v", err)
+	}
+
+	return rendered, report, nil
+}
+
+// WriteOutput writes the payload to the output, and records it in the
+// clipboard history when it goes to the clipboard. A failure to record is
+// only logged, as the payload was copied.
+func (s *stringUtils) WriteOutput(output, payload string, origin cliphistory.Origin) error {
+	if strings.TrimSpace(output) != "" {
+		if err := s.osLayer.WriteOutput(output, payload); err != nil {
+			return fmt.Errorf("os: %v", err)
+		}
+		return nil
+	}
+
+	if err := s.writeClipboard(payload); err != nil {
+		return err
+	}
+
+	if err := s.history.Add(origin, payload); err != nil {
+		log.Warnf("record clip history: %v", err)
+	}
+
+	return nil
+}
+
+// writeClipboard copies the payload with the configured clipboard backend.
+// Falling back to the file backend is logged, so the user knows where to find it.
+func (s *stringUtils) writeClipboard(payload string) error {
+	opts := s.clipboardOptions()
+
+	used, err := s.osLayer.WriteClipboard(opts, payload)
+	if err != nil {
+		return fmt.Errorf("os: %v", err)
+	}
+
+	if used == clipboard.File && opts.Backend != clipboard.File {
+		log.Warnf("no clipboard available, wrote to '%s'", opts.File)
+	}
+
+	return nil
+}
+
+// ClipHistory lists the recorded clips, most recent first.
+func (s *stringUtils) ClipHistory() ([]cliphistory.Entry, error) {
+	return s.history.List()
+}
+
+// GetClip returns the n-th most recent clip, starting at 1.
+func (s *stringUtils) GetClip(n int) (*cliphistory.Entry, error) {
+	return s.history.Get(n)
+}
+
+// RestoreClip copies the n-th most recent clip back to the clipboard.
+func (s *stringUtils) RestoreClip(n int) (*cliphistory.Entry, error) {
+	entry, err := s.history.Get(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.writeClipboard(entry.Payload); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// PlanApplyClipboard reads a response from the input, and compares the files
+// it holds with the ones under the root.
+func (s *stringUtils) PlanApplyClipboard(opts *pasteback.Options) (*pasteback.Plan, error) {
+	if opts == nil {
+		return nil, errors.New(sysconsts.ErrOptsNil)
+	}
+
+	opts.Root = strings.TrimSpace(opts.Root)
+	if opts.Root == "" {
+		return nil, errors.New(sysconsts.ErrRootMissing)
+	}
+
+	var text string
+	var err error
+	if strings.TrimSpace(opts.Input) == "" {
+		text, _, err = s.osLayer.ReadClipboard(s.clipboardOptions())
+	} else {
+		text, err = s.osLayer.ReadInput(opts.Input)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os: %v", err)
+	}
+
+	files, err := pasteback.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("parse: %v", err)
+	}
+
+	plan, err := pasteback.NewPlan(opts.Root, files)
+	if err != nil {
+		return nil, fmt.Errorf("plan: %v", err)
+	}
+
+	return plan, nil
+}
+
+// ApplyClipboard writes the changes of the plan, backing up the files it
+// overwrites in the app dir.
+func (s *stringUtils) ApplyClipboard(plan *pasteback.Plan) (*pasteback.Result, error) {
+	backupDir := pasteback.NewBackupDir(filepath.Join(s.conf.Settings.AppDir, pasteback.BackupDirName))
+
+	result, err := pasteback.Apply(plan, backupDir)
+	if err != nil {
+		return result, fmt.Errorf("apply: %v", err)
+	}
+
+	return result, nil
+}
+
+// ListSnippets lists the stored snippets, sorted by name.
+func (s *stringUtils) ListSnippets() ([]snippet.Snippet, error) {
+	return s.snippets.List()
+}
+
+// GetSnippet returns the snippet with the name.
+func (s *stringUtils) GetSnippet(name string) (*snippet.Snippet, error) {
+	return s.snippets.Get(name)
+}
+
+// AddSnippets stores the snippets, replacing existing ones only when overwriting.
+func (s *stringUtils) AddSnippets(overwrite bool, snippets ...snippet.Snippet) error {
+	return s.snippets.Add(overwrite, snippets...)
+}
+
+// RemoveSnippet deletes the snippet with the name.
+func (s *stringUtils) RemoveSnippet(name string) error {
+	return s.snippets.Remove(name)
+}
+
+// SearchSnippets ranks the snippets matching the query, best first.
+func (s *stringUtils) SearchSnippets(query string) ([]snippet.Match, error) {
+	snippets, err := s.snippets.List()
+	if err != nil {
+		return nil, err
+	}
+	return snippet.Search(snippets, query), nil
+}
+
+// ClipSnippet fills in the placeholders of the snippet, and writes it to the
+// output, or the clipboard when empty.
+func (s *stringUtils) ClipSnippet(name string, values map[string]string, output string) error {
+	snip, err := s.snippets.Get(name)
+	if err != nil {
+		return err
+	}
+
+	text, err := snippet.Expand(snip.Text, values)
+	if err != nil {
+		return fmt.Errorf("expand '%s': %v", name, err)
+	}
+
+	return s.WriteOutput(output, text, cliphistory.Origin{Command: cliphistory.CommandSnippet, Source: name})
+}
+
+// applyClipDefaults validates the root, and fills the exclusions,
+// byte budgets and redaction patterns file from the config.
+func (s *stringUtils) applyClipDefaults(opts *cliputil.ClipOptions) error {
+	opts.Root = strings.TrimSpace(opts.Root)
+	if opts.Root == "" {
+		return errors.New(sysconsts.ErrRootMissing)
+	}
+
+	if opts.Exclude == nil {
+		opts.Exclude = make([]string, 0)
+	}
+
+	opts.Exclude = append(opts.Exclude, s.conf.CopyToClipboard.Exclusions...)
+
+	if opts.MaxFileBytes == 0 {
+		opts.MaxFileBytes = s.conf.CopyToClipboard.MaxFileBytes
+	}
+
+	if opts.MaxTotalBytes == 0 {
+		opts.MaxTotalBytes = s.conf.CopyToClipboard.MaxTotalBytes
+	}
+
+	if opts.Redact.PatternsFile == "" {
+		opts.Redact.PatternsFile = s.conf.CopyToClipboard.RedactPatternsFile
+	}
+
+	return nil
+}
+
+// clipboardOptions selects the configured clipboard backend.
+func (s *stringUtils) clipboardOptions() *clipboard.Options {
+	return &clipboard.Options{
+		Backend: s.conf.CopyToClipboard.Backend,
+		File:    s.conf.CopyToClipboard.File,
+	}
+}
+
+// absPath makes the recorded source independent of where the command ran.
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
 }
@@ -2,18 +2,26 @@ package strsrv
 
 import (
 	"errors"
-	"github.com/dembygenesis/local.tools/internal/cli/clifakes"
 	"github.com/dembygenesis/local.tools/internal/config"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/cliphistory"
+	"github.com/dembygenesis/local.tools/internal/lib/pasteback"
+	"github.com/dembygenesis/local.tools/internal/lib/preface"
+	"github.com/dembygenesis/local.tools/internal/lib/prompt"
+	"github.com/dembygenesis/local.tools/internal/lib/snippet"
+	"github.com/dembygenesis/local.tools/internal/services/strsrv/strsrvfakes"
 	"github.com/dembygenesis/local.tools/internal/sysconsts"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
 	"github.com/stretchr/testify/require"
+	"path/filepath"
 	"testing"
 )
 
 func Test_New_Success(t *testing.T) {
 	conf := config.App{}
-	fakeOsLayer := clifakes.FakeStringService{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
 
-	fakeOsLayer.CopyRootPathToClipboardReturns(nil, nil)
+	fakeOsLayer.RenderRootPathReturns("", nil, nil)
 
 	_, err := New(&conf, &fakeOsLayer)
 	require.NoError(t, err, "config error")
@@ -24,51 +32,470 @@ func Test_CopyRootPathToClipboard_Success(t *testing.T) {
 	conf.CopyToClipboard = config.CopyToClipboard{
 		Exclusions: []string{"ab", "cd"},
 	}
-	fakeOsLayer := clifakes.FakeStringService{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
 
-	fakeOsLayer.CopyRootPathToClipboardReturns(nil, nil)
+	fakeOsLayer.RenderRootPathReturns("", nil, nil)
 
 	fakeStringUtils, err := New(&conf, &fakeOsLayer)
 	require.NoError(t, err, "config error")
 
-	_, err = fakeStringUtils.CopyRootPathToClipboard("test", nil)
+	_, err = fakeStringUtils.CopyRootPathToClipboard(&cliputil.ClipOptions{Root: "test"})
 	require.NoError(t, err, "no error expected")
 }
 
 func Test_CopyRootPathToClipboard_Fail_Empty_Root(t *testing.T) {
 	conf := config.App{}
-	osLayer := clifakes.FakeStringService{}
+	osLayer := strsrvfakes.FakeOsLayer{}
 
-	osLayer.CopyRootPathToClipboardReturns(nil, errors.New("mock error"))
+	osLayer.RenderRootPathReturns("", nil, errors.New("mock error"))
 
 	fakeStringUtils, err := New(&conf, &osLayer)
 	require.NoError(t, err, "config error")
 
-	_, err = fakeStringUtils.CopyRootPathToClipboard("test", nil)
+	_, err = fakeStringUtils.CopyRootPathToClipboard(&cliputil.ClipOptions{Root: "test"})
 	require.Error(t, err, "error expected")
 	require.Contains(t, err.Error(), "os:")
 }
 
 func Test_New_Conf_Fail(t *testing.T) {
 	var conf *config.App
-	fakeOsLayer := clifakes.FakeStringService{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
 
-	fakeOsLayer.CopyRootPathToClipboardReturns(nil, nil)
+	fakeOsLayer.RenderRootPathReturns("", nil, nil)
 	_, err := New(conf, &fakeOsLayer)
 	require.Error(t, err)
 }
 
 func Test_CopyRootPathToClipBoard_Empty_Root_Fail(t *testing.T) {
 	conf := config.App{}
-	fakeOsLayer := clifakes.FakeStringService{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
 
-	fakeOsLayer.CopyRootPathToClipboardReturns(nil, errors.New("an error"))
+	fakeOsLayer.RenderRootPathReturns("", nil, errors.New("an error"))
 
 	fakeStringUtils, err := New(&conf, &fakeOsLayer)
 	require.NoError(t, err, "config error")
 
-	_, err = fakeStringUtils.CopyRootPathToClipboard("", nil)
+	_, err = fakeStringUtils.CopyRootPathToClipboard(&cliputil.ClipOptions{Root: ""})
 
 	require.Error(t, err, "error expected")
 	require.Contains(t, err.Error(), errors.New(sysconsts.ErrRootMissing).Error())
 }
+
+func Test_CopyRootPathToClipboard_Applies_Config_Budgets(t *testing.T) {
+	conf := config.App{}
+	conf.CopyToClipboard = config.CopyToClipboard{
+		MaxFileBytes:       10,
+		MaxTotalBytes:      20,
+		RedactPatternsFile: "redact-patterns",
+	}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	_, err = fakeStringUtils.CopyRootPathToClipboard(&cliputil.ClipOptions{Root: "test", MaxTotalBytes: -1})
+	require.NoError(t, err, "no error expected")
+
+	opts := fakeOsLayer.RenderRootPathArgsForCall(0)
+	require.Equal(t, int64(10), opts.MaxFileBytes, "config budget should fill unset value")
+	require.Equal(t, int64(-1), opts.MaxTotalBytes, "explicit value should win over config")
+	require.Equal(t, "redact-patterns", opts.Redact.PatternsFile, "config should fill the patterns file")
+	require.False(t, opts.Redact.Disabled, "redaction is on by default")
+}
+
+func Test_CopyContextToClipboard_Applies_Config_Defaults(t *testing.T) {
+	conf := config.App{}
+	conf.CopyToClipboard = config.CopyToClipboard{
+		Exclusions: []string{".git"},
+		MaxTokens:  1000,
+		Tokenizer:  "bpe",
+		BPEFile:    "ranks.tiktoken",
+	}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	_, err = fakeStringUtils.CopyContextToClipboard(&cliputil.ContextOptions{
+		ClipOptions: cliputil.ClipOptions{Root: " test "},
+	})
+	require.NoError(t, err, "no error expected")
+
+	opts := fakeOsLayer.RenderContextArgsForCall(0)
+	require.Equal(t, "test", opts.Root)
+	require.Equal(t, []string{".git"}, opts.Exclude)
+	require.Equal(t, 1000, opts.MaxTokens)
+	require.Equal(t, "bpe", opts.Tokenizer.Kind)
+	require.Equal(t, "ranks.tiktoken", opts.Tokenizer.BPEFile)
+}
+
+func Test_CopyContextToClipboard_Fail(t *testing.T) {
+	conf := config.App{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+	fakeOsLayer.RenderContextReturns("", nil, errors.New("mock error"))
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	_, err = fakeStringUtils.CopyContextToClipboard(nil)
+	require.Error(t, err)
+
+	_, err = fakeStringUtils.CopyContextToClipboard(&cliputil.ContextOptions{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), sysconsts.ErrRootMissing)
+
+	_, err = fakeStringUtils.CopyContextToClipboard(&cliputil.ContextOptions{
+		ClipOptions: cliputil.ClipOptions{Root: "test"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "os:")
+}
+
+func Test_RenderRootPath_Applies_Config_Defaults(t *testing.T) {
+	conf := config.App{}
+	conf.CopyToClipboard = config.CopyToClipboard{
+		Exclusions:   []string{"vendor"},
+		MaxFileBytes: 10,
+	}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+	fakeOsLayer.RenderRootPathReturns("rendered", &cliputil.ClipReport{}, nil)
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	rendered, _, err := fakeStringUtils.RenderRootPath(&cliputil.ClipOptions{Root: " test "})
+	require.NoError(t, err)
+	require.Equal(t, "rendered", rendered)
+
+	opts := fakeOsLayer.RenderRootPathArgsForCall(0)
+	require.Equal(t, "test", opts.Root)
+	require.Equal(t, []string{"vendor"}, opts.Exclude)
+	require.Equal(t, int64(10), opts.MaxFileBytes)
+}
+
+func Test_RenderRootPath_WriteOutput_Fail(t *testing.T) {
+	conf := config.App{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+	fakeOsLayer.RenderRootPathReturns("", nil, errors.New("mock error"))
+	fakeOsLayer.WriteOutputReturns(errors.New("mock error"))
+	fakeOsLayer.WriteClipboardReturns("", errors.New("mock error"))
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	_, _, err = fakeStringUtils.RenderRootPath(nil)
+	require.Error(t, err)
+
+	_, _, err = fakeStringUtils.RenderRootPath(&cliputil.ClipOptions{})
+	require.ErrorContains(t, err, sysconsts.ErrRootMissing)
+
+	_, _, err = fakeStringUtils.RenderRootPath(&cliputil.ClipOptions{Root: "test"})
+	require.ErrorContains(t, err, "os:")
+
+	err = fakeStringUtils.WriteOutput("", "payload", cliphistory.Origin{})
+	require.ErrorContains(t, err, "os:")
+
+	err = fakeStringUtils.WriteOutput("-", "payload", cliphistory.Origin{})
+	require.ErrorContains(t, err, "os:")
+}
+
+func Test_WriteOutput_Records_Clipboard_History(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.CopyToClipboard = config.CopyToClipboard{HistoryLimit: 10}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+	fakeOsLayer.RenderRootPathReturns("rendered", &cliputil.ClipReport{}, nil)
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	_, err = fakeStringUtils.CopyRootPathToClipboard(&cliputil.ClipOptions{Root: "test"})
+	require.NoError(t, err)
+
+	_, err = fakeStringUtils.CopyRootPathToClipboard(&cliputil.ClipOptions{Root: "test", Output: "-"})
+	require.NoError(t, err)
+
+	err = fakeStringUtils.WriteOutput("", "the prompt", cliphistory.Origin{Command: cliphistory.CommandPrompt})
+	require.NoError(t, err)
+
+	entries, err := fakeStringUtils.ClipHistory()
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "only clipboard writes are recorded")
+	require.Equal(t, "the prompt", entries[0].Preview(0))
+	require.Equal(t, cliphistory.CommandFiles, entries[1].Command)
+	require.True(t, filepath.IsAbs(entries[1].Source))
+
+	entry, err := fakeStringUtils.RestoreClip(2)
+	require.NoError(t, err)
+	require.Equal(t, "rendered", entry.Payload)
+
+	require.Equal(t, 1, fakeOsLayer.WriteOutputCallCount(), "only the stdout copy goes through the output")
+	_, payload := fakeOsLayer.WriteClipboardArgsForCall(fakeOsLayer.WriteClipboardCallCount() - 1)
+	require.Equal(t, "rendered", payload)
+
+	entries, err = fakeStringUtils.ClipHistory()
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "restoring is not recorded again")
+
+	_, err = fakeStringUtils.RestoreClip(3)
+	require.Error(t, err)
+}
+
+func Test_WriteOutput_Uses_Configured_Clipboard(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.CopyToClipboard = config.CopyToClipboard{Backend: clipboard.Tmux, File: "clip.txt"}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+	fakeOsLayer.WriteClipboardReturns(clipboard.Tmux, nil)
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	err = fakeStringUtils.WriteOutput("", "payload", cliphistory.Origin{})
+	require.NoError(t, err)
+
+	require.Equal(t, 0, fakeOsLayer.WriteOutputCallCount())
+	require.Equal(t, 1, fakeOsLayer.WriteClipboardCallCount())
+
+	opts, payload := fakeOsLayer.WriteClipboardArgsForCall(0)
+	require.Equal(t, &clipboard.Options{Backend: clipboard.Tmux, File: "clip.txt"}, opts)
+	require.Equal(t, "payload", payload)
+}
+
+func Test_PlanApplyClipboard(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.CopyToClipboard = config.CopyToClipboard{Backend: clipboard.Tmux}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+	fakeOsLayer.ReadClipboardReturns("--- main.go ---\n\npackage main\n", clipboard.Tmux, nil)
+	fakeOsLayer.ReadInputReturns("no files here", nil)
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	root := t.TempDir()
+	plan, err := fakeStringUtils.PlanApplyClipboard(&pasteback.Options{Root: root})
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	require.Equal(t, pasteback.OpCreate, plan.Changes[0].Op)
+	require.Equal(t, clipboard.Tmux, fakeOsLayer.ReadClipboardArgsForCall(0).Backend)
+
+	_, err = fakeStringUtils.PlanApplyClipboard(&pasteback.Options{Root: root, Input: "-"})
+	require.ErrorContains(t, err, "parse:")
+	require.Equal(t, "-", fakeOsLayer.ReadInputArgsForCall(0))
+
+	_, err = fakeStringUtils.PlanApplyClipboard(&pasteback.Options{})
+	require.ErrorContains(t, err, sysconsts.ErrRootMissing)
+
+	result, err := fakeStringUtils.ApplyClipboard(plan)
+	require.NoError(t, err)
+	require.Equal(t, []string{"main.go"}, result.Written)
+	require.FileExists(t, filepath.Join(root, "main.go"))
+}
+
+func Test_ClipSnippet(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.CopyToClipboard = config.CopyToClipboard{HistoryLimit: 10}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	fakeStringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	err = fakeStringUtils.AddSnippets(false, snippet.Snippet{Name: "greet", Text: "hi {{name}}, {{mood|ok}}?"})
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(conf.Settings.AppDir, snippet.FileName))
+
+	err = fakeStringUtils.ClipSnippet("greet", map[string]string{"name": "bob"}, "")
+	require.NoError(t, err)
+	_, payload := fakeOsLayer.WriteClipboardArgsForCall(0)
+	require.Equal(t, "hi bob, ok?", payload)
+
+	entries, err := fakeStringUtils.ClipHistory()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, cliphistory.CommandSnippet, entries[0].Command)
+	require.Equal(t, "greet", entries[0].Source)
+
+	err = fakeStringUtils.ClipSnippet("greet", nil, "")
+	require.ErrorContains(t, err, "missing values for: name")
+
+	err = fakeStringUtils.ClipSnippet("nope", nil, "")
+	require.ErrorContains(t, err, "not found")
+
+	matches, err := fakeStringUtils.SearchSnippets("gr")
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+
+	require.NoError(t, fakeStringUtils.RemoveSnippet("greet"))
+	snippets, err := fakeStringUtils.ListSnippets()
+	require.NoError(t, err)
+	require.Empty(t, snippets)
+}
+
+func Test_CopyPromptToClipboard_Success(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	fakeOsLayer.RenderPrefaceReturns("the preface", nil)
+	fakeOsLayer.RenderRootPathReturns("the files", &cliputil.ClipReport{Files: []string{"main.go"}}, nil)
+
+	stringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	report, err := stringUtils.CopyPromptToClipboard(&prompt.Options{
+		Preface:  preface.Options{Name: "go-review"},
+		Clip:     cliputil.ClipOptions{Root: ".", Output: "-"},
+		Question: "the question",
+		Order:    []string{"question", "preface", "files"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"main.go"}, report.Files)
+
+	libraryDir, prefaceOpts := fakeOsLayer.RenderPrefaceArgsForCall(0)
+	require.Equal(t, filepath.Join(conf.Settings.AppDir, preface.DirName), libraryDir, "the prefaces live in the app dir")
+	require.Equal(t, "go-review", prefaceOpts.Name)
+
+	output, payload := fakeOsLayer.WriteOutputArgsForCall(0)
+	require.Equal(t, "-", output)
+	require.Equal(t, "=== QUESTION ===\n\nthe question\n\n=== PREFACE ===\n\nthe preface\n\n=== FILES ===\n\nthe files\n", payload)
+}
+
+func Test_CopyPromptToClipboard_Records_History(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.CopyToClipboard.HistoryLimit = 5
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	fakeOsLayer.RenderRootPathReturns("the files", &cliputil.ClipReport{}, nil)
+
+	stringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	_, err = stringUtils.CopyPromptToClipboard(&prompt.Options{
+		NoPreface: true,
+		Clip:      cliputil.ClipOptions{Root: "."},
+		Question:  "the question",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, fakeOsLayer.WriteClipboardCallCount(), "no output goes to the clipboard")
+
+	entries, err := stringUtils.ClipHistory()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, cliphistory.CommandPrompt, entries[0].Command)
+	require.True(t, filepath.IsAbs(entries[0].Source), "the root is recorded as an absolute path")
+}
+
+func Test_ClipPreface_Records_History(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.CopyToClipboard.HistoryLimit = 5
+	conf.CopyToClipboard.Backend = clipboard.Tmux
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	fakeOsLayer.RenderPrefaceReturns("the preface", nil)
+	fakeOsLayer.WriteClipboardReturns(clipboard.Tmux, nil)
+
+	stringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	require.NoError(t, stringUtils.ClipPreface(&preface.Options{Name: " go-review "}))
+
+	libraryDir, opts := fakeOsLayer.RenderPrefaceArgsForCall(0)
+	require.Equal(t, filepath.Join(conf.Settings.AppDir, preface.DirName), libraryDir)
+	require.Equal(t, " go-review ", opts.Name)
+
+	clipOpts, payload := fakeOsLayer.WriteClipboardArgsForCall(0)
+	require.Equal(t, clipboard.Tmux, clipOpts.Backend, "the configured backend is used")
+	require.Equal(t, "the preface", payload)
+
+	require.NoError(t, stringUtils.ClipPreface(&preface.Options{}))
+
+	entries, err := stringUtils.ClipHistory()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, cliphistory.Origin{Command: cliphistory.CommandPreface, Source: preface.DefaultName}, entries[0].Origin, "the default preface is recorded by name")
+	require.Equal(t, cliphistory.Origin{Command: cliphistory.CommandPreface, Source: "go-review"}, entries[1].Origin)
+}
+
+func Test_ClipPreface_Fail(t *testing.T) {
+	conf := config.App{}
+	conf.Settings.AppDir = t.TempDir()
+	conf.CopyToClipboard.HistoryLimit = 5
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	stringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	err = stringUtils.ClipPreface(nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), sysconsts.ErrOptsNil)
+
+	fakeOsLayer.RenderPrefaceReturns("", errors.New("mock error"))
+	err = stringUtils.ClipPreface(&preface.Options{Name: "nothing-like-it"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "render preface")
+
+	fakeOsLayer.RenderPrefaceReturns("the preface", nil)
+	fakeOsLayer.WriteClipboardReturns("", errors.New("mock error"))
+	err = stringUtils.ClipPreface(&preface.Options{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "clip preface")
+
+	entries, err := stringUtils.ClipHistory()
+	require.NoError(t, err)
+	require.Empty(t, entries, "failed clips aren't recorded")
+}
+
+func Test_CopyPromptToClipboard_Skips_Sections(t *testing.T) {
+	conf := config.App{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	stringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	report, err := stringUtils.CopyPromptToClipboard(&prompt.Options{
+		NoPreface: true,
+		Clip:      cliputil.ClipOptions{Output: "-"},
+		Question:  "the question",
+	})
+	require.NoError(t, err)
+	require.Empty(t, report.Files)
+
+	require.Zero(t, fakeOsLayer.RenderPrefaceCallCount(), "no preface requested")
+	require.Zero(t, fakeOsLayer.RenderRootPathCallCount(), "no root given")
+
+	_, payload := fakeOsLayer.WriteOutputArgsForCall(0)
+	require.Equal(t, "=== QUESTION ===\n\nthe question\n", payload)
+}
+
+func Test_CopyPromptToClipboard_Fail(t *testing.T) {
+	conf := config.App{}
+	fakeOsLayer := strsrvfakes.FakeOsLayer{}
+
+	stringUtils, err := New(&conf, &fakeOsLayer)
+	require.NoError(t, err, "config error")
+
+	_, err = stringUtils.CopyPromptToClipboard(nil)
+	require.ErrorContains(t, err, sysconsts.ErrOptsNil)
+
+	_, err = stringUtils.CopyPromptToClipboard(&prompt.Options{Question: " "})
+	require.ErrorContains(t, err, "question is empty")
+
+	_, err = stringUtils.CopyPromptToClipboard(&prompt.Options{Question: "q", Order: []string{"answer"}})
+	require.ErrorContains(t, err, "order:")
+
+	fakeOsLayer.RenderPrefaceReturns("", errors.New("mock error"))
+	_, err = stringUtils.CopyPromptToClipboard(&prompt.Options{Question: "q"})
+	require.ErrorContains(t, err, "render preface:")
+
+	fakeOsLayer.RenderRootPathReturns("", nil, errors.New("mock error"))
+	_, err = stringUtils.CopyPromptToClipboard(&prompt.Options{Question: "q", NoPreface: true, Clip: cliputil.ClipOptions{Root: "."}})
+	require.ErrorContains(t, err, "render files:")
+
+	fakeOsLayer.WriteOutputReturns(errors.New("mock error"))
+	_, err = stringUtils.CopyPromptToClipboard(&prompt.Options{Question: "q", NoPreface: true, Clip: cliputil.ClipOptions{Output: "-"}})
+	require.ErrorContains(t, err, "write prompt:")
+}
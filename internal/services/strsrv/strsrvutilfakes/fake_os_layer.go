@@ -3,43 +3,376 @@ package strsrvutilfakes
 
 import (
 	"sync"
+
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/utilities/cliputil"
 )
 
 type FakeOsLayer struct {
-	CopyRootPathToClipboardStub        func(string, []string) ([]string, error)
-	copyRootPathToClipboardMutex       sync.RWMutex
-	copyRootPathToClipboardArgsForCall []struct {
+	ReadClipboardStub        func(*clipboard.Options) (string, string, error)
+	readClipboardMutex       sync.RWMutex
+	readClipboardArgsForCall []struct {
+		arg1 *clipboard.Options
+	}
+	readClipboardReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	readClipboardReturnsOnCall map[int]struct {
+		result1 string
+		result2 string
+		result3 error
+	}
+	ReadInputStub        func(string) (string, error)
+	readInputMutex       sync.RWMutex
+	readInputArgsForCall []struct {
 		arg1 string
-		arg2 []string
 	}
-	copyRootPathToClipboardReturns struct {
-		result1 []string
+	readInputReturns struct {
+		result1 string
+		result2 error
+	}
+	readInputReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RenderContextStub        func(*cliputil.ContextOptions) (string, *cliputil.ContextReport, error)
+	renderContextMutex       sync.RWMutex
+	renderContextArgsForCall []struct {
+		arg1 *cliputil.ContextOptions
+	}
+	renderContextReturns struct {
+		result1 string
+		result2 *cliputil.ContextReport
+		result3 error
+	}
+	renderContextReturnsOnCall map[int]struct {
+		result1 string
+		result2 *cliputil.ContextReport
+		result3 error
+	}
+	RenderRootPathStub        func(*cliputil.ClipOptions) (string, *cliputil.ClipReport, error)
+	renderRootPathMutex       sync.RWMutex
+	renderRootPathArgsForCall []struct {
+		arg1 *cliputil.ClipOptions
+	}
+	renderRootPathReturns struct {
+		result1 string
+		result2 *cliputil.ClipReport
+		result3 error
+	}
+	renderRootPathReturnsOnCall map[int]struct {
+		result1 string
+		result2 *cliputil.ClipReport
+		result3 error
+	}
+	WriteClipboardStub        func(*clipboard.Options, string) (string, error)
+	writeClipboardMutex       sync.RWMutex
+	writeClipboardArgsForCall []struct {
+		arg1 *clipboard.Options
+		arg2 string
+	}
+	writeClipboardReturns struct {
+		result1 string
 		result2 error
 	}
-	copyRootPathToClipboardReturnsOnCall map[int]struct {
-		result1 []string
+	writeClipboardReturnsOnCall map[int]struct {
+		result1 string
 		result2 error
 	}
+	WriteOutputStub        func(string, string) error
+	writeOutputMutex       sync.RWMutex
+	writeOutputArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	writeOutputReturns struct {
+		result1 error
+	}
+	writeOutputReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeOsLayer) CopyRootPathToClipboard(arg1 string, arg2 []string) ([]string, error) {
-	var arg2Copy []string
-	if arg2 != nil {
-		arg2Copy = make([]string, len(arg2))
-		copy(arg2Copy, arg2)
+func (fake *FakeOsLayer) ReadClipboard(arg1 *clipboard.Options) (string, string, error) {
+	fake.readClipboardMutex.Lock()
+	ret, specificReturn := fake.readClipboardReturnsOnCall[len(fake.readClipboardArgsForCall)]
+	fake.readClipboardArgsForCall = append(fake.readClipboardArgsForCall, struct {
+		arg1 *clipboard.Options
+	}{arg1})
+	stub := fake.ReadClipboardStub
+	fakeReturns := fake.readClipboardReturns
+	fake.recordInvocation("ReadClipboard", []interface{}{arg1})
+	fake.readClipboardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
 	}
-	fake.copyRootPathToClipboardMutex.Lock()
-	ret, specificReturn := fake.copyRootPathToClipboardReturnsOnCall[len(fake.copyRootPathToClipboardArgsForCall)]
-	fake.copyRootPathToClipboardArgsForCall = append(fake.copyRootPathToClipboardArgsForCall, struct {
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeOsLayer) ReadClipboardCallCount() int {
+	fake.readClipboardMutex.RLock()
+	defer fake.readClipboardMutex.RUnlock()
+	return len(fake.readClipboardArgsForCall)
+}
+
+func (fake *FakeOsLayer) ReadClipboardCalls(stub func(*clipboard.Options) (string, string, error)) {
+	fake.readClipboardMutex.Lock()
+	defer fake.readClipboardMutex.Unlock()
+	fake.ReadClipboardStub = stub
+}
+
+func (fake *FakeOsLayer) ReadClipboardArgsForCall(i int) *clipboard.Options {
+	fake.readClipboardMutex.RLock()
+	defer fake.readClipboardMutex.RUnlock()
+	argsForCall := fake.readClipboardArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeOsLayer) ReadClipboardReturns(result1 string, result2 string, result3 error) {
+	fake.readClipboardMutex.Lock()
+	defer fake.readClipboardMutex.Unlock()
+	fake.ReadClipboardStub = nil
+	fake.readClipboardReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeOsLayer) ReadClipboardReturnsOnCall(i int, result1 string, result2 string, result3 error) {
+	fake.readClipboardMutex.Lock()
+	defer fake.readClipboardMutex.Unlock()
+	fake.ReadClipboardStub = nil
+	if fake.readClipboardReturnsOnCall == nil {
+		fake.readClipboardReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 string
+			result3 error
+		})
+	}
+	fake.readClipboardReturnsOnCall[i] = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeOsLayer) ReadInput(arg1 string) (string, error) {
+	fake.readInputMutex.Lock()
+	ret, specificReturn := fake.readInputReturnsOnCall[len(fake.readInputArgsForCall)]
+	fake.readInputArgsForCall = append(fake.readInputArgsForCall, struct {
 		arg1 string
-		arg2 []string
-	}{arg1, arg2Copy})
-	stub := fake.CopyRootPathToClipboardStub
-	fakeReturns := fake.copyRootPathToClipboardReturns
-	fake.recordInvocation("CopyRootPathToClipboard", []interface{}{arg1, arg2Copy})
-	fake.copyRootPathToClipboardMutex.Unlock()
+	}{arg1})
+	stub := fake.ReadInputStub
+	fakeReturns := fake.readInputReturns
+	fake.recordInvocation("ReadInput", []interface{}{arg1})
+	fake.readInputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeOsLayer) ReadInputCallCount() int {
+	fake.readInputMutex.RLock()
+	defer fake.readInputMutex.RUnlock()
+	return len(fake.readInputArgsForCall)
+}
+
+func (fake *FakeOsLayer) ReadInputCalls(stub func(string) (string, error)) {
+	fake.readInputMutex.Lock()
+	defer fake.readInputMutex.Unlock()
+	fake.ReadInputStub = stub
+}
+
+func (fake *FakeOsLayer) ReadInputArgsForCall(i int) string {
+	fake.readInputMutex.RLock()
+	defer fake.readInputMutex.RUnlock()
+	argsForCall := fake.readInputArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeOsLayer) ReadInputReturns(result1 string, result2 error) {
+	fake.readInputMutex.Lock()
+	defer fake.readInputMutex.Unlock()
+	fake.ReadInputStub = nil
+	fake.readInputReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOsLayer) ReadInputReturnsOnCall(i int, result1 string, result2 error) {
+	fake.readInputMutex.Lock()
+	defer fake.readInputMutex.Unlock()
+	fake.ReadInputStub = nil
+	if fake.readInputReturnsOnCall == nil {
+		fake.readInputReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.readInputReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeOsLayer) RenderContext(arg1 *cliputil.ContextOptions) (string, *cliputil.ContextReport, error) {
+	fake.renderContextMutex.Lock()
+	ret, specificReturn := fake.renderContextReturnsOnCall[len(fake.renderContextArgsForCall)]
+	fake.renderContextArgsForCall = append(fake.renderContextArgsForCall, struct {
+		arg1 *cliputil.ContextOptions
+	}{arg1})
+	stub := fake.RenderContextStub
+	fakeReturns := fake.renderContextReturns
+	fake.recordInvocation("RenderContext", []interface{}{arg1})
+	fake.renderContextMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeOsLayer) RenderContextCallCount() int {
+	fake.renderContextMutex.RLock()
+	defer fake.renderContextMutex.RUnlock()
+	return len(fake.renderContextArgsForCall)
+}
+
+func (fake *FakeOsLayer) RenderContextCalls(stub func(*cliputil.ContextOptions) (string, *cliputil.ContextReport, error)) {
+	fake.renderContextMutex.Lock()
+	defer fake.renderContextMutex.Unlock()
+	fake.RenderContextStub = stub
+}
+
+func (fake *FakeOsLayer) RenderContextArgsForCall(i int) *cliputil.ContextOptions {
+	fake.renderContextMutex.RLock()
+	defer fake.renderContextMutex.RUnlock()
+	argsForCall := fake.renderContextArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeOsLayer) RenderContextReturns(result1 string, result2 *cliputil.ContextReport, result3 error) {
+	fake.renderContextMutex.Lock()
+	defer fake.renderContextMutex.Unlock()
+	fake.RenderContextStub = nil
+	fake.renderContextReturns = struct {
+		result1 string
+		result2 *cliputil.ContextReport
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeOsLayer) RenderContextReturnsOnCall(i int, result1 string, result2 *cliputil.ContextReport, result3 error) {
+	fake.renderContextMutex.Lock()
+	defer fake.renderContextMutex.Unlock()
+	fake.RenderContextStub = nil
+	if fake.renderContextReturnsOnCall == nil {
+		fake.renderContextReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 *cliputil.ContextReport
+			result3 error
+		})
+	}
+	fake.renderContextReturnsOnCall[i] = struct {
+		result1 string
+		result2 *cliputil.ContextReport
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeOsLayer) RenderRootPath(arg1 *cliputil.ClipOptions) (string, *cliputil.ClipReport, error) {
+	fake.renderRootPathMutex.Lock()
+	ret, specificReturn := fake.renderRootPathReturnsOnCall[len(fake.renderRootPathArgsForCall)]
+	fake.renderRootPathArgsForCall = append(fake.renderRootPathArgsForCall, struct {
+		arg1 *cliputil.ClipOptions
+	}{arg1})
+	stub := fake.RenderRootPathStub
+	fakeReturns := fake.renderRootPathReturns
+	fake.recordInvocation("RenderRootPath", []interface{}{arg1})
+	fake.renderRootPathMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeOsLayer) RenderRootPathCallCount() int {
+	fake.renderRootPathMutex.RLock()
+	defer fake.renderRootPathMutex.RUnlock()
+	return len(fake.renderRootPathArgsForCall)
+}
+
+func (fake *FakeOsLayer) RenderRootPathCalls(stub func(*cliputil.ClipOptions) (string, *cliputil.ClipReport, error)) {
+	fake.renderRootPathMutex.Lock()
+	defer fake.renderRootPathMutex.Unlock()
+	fake.RenderRootPathStub = stub
+}
+
+func (fake *FakeOsLayer) RenderRootPathArgsForCall(i int) *cliputil.ClipOptions {
+	fake.renderRootPathMutex.RLock()
+	defer fake.renderRootPathMutex.RUnlock()
+	argsForCall := fake.renderRootPathArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeOsLayer) RenderRootPathReturns(result1 string, result2 *cliputil.ClipReport, result3 error) {
+	fake.renderRootPathMutex.Lock()
+	defer fake.renderRootPathMutex.Unlock()
+	fake.RenderRootPathStub = nil
+	fake.renderRootPathReturns = struct {
+		result1 string
+		result2 *cliputil.ClipReport
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeOsLayer) RenderRootPathReturnsOnCall(i int, result1 string, result2 *cliputil.ClipReport, result3 error) {
+	fake.renderRootPathMutex.Lock()
+	defer fake.renderRootPathMutex.Unlock()
+	fake.RenderRootPathStub = nil
+	if fake.renderRootPathReturnsOnCall == nil {
+		fake.renderRootPathReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 *cliputil.ClipReport
+			result3 error
+		})
+	}
+	fake.renderRootPathReturnsOnCall[i] = struct {
+		result1 string
+		result2 *cliputil.ClipReport
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeOsLayer) WriteClipboard(arg1 *clipboard.Options, arg2 string) (string, error) {
+	fake.writeClipboardMutex.Lock()
+	ret, specificReturn := fake.writeClipboardReturnsOnCall[len(fake.writeClipboardArgsForCall)]
+	fake.writeClipboardArgsForCall = append(fake.writeClipboardArgsForCall, struct {
+		arg1 *clipboard.Options
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WriteClipboardStub
+	fakeReturns := fake.writeClipboardReturns
+	fake.recordInvocation("WriteClipboard", []interface{}{arg1, arg2})
+	fake.writeClipboardMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2)
 	}
@@ -49,56 +382,128 @@ func (fake *FakeOsLayer) CopyRootPathToClipboard(arg1 string, arg2 []string) ([]
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakeOsLayer) CopyRootPathToClipboardCallCount() int {
-	fake.copyRootPathToClipboardMutex.RLock()
-	defer fake.copyRootPathToClipboardMutex.RUnlock()
-	return len(fake.copyRootPathToClipboardArgsForCall)
+func (fake *FakeOsLayer) WriteClipboardCallCount() int {
+	fake.writeClipboardMutex.RLock()
+	defer fake.writeClipboardMutex.RUnlock()
+	return len(fake.writeClipboardArgsForCall)
 }
 
-func (fake *FakeOsLayer) CopyRootPathToClipboardCalls(stub func(string, []string) ([]string, error)) {
-	fake.copyRootPathToClipboardMutex.Lock()
-	defer fake.copyRootPathToClipboardMutex.Unlock()
-	fake.CopyRootPathToClipboardStub = stub
+func (fake *FakeOsLayer) WriteClipboardCalls(stub func(*clipboard.Options, string) (string, error)) {
+	fake.writeClipboardMutex.Lock()
+	defer fake.writeClipboardMutex.Unlock()
+	fake.WriteClipboardStub = stub
 }
 
-func (fake *FakeOsLayer) CopyRootPathToClipboardArgsForCall(i int) (string, []string) {
-	fake.copyRootPathToClipboardMutex.RLock()
-	defer fake.copyRootPathToClipboardMutex.RUnlock()
-	argsForCall := fake.copyRootPathToClipboardArgsForCall[i]
+func (fake *FakeOsLayer) WriteClipboardArgsForCall(i int) (*clipboard.Options, string) {
+	fake.writeClipboardMutex.RLock()
+	defer fake.writeClipboardMutex.RUnlock()
+	argsForCall := fake.writeClipboardArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeOsLayer) CopyRootPathToClipboardReturns(result1 []string, result2 error) {
-	fake.copyRootPathToClipboardMutex.Lock()
-	defer fake.copyRootPathToClipboardMutex.Unlock()
-	fake.CopyRootPathToClipboardStub = nil
-	fake.copyRootPathToClipboardReturns = struct {
-		result1 []string
+func (fake *FakeOsLayer) WriteClipboardReturns(result1 string, result2 error) {
+	fake.writeClipboardMutex.Lock()
+	defer fake.writeClipboardMutex.Unlock()
+	fake.WriteClipboardStub = nil
+	fake.writeClipboardReturns = struct {
+		result1 string
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeOsLayer) CopyRootPathToClipboardReturnsOnCall(i int, result1 []string, result2 error) {
-	fake.copyRootPathToClipboardMutex.Lock()
-	defer fake.copyRootPathToClipboardMutex.Unlock()
-	fake.CopyRootPathToClipboardStub = nil
-	if fake.copyRootPathToClipboardReturnsOnCall == nil {
-		fake.copyRootPathToClipboardReturnsOnCall = make(map[int]struct {
-			result1 []string
+func (fake *FakeOsLayer) WriteClipboardReturnsOnCall(i int, result1 string, result2 error) {
+	fake.writeClipboardMutex.Lock()
+	defer fake.writeClipboardMutex.Unlock()
+	fake.WriteClipboardStub = nil
+	if fake.writeClipboardReturnsOnCall == nil {
+		fake.writeClipboardReturnsOnCall = make(map[int]struct {
+			result1 string
 			result2 error
 		})
 	}
-	fake.copyRootPathToClipboardReturnsOnCall[i] = struct {
-		result1 []string
+	fake.writeClipboardReturnsOnCall[i] = struct {
+		result1 string
 		result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeOsLayer) WriteOutput(arg1 string, arg2 string) error {
+	fake.writeOutputMutex.Lock()
+	ret, specificReturn := fake.writeOutputReturnsOnCall[len(fake.writeOutputArgsForCall)]
+	fake.writeOutputArgsForCall = append(fake.writeOutputArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WriteOutputStub
+	fakeReturns := fake.writeOutputReturns
+	fake.recordInvocation("WriteOutput", []interface{}{arg1, arg2})
+	fake.writeOutputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeOsLayer) WriteOutputCallCount() int {
+	fake.writeOutputMutex.RLock()
+	defer fake.writeOutputMutex.RUnlock()
+	return len(fake.writeOutputArgsForCall)
+}
+
+func (fake *FakeOsLayer) WriteOutputCalls(stub func(string, string) error) {
+	fake.writeOutputMutex.Lock()
+	defer fake.writeOutputMutex.Unlock()
+	fake.WriteOutputStub = stub
+}
+
+func (fake *FakeOsLayer) WriteOutputArgsForCall(i int) (string, string) {
+	fake.writeOutputMutex.RLock()
+	defer fake.writeOutputMutex.RUnlock()
+	argsForCall := fake.writeOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeOsLayer) WriteOutputReturns(result1 error) {
+	fake.writeOutputMutex.Lock()
+	defer fake.writeOutputMutex.Unlock()
+	fake.WriteOutputStub = nil
+	fake.writeOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOsLayer) WriteOutputReturnsOnCall(i int, result1 error) {
+	fake.writeOutputMutex.Lock()
+	defer fake.writeOutputMutex.Unlock()
+	fake.WriteOutputStub = nil
+	if fake.writeOutputReturnsOnCall == nil {
+		fake.writeOutputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.writeOutputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeOsLayer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.copyRootPathToClipboardMutex.RLock()
-	defer fake.copyRootPathToClipboardMutex.RUnlock()
+	fake.readClipboardMutex.RLock()
+	defer fake.readClipboardMutex.RUnlock()
+	fake.readInputMutex.RLock()
+	defer fake.readInputMutex.RUnlock()
+	fake.renderContextMutex.RLock()
+	defer fake.renderContextMutex.RUnlock()
+	fake.renderRootPathMutex.RLock()
+	defer fake.renderRootPathMutex.RUnlock()
+	fake.writeClipboardMutex.RLock()
+	defer fake.writeClipboardMutex.RUnlock()
+	fake.writeOutputMutex.RLock()
+	defer fake.writeOutputMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
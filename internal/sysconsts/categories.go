@@ -1,7 +1,7 @@
 package sysconsts
 
 const (
-	CategoryTypeUserTypes = "User Type"
+	CategoryTypeUserTypes = "User Types"
 
 	CategorySuperAdmin  = "Super Admin"
 	CategoryAdmin       = "Admin"
@@ -3,10 +3,13 @@ package cliputil
 import (
 	"context"
 	"fmt"
-	"github.com/atotto/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/doc"
+	"github.com/dembygenesis/local.tools/internal/lib/gitlib"
 	"github.com/dembygenesis/local.tools/internal/lib/logger"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
 	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
-	"os"
+	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
 	"path/filepath"
 	"strings"
 )
@@ -15,64 +18,134 @@ var (
 	log = logger.New(context.TODO())
 )
 
-func visit(files *[]string, root string, ignoredPrefixed []string) filepath.WalkFunc {
-	return func(path string, info os.FileInfo, err error) error {
+type ClipOptions struct {
+	Root          string   `mapstructure:"root" validate:"required" json:"root"`
+	Include       []string `mapstructure:"include" json:"include"`
+	Exclude       []string `mapstructure:"exclude" json:"exclude"`
+	MaxFileBytes  int64    `mapstructure:"max_file_bytes" json:"max_file_bytes"`
+	MaxTotalBytes int64    `mapstructure:"max_total_bytes" json:"max_total_bytes"`
 
-		if err != nil {
-			fmt.Printf("Encountered an error accessing path %s: %s\n", path, err)
-			return err
-		}
+	// Format is one of "plain" (default), "markdown", "xml" or "json".
+	Format string `mapstructure:"format" json:"format"`
 
-		relativePath, err := filepath.Rel(root, path)
-		if err != nil {
-			fmt.Printf("Error calculating relative path for %s: %s\n", path, err)
-			return err
-		}
-		for _, prefix := range ignoredPrefixed {
-			if strings.HasPrefix(strings.TrimSpace(relativePath), strings.TrimSpace(prefix)) {
-				if info.IsDir() {
-					return filepath.SkipDir // Skip the entire directory
-				}
-				return nil // Skip this file
-			}
-		}
+	// Output is where the result goes: the clipboard when empty,
+	// stdout when "-", or a file path.
+	Output string `mapstructure:"output" json:"output"`
 
-		*files = append(*files, path)
+	// Tree prepends an ASCII tree of the clipped files.
+	Tree bool `mapstructure:"tree" json:"tree"`
 
-		return nil
-	}
+	// TreeOnly clips the tree without the file contents.
+	TreeOnly bool `mapstructure:"tree_only" json:"tree_only"`
+
+	// Diff limits the selection to the files git reports as changed.
+	Diff gitlib.DiffOptions `mapstructure:"diff" json:"diff"`
+
+	// DiffOnly clips the unified diff of each changed file instead of its contents.
+	DiffOnly bool `mapstructure:"diff_only" json:"diff_only"`
+
+	// Redact masks the secrets found in the clipped files, unless disabled.
+	Redact redact.Config `mapstructure:"redact" json:"redact"`
 }
 
-func CopyRootPathToClipboard(root string, exclude []string) ([]string, error) {
+func (c *ClipOptions) Validate() error {
+	return validationutils.Validate(c)
+}
 
-	var files []string
+// CopyRootPathToClipboard clips the contents of the files selected under the root,
+// rendered in the requested format, to the requested output. The clipboard
+// output goes to the backend of "clip".
+func CopyRootPathToClipboard(opts *ClipOptions, clip *clipboard.Options) (*ClipReport, error) {
+	rendered, report, err := RenderRootPath(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	err := filepath.Walk(root, visit(&files, root, exclude))
+	if err := WriteOutput(clip, opts.Output, rendered); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// RenderRootPath renders the contents of the files selected under the root in
+// the requested format, optionally preceded by a tree of the rendered files.
+// With diff options set, only changed files are rendered, either whole or as unified diffs.
+// Secrets are masked, and listed in the report.
+// Binary files, unreadable files, and files over the byte budgets are left out,
+// and listed in a closing summary. The budgets don't apply to tree-only clips,
+// as no contents are clipped.
+func RenderRootPath(opts *ClipOptions) (string, *ClipReport, error) {
+	if opts == nil {
+		return "", nil, fmt.Errorf("opts nil")
+	}
+
+	files, walkSkipped, err := SelectFiles(opts)
 	if err != nil {
 		log.Warnf("file walk error: %s\n", err)
-		return files, fmt.Errorf("file walk: %v", err)
+		return "", nil, fmt.Errorf("file walk: %v", err)
 	}
 
-	var contentBuilder strings.Builder
-	for _, file := range files {
-		data, err := os.ReadFile(file)
-		if err != nil {
-			if !strings.Contains(err.Error(), "is a directory") {
-				log.Warnf("reading file '%s' failed: %s\n", file, err)
-			}
-		}
-		fileContent := string(data)
+	readOpts := opts
+	if opts.TreeOnly {
+		unbudgeted := *opts
+		unbudgeted.MaxFileBytes, unbudgeted.MaxTotalBytes = 0, 0
+		readOpts = &unbudgeted
+	}
+
+	contents, report, err := readSelected(files, readOpts)
+	if err != nil {
+		return "", nil, err
+	}
+	report.Skipped = append(walkSkipped, report.Skipped...)
 
-		contentBuilder.WriteString(fmt.Sprintf("\n\n--- %s ---\n\n", file))
-		contentBuilder.WriteString(fileContent)
+	payload := &Payload{
+		Files: contents,
+		Notes: report.Summary(),
 	}
 
-	if err := clipboard.WriteAll(contentBuilder.String()); err != nil {
-		log.Warnf("Clipboard write error: %s\n", err)
-		return files, fmt.Errorf("clip: %v", err)
+	if opts.Tree || opts.TreeOnly {
+		payload.Tree = renderTree(opts.Root, contents)
+	}
+
+	if opts.TreeOnly {
+		payload.Files = nil
+	}
+
+	rendered, err := Render(opts.Format, payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("render: %v", err)
 	}
 
-	return files, nil
+	return rendered, report, nil
+}
+
+// renderTree renders the clipped files as a tree relative to the root.
+func renderTree(root string, contents []FileContent) string {
+	entries := make([]doc.TreeEntry, 0, len(contents))
+	for _, content := range contents {
+		rel, err := filepath.Rel(root, content.Path)
+		if err != nil {
+			rel = content.Path
+		}
+		entries = append(entries, doc.TreeEntry{
+			Path:  filepath.ToSlash(rel),
+			Size:  int64(len(content.Content)),
+			Lines: countLines(content.Content),
+		})
+	}
+	return doc.GetAsTree(root, entries)
+}
+
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	lines := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		lines++
+	}
+	return lines
 }
 
 // GetJSONAndCopyToClipboard generates a JSON string from the input and copies it to the clipboard.
@@ -89,7 +162,7 @@ func GetJSONAndCopyToClipboard(i ...interface{}) string {
 		return ""
 	}
 
-	err := clipboard.WriteAll(jsonStr)
+	_, err := clipboard.Write(nil, jsonStr)
 	if err != nil {
 		fmt.Printf("Error copying to clipboard: %v\n", err)
 		return ""
@@ -0,0 +1,152 @@
+package cliputil
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testCaptureStdout redirects payloads written to stdout into a buffer.
+func testCaptureStdout(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	stdout = &buf
+	t.Cleanup(func() { stdout = os.Stdout })
+
+	return &buf
+}
+
+func TestCopyRootPathToClipboard_Tree(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		"main.go":     "package main\n\nfunc main() {}\n",
+		"pkg/util.go": "package pkg",
+	})
+
+	buf := testCaptureStdout(t)
+
+	report, err := CopyRootPathToClipboard(&ClipOptions{
+		Root:   root,
+		Output: OutputStdout,
+		Tree:   true,
+	}, nil)
+	require.NoError(t, err)
+	assert.Len(t, report.Files, 2)
+
+	out := buf.String()
+	assert.Contains(t, out, "--- tree ---")
+	assert.Contains(t, out, "├── pkg/\n│   └── util.go (11 B, 1 lines)\n└── main.go (29 B, 3 lines)")
+	assert.Contains(t, out, "package pkg")
+}
+
+func TestCopyRootPathToClipboard_Tree_Only(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		"main.go": "package main",
+	})
+
+	buf := testCaptureStdout(t)
+
+	_, err := CopyRootPathToClipboard(&ClipOptions{
+		Root:     root,
+		Output:   OutputStdout,
+		Format:   FormatMarkdown,
+		TreeOnly: true,
+	}, nil)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "### tree")
+	assert.Contains(t, out, "main.go (12 B, 1 lines)")
+	assert.NotContains(t, out, "package main")
+}
+
+func TestCopyRootPathToClipboard_TreeOnly_Ignores_Budgets(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		"a.go": "package main\n\nfunc a() {}\n",
+		"b.go": "package main\n\nfunc b() {}\n",
+	})
+
+	buf := testCaptureStdout(t)
+
+	report, err := CopyRootPathToClipboard(&ClipOptions{
+		Root:          root,
+		Output:        OutputStdout,
+		TreeOnly:      true,
+		MaxFileBytes:  10,
+		MaxTotalBytes: 10,
+	}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, report.Skipped, "no contents are clipped, so nothing is over budget")
+
+	out := buf.String()
+	assert.Contains(t, out, "a.go (26 B, 3 lines)")
+	assert.Contains(t, out, "b.go (26 B, 3 lines)")
+	assert.NotContains(t, out, "skipped")
+}
+
+func TestCopyRootPathToClipboard_Tree_JSON(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		"main.go": "package main",
+		"big.go":  "package main\n\nfunc big() {}\n",
+	})
+
+	buf := testCaptureStdout(t)
+
+	_, err := CopyRootPathToClipboard(&ClipOptions{
+		Root:         root,
+		Output:       OutputStdout,
+		Format:       FormatJSON,
+		Tree:         true,
+		MaxFileBytes: 20,
+	}, nil)
+	require.NoError(t, err)
+
+	var parsed jsonPayload
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed), "output should be valid json")
+	assert.Contains(t, parsed.Tree, "main.go (12 B, 1 lines)")
+	require.Len(t, parsed.Files, 1)
+	assert.Equal(t, filepath.Join(root, "main.go"), parsed.Files[0].Path)
+	assert.Contains(t, parsed.Notes, "big.go", "the skipped files summary is kept")
+}
+
+func TestCopyRootPathToClipboard_Redacts_Secrets(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{
+		".env":    "DB_HOST=localhost\nDB_PASS=secret\n",
+		"main.go": "package main",
+	})
+
+	buf := testCaptureStdout(t)
+
+	report, err := CopyRootPathToClipboard(&ClipOptions{
+		Root:   root,
+		Output: OutputStdout,
+	}, nil)
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "DB_HOST=localhost\nDB_PASS=[REDACTED:env-secret]")
+	assert.NotContains(t, out, "secret\n")
+	require.Len(t, report.Redacted, 1)
+	assert.Equal(t, filepath.Join(root, ".env"), report.Redacted[0].Path)
+	assert.Equal(t, 2, report.Redacted[0].Line)
+
+	buf.Reset()
+	report, err = CopyRootPathToClipboard(&ClipOptions{
+		Root:   root,
+		Output: OutputStdout,
+		Redact: redact.Config{Disabled: true},
+	}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "DB_PASS=secret")
+	assert.Empty(t, report.Redacted)
+}
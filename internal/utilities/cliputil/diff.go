@@ -0,0 +1,197 @@
+package cliputil
+
+import (
+	"context"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/gitlib"
+	"github.com/dembygenesis/local.tools/internal/lib/pathmatch"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
+	"path/filepath"
+)
+
+// DiffLanguage is the markdown code fence language used for unified diffs.
+const DiffLanguage = "diff"
+
+// changedFile is a file that changed, with the path it is clipped under.
+type changedFile struct {
+	path   string
+	change gitlib.ChangedFile
+}
+
+// selectChanged narrows the selected files down to the ones git reports as changed.
+// Deleted files no longer exist to be selected, so they are only kept (after the
+// include and exclude globs) when clipping diffs.
+func selectChanged(files []string, opts *ClipOptions) ([]changedFile, error) {
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, fmt.Errorf("abs root: %v", err)
+	}
+
+	changes, err := gitlib.ChangedFiles(context.TODO(), root, &opts.Diff)
+	if err != nil {
+		return nil, fmt.Errorf("changed files: %v", err)
+	}
+
+	byPath := make(map[string]gitlib.ChangedFile, len(changes))
+	for _, change := range changes {
+		byPath[filepath.FromSlash(change.Path)] = change
+	}
+
+	selected := make([]changedFile, 0, len(changes))
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			return nil, fmt.Errorf("abs path '%s': %v", file, err)
+		}
+		rel, err := filepath.Rel(root, abs)
+		if err != nil {
+			return nil, fmt.Errorf("rel path '%s': %v", file, err)
+		}
+		if change, ok := byPath[rel]; ok && !change.Deleted {
+			selected = append(selected, changedFile{path: file, change: change})
+		}
+	}
+
+	if !opts.DiffOnly {
+		return selected, nil
+	}
+
+	excludes := pathmatch.New(opts.Exclude...)
+	includes := pathmatch.New(opts.Include...)
+	for _, change := range changes {
+		if !change.Deleted || excludes.Excluded(change.Path, false) {
+			continue
+		}
+		if includes.Len() > 0 && !includes.AnyMatch(change.Path, false) {
+			continue
+		}
+		selected = append(selected, changedFile{
+			path:   filepath.Join(opts.Root, filepath.FromSlash(change.Path)),
+			change: change,
+		})
+	}
+
+	return selected, nil
+}
+
+// readDiffs clips the unified diff of each changed file instead of its contents,
+// skipping anything that does not fit the per-file or total byte budgets.
+func readDiffs(changed []changedFile, opts *ClipOptions) ([]FileContent, *ClipReport) {
+	report := &ClipReport{
+		Files:   make([]string, 0, len(changed)),
+		Skipped: make([]SkippedFile, 0),
+	}
+	contents := make([]FileContent, 0, len(changed))
+
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		root = opts.Root
+	}
+
+	for _, file := range changed {
+		diff, err := gitlib.FileDiff(context.TODO(), root, file.change, &opts.Diff)
+		if err != nil {
+			report.Skipped = append(report.Skipped, SkippedFile{Path: file.path, Reason: SkipReadError, Detail: err.Error()})
+			continue
+		}
+
+		size := int64(len(diff))
+		if opts.MaxFileBytes > 0 && size > opts.MaxFileBytes {
+			report.Skipped = append(report.Skipped, SkippedFile{
+				Path:   file.path,
+				Reason: SkipFileTooLarge,
+				Size:   size,
+				Detail: fmt.Sprintf("limit %s", strutil.FormatBytes(opts.MaxFileBytes)),
+			})
+			continue
+		}
+		if opts.MaxTotalBytes > 0 && report.TotalBytes+size > opts.MaxTotalBytes {
+			report.Skipped = append(report.Skipped, SkippedFile{
+				Path:   file.path,
+				Reason: SkipBudgetExhausted,
+				Size:   size,
+				Detail: fmt.Sprintf("limit %s", strutil.FormatBytes(opts.MaxTotalBytes)),
+			})
+			continue
+		}
+
+		report.Files = append(report.Files, file.path)
+		report.TotalBytes += size
+		contents = append(contents, FileContent{
+			Path:     file.path,
+			Content:  diff,
+			Language: DiffLanguage,
+		})
+	}
+
+	return contents, report
+}
+
+// readSelected reads the selected files, or only the changed ones (whole or
+// as diffs) when diff options are set, and masks their secrets.
+func readSelected(files []string, opts *ClipOptions) ([]FileContent, *ClipReport, error) {
+	contents, report, err := readContents(files, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := redactContents(contents, report, &opts.Redact); err != nil {
+		return nil, nil, err
+	}
+
+	return contents, report, nil
+}
+
+// readContents reads the selected files, or their changes.
+func readContents(files []string, opts *ClipOptions) ([]FileContent, *ClipReport, error) {
+	if !opts.Diff.Enabled() {
+		if opts.DiffOnly {
+			return nil, nil, fmt.Errorf("clipping diffs needs a revision, or staged")
+		}
+		contents, report := ReadFiles(files, opts)
+		return contents, report, nil
+	}
+
+	changed, err := selectChanged(files, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("git diff: %v", err)
+	}
+
+	if opts.DiffOnly {
+		contents, report := readDiffs(changed, opts)
+		return contents, report, nil
+	}
+
+	contents, report := ReadFiles(changedPaths(changed), opts)
+	return contents, report, nil
+}
+
+// redactContents masks the secrets of the contents in place, and lists them in the report.
+func redactContents(contents []FileContent, report *ClipReport, cfg *redact.Config) error {
+	redactor, err := redact.New(cfg)
+	if err != nil {
+		return fmt.Errorf("redact: %v", err)
+	}
+
+	report.Redacted = make([]redact.Finding, 0)
+	for i := range contents {
+		var findings []redact.Finding
+		contents[i].Content, findings = redactor.Redact(contents[i].Content)
+		for _, finding := range findings {
+			finding.Path = contents[i].Path
+			report.Redacted = append(report.Redacted, finding)
+		}
+	}
+
+	return nil
+}
+
+// changedPaths returns the clipped paths of the changed files.
+func changedPaths(changed []changedFile) []string {
+	paths := make([]string, 0, len(changed))
+	for _, file := range changed {
+		paths = append(paths, file.path)
+	}
+	return paths
+}
@@ -0,0 +1,92 @@
+package cliputil
+
+import (
+	"github.com/dembygenesis/local.tools/internal/lib/gitlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// testGitRepo commits the files to a new repository at the root.
+func testGitRepo(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+
+	testWriteFiles(t, root, files)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "test"},
+		{"config", "commit.gpgsign", "false"},
+		{"add", "-A"},
+		{"commit", "-q", "-m", "init"},
+	} {
+		out, err := exec.Command("git", append([]string{"-C", root}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+}
+
+func TestCopyRootPathToClipboard_Git_Diff(t *testing.T) {
+	root := t.TempDir()
+	testGitRepo(t, root, map[string]string{
+		"main.go":    "package main\n",
+		"pkg/a.go":   "package pkg\n\nvar a = 1\n",
+		"pkg/old.go": "package pkg\n",
+		"notes.md":   "notes\n",
+	})
+
+	testWriteFiles(t, root, map[string]string{
+		"pkg/a.go":   "package pkg\n\nvar a = 2\n",
+		"pkg/new.go": "package pkg\n\nvar b = 1\n",
+		"notes.md":   "more notes\n",
+	})
+	require.NoError(t, os.Remove(filepath.Join(root, "pkg", "old.go")))
+
+	buf := testCaptureStdout(t)
+
+	report, err := CopyRootPathToClipboard(&ClipOptions{
+		Root:    root,
+		Exclude: []string{"*.md"},
+		Output:  OutputStdout,
+		Diff:    gitlib.DiffOptions{Rev: "HEAD"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pkg/a.go", "pkg/new.go"}, testRelFiles(t, root, report.Files), "changed files that still exist")
+	assert.Contains(t, buf.String(), "var a = 2")
+
+	buf.Reset()
+	report, err = CopyRootPathToClipboard(&ClipOptions{
+		Root:     root,
+		Exclude:  []string{"*.md"},
+		Format:   FormatMarkdown,
+		Output:   OutputStdout,
+		Diff:     gitlib.DiffOptions{Rev: "HEAD", ContextLines: 1},
+		DiffOnly: true,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pkg/a.go", "pkg/new.go", "pkg/old.go"}, testRelFiles(t, root, report.Files), "deleted files have diffs too")
+
+	out := buf.String()
+	assert.Contains(t, out, "```diff\n")
+	assert.Contains(t, out, "\n \n-var a = 1\n+var a = 2\n")
+	assert.Contains(t, out, "+var b = 1\n")
+	assert.Contains(t, out, "-package pkg\n")
+	assert.NotContains(t, out, "notes")
+}
+
+func TestCopyRootPathToClipboard_Git_Diff_Fail(t *testing.T) {
+	root := t.TempDir()
+	testWriteFiles(t, root, map[string]string{"main.go": "package main\n"})
+
+	_, err := CopyRootPathToClipboard(&ClipOptions{Root: root, Output: OutputStdout, DiffOnly: true}, nil)
+	require.Error(t, err, "diffs without a revision or staged")
+
+	_, err = CopyRootPathToClipboard(&ClipOptions{Root: root, Output: OutputStdout, Diff: gitlib.DiffOptions{Staged: true}}, nil)
+	require.Error(t, err, "not a repository")
+}
@@ -0,0 +1,216 @@
+package cliputil
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatXML      = "xml"
+	FormatJSON     = "json"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{FormatPlain, FormatMarkdown, FormatXML, FormatJSON}
+
+// markdownLanguages maps file extensions to markdown code fence language tags.
+var markdownLanguages = map[string]string{
+	".go":         "go",
+	".mod":        "go",
+	".sum":        "text",
+	".sql":        "sql",
+	".js":         "javascript",
+	".mjs":        "javascript",
+	".cjs":        "javascript",
+	".jsx":        "jsx",
+	".ts":         "typescript",
+	".tsx":        "tsx",
+	".json":       "json",
+	".yaml":       "yaml",
+	".yml":        "yaml",
+	".toml":       "toml",
+	".xml":        "xml",
+	".html":       "html",
+	".css":        "css",
+	".scss":       "scss",
+	".md":         "markdown",
+	".sh":         "bash",
+	".bash":       "bash",
+	".zsh":        "zsh",
+	".py":         "python",
+	".rb":         "ruby",
+	".rs":         "rust",
+	".java":       "java",
+	".kt":         "kotlin",
+	".c":          "c",
+	".h":          "c",
+	".cpp":        "cpp",
+	".hpp":        "cpp",
+	".cs":         "csharp",
+	".php":        "php",
+	".swift":      "swift",
+	".proto":      "protobuf",
+	".dockerfile": "dockerfile",
+	".env":        "dotenv",
+}
+
+// markdownFileNames maps well known extensionless file names to language tags.
+var markdownFileNames = map[string]string{
+	"dockerfile": "dockerfile",
+	"makefile":   "makefile",
+}
+
+// MarkdownLanguage infers the code fence language tag from the file name.
+func MarkdownLanguage(path string) string {
+	base := strings.ToLower(filepath.Base(path))
+	if lang, ok := markdownFileNames[base]; ok {
+		return lang
+	}
+	return markdownLanguages[strings.ToLower(filepath.Ext(base))]
+}
+
+// Payload is everything a clip renders.
+type Payload struct {
+	// Tree is an optional preamble showing the layout of the files.
+	Tree string
+
+	Files []FileContent
+
+	// Notes are closing remarks, such as the skipped files summary.
+	Notes string
+}
+
+// jsonPayload is the "json" rendering of a payload.
+type jsonPayload struct {
+	Tree  string        `json:"tree,omitempty"`
+	Files []FileContent `json:"files"`
+	Notes string        `json:"notes,omitempty"`
+}
+
+// Render renders the payload in the given format. The tree and notes are
+// placed in a way that fits the format, "json" renders an object with
+// "tree", "files" and "notes" keys.
+func Render(format string, payload *Payload) (string, error) {
+	if payload == nil {
+		payload = &Payload{}
+	}
+
+	var sb strings.Builder
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FormatPlain:
+		if payload.Tree != "" {
+			sb.WriteString("\n\n--- tree ---\n\n" + payload.Tree)
+		}
+		for _, content := range payload.Files {
+			sb.WriteString(fileSection(content))
+		}
+		if payload.Notes != "" {
+			sb.WriteString("\n\n" + payload.Notes)
+		}
+	case FormatMarkdown:
+		if payload.Tree != "" {
+			sb.WriteString(markdownSection(FileContent{Path: "tree", Content: payload.Tree}))
+		}
+		for _, content := range payload.Files {
+			sb.WriteString(markdownSection(content))
+		}
+		if payload.Notes != "" {
+			sb.WriteString(payload.Notes)
+		}
+	case FormatXML:
+		sb.WriteString("<files>\n")
+		if payload.Tree != "" {
+			sb.WriteString("<tree><![CDATA[" + EscapeCDATA(payload.Tree) + "]]></tree>\n")
+		}
+		for _, content := range payload.Files {
+			sb.WriteString(xmlSection(content))
+		}
+		if payload.Notes != "" {
+			sb.WriteString("<notes>")
+			_ = xml.EscapeText(&sb, []byte(payload.Notes))
+			sb.WriteString("</notes>\n")
+		}
+		sb.WriteString("</files>\n")
+	case FormatJSON:
+		contents := payload.Files
+		if contents == nil {
+			contents = make([]FileContent, 0)
+		}
+		b, err := json.MarshalIndent(jsonPayload{
+			Tree:  payload.Tree,
+			Files: contents,
+			Notes: payload.Notes,
+		}, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal json: %v", err)
+		}
+		sb.Write(b)
+	default:
+		return "", fmt.Errorf("unknown format '%s', expected one of: %s", format, strings.Join(Formats, ", "))
+	}
+
+	return sb.String(), nil
+}
+
+// fileSection renders a file with the header used to identify it in plain clipped output.
+func fileSection(content FileContent) string {
+	return fmt.Sprintf("\n\n--- %s ---\n\n", content.Path) + content.Content
+}
+
+// markdownSection renders a file as a fenced code block, using a fence longer
+// than any backtick run in the content so it cannot be closed early.
+func markdownSection(content FileContent) string {
+	fence := strings.Repeat("`", max(3, longestRun(content.Content, '`')+1))
+
+	body := content.Content
+	if !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+
+	lang := content.Language
+	if lang == "" {
+		lang = MarkdownLanguage(content.Path)
+	}
+
+	return fmt.Sprintf("### %s\n\n%s%s\n%s%s\n\n", content.Path, fence, lang, body, fence)
+}
+
+// xmlSection renders a file as a <file> element, keeping the content
+// readable in a CDATA section.
+func xmlSection(content FileContent) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<file path="`)
+	_ = xml.EscapeText(&sb, []byte(content.Path))
+	sb.WriteString(`"><![CDATA[`)
+	sb.WriteString(EscapeCDATA(content.Content))
+	sb.WriteString("]]></file>\n")
+
+	return sb.String()
+}
+
+// EscapeCDATA splits "]]>" so the text cannot close its CDATA section early.
+func EscapeCDATA(s string) string {
+	return strings.ReplaceAll(s, "]]>", "]]]]><![CDATA[>")
+}
+
+func longestRun(s string, r rune) int {
+	longest, current := 0, 0
+	for _, c := range s {
+		if c != r {
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
@@ -0,0 +1,102 @@
+package cliputil
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testContents = []FileContent{
+	{Path: "cmd/main.go", Content: "package main\n"},
+	{Path: "docs/readme.md", Content: "```sh\necho hi\n```"},
+	{Path: "web/a&b.html", Content: "<p>]]></p>"},
+}
+
+func TestRender_Plain(t *testing.T) {
+	out, err := Render("", &Payload{Files: testContents[:1], Notes: "notes"})
+	require.NoError(t, err)
+	assert.Equal(t, "\n\n--- cmd/main.go ---\n\npackage main\n\n\nnotes", out)
+}
+
+func TestRender_Markdown(t *testing.T) {
+	out, err := Render(FormatMarkdown, &Payload{Files: testContents[:2]})
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "### cmd/main.go\n\n```go\npackage main\n```\n")
+	assert.Contains(t, out, "### docs/readme.md\n\n````markdown\n```sh\necho hi\n```\n````\n", "fence should outgrow inner backticks")
+}
+
+func TestRender_XML(t *testing.T) {
+	out, err := Render(FormatXML, &Payload{Files: testContents, Notes: "skipped <1>"})
+	require.NoError(t, err)
+
+	var parsed struct {
+		Files []struct {
+			Path    string `xml:"path,attr"`
+			Content string `xml:",chardata"`
+		} `xml:"file"`
+		Notes string `xml:"notes"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(out), &parsed), "output should be valid xml")
+	require.Len(t, parsed.Files, 3)
+
+	for i, f := range parsed.Files {
+		assert.Equal(t, testContents[i].Path, f.Path)
+		assert.Equal(t, testContents[i].Content, f.Content)
+	}
+	assert.Equal(t, "skipped <1>", parsed.Notes)
+}
+
+func TestRender_JSON(t *testing.T) {
+	out, err := Render(FormatJSON, &Payload{Tree: "a/\n  b.go", Files: testContents, Notes: "skipped <1>"})
+	require.NoError(t, err)
+
+	var parsed jsonPayload
+	require.NoError(t, json.Unmarshal([]byte(out), &parsed))
+	assert.Equal(t, "a/\n  b.go", parsed.Tree)
+	assert.Equal(t, testContents, parsed.Files)
+	assert.Equal(t, "skipped <1>", parsed.Notes)
+
+	out, err = Render(FormatJSON, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"files": []}`, out, "the tree and notes are left out when empty")
+}
+
+func TestRender_Unknown_Format(t *testing.T) {
+	_, err := Render("yaml", &Payload{Files: testContents})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown format")
+}
+
+func TestMarkdownLanguage(t *testing.T) {
+	assert.Equal(t, "go", MarkdownLanguage("a/b.go"))
+	assert.Equal(t, "yaml", MarkdownLanguage("ci.YML"))
+	assert.Equal(t, "dockerfile", MarkdownLanguage("docker/Dockerfile"))
+	assert.Equal(t, "", MarkdownLanguage("LICENSE"))
+}
+
+func TestWriteOutput(t *testing.T) {
+	buf := testCaptureStdout(t)
+
+	require.NoError(t, WriteOutput(nil, OutputStdout, "to stdout"))
+	assert.Equal(t, "to stdout", buf.String())
+
+	outFile := filepath.Join(t.TempDir(), "nested", "out.md")
+	require.NoError(t, WriteOutput(nil, " "+outFile+" ", "to file"))
+
+	b, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Equal(t, "to file", string(b))
+
+	clipFile := filepath.Join(t.TempDir(), "clip.txt")
+	require.NoError(t, WriteOutput(&clipboard.Options{Backend: clipboard.File, File: clipFile}, "", "to clipboard"))
+
+	b, err = os.ReadFile(clipFile)
+	require.NoError(t, err)
+	assert.Equal(t, "to clipboard", string(b), "the clipboard output goes to the given backend")
+}
@@ -0,0 +1,67 @@
+package cliputil
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/fslib"
+	"io"
+	"os"
+	"strings"
+)
+
+// OutputStdout writes the payload to stdout instead of the clipboard.
+const OutputStdout = "-"
+
+// InputStdin reads the input from stdin instead of the clipboard.
+const InputStdin = "-"
+
+var (
+	stdout io.Writer = os.Stdout
+	stdin  io.Reader = os.Stdin
+)
+
+// WriteOutput sends the payload to the clipboard backend of "clip" when
+// "output" is empty, to stdout when it is "-", and to the file it names otherwise.
+func WriteOutput(clip *clipboard.Options, output, payload string) error {
+	switch output = strings.TrimSpace(output); output {
+	case "":
+		if _, err := clipboard.Write(clip, payload); err != nil {
+			log.Warnf("Clipboard write error: %s\n", err)
+			return fmt.Errorf("clip: %v", err)
+		}
+	case OutputStdout:
+		if _, err := io.WriteString(stdout, payload); err != nil {
+			return fmt.Errorf("write stdout: %v", err)
+		}
+	default:
+		if err := fslib.CreateFileWithDirs(output, []byte(payload)); err != nil {
+			return fmt.Errorf("write output file: %v", err)
+		}
+	}
+	return nil
+}
+
+// ReadInput reads from the first readable clipboard backend when "input" is
+// empty, from stdin when it is "-", and from the file it names otherwise.
+func ReadInput(input string) (string, error) {
+	switch input = strings.TrimSpace(input); input {
+	case "":
+		text, _, err := clipboard.Read(nil)
+		if err != nil {
+			return "", fmt.Errorf("read clipboard: %v", err)
+		}
+		return text, nil
+	case InputStdin:
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("read stdin: %v", err)
+		}
+		return string(b), nil
+	default:
+		b, err := os.ReadFile(input)
+		if err != nil {
+			return "", fmt.Errorf("read input file: %v", err)
+		}
+		return string(b), nil
+	}
+}
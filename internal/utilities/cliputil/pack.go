@@ -0,0 +1,272 @@
+package cliputil
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/clipboard"
+	"github.com/dembygenesis/local.tools/internal/lib/pathmatch"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/dembygenesis/local.tools/internal/lib/tokenizer"
+	"github.com/dembygenesis/local.tools/internal/utilities/validationutils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ContextOptions struct {
+	ClipOptions `mapstructure:",squash"`
+
+	// MaxTokens is the token budget files are packed into.
+	MaxTokens int `mapstructure:"max_tokens" validate:"required,greater_than_zero" json:"max_tokens"`
+
+	// Focus globs (gitignore syntax, relative to the root) rank matching files first.
+	Focus []string `mapstructure:"focus" json:"focus"`
+
+	// Near ranks files by their directory distance to this file.
+	Near string `mapstructure:"near" json:"near"`
+
+	Tokenizer tokenizer.Config `mapstructure:"tokenizer" json:"tokenizer"`
+}
+
+func (c *ContextOptions) Validate() error {
+	return validationutils.Validate(c)
+}
+
+// PackedFile is a candidate file, with how it ranked and what it costs.
+type PackedFile struct {
+	Path     string    `json:"path"`
+	Tokens   int       `json:"tokens"`
+	Focused  bool      `json:"focused"`
+	Distance int       `json:"distance"`
+	ModTime  time.Time `json:"mod_time"`
+}
+
+// ContextReport describes the outcome of a packed clip.
+type ContextReport struct {
+	Tokenizer   string        `json:"tokenizer"`
+	MaxTokens   int           `json:"max_tokens"`
+	TotalTokens int           `json:"total_tokens"`
+	Included    []PackedFile  `json:"included"`
+	LeftOut     []PackedFile  `json:"left_out"`
+	Skipped     []SkippedFile `json:"skipped"`
+
+	// Redacted lists the masked secrets of every candidate file, by file and line.
+	Redacted []redact.Finding `json:"redacted"`
+}
+
+// Summary lists the files that did not fit the token budget, or returns an
+// empty string when everything fit.
+func (r *ContextReport) Summary() string {
+	if r == nil || len(r.LeftOut) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- left out %d file(s) over the %d token budget ---\n\n", len(r.LeftOut), r.MaxTokens))
+	for _, f := range r.LeftOut {
+		sb.WriteString(fmt.Sprintf("- %s (~%d tokens)\n", f.Path, f.Tokens))
+	}
+	return sb.String()
+}
+
+// CopyContextToClipboard clips the most relevant files under the root that fit
+// the token budget, to the requested output. The clipboard output goes to the
+// backend of "clip".
+func CopyContextToClipboard(opts *ContextOptions, clip *clipboard.Options) (*ContextReport, error) {
+	rendered, report, err := RenderContext(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := WriteOutput(clip, opts.Output, rendered); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// RenderContext renders the most relevant files under the root that fit the
+// token budget. Files are ranked by matching a focus glob first, then by
+// their distance to the "near" file, then by most recently modified.
+func RenderContext(opts *ContextOptions) (string, *ContextReport, error) {
+	if opts == nil {
+		return "", nil, fmt.Errorf("opts nil")
+	}
+
+	if err := opts.Validate(); err != nil {
+		return "", nil, fmt.Errorf("validate: %v", err)
+	}
+
+	tk, err := tokenizer.New(&opts.Tokenizer)
+	if err != nil {
+		return "", nil, fmt.Errorf("tokenizer: %v", err)
+	}
+
+	files, walkSkipped, err := SelectFiles(&opts.ClipOptions)
+	if err != nil {
+		return "", nil, fmt.Errorf("file walk: %v", err)
+	}
+
+	// The byte budgets would drop files in walk order, before they are ranked,
+	// the token budget is the only one applied.
+	readOpts := opts.ClipOptions
+	readOpts.MaxFileBytes, readOpts.MaxTotalBytes = 0, 0
+
+	contents, clipReport, err := readSelected(files, &readOpts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Fail on an unknown format before doing any work.
+	if _, err := Render(opts.Format, nil); err != nil {
+		return "", nil, fmt.Errorf("render: %v", err)
+	}
+
+	ranked, err := rankFiles(contents, opts, tk)
+	if err != nil {
+		return "", nil, fmt.Errorf("rank: %v", err)
+	}
+
+	included, report := packFiles(ranked, opts.MaxTokens)
+	report.Tokenizer = tk.Name()
+	report.Skipped = append(walkSkipped, clipReport.Skipped...)
+	report.Redacted = clipReport.Redacted
+
+	rendered, err := Render(opts.Format, &Payload{
+		Files: included,
+		Notes: report.Summary(),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("render: %v", err)
+	}
+
+	return rendered, report, nil
+}
+
+type rankedFile struct {
+	PackedFile
+	content FileContent
+}
+
+// rankFiles estimates the tokens of each file, and sorts them by relevance.
+func rankFiles(contents []FileContent, opts *ContextOptions, tk tokenizer.Tokenizer) ([]rankedFile, error) {
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, fmt.Errorf("abs root: %v", err)
+	}
+
+	focus := &pathmatch.Matcher{}
+	focus.AddLines(opts.Focus, "")
+
+	nearFile, nearDir := "", ""
+	if strings.TrimSpace(opts.Near) != "" {
+		near, err := filepath.Abs(opts.Near)
+		if err != nil {
+			return nil, fmt.Errorf("abs near: %v", err)
+		}
+		info, err := os.Stat(near)
+		if err != nil {
+			return nil, fmt.Errorf("near: %v", err)
+		}
+		nearDir = near
+		if !info.IsDir() {
+			nearFile, nearDir = near, filepath.Dir(near)
+		}
+	}
+
+	ranked := make([]rankedFile, 0, len(contents))
+	for _, content := range contents {
+		abs, err := filepath.Abs(content.Path)
+		if err != nil {
+			return nil, fmt.Errorf("abs path: %v", err)
+		}
+
+		rel, err := filepath.Rel(root, abs)
+		if err != nil {
+			return nil, fmt.Errorf("rel path: %v", err)
+		}
+
+		// Count the file as rendered, so headers and fences are accounted for.
+		section, err := Render(opts.Format, &Payload{Files: []FileContent{content}})
+		if err != nil {
+			return nil, fmt.Errorf("render: %v", err)
+		}
+
+		f := rankedFile{
+			PackedFile: PackedFile{
+				Path:    content.Path,
+				Tokens:  tk.Count(section),
+				Focused: focus.AnyMatch(filepath.ToSlash(rel), false),
+			},
+			content: content,
+		}
+
+		if nearDir != "" {
+			f.Distance = dirDistance(nearDir, nearFile, abs)
+		}
+
+		if info, err := os.Stat(content.Path); err == nil {
+			f.ModTime = info.ModTime()
+		}
+
+		ranked = append(ranked, f)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Focused != b.Focused {
+			return a.Focused
+		}
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if !a.ModTime.Equal(b.ModTime) {
+			return a.ModTime.After(b.ModTime)
+		}
+		return a.Path < b.Path
+	})
+
+	return ranked, nil
+}
+
+// packFiles greedily takes ranked files while they fit the budget. A file that
+// does not fit is left out, but smaller files after it may still be taken.
+func packFiles(ranked []rankedFile, maxTokens int) ([]FileContent, *ContextReport) {
+	report := &ContextReport{
+		MaxTokens: maxTokens,
+		Included:  make([]PackedFile, 0),
+		LeftOut:   make([]PackedFile, 0),
+	}
+
+	included := make([]FileContent, 0, len(ranked))
+	for _, f := range ranked {
+		if report.TotalTokens+f.Tokens > maxTokens {
+			report.LeftOut = append(report.LeftOut, f.PackedFile)
+			continue
+		}
+		report.TotalTokens += f.Tokens
+		report.Included = append(report.Included, f.PackedFile)
+		included = append(included, f.content)
+	}
+
+	return included, report
+}
+
+// dirDistance is the amount of directory hops between the near directory and
+// the folder of the file, with the near file itself at -1 so it always ranks first.
+func dirDistance(nearDir, nearFile, path string) int {
+	if nearFile == path {
+		return -1
+	}
+
+	a := strings.Split(filepath.ToSlash(nearDir), "/")
+	b := strings.Split(filepath.ToSlash(filepath.Dir(path)), "/")
+
+	common := 0
+	for common < len(a) && common < len(b) && a[common] == b[common] {
+		common++
+	}
+
+	return (len(a) - common) + (len(b) - common)
+}
@@ -0,0 +1,152 @@
+package cliputil
+
+import (
+	"github.com/dembygenesis/local.tools/internal/lib/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRankedPaths(t *testing.T, root string, ranked []rankedFile) []string {
+	t.Helper()
+
+	paths := make([]string, 0, len(ranked))
+	for _, f := range ranked {
+		paths = append(paths, f.Path)
+	}
+	return testRelFiles(t, root, paths)
+}
+
+func TestRankFiles(t *testing.T) {
+	root := t.TempDir()
+
+	testWriteFiles(t, root, map[string]string{
+		"api/handler.go":      "package api",
+		"api/handler_test.go": "package api",
+		"api/dto/dto.go":      "package dto",
+		"model/user.go":       "package model",
+		"readme.md":           "# readme",
+	})
+
+	// Make "readme.md" the most recently modified file.
+	now := time.Now()
+	for i, name := range []string{"api/handler.go", "api/handler_test.go", "api/dto/dto.go", "model/user.go", "readme.md"} {
+		mod := now.Add(time.Duration(i) * time.Minute)
+		require.NoError(t, os.Chtimes(filepath.Join(root, name), mod, mod))
+	}
+
+	files, _, err := SelectFiles(&ClipOptions{Root: root})
+	require.NoError(t, err)
+
+	contents, _ := ReadFiles(files, &ClipOptions{Root: root})
+
+	t.Run("Recency", func(t *testing.T) {
+		ranked, err := rankFiles(contents, &ContextOptions{ClipOptions: ClipOptions{Root: root}}, tokenizer.NewChars(4))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"readme.md",
+			"model/user.go",
+			"api/dto/dto.go",
+			"api/handler_test.go",
+			"api/handler.go",
+		}, testRankedPaths(t, root, ranked))
+	})
+
+	t.Run("Focus Then Near", func(t *testing.T) {
+		ranked, err := rankFiles(contents, &ContextOptions{
+			ClipOptions: ClipOptions{Root: root},
+			Focus:       []string{"*_test.go"},
+			Near:        filepath.Join(root, "api", "handler.go"),
+		}, tokenizer.NewChars(4))
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"api/handler_test.go",
+			"api/handler.go",
+			// One hop away each, so the most recently modified wins.
+			"readme.md",
+			"api/dto/dto.go",
+			"model/user.go",
+		}, testRankedPaths(t, root, ranked))
+	})
+
+	t.Run("Near Missing", func(t *testing.T) {
+		_, err := rankFiles(contents, &ContextOptions{
+			ClipOptions: ClipOptions{Root: root},
+			Near:        filepath.Join(root, "missing.go"),
+		}, tokenizer.NewChars(4))
+		require.Error(t, err)
+	})
+}
+
+func TestPackFiles(t *testing.T) {
+	ranked := []rankedFile{
+		{PackedFile: PackedFile{Path: "a", Tokens: 40}, content: FileContent{Path: "a"}},
+		{PackedFile: PackedFile{Path: "b", Tokens: 70}, content: FileContent{Path: "b"}},
+		{PackedFile: PackedFile{Path: "c", Tokens: 50}, content: FileContent{Path: "c"}},
+		{PackedFile: PackedFile{Path: "d", Tokens: 20}, content: FileContent{Path: "d"}},
+	}
+
+	included, report := packFiles(ranked, 100)
+
+	require.Len(t, included, 2)
+	assert.Equal(t, "a", included[0].Path)
+	assert.Equal(t, "c", included[1].Path)
+	assert.Equal(t, 90, report.TotalTokens)
+	require.Len(t, report.LeftOut, 2)
+	assert.Equal(t, "b", report.LeftOut[0].Path)
+	assert.Equal(t, "d", report.LeftOut[1].Path)
+
+	summary := report.Summary()
+	assert.Contains(t, summary, "left out 2 file(s) over the 100 token budget")
+	assert.Contains(t, summary, "- b (~70 tokens)")
+}
+
+func TestPackFiles_Fits_Smaller_Files_After_A_Large_One(t *testing.T) {
+	ranked := []rankedFile{
+		{PackedFile: PackedFile{Path: "huge", Tokens: 500}},
+		{PackedFile: PackedFile{Path: "small", Tokens: 5}},
+	}
+
+	included, report := packFiles(ranked, 10)
+	require.Len(t, included, 1)
+	assert.Equal(t, 5, report.TotalTokens)
+	assert.Equal(t, "huge", report.LeftOut[0].Path)
+}
+
+func TestRenderContext_Ranks_Before_Byte_Budgets(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.go", "b.go", "z_focus.go"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(strings.Repeat("x", 400)), 0644))
+	}
+
+	rendered, report, err := RenderContext(&ContextOptions{
+		// The byte budget only fits the first file in walk order.
+		ClipOptions: ClipOptions{Root: root, MaxFileBytes: 500, MaxTotalBytes: 500},
+		MaxTokens:   150,
+		Focus:       []string{"z_focus.go"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, report.Skipped, "only the token budget leaves files out")
+	require.Len(t, report.Included, 1)
+	assert.Equal(t, "z_focus.go", filepath.Base(report.Included[0].Path))
+	assert.Contains(t, rendered, "z_focus.go")
+	assert.Len(t, report.LeftOut, 2)
+}
+
+func TestCopyContextToClipboard_Fail_Validate(t *testing.T) {
+	_, err := CopyContextToClipboard(&ContextOptions{ClipOptions: ClipOptions{Root: "."}}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "validate:")
+
+	_, err = CopyContextToClipboard(&ContextOptions{
+		ClipOptions: ClipOptions{Root: "."},
+		MaxTokens:   10,
+		Tokenizer:   tokenizer.Config{Kind: "nope"},
+	}, nil)
+	require.Error(t, err)
+	require.True(t, strings.Contains(err.Error(), "tokenizer:"))
+}
@@ -0,0 +1,173 @@
+package cliputil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/redact"
+	"github.com/dembygenesis/local.tools/internal/utilities/strutil"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// sniffLen is how much of a file is inspected to decide if it is binary,
+// the same window git uses.
+const sniffLen = 8000
+
+type SkipReason string
+
+const (
+	SkipBinary          SkipReason = "binary"
+	SkipFileTooLarge    SkipReason = "exceeds per-file budget"
+	SkipBudgetExhausted SkipReason = "exceeds total budget"
+	SkipReadError       SkipReason = "read error"
+)
+
+// SkippedFile is a selected file that was left out of the clipped output.
+type SkippedFile struct {
+	Path   string     `json:"path"`
+	Reason SkipReason `json:"reason"`
+	Size   int64      `json:"size"`
+	Detail string     `json:"detail,omitempty"`
+}
+
+// FileContent is a selected file that made it into the clipped output.
+type FileContent struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+
+	// Language overrides the code fence language inferred from the path.
+	Language string `json:"language,omitempty"`
+}
+
+// ClipReport describes the outcome of a clip.
+type ClipReport struct {
+	Files      []string      `json:"files"`
+	Skipped    []SkippedFile `json:"skipped"`
+	TotalBytes int64         `json:"total_bytes"`
+
+	// Redacted lists the masked secrets, by file and line.
+	Redacted []redact.Finding `json:"redacted"`
+}
+
+// Summary lists the skipped files and why, or returns an empty
+// string when nothing was skipped.
+func (r *ClipReport) Summary() string {
+	if r == nil || len(r.Skipped) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- skipped %d file(s) ---\n\n", len(r.Skipped)))
+	for _, s := range r.Skipped {
+		sb.WriteString(fmt.Sprintf("- %s (%s, %s", s.Path, s.Reason, strutil.FormatBytes(s.Size)))
+		if s.Detail != "" {
+			sb.WriteString(": " + s.Detail)
+		}
+		sb.WriteString(")\n")
+	}
+	return sb.String()
+}
+
+// IsBinary reports whether the sample looks like binary content: it either
+// holds a NUL byte, or is not valid UTF-8.
+func IsBinary(sample []byte) bool {
+	if len(sample) > sniffLen {
+		sample = sample[:sniffLen]
+	}
+
+	if bytes.IndexByte(sample, 0) != -1 {
+		return true
+	}
+
+	// The sample may end in the middle of a multibyte rune.
+	for i := 0; i < utf8.UTFMax && len(sample) > 0; i++ {
+		if utf8.Valid(sample) {
+			return false
+		}
+		sample = sample[:len(sample)-1]
+	}
+
+	return len(sample) > 0
+}
+
+// ReadFiles reads the selected files, skipping binaries, read errors and
+// anything that does not fit the per-file or total byte budgets.
+func ReadFiles(files []string, opts *ClipOptions) ([]FileContent, *ClipReport) {
+	report := &ClipReport{
+		Files:   make([]string, 0, len(files)),
+		Skipped: make([]SkippedFile, 0),
+	}
+	contents := make([]FileContent, 0, len(files))
+
+	for _, file := range files {
+		content, skipped := readFile(file, opts, report.TotalBytes)
+		if skipped != nil {
+			report.Skipped = append(report.Skipped, *skipped)
+			continue
+		}
+
+		report.Files = append(report.Files, file)
+		report.TotalBytes += int64(len(content))
+		contents = append(contents, FileContent{
+			Path:    file,
+			Content: content,
+		})
+	}
+
+	return contents, report
+}
+
+func readFile(file string, opts *ClipOptions, usedBytes int64) (string, *SkippedFile) {
+	skip := func(reason SkipReason, size int64, detail string) (string, *SkippedFile) {
+		return "", &SkippedFile{Path: file, Reason: reason, Size: size, Detail: detail}
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return skip(SkipReadError, 0, err.Error())
+	}
+
+	size := info.Size()
+	if opts.MaxFileBytes > 0 && size > opts.MaxFileBytes {
+		return skip(SkipFileTooLarge, size, fmt.Sprintf("limit %s", strutil.FormatBytes(opts.MaxFileBytes)))
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return skip(SkipReadError, size, err.Error())
+	}
+	defer f.Close()
+
+	// Sniff before reading the rest, so large binaries are never fully loaded.
+	sample := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, sample)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return skip(SkipReadError, size, err.Error())
+	}
+	sample = sample[:n]
+
+	if IsBinary(sample) {
+		return skip(SkipBinary, size, "")
+	}
+
+	if opts.MaxTotalBytes > 0 && usedBytes+size > opts.MaxTotalBytes {
+		return skip(SkipBudgetExhausted, size, fmt.Sprintf("limit %s", strutil.FormatBytes(opts.MaxTotalBytes)))
+	}
+
+	rest, err := io.ReadAll(f)
+	if err != nil {
+		return skip(SkipReadError, size, err.Error())
+	}
+	data := append(sample, rest...)
+
+	// The file may have changed since it was stat'ed.
+	size = int64(len(data))
+	if opts.MaxTotalBytes > 0 && usedBytes+size > opts.MaxTotalBytes {
+		return skip(SkipBudgetExhausted, size, fmt.Sprintf("limit %s", strutil.FormatBytes(opts.MaxTotalBytes)))
+	}
+
+	return string(data), nil
+}
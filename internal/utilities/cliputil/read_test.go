@@ -0,0 +1,97 @@
+package cliputil
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsBinary(t *testing.T) {
+	type testCase struct {
+		name     string
+		sample   []byte
+		expected bool
+	}
+
+	testCases := []testCase{
+		{name: "Empty", sample: []byte{}, expected: false},
+		{name: "Plain Text", sample: []byte("package main\n"), expected: false},
+		{name: "UTF-8 Text", sample: []byte("héllo wörld ✅"), expected: false},
+		{name: "Truncated Rune", sample: []byte("ok ✅")[:5], expected: false},
+		{name: "NUL Byte", sample: []byte("abc\x00def"), expected: true},
+		{name: "PNG Header", sample: []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), expected: true},
+		{name: "Invalid UTF-8", sample: []byte{0xff, 0xfe, 0xfd, 0x41, 0x42, 0x43, 0x44, 0x45}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsBinary(tc.sample))
+		})
+	}
+}
+
+func TestReadFiles_Skips_Binary_And_Budgets(t *testing.T) {
+	root := t.TempDir()
+
+	testWriteFiles(t, root, map[string]string{
+		"a.go":         "package a",
+		"image.png":    "\x89PNG\r\n\x1a\n\x00\x00",
+		"package.lock": strings.Repeat("x", 100),
+		"b.go":         "package b",
+		"c.go":         "package c",
+	})
+
+	files := []string{
+		filepath.Join(root, "a.go"),
+		filepath.Join(root, "image.png"),
+		filepath.Join(root, "package.lock"),
+		filepath.Join(root, "b.go"),
+		filepath.Join(root, "missing.go"),
+		filepath.Join(root, "c.go"),
+	}
+
+	contents, report := ReadFiles(files, &ClipOptions{
+		Root:          root,
+		MaxFileBytes:  50,
+		MaxTotalBytes: 18,
+	})
+
+	require.Len(t, contents, 2)
+	assert.Equal(t, "package a", contents[0].Content)
+	assert.Equal(t, "package b", contents[1].Content)
+	assert.Equal(t, int64(18), report.TotalBytes)
+	assert.Equal(t, []string{files[0], files[3]}, report.Files)
+
+	reasons := make(map[string]SkipReason)
+	for _, skipped := range report.Skipped {
+		reasons[filepath.Base(skipped.Path)] = skipped.Reason
+	}
+
+	assert.Equal(t, map[string]SkipReason{
+		"image.png":    SkipBinary,
+		"package.lock": SkipFileTooLarge,
+		"missing.go":   SkipReadError,
+		"c.go":         SkipBudgetExhausted,
+	}, reasons)
+
+	summary := report.Summary()
+	assert.Contains(t, summary, "skipped 4 file(s)")
+	assert.Contains(t, summary, "image.png (binary")
+	assert.Contains(t, summary, "package.lock (exceeds per-file budget")
+}
+
+func TestReadFiles_No_Budgets(t *testing.T) {
+	root := t.TempDir()
+
+	testWriteFiles(t, root, map[string]string{
+		"big.txt": strings.Repeat("y", sniffLen*2),
+	})
+
+	contents, report := ReadFiles([]string{filepath.Join(root, "big.txt")}, &ClipOptions{Root: root})
+	require.Len(t, contents, 1)
+	assert.Len(t, contents[0].Content, sniffLen*2)
+	assert.Empty(t, report.Skipped)
+	assert.Empty(t, report.Summary())
+}
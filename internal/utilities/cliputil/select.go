@@ -0,0 +1,107 @@
+package cliputil
+
+import (
+	"fmt"
+	"github.com/dembygenesis/local.tools/internal/lib/pathmatch"
+	"io/fs"
+	"path/filepath"
+)
+
+// SelectFiles walks the root in lexical order and returns the files to clip.
+//
+// Paths are skipped when excluded by any ".gitignore" (from the repository
+// root down to each visited directory) or ".git/info/exclude", or by the
+// gitignore-style "Exclude" patterns. When "Include" globs are provided,
+// only files matching at least one of them are returned.
+//
+// Paths below the root that cannot be read are not fatal, and are returned
+// as skipped entries instead.
+func SelectFiles(opts *ClipOptions) ([]string, []SkippedFile, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("validate: %v", err)
+	}
+
+	root, err := filepath.Abs(opts.Root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("abs root: %v", err)
+	}
+
+	ignores, matchRoot, err := pathmatch.LoadGitIgnores(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load gitignores: %v", err)
+	}
+
+	relRoot, err := filepath.Rel(matchRoot, root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rel root: %v", err)
+	}
+
+	excludes := &pathmatch.Matcher{}
+	excludes.AddLines(opts.Exclude, relRoot)
+
+	includes := &pathmatch.Matcher{}
+	includes.AddLines(opts.Include, relRoot)
+
+	var (
+		files   []string
+		skipped []SkippedFile
+	)
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			log.Warnf("Encountered an error accessing path %s: %s\n", path, err)
+			skipped = append(skipped, SkippedFile{
+				Path:   selectedPath(opts.Root, root, path),
+				Reason: SkipReadError,
+				Detail: err.Error(),
+			})
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(matchRoot, path)
+		if err != nil {
+			return fmt.Errorf("rel path '%s': %v", path, err)
+		}
+
+		if path != root && (ignores.Excluded(rel, d.IsDir()) || excludes.Excluded(rel, d.IsDir())) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.IsDir() {
+			if err := ignores.AddFile(filepath.Join(path, pathmatch.GitIgnoreFile), rel); err != nil {
+				return fmt.Errorf("add gitignore: %v", err)
+			}
+			return nil
+		}
+
+		if includes.Len() > 0 && !includes.AnyMatch(rel, false) {
+			return nil
+		}
+
+		files = append(files, selectedPath(opts.Root, root, path))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return files, skipped, nil
+}
+
+// selectedPath keeps returned paths in the same form the root was given in,
+// so relative roots yield relative file paths.
+func selectedPath(givenRoot, absRoot, path string) string {
+	rel, err := filepath.Rel(absRoot, path)
+	if err != nil {
+		return path
+	}
+	return filepath.Join(givenRoot, rel)
+}
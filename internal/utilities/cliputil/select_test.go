@@ -0,0 +1,100 @@
+package cliputil
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testWriteFiles creates the files (relative to root) with the given contents.
+func testWriteFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		fullPath := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755), "create dir")
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644), "write file")
+	}
+}
+
+func testRelFiles(t *testing.T, root string, files []string) []string {
+	t.Helper()
+
+	rel := make([]string, 0, len(files))
+	for _, file := range files {
+		r, err := filepath.Rel(root, file)
+		require.NoError(t, err)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	return rel
+}
+
+func TestSelectFiles_GitIgnore(t *testing.T) {
+	root := t.TempDir()
+
+	testWriteFiles(t, root, map[string]string{
+		".git/HEAD":                 "ref: refs/heads/main",
+		".git/info/exclude":         "*.local\n",
+		".gitignore":                "node_modules/\n*.log\n!keep.log\n/build\n",
+		"main.go":                   "package main",
+		"debug.log":                 "noise",
+		"keep.log":                  "signal",
+		"settings.local":            "mine",
+		"build/out.bin":             "bin",
+		"web/node_modules/x/i.js":   "dep",
+		"web/app.js":                "app",
+		"web/build/keep.js":         "not anchored at web",
+		"pkg/.gitignore":            "*.gen.go\n",
+		"pkg/model.go":              "package pkg",
+		"pkg/model.gen.go":          "package pkg",
+		"other/model.gen.go":        "package other",
+		"vendor/.gitignore":         "*\n",
+		"vendor/lib/should_skip.go": "package lib",
+	})
+
+	files, _, err := SelectFiles(&ClipOptions{Root: root})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		".gitignore",
+		"main.go",
+		"keep.log",
+		"web/app.js",
+		"web/build/keep.js",
+		"pkg/.gitignore",
+		"pkg/model.go",
+		"other/model.gen.go",
+	}, testRelFiles(t, root, files))
+}
+
+func TestSelectFiles_Include_Exclude(t *testing.T) {
+	root := t.TempDir()
+
+	testWriteFiles(t, root, map[string]string{
+		"main.go":         "package main",
+		"readme.md":       "# readme",
+		"docs/a.go":       "package docs",
+		"internal/b.go":   "package internal",
+		"internal/b.json": "{}",
+	})
+
+	files, _, err := SelectFiles(&ClipOptions{
+		Root:    root,
+		Include: []string{"*.go"},
+		Exclude: []string{"docs/"},
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"main.go",
+		"internal/b.go",
+	}, testRelFiles(t, root, files))
+}
+
+func TestSelectFiles_Fail_Validate(t *testing.T) {
+	_, _, err := SelectFiles(&ClipOptions{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "validate:")
+}
@@ -0,0 +1 @@
+go run ./cmd/hash_passwords
\ No newline at end of file